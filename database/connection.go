@@ -19,18 +19,30 @@ package database
 import (
     "github.com/jinzhu/gorm"
     _ "github.com/jinzhu/gorm/dialects/postgres"
+    _ "github.com/jinzhu/gorm/dialects/sqlite"
+    "github.com/IBM/ubiquity/resources"
     "github.com/IBM/ubiquity/utils/logs"
+    "github.com/IBM/ubiquity/utils/metrics"
+    "database/sql"
     "errors"
+    "sync"
+    "time"
 )
 
-var globalConnectionFactory ConnectionFactory = nil
+var globalConnectionPool *connectionPool = nil
 
-func initConnectionFactory(connectionFactory ConnectionFactory) func() {
-	if globalConnectionFactory != nil {
-		panic("globalConnectionFactory already initialized")
+func initConnectionFactory(connectionFactory ConnectionFactory, poolConfig resources.DatabasePoolConfig) func() {
+	if globalConnectionPool != nil {
+		panic("globalConnectionPool already initialized")
+	}
+	pool := &connectionPool{factory: connectionFactory, config: poolConfig, logger: logs.GetLogger()}
+	globalConnectionPool = pool
+	setSchemaMigrated(false)
+	return func() {
+		pool.close()
+		globalConnectionPool = nil
+		setSchemaMigrated(false)
 	}
-	globalConnectionFactory = connectionFactory
-	return func() { globalConnectionFactory = nil }
 }
 
 type ConnectionFactory interface {
@@ -42,6 +54,13 @@ type postgresFactory struct {
 	psqlLog string
 }
 
+// sqliteFactory opens the SQLite DB file, the connections wait for each other's writes instead of failing on a locked DB
+type sqliteFactory struct {
+	path string
+}
+
+const sqliteParams = "?_busy_timeout=10000&_journal_mode=WAL"
+
 type testErrorFactory struct {
 }
 
@@ -54,6 +73,12 @@ func (f *postgresFactory) newConnection() (*gorm.DB, error) {
 	return gorm.Open("postgres", f.psql)
 }
 
+func (f *sqliteFactory) newConnection() (*gorm.DB, error) {
+	logger := logs.GetLogger()
+	logger.Debug("", logs.Args{{"sqlite", f.path}})
+	return gorm.Open("sqlite3", f.path+sqliteParams)
+}
+
 func (f *testErrorFactory) newConnection() (*gorm.DB, error) {
 	return nil, errors.New("testErrorFactory")
 }
@@ -63,54 +88,211 @@ func (f *testCorrectFactory) newConnection() (*gorm.DB, error) {
     return *db, nil
 }
 
+// connectionPool holds the *gorm.DB of the server, its database/sql pool is shared by all the connections.
+// The pool is created on first use and pinged when it was not checked for the health check interval,
+// a failed connect or ping is retried with an exponential backoff.
+// The advisory locks are held on the connections of a separate lock DB, which does not keep idle connections,
+// so the locks do not take the connections of the pool and a released connection ends the session of its lock.
+type connectionPool struct {
+	factory   ConnectionFactory
+	config    resources.DatabasePoolConfig
+	logger    logs.Logger
+	lock      sync.Mutex
+	db        *gorm.DB
+	lockDb    *gorm.DB
+	checkedAt time.Time
+	checking  chan struct{} // closed once the running connect or ping is done, nil if none is running
+	checkErr  error
+	closed    bool
+}
+
+func (p *connectionPool) get() (*gorm.DB, error) {
+	retryInterval := p.config.RetryInterval
+	for retry := 0; ; retry++ {
+		db, err := p.check()
+		if err == nil {
+			return db, nil
+		}
+		metrics.DatabaseOpenFailuresTotal.Inc()
+		if retry >= p.config.OpenRetries {
+			return nil, err
+		}
+		p.logger.Warning("DB connection failed, retrying", logs.Args{{"error", err}, {"retry", retry + 1}, {"wait", retryInterval}})
+		time.Sleep(retryInterval)
+		retryInterval *= 2
+	}
+}
+
+// getLockDb returns the DB of the advisory locks, it is connected together with the pool
+func (p *connectionPool) getLockDb() *sql.DB {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.lockDb == nil {
+		return nil
+	}
+	return p.lockDb.DB()
+}
+
+// check returns the pooled DB, connecting it if needed and pinging it once the health check interval passed.
+// A single request connects or pings at a time, the others wait for it only while there is no DB yet
+// (a healthy request does not wait for the ping of another one), and nothing waits under the lock.
+func (p *connectionPool) check() (*gorm.DB, error) {
+	p.lock.Lock()
+	if p.db != nil && (p.checking != nil || time.Since(p.checkedAt) < p.config.HealthCheckInterval) {
+		db := p.db
+		p.lock.Unlock()
+		return db, nil
+	}
+	if checking := p.checking; checking != nil {
+		p.lock.Unlock()
+		<-checking
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		return p.db, p.checkErr
+	}
+	checking := make(chan struct{})
+	p.checking = checking
+	db := p.db
+	p.lock.Unlock()
+
+	var lockDb *gorm.DB
+	var err error
+	if db == nil {
+		db, lockDb, err = p.connect()
+	} else {
+		err = db.DB().Ping()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	defer close(checking)
+	p.checking = nil
+	p.checkErr = err
+	if err != nil {
+		return nil, err
+	}
+	if p.closed {
+		closeDb(p.logger, lockDb)
+		closeDb(p.logger, db)
+		return nil, errors.New("Connection pool closed")
+	}
+	if p.db == nil {
+		p.db = db
+		p.lockDb = lockDb
+	}
+	p.checkedAt = time.Now()
+	return p.db, nil
+}
+
+// connect opens the pool and the lock DB
+func (p *connectionPool) connect() (*gorm.DB, *gorm.DB, error) {
+	db, err := p.factory.newConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the test factory has no DB
+	if db == nil {
+		return nil, nil, nil
+	}
+	db.DB().SetMaxOpenConns(p.config.MaxOpenConns)
+	if p.config.MaxIdleConns > 0 {
+		db.DB().SetMaxIdleConns(p.config.MaxIdleConns)
+	}
+	db.DB().SetConnMaxLifetime(p.config.ConnMaxLifetime)
+	registerWriteFence(db)
+
+	lockDb, err := p.factory.newConnection()
+	if err != nil {
+		closeDb(p.logger, db)
+		return nil, nil, err
+	}
+	// a lock connection is closed once released, which ends its session with any lock or setting left on it
+	lockDb.DB().SetMaxIdleConns(0)
+	return db, lockDb, nil
+}
+
+func (p *connectionPool) close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.closed = true
+	closeDb(p.logger, p.lockDb)
+	closeDb(p.logger, p.db)
+	p.db = nil
+	p.lockDb = nil
+}
+
+func closeDb(logger logs.Logger, db *gorm.DB) {
+	if db == nil {
+		return
+	}
+	if err := db.Close(); err != nil {
+		logger.ErrorRet(err, "db.Close failed")
+	}
+}
+
+// Connection is a request's use of the shared pool, Close releases it without closing the pool
 type Connection struct {
-	factory ConnectionFactory
-	logger  logs.Logger
-	db      *gorm.DB
+	pool   *connectionPool
+	logger logs.Logger
+	db     *gorm.DB
+	open   bool
 }
 
 func NewConnection() Connection {
-	return Connection{logger: logs.GetLogger(), factory: globalConnectionFactory}
+	return Connection{logger: logs.GetLogger(), pool: globalConnectionPool}
 }
 
 func (c *Connection) Open() error {
 	defer c.logger.Trace(logs.DEBUG)()
+
+	if err := c.openPooled(); err != nil {
+		return err
+	}
+
+	// migrate the schema, if the DB could not be reached when the server started
+	if err := migrateOnce(c.db); err != nil {
+		defer c.Close()
+		return c.logger.ErrorRet(err, "migrateOnce failed")
+	}
+
+	return nil
+}
+
+// openPooled opens the connection without migrating the schema, for the heartbeat of a standby server that must not migrate it
+func (c *Connection) openPooled() error {
 	var err error
 
 	// sanity
-	if c.db != nil {
+	if c.open {
 		return c.logger.ErrorRet(errors.New("Connection already open"), "failed")
 	}
-
-	// open db connection
-	if c.db, err = c.factory.newConnection(); err != nil {
-		return c.logger.ErrorRet(err, "failed")
+	if c.pool == nil {
+		metrics.DatabaseOpenFailuresTotal.Inc()
+		return c.logger.ErrorRet(errors.New("Connection factory not initialized"), "failed")
 	}
 
-	// do migrations
-	if err = doMigrations(*c); err != nil {
-		defer c.Close()
-		return c.logger.ErrorRet(err, "doMigrations failed")
+	// get the pooled db
+	if c.db, err = c.pool.get(); err != nil {
+		return c.logger.ErrorRet(err, "failed")
 	}
+	c.open = true
 
 	return nil
 }
 
 func (c *Connection) Close() error {
 	defer c.logger.Trace(logs.DEBUG)()
-	var err error
 
 	// sanity
-	if c.db == nil {
+	if !c.open {
 		return c.logger.ErrorRet(errors.New("Connection already closed"), "failed")
 	}
 
-	// close db connection
-	err = c.db.Close()
+	// the pooled connections are returned to the pool by each statement, so only the db is released
 	c.db = nil
-	if err != nil {
-		return c.logger.ErrorRet(err, "failed")
-	}
+	c.open = false
 
 	return nil
 }
@@ -120,3 +302,11 @@ func (c *Connection) GetDb() *gorm.DB {
 
 	return c.db
 }
+
+// getLockDb returns the DB of the advisory locks, its connections are not shared with GetDb and are never reused
+func (c *Connection) getLockDb() *sql.DB {
+	if !c.open {
+		return nil
+	}
+	return c.pool.getLockDb()
+}
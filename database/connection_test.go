@@ -17,7 +17,14 @@
 package database_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
 	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -44,5 +51,74 @@ var _ = Describe("Connection", func() {
             err = dbConnection.Close()
             Expect(err).To(HaveOccurred())
         })
+        It("close success after open", func() {
+			defer database.InitTestCorrect()()
+            dbConnection := database.NewConnection()
+            Expect(dbConnection.Open()).To(Succeed())
+            Expect(dbConnection.Close()).To(Succeed())
+            Expect(dbConnection.Close()).ToNot(Succeed())
+        })
+        It("open fail if the factory fails", func() {
+			defer database.InitTestError()()
+            dbConnection := database.NewConnection()
+            err = dbConnection.Open()
+            Expect(err).To(HaveOccurred())
+            Expect(dbConnection.GetDb()).To(BeNil())
+        })
+        It("open fail if the connection factory is not initialized", func() {
+            dbConnection := database.NewConnection()
+            err = dbConnection.Open()
+            Expect(err).To(HaveOccurred())
+            Expect(dbConnection.GetDb()).To(BeNil())
+        })
     })
+
+	Context("with a SQLite database", func() {
+		var dir string
+		BeforeEach(func() {
+			dir, err = ioutil.TempDir("", "ubiquity-connection")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should share the pooled DB between the connections", func() {
+			defer database.InitSqlite(filepath.Join(dir, "ubiquity.db"))()
+			first := database.NewConnection()
+			Expect(first.Open()).To(Succeed())
+			second := database.NewConnection()
+			Expect(second.Open()).To(Succeed())
+			defer second.Close()
+			Expect(second.GetDb()).To(BeIdenticalTo(first.GetDb()))
+
+			// closing a connection does not close the pool
+			Expect(first.Close()).To(Succeed())
+			Expect(first.GetDb()).To(BeNil())
+			var count int
+			Expect(second.GetDb().Table("volumes").Count(&count).Error).ToNot(HaveOccurred())
+		})
+
+		It("should not hold the other requests while a request waits to retry the connect", func() {
+			// the DB cannot be opened under a regular file
+			Expect(ioutil.WriteFile(filepath.Join(dir, "file"), []byte{}, 0600)).To(Succeed())
+			pool := resources.DatabasePoolConfig{OpenRetries: 2, RetryInterval: 200 * time.Millisecond}
+			defer database.InitializeFromConfig(resources.DatabaseConfig{Type: resources.DatabaseTypeSqlite, SqlitePath: filepath.Join(dir, "file", "ubiquity.db"), Pool: pool})()
+
+			// every request retries for 600ms, which would add up if they waited for each other
+			start := time.Now()
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					dbConnection := database.NewConnection()
+					Expect(dbConnection.Open()).ToNot(Succeed())
+				}()
+			}
+			wg.Wait()
+			Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+		})
+	})
 })
@@ -18,6 +18,7 @@ package database
 
 import (
     "os"
+    "path/filepath"
     "github.com/IBM/ubiquity/utils"
     "github.com/IBM/ubiquity/utils/logs"
     "github.com/IBM/ubiquity/resources"
@@ -101,27 +102,60 @@ func GetPsqlWithPassowrdStarred(psql string) string {
 }
 
 func InitPostgres(hostname string) func() {
+	return initPostgres(hostname, resources.DatabasePoolConfig{})
+}
+
+func initPostgres(hostname string, poolConfig resources.DatabasePoolConfig) func() {
 	defer logs.GetLogger().Trace(logs.DEBUG)()
 	psqlStr := GetPsqlConnectionParams(hostname) + " " + GetPsqlSslParams()
-	return initConnectionFactory(&postgresFactory{psql: psqlStr, psqlLog: GetPsqlWithPassowrdStarred(psqlStr)})
+	return initConnectionFactory(&postgresFactory{psql: psqlStr, psqlLog: GetPsqlWithPassowrdStarred(psqlStr)}, poolConfig)
+}
+
+// InitSqlite sets the Ubiquity DB to the SQLite DB file, which is created with its directory if missing
+func InitSqlite(path string) func() {
+	return initSqlite(path, resources.DatabasePoolConfig{})
+}
+
+func initSqlite(path string, poolConfig resources.DatabasePoolConfig) func() {
+	defer logs.GetLogger().Trace(logs.DEBUG)()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		logs.GetLogger().ErrorRet(err, "os.MkdirAll failed", logs.Args{{"path", path}})
+	}
+	return initConnectionFactory(&sqliteFactory{path: path}, poolConfig)
 }
 
 func InitTestError() func() {
     defer logs.GetLogger().Trace(logs.DEBUG)()
-    return initConnectionFactory(&testErrorFactory{})
+    return initConnectionFactory(&testErrorFactory{}, resources.DatabasePoolConfig{})
 }
 
 func InitTestCorrect() func() {
     defer logs.GetLogger().Trace(logs.DEBUG)()
-    return initConnectionFactory(&testCorrectFactory{})
+    cleanup := initConnectionFactory(&testCorrectFactory{}, resources.DatabasePoolConfig{})
+    // the test factory has no DB to migrate
+    setSchemaMigrated(true)
+    return cleanup
+}
+
+// InitializeFromConfig sets the Ubiquity DB of the given type and its connection pool, a postgres DB is set by the UBIQUITY_DB_* environment
+func InitializeFromConfig(config resources.DatabaseConfig) func() {
+	defer logs.GetLogger().Trace(logs.DEBUG)()
+	if config.Type == resources.DatabaseTypeSqlite {
+		return initSqlite(config.SqlitePath, config.Pool)
+	}
+	return initialize(config.Pool)
 }
 
 func Initialize() func() {
+	return initialize(resources.DatabasePoolConfig{})
+}
+
+func initialize(poolConfig resources.DatabasePoolConfig) func() {
 	defer logs.GetLogger().Trace(logs.DEBUG)()
 
     psqlHost := os.Getenv(KeyPsqlHost)
     if psqlHost != "" {
-        return InitPostgres(psqlHost)
+        return initPostgres(psqlHost, poolConfig)
     }
     return func() {
         logs.GetLogger().ErrorRet(&utils.NoENVKeyError{KeyPsqlHost}, "failed")
@@ -0,0 +1,145 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	OperationCreateVolume = "create-volume"
+	OperationRemoveVolume = "remove-volume"
+)
+
+const (
+	OperationPending    = "pending"
+	OperationCompleted  = "completed"
+	OperationRolledBack = "rolled-back"
+)
+
+// Operation is an entry of the operation journal. A backend records the intent of a create or a remove before it changes
+// the storage, and completes the entry (or rolls it back) once the storage and the Ubiquity DB agree again.
+// The entries that are still pending when the server starts are the operations that a restart interrupted.
+type Operation struct {
+	gorm.Model
+	Backend     string
+	Type        string
+	VolumeName  string
+	StorageName string // the name of the volume on the storage, it is known before the storage is changed
+	StorageId   string // the id of the volume on the storage (e.g the WWN), once it is known
+	State       string
+}
+
+//go:generate counterfeiter -o ../fakes/fake_journal.go . Journal
+
+// Journal is the operation journal of a backend instance
+type Journal interface {
+	// Begin records the operation as pending, before the storage is changed
+	Begin(operation *Operation) error
+	// Update records the storage id of the operation, once the storage returned it
+	Update(operation *Operation) error
+	// Complete records that the operation is done on the storage and in the Ubiquity DB
+	Complete(operation *Operation) error
+	// RollBack records that the operation was undone, the creating record of a rolled back create is deleted
+	RollBack(operation *Operation) error
+	// ListPending returns the pending operations of the backend, the oldest first
+	ListPending() ([]Operation, error)
+}
+
+type journal struct {
+	logger  logs.Logger
+	backend string
+}
+
+// NewJournal returns the operation journal of the backend instance in the Ubiquity DB
+func NewJournal(backend string) Journal {
+	return &journal{logger: logs.GetLogger(), backend: backend}
+}
+
+func (j *journal) Begin(operation *Operation) error {
+	defer j.logger.Trace(logs.DEBUG)()
+
+	operation.Backend = j.backend
+	operation.State = OperationPending
+	return j.exec(func(db *gorm.DB) error {
+		return db.Create(operation).Error
+	})
+}
+
+func (j *journal) Update(operation *Operation) error {
+	defer j.logger.Trace(logs.DEBUG)()
+
+	return j.exec(func(db *gorm.DB) error {
+		return db.Model(operation).Update("storage_id", operation.StorageId).Error
+	})
+}
+
+func (j *journal) Complete(operation *Operation) error {
+	defer j.logger.Trace(logs.DEBUG)()
+
+	return j.exec(func(db *gorm.DB) error {
+		return db.Model(operation).Update("state", OperationCompleted).Error
+	})
+}
+
+func (j *journal) RollBack(operation *Operation) error {
+	defer j.logger.Trace(logs.DEBUG)()
+
+	return j.exec(func(db *gorm.DB) error {
+		tx := db.Begin()
+		if tx.Error != nil {
+			return tx.Error
+		}
+		if operation.Type == OperationCreateVolume {
+			err := tx.Where("name = ? AND backend = ? AND status = ?", operation.VolumeName, operation.Backend, resources.VolumeStatusCreating).Delete(&resources.Volume{}).Error
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if err := tx.Model(operation).Update("state", OperationRolledBack).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	})
+}
+
+func (j *journal) ListPending() ([]Operation, error) {
+	defer j.logger.Trace(logs.DEBUG)()
+
+	var operations []Operation
+	err := j.exec(func(db *gorm.DB) error {
+		return db.Where("backend = ? AND state = ?", j.backend, OperationPending).Order("id").Find(&operations).Error
+	})
+	return operations, err
+}
+
+func (j *journal) exec(f func(db *gorm.DB) error) error {
+	dbConnection := NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return j.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	if err := f(dbConnection.GetDb()); err != nil {
+		return j.logger.ErrorRet(err, "failed", logs.Args{{"backend", j.backend}})
+	}
+	return nil
+}
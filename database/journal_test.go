@@ -0,0 +1,90 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+)
+
+var _ = Describe("Journal", func() {
+	var (
+		dir     string
+		cleanup func()
+		journal database.Journal
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ubiquity-journal")
+		Expect(err).ToNot(HaveOccurred())
+		cleanup = database.InitSqlite(filepath.Join(dir, "ubiquity.db"))
+		journal = database.NewJournal("backend1")
+	})
+	AfterEach(func() {
+		cleanup()
+		os.RemoveAll(dir)
+	})
+
+	It("should list the pending operations of the backend only", func() {
+		create := &database.Operation{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageName: "u_vol1"}
+		Expect(journal.Begin(create)).To(Succeed())
+		remove := &database.Operation{Type: database.OperationRemoveVolume, VolumeName: "vol2", StorageId: "wwn2"}
+		Expect(journal.Begin(remove)).To(Succeed())
+		Expect(database.NewJournal("backend2").Begin(&database.Operation{Type: database.OperationCreateVolume, VolumeName: "vol3"})).To(Succeed())
+
+		Expect(journal.Complete(remove)).To(Succeed())
+		create.StorageId = "wwn1"
+		Expect(journal.Update(create)).To(Succeed())
+
+		operations, err := journal.ListPending()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(operations).To(HaveLen(1))
+		Expect(operations[0].VolumeName).To(Equal("vol1"))
+		Expect(operations[0].StorageId).To(Equal("wwn1"))
+		Expect(operations[0].State).To(Equal(database.OperationPending))
+	})
+	It("should delete the creating volume of a rolled back create", func() {
+		dbConnection := database.NewConnection()
+		Expect(dbConnection.Open()).To(Succeed())
+		defer dbConnection.Close()
+		db := dbConnection.GetDb()
+		Expect(db.Create(&resources.Volume{Name: "vol1", Backend: "backend1", Status: resources.VolumeStatusCreating}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&resources.Volume{Name: "vol2", Backend: "backend1", Status: resources.VolumeStatusAvailable}).Error).ToNot(HaveOccurred())
+
+		// only a volume that is still creating is deleted
+		for _, name := range []string{"vol1", "vol2"} {
+			create := &database.Operation{Type: database.OperationCreateVolume, VolumeName: name}
+			Expect(journal.Begin(create)).To(Succeed())
+			Expect(journal.RollBack(create)).To(Succeed())
+		}
+
+		var volumes []resources.Volume
+		Expect(db.Order("name").Find(&volumes).Error).ToNot(HaveOccurred())
+		Expect(volumes).To(HaveLen(1))
+		Expect(volumes[0].Name).To(Equal("vol2"))
+		operations, err := journal.ListPending()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(operations).To(BeEmpty())
+	})
+})
@@ -0,0 +1,240 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+const HeartbeatLeaseName = "ubiquity-heartbeat"
+
+// Lease is a named lease in the Ubiquity DB, held by Holder until ExpiresAt unless renewed.
+// Token is the fencing token, it is incremented every time the lease is acquired.
+// The times are set by the database clock, so the servers do not depend on each other's clocks.
+type Lease struct {
+	Name      string `gorm:"primary_key"`
+	Holder    string
+	Token     int64
+	RenewedAt time.Time
+	ExpiresAt time.Time
+}
+
+// acquireLeaseSql takes the lease if it is missing, expired or already held by the holder, and returns the new fencing token
+const acquireLeaseSql = `INSERT INTO leases (name, holder, token, renewed_at, expires_at)
+VALUES (?, ?, 1, now(), now() + ? * interval '1 millisecond')
+ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, token = leases.token + 1, renewed_at = EXCLUDED.renewed_at, expires_at = EXCLUDED.expires_at
+WHERE leases.expires_at < now() OR leases.holder = EXCLUDED.holder
+RETURNING token`
+
+// createLeasesTableSql creates the leases table for the heartbeat, which is acquired before the server migrates the schema
+const createLeasesTableSql = `CREATE TABLE IF NOT EXISTS leases (name text, holder text, token bigint,
+renewed_at timestamp with time zone, expires_at timestamp with time zone, PRIMARY KEY (name))`
+
+// renewLeaseSql extends the lease only if the holder still holds it with the same fencing token
+const renewLeaseSql = `UPDATE leases SET renewed_at = now(), expires_at = now() + ? * interval '1 millisecond'
+WHERE name = ? AND holder = ? AND token = ?`
+
+// leaseHeartbeat is a heartbeat backed by a lease in the Ubiquity DB
+type leaseHeartbeat struct {
+	logger        logs.Logger
+	name          string
+	holder        string
+	leaseDuration time.Duration
+	token         int64
+	tableCreated  bool
+}
+
+// NewLeaseHeartbeat returns a heartbeat backed by the lease with the given name in the Ubiquity DB,
+// the holder identifies this server (the host name and process id by default).
+func NewLeaseHeartbeat(name string, holder string, config resources.HeartbeatConfig) utils.FencedHeartbeat {
+	if holder == "" {
+		hostname, _ := os.Hostname()
+		holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	return &leaseHeartbeat{logger: logs.GetLogger(), name: name, holder: holder, leaseDuration: config.LeaseDuration}
+}
+
+// Exists returns true if the lease is held and has not expired
+func (l *leaseHeartbeat) Exists() (bool, error) {
+	defer l.logger.Trace(logs.DEBUG)()
+
+	dbConnection := NewConnection()
+	if err := l.open(&dbConnection); err != nil {
+		return false, err
+	}
+	defer dbConnection.Close()
+
+	var count int
+	if err := dbConnection.GetDb().Model(&Lease{}).Where("name = ? AND expires_at > now()", l.name).Count(&count).Error; err != nil {
+		return false, l.logger.ErrorRet(err, "failed to count leases")
+	}
+	return count > 0, nil
+}
+
+// Create acquires the lease and its new fencing token, it fails with HeartbeatHeldError if another holder holds the lease
+func (l *leaseHeartbeat) Create() error {
+	defer l.logger.Trace(logs.DEBUG)()
+
+	dbConnection := NewConnection()
+	if err := l.open(&dbConnection); err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	var token int64
+	err := dbConnection.GetDb().Raw(acquireLeaseSql, l.name, l.holder, l.leaseDuration.Nanoseconds()/int64(time.Millisecond)).Row().Scan(&token)
+	if err == sql.ErrNoRows {
+		var lease Lease
+		dbConnection.GetDb().Where("name = ?", l.name).First(&lease)
+		return l.logger.ErrorRet(&utils.HeartbeatHeldError{Holder: lease.Holder}, "failed")
+	}
+	if err != nil {
+		return l.logger.ErrorRet(err, "failed to acquire lease")
+	}
+	l.token = token
+	l.logger.Info("lease acquired", logs.Args{{"lease", l.name}, {"holder", l.holder}, {"token", token}})
+	return nil
+}
+
+// Update renews the lease, it fails with HeartbeatLostError if the lease was taken over since it was acquired
+func (l *leaseHeartbeat) Update() error {
+	defer l.logger.Trace(logs.DEBUG)()
+
+	dbConnection := NewConnection()
+	if err := l.open(&dbConnection); err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	result := dbConnection.GetDb().Exec(renewLeaseSql, l.leaseDuration.Nanoseconds()/int64(time.Millisecond), l.name, l.holder, l.token)
+	if result.Error != nil {
+		return l.logger.ErrorRet(result.Error, "failed to renew lease")
+	}
+	if result.RowsAffected == 0 {
+		return l.logger.ErrorRet(&utils.HeartbeatLostError{Holder: l.holder, Token: l.token}, "failed")
+	}
+	return nil
+}
+
+// GetLastUpdateTimestamp returns the time the lease was last renewed, in the local clock
+func (l *leaseHeartbeat) GetLastUpdateTimestamp() (time.Time, error) {
+	defer l.logger.Trace(logs.DEBUG)()
+
+	dbConnection := NewConnection()
+	if err := l.open(&dbConnection); err != nil {
+		return time.Time{}, err
+	}
+	defer dbConnection.Close()
+
+	// the age of the lease is computed by the database, so the clock of the database does not need to match the local one
+	var ageMilliseconds float64
+	err := dbConnection.GetDb().Raw("SELECT EXTRACT(EPOCH FROM now() - renewed_at) * 1000 FROM leases WHERE name = ?", l.name).Row().Scan(&ageMilliseconds)
+	if err != nil {
+		return time.Time{}, l.logger.ErrorRet(err, "failed to get lease")
+	}
+	return time.Now().Add(-time.Duration(ageMilliseconds) * time.Millisecond), nil
+}
+
+// FencingToken returns the fencing token of the lease since it was last acquired by this server
+func (l *leaseHeartbeat) FencingToken() int64 {
+	return l.token
+}
+
+// writeFence is the lease and fencing token that the writes to the Ubiquity DB are checked against, see FenceWrites
+type writeFence struct {
+	lease string
+	token int64
+}
+
+var (
+	writeFenceLock sync.RWMutex
+	currentFence   *writeFence
+)
+
+// FenceWrites rejects the writes (create, update and delete) to the Ubiquity DB once the lease was acquired again since the
+// server got the fencing token, so a server that lost its lease to a peer cannot write after the peer took over.
+// It returns a function that stops fencing the writes.
+func FenceWrites(lease string, token int64) func() {
+	writeFenceLock.Lock()
+	defer writeFenceLock.Unlock()
+	currentFence = &writeFence{lease: lease, token: token}
+	return func() {
+		writeFenceLock.Lock()
+		defer writeFenceLock.Unlock()
+		currentFence = nil
+	}
+}
+
+func getWriteFence() *writeFence {
+	writeFenceLock.RLock()
+	defer writeFenceLock.RUnlock()
+	return currentFence
+}
+
+// registerWriteFence checks the fencing token in the transaction of every create, update and delete of the DB
+func registerWriteFence(db *gorm.DB) {
+	db.Callback().Create().After("gorm:begin_transaction").Register("ubiquity:fence_write", fenceWriteCallback)
+	db.Callback().Update().After("gorm:begin_transaction").Register("ubiquity:fence_write", fenceWriteCallback)
+	db.Callback().Delete().After("gorm:begin_transaction").Register("ubiquity:fence_write", fenceWriteCallback)
+}
+
+// fenceWriteCallback fails the write if the lease has a newer fencing token than the server's. On Postgres the lease row is
+// locked until the write commits, so a peer cannot take the lease over between the check and the write.
+func fenceWriteCallback(scope *gorm.Scope) {
+	fence := getWriteFence()
+	if fence == nil || scope.HasError() {
+		return
+	}
+	query := "SELECT token FROM leases WHERE name = ?"
+	if scope.Dialect().GetName() == "postgres" {
+		query += " FOR SHARE"
+	}
+	var token int64
+	err := scope.NewDB().Raw(query, fence.lease).Row().Scan(&token)
+	if err == sql.ErrNoRows || (err == nil && token != fence.token) {
+		err = &utils.StaleFencingTokenError{Lease: fence.lease, Token: fence.token}
+	}
+	if err != nil {
+		scope.Err(err)
+	}
+}
+
+// open opens the connection of the lease without migrating the schema, since a standby server must not migrate it
+// under the active one, and creates the leases table if the DB was not migrated yet
+func (l *leaseHeartbeat) open(dbConnection *Connection) error {
+	if err := dbConnection.openPooled(); err != nil {
+		return l.logger.ErrorRet(err, "dbConnection.openPooled failed")
+	}
+	if l.tableCreated {
+		return nil
+	}
+	if err := dbConnection.GetDb().Exec(createLeasesTableSql).Error; err != nil {
+		dbConnection.Close()
+		return l.logger.ErrorRet(err, "failed to create the leases table")
+	}
+	l.tableCreated = true
+	return nil
+}
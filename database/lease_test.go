@@ -0,0 +1,149 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	"github.com/jinzhu/gorm"
+)
+
+var _ = Describe("Lease", func() {
+	var (
+		config resources.HeartbeatConfig
+	)
+	BeforeEach(func() {
+		config = resources.HeartbeatConfig{Mode: resources.HeartbeatModeDatabase, Interval: time.Second, LeaseDuration: 3 * time.Second}
+	})
+
+	Context(".Create", func() {
+		It("should fail if the database is not reachable", func() {
+			defer database.InitTestError()()
+			heartbeat := database.NewLeaseHeartbeat("test-lease", "server1", config)
+			Expect(heartbeat.Create()).To(HaveOccurred())
+			Expect(heartbeat.FencingToken()).To(BeZero())
+		})
+	})
+
+	Context("with a database", func() {
+		var name string
+		BeforeEach(func() {
+			if os.Getenv(database.KeyPsqlHost) == "" {
+				Skip(database.KeyPsqlHost + " environment is empty, skip the lease DB integration test.")
+			}
+			name = "test-lease-" + time.Now().Format("150405.000000")
+		})
+
+		It("should hand the lease to a single holder with increasing fencing tokens", func() {
+			defer database.Initialize()()
+			first := database.NewLeaseHeartbeat(name, "server1", config)
+			second := database.NewLeaseHeartbeat(name, "server2", config)
+
+			Expect(first.Create()).To(Succeed())
+			Expect(first.FencingToken()).To(Equal(int64(1)))
+			exists, err := second.Exists()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(second.Create()).To(BeAssignableToTypeOf(&utils.HeartbeatHeldError{}))
+			Expect(first.Update()).To(Succeed())
+
+			// a holder that is not renewed for the lease duration loses the lease, and gets fenced
+			short := config
+			short.LeaseDuration = time.Millisecond
+			expiring := database.NewLeaseHeartbeat(name, "server1", short)
+			Expect(expiring.Create()).To(Succeed())
+			time.Sleep(10 * time.Millisecond)
+			Expect(second.Create()).To(Succeed())
+			Expect(second.FencingToken()).To(Equal(int64(3)))
+			Expect(expiring.Update()).To(BeAssignableToTypeOf(&utils.HeartbeatLostError{}))
+		})
+
+		It("should not migrate the schema before the lease is acquired", func() {
+			component := name + "-component"
+			migrated := false
+			database.RegisterMigrations(component, database.Migration{Version: 1, Up: func(db *gorm.DB) error { migrated = true; return nil }})
+			defer database.UnregisterMigrations(component)
+
+			defer database.Initialize()()
+			heartbeat := database.NewLeaseHeartbeat(name, "server1", config)
+			_, err := heartbeat.Exists()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeat.Create()).To(Succeed())
+			Expect(migrated).To(BeFalse())
+
+			Expect(database.Migrate()).To(Succeed())
+			Expect(migrated).To(BeTrue())
+		})
+	})
+
+	Context(".FenceWrites", func() {
+		var (
+			dir     string
+			cleanup func()
+		)
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "ubiquity-lease")
+			Expect(err).ToNot(HaveOccurred())
+			cleanup = database.InitSqlite(filepath.Join(dir, "ubiquity.db"))
+		})
+		AfterEach(func() {
+			cleanup()
+			os.RemoveAll(dir)
+		})
+
+		It("should reject the writes of a holder once the lease was taken over", func() {
+			dbConnection := database.NewConnection()
+			Expect(dbConnection.Open()).To(Succeed())
+			defer dbConnection.Close()
+			db := dbConnection.GetDb()
+			Expect(db.Create(&database.Lease{Name: "test-lease", Holder: "server1", Token: 1}).Error).ToNot(HaveOccurred())
+
+			defer database.FenceWrites("test-lease", 1)()
+			volume := &resources.Volume{Name: "vol1", Backend: "backend1", Status: resources.VolumeStatusAvailable}
+			Expect(db.Create(volume).Error).ToNot(HaveOccurred())
+
+			// server2 takes the lease over, then the writes of server1 are rolled back
+			Expect(db.Exec("UPDATE leases SET holder = ?, token = ? WHERE name = ?", "server2", 2, "test-lease").Error).ToNot(HaveOccurred())
+			err := db.Create(&resources.Volume{Name: "vol2", Backend: "backend1", Status: resources.VolumeStatusAvailable}).Error
+			Expect(err).To(BeAssignableToTypeOf(&utils.StaleFencingTokenError{}))
+			Expect(db.Model(volume).Update("attached_host", "host1").Error).To(BeAssignableToTypeOf(&utils.StaleFencingTokenError{}))
+			Expect(db.Delete(volume).Error).To(BeAssignableToTypeOf(&utils.StaleFencingTokenError{}))
+
+			var volumes []resources.Volume
+			Expect(db.Find(&volumes).Error).ToNot(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Name).To(Equal("vol1"))
+			Expect(volumes[0].AttachedHost).To(BeEmpty())
+		})
+		It("should not check the writes if they are not fenced", func() {
+			dbConnection := database.NewConnection()
+			Expect(dbConnection.Open()).To(Succeed())
+			defer dbConnection.Close()
+			Expect(dbConnection.GetDb().Create(&resources.Volume{Name: "vol1", Backend: "backend1"}).Error).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,283 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
+)
+
+// lockNotAvailableCode is the postgres error code of a lock that was not acquired within lock_timeout
+const lockNotAvailableCode = "55P03"
+
+// lockers are the lockers created by NewLocker, by namespace, which are listed by GetHeldLocks
+var (
+	lockers     = make(map[string][]utils.Locker)
+	lockersLock = &sync.Mutex{}
+)
+
+// NewLocker returns the locker of the configured mode. The names of the locks are scoped by the namespace,
+// so the locks of different users (e.g the volumes and the hosts of an SCBE instance) do not collide in the DB.
+func NewLocker(namespace string, config resources.LockerConfig) utils.Locker {
+	var locker utils.Locker
+	if config.Mode == resources.LockerModeDatabase {
+		locker = NewAdvisoryLocker(namespace, config.Timeout)
+	} else {
+		locker = utils.NewLockerWithTimeout(config.Timeout)
+	}
+	lockersLock.Lock()
+	defer lockersLock.Unlock()
+	lockers[namespace] = append(lockers[namespace], locker)
+	return locker
+}
+
+// GetHeldLocks returns the locks held through all the lockers created by NewLocker, the oldest first.
+// The advisory locks that are held by the other servers are not listed.
+func GetHeldLocks() []resources.LockInfo {
+	lockersLock.Lock()
+	defer lockersLock.Unlock()
+	locks := make([]resources.LockInfo, 0)
+	for namespace, namespaceLockers := range lockers {
+		for _, locker := range namespaceLockers {
+			for _, lock := range locker.HeldLocks() {
+				lock.Namespace = namespace
+				locks = append(locks, lock)
+			}
+		}
+	}
+	utils.SortLockInfos(locks)
+	return locks
+}
+
+// advisoryLocker is a locker backed by postgres advisory locks, so it serializes the requests of all the Ubiquity servers.
+// An advisory lock belongs to a DB session, so every held lock keeps its own connection of the lock DB until it is unlocked
+// (outside of the pool of the requests, so the held locks do not starve their DB work), and a lock of a server that dies
+// is released as soon as its connection is closed.
+type advisoryLocker struct {
+	logger    logs.Logger
+	namespace string
+	timeout   time.Duration
+	heldLock  *sync.Mutex
+	held      map[string][]*advisoryLock
+	waiters   map[string]int
+}
+
+// advisoryLock is an advisory lock held by the session of its dedicated connection of the lock DB
+type advisoryLock struct {
+	utils.LockHolder
+	dbConnection Connection
+	conn         *sql.Conn
+	key          int64
+}
+
+// NewAdvisoryLocker returns a locker backed by postgres advisory locks in the Ubiquity DB,
+// that gives up waiting for a lock after the timeout (the default timeout if not positive)
+func NewAdvisoryLocker(namespace string, timeout time.Duration) utils.Locker {
+	if timeout <= 0 {
+		timeout = utils.DefaultLockTimeout * time.Second
+	}
+	return &advisoryLocker{logger: logs.GetLogger(), namespace: namespace, timeout: timeout, heldLock: &sync.Mutex{}, held: make(map[string][]*advisoryLock), waiters: make(map[string]int)}
+}
+
+func (l *advisoryLocker) WriteLock(name string) error {
+	return l.TryWriteLock(context.Background(), name)
+}
+
+func (l *advisoryLocker) WriteUnlock(name string) {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	l.unlock(name, metrics.LockModeWrite)
+}
+
+func (l *advisoryLocker) ReadLock(name string) error {
+	return l.TryReadLock(context.Background(), name)
+}
+
+func (l *advisoryLocker) ReadUnlock(name string) {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	l.unlock(name, metrics.LockModeRead)
+}
+
+func (l *advisoryLocker) TryWriteLock(ctx context.Context, name string) error {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return l.lock(ctx, name, metrics.LockModeWrite)
+}
+
+func (l *advisoryLocker) TryReadLock(ctx context.Context, name string) error {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return l.lock(ctx, name, metrics.LockModeRead)
+}
+
+func (l *advisoryLocker) HeldLocks() []resources.LockInfo {
+	defer l.logger.Trace(logs.DEBUG)()
+
+	l.heldLock.Lock()
+	defer l.heldLock.Unlock()
+	locks := make([]resources.LockInfo, 0)
+	for name, nameLocks := range l.held {
+		for _, lock := range nameLocks {
+			locks = append(locks, utils.NewLockInfo(name, lock.LockHolder, l.waiters[name]))
+		}
+	}
+	utils.SortLockInfos(locks)
+	return locks
+}
+
+// AdvisoryLockKey returns the key of the advisory lock of the name in the namespace
+func AdvisoryLockKey(namespace string, name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(namespace + "/" + name))
+	return int64(hash.Sum64())
+}
+
+// lock acquires the advisory lock of the name on a new connection of the lock DB, which is kept until the lock is released.
+// The DB gives up waiting at the deadline of the context (lock_timeout), and the connection is closed on failure,
+// which ends its session, so a lock that is acquired while the wait is cancelled is never left behind.
+func (l *advisoryLocker) lock(ctx context.Context, name string, mode string) error {
+	start := time.Now()
+	defer metrics.ObserveLockWait(mode, start)
+	requestId := logs.GetRequestId()
+
+	l.heldLock.Lock()
+	l.waiters[name]++
+	l.heldLock.Unlock()
+	defer func() {
+		l.heldLock.Lock()
+		defer l.heldLock.Unlock()
+		if l.waiters[name]--; l.waiters[name] == 0 {
+			delete(l.waiters, name)
+		}
+	}()
+
+	dbConnection := NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return l.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	lockDb := dbConnection.getLockDb()
+	if lockDb == nil {
+		dbConnection.Close()
+		return l.logger.ErrorRet(errors.New("lock DB not connected"), "failed")
+	}
+	conn, err := lockDb.Conn(ctx)
+	if err != nil {
+		dbConnection.Close()
+		return l.lockError(ctx, err, name, mode, start)
+	}
+	lock := &advisoryLock{LockHolder: utils.LockHolder{Mode: mode, RequestId: requestId}, dbConnection: dbConnection, conn: conn, key: AdvisoryLockKey(l.namespace, name)}
+
+	var lockTimeout int64
+	if deadline, ok := ctx.Deadline(); ok {
+		if lockTimeout = time.Until(deadline).Nanoseconds() / int64(time.Millisecond); lockTimeout < 1 {
+			lockTimeout = 1
+		}
+	}
+	lockSql := "SELECT pg_advisory_lock($1)"
+	if mode == metrics.LockModeRead {
+		lockSql = "SELECT pg_advisory_lock_shared($1)"
+	}
+	if _, err = conn.ExecContext(ctx, fmt.Sprintf("SET lock_timeout = %d", lockTimeout)); err == nil {
+		if _, err = conn.ExecContext(ctx, lockSql, lock.key); err == nil {
+			// the connection is not reused, but the lock timeout must not apply to the statements of the session that follow
+			_, err = conn.ExecContext(ctx, "RESET lock_timeout")
+		}
+	}
+	if err != nil {
+		l.close(lock)
+		return l.lockError(ctx, err, name, mode, start)
+	}
+
+	lock.Since = time.Now()
+	l.heldLock.Lock()
+	defer l.heldLock.Unlock()
+	l.held[name] = append(l.held[name], lock)
+	return nil
+}
+
+// lockError returns LockTimeoutError if the lock was not acquired because the wait timed out or was cancelled
+func (l *advisoryLocker) lockError(ctx context.Context, err error, name string, mode string, start time.Time) error {
+	if pqErr, ok := err.(*pq.Error); (ok && pqErr.Code == lockNotAvailableCode) || ctx.Err() != nil {
+		metrics.ObserveLockTimeout(mode)
+		return l.logger.ErrorRet(&utils.LockTimeoutError{Name: name, Timeout: time.Since(start)}, "failed")
+	}
+	return l.logger.ErrorRet(err, "failed to acquire advisory lock", logs.Args{{"lockName", name}, {"mode", mode}})
+}
+
+// unlock releases the advisory lock held in the mode by the current request (or else by the oldest request)
+func (l *advisoryLocker) unlock(name string, mode string) {
+	requestId := logs.GetRequestId()
+	l.heldLock.Lock()
+	locks := l.held[name]
+	index := -1
+	for i, lock := range locks {
+		if lock.Mode != mode {
+			continue
+		}
+		if lock.RequestId == requestId {
+			index = i
+			break
+		}
+		if index == -1 {
+			index = i
+		}
+	}
+	if index == -1 {
+		l.heldLock.Unlock()
+		l.logger.Warning("lock is not held", logs.Args{{"lockName", name}, {"mode", mode}})
+		return
+	}
+	lock := locks[index]
+	if locks = append(locks[:index], locks[index+1:]...); len(locks) == 0 {
+		delete(l.held, name)
+	} else {
+		l.held[name] = locks
+	}
+	l.heldLock.Unlock()
+
+	unlockSql := "SELECT pg_advisory_unlock($1)"
+	if mode == metrics.LockModeRead {
+		unlockSql = "SELECT pg_advisory_unlock_shared($1)"
+	}
+	if _, err := lock.conn.ExecContext(context.Background(), unlockSql, lock.key); err != nil {
+		// closing the connection ends the session (the lock DB keeps no idle connections), which releases the lock anyway
+		l.logger.ErrorRet(err, "failed to release advisory lock", logs.Args{{"lockName", name}})
+	}
+	l.close(lock)
+}
+
+// close closes the connection of the lock, the lock DB keeps no idle connections so this ends the session of the lock
+func (l *advisoryLocker) close(lock *advisoryLock) {
+	lock.conn.Close()
+	lock.dbConnection.Close()
+}
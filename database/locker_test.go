@@ -0,0 +1,132 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package database_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+)
+
+var _ = Describe("Locker", func() {
+
+	Context(".NewLocker", func() {
+		It("should return a memory locker that does not need the database", func() {
+			defer database.InitTestError()()
+			locker := database.NewLocker("test", resources.LockerConfig{Mode: resources.LockerModeMemory, Timeout: time.Second})
+			Expect(locker.WriteLock("volume1")).To(Succeed())
+			locker.WriteUnlock("volume1")
+		})
+
+		It("should return an advisory locker that fails if the database is not reachable", func() {
+			defer database.InitTestError()()
+			locker := database.NewLocker("test", resources.LockerConfig{Mode: resources.LockerModeDatabase, Timeout: time.Second})
+			Expect(locker.WriteLock("volume1")).To(HaveOccurred())
+			Expect(locker.ReadLock("volume1")).To(HaveOccurred())
+		})
+	})
+
+	Context(".GetHeldLocks", func() {
+		It("should list the locks of all the lockers with their namespaces", func() {
+			volumes := database.NewLocker("test-volume", resources.LockerConfig{Mode: resources.LockerModeMemory})
+			hosts := database.NewLocker("test-host", resources.LockerConfig{Mode: resources.LockerModeMemory})
+			Expect(volumes.WriteLock("volume1")).To(Succeed())
+			Expect(hosts.WriteLock("host1")).To(Succeed())
+
+			locks := database.GetHeldLocks()
+			Expect(locks).To(HaveLen(2))
+			Expect(locks[0].Namespace).To(Equal("test-volume"))
+			Expect(locks[0].Name).To(Equal("volume1"))
+			Expect(locks[1].Namespace).To(Equal("test-host"))
+			Expect(locks[1].Name).To(Equal("host1"))
+
+			volumes.WriteUnlock("volume1")
+			hosts.WriteUnlock("host1")
+			Expect(database.GetHeldLocks()).To(BeEmpty())
+		})
+	})
+
+	Context(".AdvisoryLockKey", func() {
+		It("should scope the keys by namespace", func() {
+			Expect(database.AdvisoryLockKey("volume", "vol1")).To(Equal(database.AdvisoryLockKey("volume", "vol1")))
+			Expect(database.AdvisoryLockKey("volume", "vol1")).ToNot(Equal(database.AdvisoryLockKey("scbe-host/scbe", "vol1")))
+		})
+	})
+
+	Context("with a database", func() {
+		var name string
+		BeforeEach(func() {
+			if os.Getenv(database.KeyPsqlHost) == "" {
+				Skip(database.KeyPsqlHost + " environment is empty, skip the advisory locker DB integration test.")
+			}
+			name = "test-lock-" + time.Now().Format("150405.000000")
+		})
+
+		It("should serialize the writers of all the lockers", func() {
+			defer database.Initialize()()
+			first := database.NewAdvisoryLocker("test", 100*time.Millisecond)
+			second := database.NewAdvisoryLocker("test", 100*time.Millisecond)
+
+			Expect(first.ReadLock(name)).To(Succeed())
+			Expect(second.ReadLock(name)).To(Succeed())
+			Expect(second.WriteLock(name)).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			first.ReadUnlock(name)
+			second.ReadUnlock(name)
+
+			Expect(first.WriteLock(name)).To(Succeed())
+			Expect(first.HeldLocks()).To(HaveLen(1))
+			Expect(second.ReadLock(name)).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Expect(second.TryReadLock(ctx, name)).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			first.WriteUnlock(name)
+			Expect(second.WriteLock(name)).To(Succeed())
+			second.WriteUnlock(name)
+			Expect(second.HeldLocks()).To(BeEmpty())
+		})
+
+		It("should not take the connections of the pool for the held locks", func() {
+			defer database.InitializeFromConfig(resources.DatabaseConfig{Pool: resources.DatabasePoolConfig{MaxOpenConns: 2}})()
+			locker := database.NewAdvisoryLocker("test", time.Second)
+			names := []string{name + "-1", name + "-2", name + "-3", name + "-4"}
+			for _, lockName := range names {
+				Expect(locker.WriteLock(lockName)).To(Succeed())
+				defer locker.WriteUnlock(lockName)
+			}
+
+			// the DB work of the requests that hold the locks is not blocked on the pool
+			done := make(chan error, 1)
+			go func() {
+				dbConnection := database.NewConnection()
+				if err := dbConnection.Open(); err != nil {
+					done <- err
+					return
+				}
+				defer dbConnection.Close()
+				var count int
+				done <- dbConnection.GetDb().Table("volumes").Count(&count).Error
+			}()
+			Eventually(done, 5*time.Second).Should(Receive(BeNil()))
+		})
+	})
+})
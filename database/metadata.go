@@ -0,0 +1,263 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+// volumeField is the field of a backend specific volume row that holds its resources.Volume, the metadata references the volume by VolumeID instead
+const volumeField = "Volume"
+
+// ExportMetadata returns the volumes, the rows of the backend specific volume tables and the snapshots of the Ubiquity DB
+func ExportMetadata(db *gorm.DB) (resources.Metadata, error) {
+	logger := logs.GetLogger()
+	defer logger.Trace(logs.DEBUG)()
+
+	metadata := resources.Metadata{
+		Version:        resources.MetadataVersion,
+		ExportedAt:     time.Now().UTC(),
+		Volumes:        []resources.Volume{},
+		BackendVolumes: make(map[string]json.RawMessage),
+		Snapshots:      []resources.Snapshot{},
+	}
+	if db.HasTable(&resources.Volume{}) {
+		// a creating volume has no backend row yet, it is not restorable
+		if err := db.Where("status <> ?", resources.VolumeStatusCreating).Order("id").Find(&metadata.Volumes).Error; err != nil {
+			return resources.Metadata{}, logger.ErrorRet(err, "db.Find failed", logs.Args{{"table", "volumes"}})
+		}
+	}
+	if db.HasTable(&resources.Snapshot{}) {
+		if err := db.Order("id").Find(&metadata.Snapshots).Error; err != nil {
+			return resources.Metadata{}, logger.ErrorRet(err, "db.Find failed", logs.Args{{"table", "snapshots"}})
+		}
+	}
+
+	for backendType, table := range getVolumeTables() {
+		// the table of a backend that was never configured does not exist
+		if !db.HasTable(table) {
+			continue
+		}
+		rows := newVolumeRows(table)
+		if err := db.Order("id").Find(rows.Interface()).Error; err != nil {
+			return resources.Metadata{}, logger.ErrorRet(err, "db.Find failed", logs.Args{{"backend", backendType}})
+		}
+		data, err := marshalVolumeRows(rows.Elem())
+		if err != nil {
+			return resources.Metadata{}, logger.ErrorRet(err, "marshalVolumeRows failed", logs.Args{{"backend", backendType}})
+		}
+		metadata.BackendVolumes[backendType] = data
+	}
+
+	logger.Info("succeeded", logs.Args{{"volumes", len(metadata.Volumes)}, {"snapshots", len(metadata.Snapshots)}})
+	return metadata, nil
+}
+
+// RestoreMetadata inserts the metadata into the Ubiquity DB, which must be migrated and have no volumes and no snapshots.
+// The rows get new IDs and the backend specific rows reference the new IDs of their volumes.
+// Nothing is inserted if the metadata is inconsistent or the restore fails.
+func RestoreMetadata(db *gorm.DB, metadata resources.Metadata) error {
+	logger := logs.GetLogger()
+	defer logger.Trace(logs.DEBUG)()
+
+	tables := getVolumeTables()
+	backendRows, err := validateMetadata(metadata, tables)
+	if err != nil {
+		return logger.ErrorRet(err, "validateMetadata failed")
+	}
+
+	models := []interface{}{&resources.Volume{}, &resources.Snapshot{}}
+	for _, table := range tables {
+		models = append(models, table)
+	}
+
+	tx := db.Begin()
+	if err = tx.Error; err != nil {
+		return logger.ErrorRet(err, "db.Begin failed")
+	}
+	if err = restoreMetadata(tx, metadata, models, backendRows); err != nil {
+		tx.Rollback()
+		return logger.ErrorRet(err, "restoreMetadata failed")
+	}
+	if err = tx.Commit().Error; err != nil {
+		return logger.ErrorRet(err, "tx.Commit failed")
+	}
+
+	logger.Info("succeeded", logs.Args{{"volumes", len(metadata.Volumes)}, {"snapshots", len(metadata.Snapshots)}})
+	return nil
+}
+
+func restoreMetadata(tx *gorm.DB, metadata resources.Metadata, models []interface{}, backendRows map[string]reflect.Value) error {
+	for _, model := range models {
+		count := 0
+		if err := tx.Model(model).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return &resources.MetadataConflictError{Reason: fmt.Sprintf("the DB is not empty, table [%s] has [%d] rows", tx.NewScope(model).TableName(), count)}
+		}
+	}
+
+	volumeIds := make(map[uint]uint)
+	for _, volume := range metadata.Volumes {
+		exportedId := volume.ID
+		volume.ID = 0
+		// the volumes of a DB that was exported before the volume status was added
+		if volume.Status == "" {
+			volume.Status = resources.VolumeStatusAvailable
+			if volume.AttachedHost != "" {
+				volume.Status = resources.VolumeStatusAttached
+			}
+		}
+		if err := tx.Create(&volume).Error; err != nil {
+			return err
+		}
+		volumeIds[exportedId] = volume.ID
+	}
+
+	for _, rows := range backendRows {
+		for i := 0; i < rows.Len(); i++ {
+			row := rows.Index(i)
+			row.FieldByName("ID").SetUint(0)
+			volumeId := row.FieldByName("VolumeID")
+			volumeId.SetUint(uint64(volumeIds[uint(volumeId.Uint())]))
+			if err := tx.Set("gorm:save_associations", false).Create(row.Addr().Interface()).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, snapshot := range metadata.Snapshots {
+		snapshot.ID = 0
+		if err := tx.Create(&snapshot).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMetadata checks the version and the consistency of the metadata, and returns the rows of the backend specific volume tables by backend type
+func validateMetadata(metadata resources.Metadata, tables map[string]interface{}) (map[string]reflect.Value, error) {
+	if metadata.Version < 1 || metadata.Version > resources.MetadataVersion {
+		return nil, &resources.MetadataVersionNotSupportedError{Version: metadata.Version}
+	}
+
+	volumesById := make(map[uint]resources.Volume)
+	volumeNames := make(map[string]string)
+	for _, volume := range metadata.Volumes {
+		// the DB volume is never kept in the DB, its backend finds it on the storage system
+		if IsDatabaseVolume(volume.Name) {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("volume [%s] is the DB volume, which is not kept in the DB", volume.Name)}
+		}
+		if _, exists := volumeNames[volume.Name]; exists {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("volume [%s] appears more than once", volume.Name)}
+		}
+		if _, exists := volumesById[volume.ID]; exists {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("volume ID [%d] appears more than once", volume.ID)}
+		}
+		volumesById[volume.ID] = volume
+		volumeNames[volume.Name] = volume.Backend
+	}
+
+	backendRows := make(map[string]reflect.Value)
+	referenced := make(map[uint]bool)
+	for backendType, data := range metadata.BackendVolumes {
+		table, ok := tables[backendType]
+		if !ok {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("backend type [%s] has no volume table", backendType)}
+		}
+		rows := newVolumeRows(table)
+		if err := json.Unmarshal(data, rows.Interface()); err != nil {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("the volumes of backend type [%s] cannot be parsed: %s", backendType, err.Error())}
+		}
+		rows = rows.Elem()
+		for i := 0; i < rows.Len(); i++ {
+			volumeId := uint(rows.Index(i).FieldByName("VolumeID").Uint())
+			volume, exists := volumesById[volumeId]
+			if !exists || volume.BackendType != backendType {
+				return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("a volume of backend type [%s] references volume ID [%d], which is not a volume of the backend type", backendType, volumeId)}
+			}
+			if referenced[volumeId] {
+				return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("volume [%s] has more than one row of backend type [%s]", volume.Name, backendType)}
+			}
+			referenced[volumeId] = true
+		}
+		backendRows[backendType] = rows
+	}
+	for _, volume := range metadata.Volumes {
+		if _, hasTable := tables[volume.BackendType]; hasTable && !referenced[volume.ID] {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("volume [%s] has no row of backend type [%s]", volume.Name, volume.BackendType)}
+		}
+	}
+
+	snapshots := make(map[string]bool)
+	for _, snapshot := range metadata.Snapshots {
+		if backend, exists := volumeNames[snapshot.VolumeName]; !exists || backend != snapshot.Backend {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("snapshot [%s] references volume [%s] of backend [%s], which is not in the metadata", snapshot.Name, snapshot.VolumeName, snapshot.Backend)}
+		}
+		key := snapshot.VolumeName + "/" + snapshot.Name
+		if snapshots[key] {
+			return nil, &resources.MetadataConflictError{Reason: fmt.Sprintf("snapshot [%s] of volume [%s] appears more than once", snapshot.Name, snapshot.VolumeName)}
+		}
+		snapshots[key] = true
+	}
+
+	return backendRows, nil
+}
+
+// getVolumeTables returns the backend specific volume tables of the registered backends by backend type
+func getVolumeTables() map[string]interface{} {
+	tables := make(map[string]interface{})
+	for _, backend := range registry.GetBackends() {
+		if backend.VolumeTable != nil {
+			tables[backend.Name] = backend.VolumeTable
+		}
+	}
+	return tables
+}
+
+// newVolumeRows returns a pointer to an empty slice of rows of the backend specific volume table
+func newVolumeRows(table interface{}) reflect.Value {
+	return reflect.New(reflect.SliceOf(reflect.TypeOf(table).Elem()))
+}
+
+// marshalVolumeRows marshals the rows of a backend specific volume table without their volume field, the rows reference their volume by VolumeID
+func marshalVolumeRows(rows reflect.Value) (json.RawMessage, error) {
+	if rows.Len() == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	data, err := json.Marshal(rows.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var fields []map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, row := range fields {
+		delete(row, volumeField)
+	}
+	return json.Marshal(fields)
+}
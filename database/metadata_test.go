@@ -0,0 +1,186 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+const testBackendType = "test-backend"
+
+type testBackendVolume struct {
+	ID       uint
+	Volume   resources.Volume
+	VolumeID uint
+	WWN      string
+}
+
+var _ = Describe("Metadata", func() {
+	var metadata resources.Metadata
+	BeforeEach(func() {
+		registry.RegisterBackend(registry.Backend{
+			Name:         testBackendType,
+			IsConfigured: func(config resources.UbiquityServerConfig) bool { return false },
+			NewStorageClient: func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+				return nil, nil
+			},
+			VolumeTable: &testBackendVolume{},
+		})
+		volume1 := resources.Volume{Name: "vol1", Backend: "backend1", BackendType: testBackendType}
+		volume1.ID = 7
+		volume2 := resources.Volume{Name: "vol2", Backend: "backend1", BackendType: testBackendType}
+		volume2.ID = 9
+		metadata = resources.Metadata{
+			Version:        resources.MetadataVersion,
+			Volumes:        []resources.Volume{volume1, volume2},
+			BackendVolumes: map[string]json.RawMessage{testBackendType: json.RawMessage(`[{"ID":1,"VolumeID":7,"WWN":"wwn1"},{"ID":2,"VolumeID":9,"WWN":"wwn2"}]`)},
+			Snapshots:      []resources.Snapshot{{Name: "snap1", VolumeName: "vol1", Backend: "backend1", StorageId: "snapwwn1"}},
+		}
+	})
+	AfterEach(func() {
+		registry.UnregisterBackend(testBackendType)
+	})
+
+	Context("with a database", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "ubiquity-metadata")
+			Expect(err).ToNot(HaveOccurred())
+			database.RegisterMigrations(testBackendType, database.Migration{Version: 1, Up: func(db *gorm.DB) error {
+				return db.AutoMigrate(&testBackendVolume{}).Error
+			}})
+		})
+		AfterEach(func() {
+			database.UnregisterMigrations(testBackendType)
+			os.RemoveAll(dir)
+		})
+
+		It("should restore the exported metadata into an empty DB", func() {
+			var exported []byte
+			func() {
+				defer database.InitSqlite(filepath.Join(dir, "exported.db"))()
+				dbConnection := database.NewConnection()
+				Expect(dbConnection.Open()).To(Succeed())
+				defer dbConnection.Close()
+				db := dbConnection.GetDb()
+
+				// the IDs of the exported volumes differ from the IDs they get when restored
+				Expect(db.Create(&resources.Volume{Name: "deleted"}).Error).ToNot(HaveOccurred())
+				Expect(db.Delete(&resources.Volume{}, "name = ?", "deleted").Error).ToNot(HaveOccurred())
+				Expect(db.Create(&testBackendVolume{Volume: resources.Volume{Name: "vol1", Backend: "backend1", BackendType: testBackendType, Labels: "app=db"}, WWN: "wwn1"}).Error).ToNot(HaveOccurred())
+				Expect(db.Create(&resources.Snapshot{Name: "snap1", VolumeName: "vol1", Backend: "backend1", StorageId: "snapwwn1"}).Error).ToNot(HaveOccurred())
+
+				metadata, err := database.ExportMetadata(db)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(metadata.Version).To(Equal(resources.MetadataVersion))
+				Expect(metadata.Volumes).To(HaveLen(1))
+				Expect(string(metadata.BackendVolumes[testBackendType])).ToNot(ContainSubstring(`"Volume":`))
+				exported, err = json.Marshal(metadata)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			defer database.InitSqlite(filepath.Join(dir, "restored.db"))()
+			dbConnection := database.NewConnection()
+			Expect(dbConnection.Open()).To(Succeed())
+			defer dbConnection.Close()
+			db := dbConnection.GetDb()
+
+			restored := resources.Metadata{}
+			Expect(json.Unmarshal(exported, &restored)).To(Succeed())
+			Expect(database.RestoreMetadata(db, restored)).To(Succeed())
+
+			var volume resources.Volume
+			Expect(db.Where("name = ?", "vol1").First(&volume).Error).ToNot(HaveOccurred())
+			Expect(volume.Labels).To(Equal("app=db"))
+			var backendVolume testBackendVolume
+			Expect(db.First(&backendVolume).Error).ToNot(HaveOccurred())
+			Expect(backendVolume.VolumeID).To(Equal(volume.ID))
+			Expect(backendVolume.WWN).To(Equal("wwn1"))
+			var snapshot resources.Snapshot
+			Expect(db.First(&snapshot).Error).ToNot(HaveOccurred())
+			Expect(snapshot.StorageId).To(Equal("snapwwn1"))
+
+			// the DB is not empty anymore
+			err := database.RestoreMetadata(db, restored)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+	})
+
+	Context(".RestoreMetadata", func() {
+		// the metadata is validated before the DB is used
+		It("should fail if the version is not supported", func() {
+			metadata.Version = resources.MetadataVersion + 1
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataVersionNotSupportedError{}))
+		})
+		It("should fail if a volume appears more than once", func() {
+			metadata.Volumes[1].Name = "vol1"
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+			Expect(err.Error()).To(ContainSubstring("vol1"))
+		})
+		It("should fail if the DB volume is given, since it is not kept in the DB", func() {
+			metadata.Volumes[1].Name = "u_instance_" + database.VolumeNameSuffix
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+		It("should fail if a backend volume references a missing volume", func() {
+			metadata.BackendVolumes[testBackendType] = json.RawMessage(`[{"ID":1,"VolumeID":7},{"ID":2,"VolumeID":8}]`)
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+		It("should fail if a volume has no backend volume", func() {
+			metadata.BackendVolumes[testBackendType] = json.RawMessage(`[{"ID":1,"VolumeID":7}]`)
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+			Expect(err.Error()).To(ContainSubstring("vol2"))
+		})
+		It("should fail if a volume has more than one backend volume", func() {
+			metadata.BackendVolumes[testBackendType] = json.RawMessage(`[{"ID":1,"VolumeID":7},{"ID":2,"VolumeID":7},{"ID":3,"VolumeID":9}]`)
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+		It("should fail if the backend type has no volume table", func() {
+			metadata.BackendVolumes["unknown"] = json.RawMessage(`[]`)
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+		It("should fail if a snapshot references a missing volume", func() {
+			metadata.Snapshots[0].Backend = "backend2"
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+		It("should fail if a snapshot appears more than once", func() {
+			metadata.Snapshots = append(metadata.Snapshots, metadata.Snapshots[0])
+			err := database.RestoreMetadata(nil, metadata)
+			Expect(err).To(BeAssignableToTypeOf(&resources.MetadataConflictError{}))
+		})
+	})
+})
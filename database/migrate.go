@@ -18,35 +18,299 @@ package database
 
 import (
 	"fmt"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
 )
 
-var migrations = new(sync.Map)
+// CoreComponent is the component of the tables shared by all the backends
+const CoreComponent = "ubiquity"
 
-func RegisterMigration(obj interface{}) {
-	defer logs.GetLogger().Trace(logs.DEBUG)()
-	migrations.Store(fmt.Sprintf("%v", obj), obj)
+// Migration is a versioned change of the schema of a component (e.g a backend package) in the Ubiquity DB.
+// The migrations of a component are applied in Version order, each one once, and the schema_version table records the
+// version of the last migration applied to every component. Up runs in a transaction and must not commit it.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(db *gorm.DB) error
 }
 
-func UnregisterAllMigrations() {
-	defer logs.GetLogger().Trace(logs.DEBUG)()
-	migrations = new(sync.Map)
+// SchemaVersion is the version of the schema of a component in the Ubiquity DB
+type SchemaVersion struct {
+	Component string `gorm:"primary_key"`
+	Version   int
+	AppliedAt time.Time
+}
+
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// MigrationFailedError is returned if a migration cannot be applied, the DB stays at the version of the previous migration
+type MigrationFailedError struct {
+	Component   string
+	Version     int
+	Description string
+	Err         error
+}
+
+func (e *MigrationFailedError) Error() string {
+	return fmt.Sprintf("Migration [%d] (%s) of component [%s] failed: %s", e.Version, e.Description, e.Component, e.Err.Error())
+}
+
+// insertSchemaVersionSql adds the component at version 0, so its row can be locked before its first migration
+const insertSchemaVersionSql = `INSERT INTO schema_version (component, version, applied_at) VALUES (?, 0, ?)
+ON CONFLICT (component) DO NOTHING`
+
+// setVolumeStatusSql sets the status of the existing volumes, a volume with a host is attached
+const setVolumeStatusSql = `UPDATE volumes SET status = CASE WHEN attached_host IS NULL OR attached_host = '' THEN ? ELSE ? END
+WHERE status IS NULL OR status = ''`
+
+var (
+	migrationsLock sync.Mutex
+	components     []string
+	migrations     = make(map[string][]Migration)
+	schemaMigrated bool
+)
+
+// The tables are created from frozen copies of the structs of each schema version, so a migration keeps
+// creating the same schema when the structs of the current version change.
+
+type volumeV1 struct {
+	gorm.Model
+	Name           string
+	Backend        string
+	BackendType    string
+	Mountpoint     string
+	SourceVolume   string
+	SourceSnapshot string
+	Labels         string
+	AttachedHost   string
+}
+
+func (volumeV1) TableName() string {
+	return "volumes"
+}
+
+type snapshotV1 struct {
+	gorm.Model
+	Name       string
+	VolumeName string
+	Backend    string
+	StorageId  string
+}
+
+func (snapshotV1) TableName() string {
+	return "snapshots"
+}
+
+type leaseV1 struct {
+	Name      string `gorm:"primary_key"`
+	Holder    string
+	Token     int64
+	RenewedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (leaseV1) TableName() string {
+	return "leases"
+}
+
+type volumeV2 struct {
+	volumeV1
+	Status string
+}
+
+func (volumeV2) TableName() string {
+	return "volumes"
+}
+
+type operationV3 struct {
+	gorm.Model
+	Backend     string
+	Type        string
+	VolumeName  string
+	StorageName string
+	StorageId   string
+	State       string
+}
+
+func (operationV3) TableName() string {
+	return "operations"
+}
+
+func init() {
+	// version 1 is the schema that was created by AutoMigrate before the schema was versioned, so existing DBs are adopted at version 1
+	RegisterMigrations(CoreComponent, Migration{
+		Version:     1,
+		Description: "create the volumes, snapshots and leases tables",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&volumeV1{}, &snapshotV1{}, &leaseV1{}).Error
+		},
+	}, Migration{
+		Version:     2,
+		Description: "add the volume status",
+		Up: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&volumeV2{}).Error; err != nil {
+				return err
+			}
+			return db.Exec(setVolumeStatusSql, resources.VolumeStatusAvailable, resources.VolumeStatusAttached).Error
+		},
+	}, Migration{
+		Version:     3,
+		Description: "create the operations table",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&operationV3{}).Error
+		},
+	})
+}
+
+// RegisterMigrations adds the migrations of the component, usually from the init function of its package.
+// The components are migrated in registration order, so the core tables are migrated before the backend tables that reference them.
+func RegisterMigrations(component string, componentMigrations ...Migration) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	if _, exists := migrations[component]; !exists {
+		components = append(components, component)
+	}
+	all := append(migrations[component], componentMigrations...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	for i, migration := range all {
+		if migration.Version <= 0 || migration.Up == nil || (i > 0 && all[i-1].Version == migration.Version) {
+			panic(fmt.Sprintf("database: migration %d of component %s must have a unique positive version and Up", migration.Version, component))
+		}
+	}
+	migrations[component] = all
+}
+
+// UnregisterMigrations removes the migrations of the component
+func UnregisterMigrations(component string) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	delete(migrations, component)
+	for i, name := range components {
+		if name == component {
+			components = append(components[:i], components[i+1:]...)
+			break
+		}
+	}
 }
 
-func doMigrations(connection Connection) error {
+// Migrate applies the pending migrations to the Ubiquity DB, the server runs it at startup.
+// If the DB cannot be reached yet (e.g the server provisions the volume of the DB) the first connection to the DB applies them.
+func Migrate() error {
 	defer logs.GetLogger().Trace(logs.DEBUG)()
 
+	dbConnection := NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return err
+	}
+	return dbConnection.Close()
+}
+
+// setSchemaMigrated sets whether the pending migrations were applied, a new connection factory may connect to another DB
+func setSchemaMigrated(migrated bool) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+	schemaMigrated = migrated
+}
+
+// migrateOnce applies the pending migrations once per process, a failed migration is retried by the next connection
+func migrateOnce(db *gorm.DB) error {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	if schemaMigrated {
+		return nil
+	}
+	if err := doMigrations(db); err != nil {
+		return err
+	}
+	schemaMigrated = true
+	return nil
+}
+
+func doMigrations(db *gorm.DB) error {
 	logger := logs.GetLogger()
+	defer logger.Trace(logs.DEBUG)()
 
-	migrations.Range(func(k, v interface{}) bool {
-		logger.Info("migrating", logs.Args{{"migration", k}})
-		if err := connection.GetDb().AutoMigrate(v).Error; err != nil {
-			logger.ErrorRet(err, "failed")
+	if err := db.AutoMigrate(&SchemaVersion{}).Error; err != nil {
+		return logger.ErrorRet(err, "db.AutoMigrate failed", logs.Args{{"table", SchemaVersion{}.TableName()}})
+	}
+
+	for _, component := range components {
+		componentMigrations := migrations[component]
+		if len(componentMigrations) == 0 {
+			continue
+		}
+		version := 0
+		for _, migration := range componentMigrations {
+			var applied bool
+			var err error
+			if version, applied, err = applyMigration(db, component, migration); err != nil {
+				return logger.ErrorRet(&MigrationFailedError{Component: component, Version: migration.Version, Description: migration.Description, Err: err}, "failed")
+			}
+			if applied {
+				logger.Info("migrated", logs.Args{{"component", component}, {"version", migration.Version}, {"description", migration.Description}})
+			}
 		}
-		migrations.Delete(k)
-		return true
-	})
 
+		// a newer server migrated the DB, its schema may not be compatible with this server
+		if latest := componentMigrations[len(componentMigrations)-1].Version; version > latest {
+			logger.Warning("The DB schema is newer than the schema of this server", logs.Args{{"component", component}, {"version", version}, {"latest", latest}})
+		}
+	}
 	return nil
 }
+
+// applyMigration applies the migration unless the component is at its version already, and returns the version of the component.
+// The row of the component in the schema_version table is locked until the migration is committed, so servers that start together apply it once.
+func applyMigration(db *gorm.DB, component string, migration Migration) (int, bool, error) {
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return 0, false, err
+	}
+	version, applied, err := applyMigrationInTransaction(tx, component, migration)
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+	if err = tx.Commit().Error; err != nil {
+		return 0, false, err
+	}
+	return version, applied, nil
+}
+
+func applyMigrationInTransaction(tx *gorm.DB, component string, migration Migration) (int, bool, error) {
+	if err := tx.Exec(insertSchemaVersionSql, component, time.Now()).Error; err != nil {
+		return 0, false, err
+	}
+	// a SQLite DB serves a single server and locks the whole DB for the write transaction
+	query := tx
+	if tx.Dialect().GetName() == "postgres" {
+		query = tx.Set("gorm:query_option", "FOR UPDATE")
+	}
+	schemaVersion := SchemaVersion{}
+	if err := query.Where("component = ?", component).First(&schemaVersion).Error; err != nil {
+		return 0, false, err
+	}
+	if schemaVersion.Version >= migration.Version {
+		return schemaVersion.Version, false, nil
+	}
+
+	if err := migration.Up(tx); err != nil {
+		return 0, false, err
+	}
+	schemaVersion.Version = migration.Version
+	schemaVersion.AppliedAt = time.Now()
+	if err := tx.Save(&schemaVersion).Error; err != nil {
+		return 0, false, err
+	}
+	return schemaVersion.Version, true, nil
+}
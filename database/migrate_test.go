@@ -0,0 +1,141 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/jinzhu/gorm"
+)
+
+var _ = Describe("Migrate", func() {
+	var component string
+	BeforeEach(func() {
+		component = "test-component-" + time.Now().Format("150405.000000")
+	})
+	AfterEach(func() {
+		database.UnregisterMigrations(component)
+	})
+	noop := func(db *gorm.DB) error { return nil }
+
+	Context(".RegisterMigrations", func() {
+		It("should panic if a version is registered twice", func() {
+			database.RegisterMigrations(component, database.Migration{Version: 1, Up: noop})
+			Expect(func() {
+				database.RegisterMigrations(component, database.Migration{Version: 1, Up: noop})
+			}).To(Panic())
+		})
+		It("should panic if the version is not positive", func() {
+			Expect(func() {
+				database.RegisterMigrations(component, database.Migration{Version: 0, Up: noop})
+			}).To(Panic())
+		})
+		It("should panic if the migration has no Up", func() {
+			Expect(func() {
+				database.RegisterMigrations(component, database.Migration{Version: 1})
+			}).To(Panic())
+		})
+	})
+
+	Context(".Migrate", func() {
+		It("should fail without a migration error if the database is not reachable", func() {
+			defer database.InitTestError()()
+			err := database.Migrate()
+			Expect(err).To(HaveOccurred())
+			Expect(err).NotTo(BeAssignableToTypeOf(&database.MigrationFailedError{}))
+		})
+	})
+
+	Context("with a database", func() {
+		var dbPath string
+		BeforeEach(func() {
+			dir, err := ioutil.TempDir("", "ubiquity-migrate")
+			Expect(err).ToNot(HaveOccurred())
+			dbPath = filepath.Join(dir, "ubiquity.db")
+		})
+		AfterEach(func() {
+			os.RemoveAll(filepath.Dir(dbPath))
+		})
+
+		It("should apply every migration once, in version order", func() {
+			var applied []int
+			database.RegisterMigrations(component,
+				database.Migration{Version: 2, Up: func(db *gorm.DB) error { applied = append(applied, 2); return nil }},
+				database.Migration{Version: 1, Up: func(db *gorm.DB) error { applied = append(applied, 1); return nil }})
+
+			cleanup := database.InitSqlite(dbPath)
+			Expect(database.Migrate()).To(Succeed())
+			cleanup()
+			Expect(applied).To(Equal([]int{1, 2}))
+
+			// a restarted server finds the component at version 2
+			cleanup = database.InitSqlite(dbPath)
+			Expect(database.Migrate()).To(Succeed())
+			cleanup()
+			Expect(applied).To(Equal([]int{1, 2}))
+		})
+		It("should stop at the failed migration and retry it on the next connection", func() {
+			fail := true
+			database.RegisterMigrations(component,
+				database.Migration{Version: 1, Up: noop},
+				database.Migration{Version: 2, Up: func(db *gorm.DB) error {
+					if fail {
+						return errors.New("fake error")
+					}
+					return nil
+				}})
+			defer database.InitSqlite(dbPath)()
+
+			err := database.Migrate()
+			Expect(err).To(BeAssignableToTypeOf(&database.MigrationFailedError{}))
+			Expect(err.(*database.MigrationFailedError).Version).To(Equal(2))
+
+			fail = false
+			Expect(database.Migrate()).To(Succeed())
+		})
+		It("should skip a component registered without migrations", func() {
+			database.RegisterMigrations(component)
+			defer database.InitSqlite(dbPath)()
+			Expect(database.Migrate()).To(Succeed())
+		})
+		It("should create the columns of the current structs", func() {
+			defer database.InitSqlite(dbPath)()
+			dbConnection := database.NewConnection()
+			Expect(dbConnection.Open()).To(Succeed())
+			defer dbConnection.Close()
+			db := dbConnection.GetDb()
+
+			for _, table := range []interface{}{&resources.Volume{}, &resources.Snapshot{}, &database.Lease{}, &database.Operation{}} {
+				scope := db.NewScope(table)
+				for _, field := range scope.Fields() {
+					if field.IsNormal {
+						Expect(db.Dialect().HasColumn(scope.TableName(), field.DBName)).To(BeTrue(), scope.TableName()+"."+field.DBName)
+					}
+				}
+			}
+		})
+	})
+})
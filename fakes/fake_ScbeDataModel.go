@@ -5,9 +5,22 @@ import (
 	"sync"
 
 	"github.com/IBM/ubiquity/local/scbe"
+	"github.com/IBM/ubiquity/resources"
 )
 
 type FakeScbeDataModel struct {
+	DeleteSnapshotStub        func(string, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteSnapshotReturns struct {
+		result1 error
+	}
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteVolumeStub        func(string) error
 	deleteVolumeMutex       sync.RWMutex
 	deleteVolumeArgsForCall []struct {
@@ -19,6 +32,22 @@ type FakeScbeDataModel struct {
 	deleteVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetSnapshotStub        func(string, string) (resources.Snapshot, bool, error)
+	getSnapshotMutex       sync.RWMutex
+	getSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getSnapshotReturns struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}
+	getSnapshotReturnsOnCall map[int]struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}
 	GetVolumeStub        func(string) (scbe.ScbeVolume, bool, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -34,12 +63,58 @@ type FakeScbeDataModel struct {
 		result2 bool
 		result3 error
 	}
-	InsertVolumeStub        func(string, string, string) error
+	GetVolumeByWwnStub        func(string) (scbe.ScbeVolume, bool, error)
+	getVolumeByWwnMutex       sync.RWMutex
+	getVolumeByWwnArgsForCall []struct {
+		arg1 string
+	}
+	getVolumeByWwnReturns struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}
+	getVolumeByWwnReturnsOnCall map[int]struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}
+	InsertClonedVolumeStub        func(string, string, string, string, string, map[string]string) error
+	insertClonedVolumeMutex       sync.RWMutex
+	insertClonedVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]string
+	}
+	insertClonedVolumeReturns struct {
+		result1 error
+	}
+	insertClonedVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertSnapshotStub        func(string, string, string) error
+	insertSnapshotMutex       sync.RWMutex
+	insertSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	insertSnapshotReturns struct {
+		result1 error
+	}
+	insertSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertVolumeStub        func(string, string, string, bool, map[string]string) error
 	insertVolumeMutex       sync.RWMutex
 	insertVolumeArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 bool
+		arg5 map[string]string
 	}
 	insertVolumeReturns struct {
 		result1 error
@@ -47,9 +122,23 @@ type FakeScbeDataModel struct {
 	insertVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListVolumesStub        func() ([]scbe.ScbeVolume, error)
+	ListSnapshotsStub        func(string) ([]resources.Snapshot, error)
+	listSnapshotsMutex       sync.RWMutex
+	listSnapshotsArgsForCall []struct {
+		arg1 string
+	}
+	listSnapshotsReturns struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	listSnapshotsReturnsOnCall map[int]struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	ListVolumesStub        func(resources.ListVolumesRequest) ([]scbe.ScbeVolume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
+		arg1 resources.ListVolumesRequest
 	}
 	listVolumesReturns struct {
 		result1 []scbe.ScbeVolume
@@ -59,10 +148,83 @@ type FakeScbeDataModel struct {
 		result1 []scbe.ScbeVolume
 		result2 error
 	}
+	UpdateVolumeAttachedHostStub        func(string, string) error
+	updateVolumeAttachedHostMutex       sync.RWMutex
+	updateVolumeAttachedHostArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateVolumeAttachedHostReturns struct {
+		result1 error
+	}
+	updateVolumeAttachedHostReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeScbeDataModel) DeleteSnapshot(arg1 string, arg2 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModel) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) DeleteSnapshotCalls(stub func(string, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModel) DeleteSnapshotArgsForCall(i int) (string, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeDataModel) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScbeDataModel) DeleteVolume(arg1 string) error {
 	fake.deleteVolumeMutex.Lock()
 	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
@@ -123,6 +285,73 @@ func (fake *FakeScbeDataModel) DeleteVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeScbeDataModel) GetSnapshot(arg1 string, arg2 string) (resources.Snapshot, bool, error) {
+	fake.getSnapshotMutex.Lock()
+	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
+	fake.getSnapshotArgsForCall = append(fake.getSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetSnapshot", []interface{}{arg1, arg2})
+	fake.getSnapshotMutex.Unlock()
+	if fake.GetSnapshotStub != nil {
+		return fake.GetSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeScbeDataModel) GetSnapshotCallCount() int {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	return len(fake.getSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) GetSnapshotCalls(stub func(string, string) (resources.Snapshot, bool, error)) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModel) GetSnapshotArgsForCall(i int) (string, string) {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	argsForCall := fake.getSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeDataModel) GetSnapshotReturns(result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	fake.getSnapshotReturns = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeScbeDataModel) GetSnapshotReturnsOnCall(i int, result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	if fake.getSnapshotReturnsOnCall == nil {
+		fake.getSnapshotReturnsOnCall = make(map[int]struct {
+			result1 resources.Snapshot
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getSnapshotReturnsOnCall[i] = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeScbeDataModel) GetVolume(arg1 string) (scbe.ScbeVolume, bool, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -189,18 +418,213 @@ func (fake *FakeScbeDataModel) GetVolumeReturnsOnCall(i int, result1 scbe.ScbeVo
 	}{result1, result2, result3}
 }
 
-func (fake *FakeScbeDataModel) InsertVolume(arg1 string, arg2 string, arg3 string) error {
+func (fake *FakeScbeDataModel) GetVolumeByWwn(arg1 string) (scbe.ScbeVolume, bool, error) {
+	fake.getVolumeByWwnMutex.Lock()
+	ret, specificReturn := fake.getVolumeByWwnReturnsOnCall[len(fake.getVolumeByWwnArgsForCall)]
+	fake.getVolumeByWwnArgsForCall = append(fake.getVolumeByWwnArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetVolumeByWwn", []interface{}{arg1})
+	fake.getVolumeByWwnMutex.Unlock()
+	if fake.GetVolumeByWwnStub != nil {
+		return fake.GetVolumeByWwnStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getVolumeByWwnReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeScbeDataModel) GetVolumeByWwnCallCount() int {
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	return len(fake.getVolumeByWwnArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) GetVolumeByWwnCalls(stub func(string) (scbe.ScbeVolume, bool, error)) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = stub
+}
+
+func (fake *FakeScbeDataModel) GetVolumeByWwnArgsForCall(i int) string {
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	argsForCall := fake.getVolumeByWwnArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScbeDataModel) GetVolumeByWwnReturns(result1 scbe.ScbeVolume, result2 bool, result3 error) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = nil
+	fake.getVolumeByWwnReturns = struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeScbeDataModel) GetVolumeByWwnReturnsOnCall(i int, result1 scbe.ScbeVolume, result2 bool, result3 error) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = nil
+	if fake.getVolumeByWwnReturnsOnCall == nil {
+		fake.getVolumeByWwnReturnsOnCall = make(map[int]struct {
+			result1 scbe.ScbeVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getVolumeByWwnReturnsOnCall[i] = struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolume(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 map[string]string) error {
+	fake.insertClonedVolumeMutex.Lock()
+	ret, specificReturn := fake.insertClonedVolumeReturnsOnCall[len(fake.insertClonedVolumeArgsForCall)]
+	fake.insertClonedVolumeArgsForCall = append(fake.insertClonedVolumeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("InsertClonedVolume", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.insertClonedVolumeMutex.Unlock()
+	if fake.InsertClonedVolumeStub != nil {
+		return fake.InsertClonedVolumeStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertClonedVolumeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolumeCallCount() int {
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	return len(fake.insertClonedVolumeArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolumeCalls(stub func(string, string, string, string, string, map[string]string) error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = stub
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolumeArgsForCall(i int) (string, string, string, string, string, map[string]string) {
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	argsForCall := fake.insertClonedVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolumeReturns(result1 error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = nil
+	fake.insertClonedVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) InsertClonedVolumeReturnsOnCall(i int, result1 error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = nil
+	if fake.insertClonedVolumeReturnsOnCall == nil {
+		fake.insertClonedVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertClonedVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshot(arg1 string, arg2 string, arg3 string) error {
+	fake.insertSnapshotMutex.Lock()
+	ret, specificReturn := fake.insertSnapshotReturnsOnCall[len(fake.insertSnapshotArgsForCall)]
+	fake.insertSnapshotArgsForCall = append(fake.insertSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("InsertSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.insertSnapshotMutex.Unlock()
+	if fake.InsertSnapshotStub != nil {
+		return fake.InsertSnapshotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshotCallCount() int {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	return len(fake.insertSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshotCalls(stub func(string, string, string) error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshotArgsForCall(i int) (string, string, string) {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	argsForCall := fake.insertSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshotReturns(result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	fake.insertSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) InsertSnapshotReturnsOnCall(i int, result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	if fake.insertSnapshotReturnsOnCall == nil {
+		fake.insertSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) InsertVolume(arg1 string, arg2 string, arg3 string, arg4 bool, arg5 map[string]string) error {
 	fake.insertVolumeMutex.Lock()
 	ret, specificReturn := fake.insertVolumeReturnsOnCall[len(fake.insertVolumeArgsForCall)]
 	fake.insertVolumeArgsForCall = append(fake.insertVolumeArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("InsertVolume", []interface{}{arg1, arg2, arg3})
+		arg4 bool
+		arg5 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("InsertVolume", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.insertVolumeMutex.Unlock()
 	if fake.InsertVolumeStub != nil {
-		return fake.InsertVolumeStub(arg1, arg2, arg3)
+		return fake.InsertVolumeStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -215,17 +639,17 @@ func (fake *FakeScbeDataModel) InsertVolumeCallCount() int {
 	return len(fake.insertVolumeArgsForCall)
 }
 
-func (fake *FakeScbeDataModel) InsertVolumeCalls(stub func(string, string, string) error) {
+func (fake *FakeScbeDataModel) InsertVolumeCalls(stub func(string, string, string, bool, map[string]string) error) {
 	fake.insertVolumeMutex.Lock()
 	defer fake.insertVolumeMutex.Unlock()
 	fake.InsertVolumeStub = stub
 }
 
-func (fake *FakeScbeDataModel) InsertVolumeArgsForCall(i int) (string, string, string) {
+func (fake *FakeScbeDataModel) InsertVolumeArgsForCall(i int) (string, string, string, bool, map[string]string) {
 	fake.insertVolumeMutex.RLock()
 	defer fake.insertVolumeMutex.RUnlock()
 	argsForCall := fake.insertVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeScbeDataModel) InsertVolumeReturns(result1 error) {
@@ -251,15 +675,79 @@ func (fake *FakeScbeDataModel) InsertVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeScbeDataModel) ListVolumes() ([]scbe.ScbeVolume, error) {
+func (fake *FakeScbeDataModel) ListSnapshots(arg1 string) ([]resources.Snapshot, error) {
+	fake.listSnapshotsMutex.Lock()
+	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
+	fake.listSnapshotsArgsForCall = append(fake.listSnapshotsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListSnapshots", []interface{}{arg1})
+	fake.listSnapshotsMutex.Unlock()
+	if fake.ListSnapshotsStub != nil {
+		return fake.ListSnapshotsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSnapshotsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeDataModel) ListSnapshotsCallCount() int {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	return len(fake.listSnapshotsArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) ListSnapshotsCalls(stub func(string) ([]resources.Snapshot, error)) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = stub
+}
+
+func (fake *FakeScbeDataModel) ListSnapshotsArgsForCall(i int) string {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	argsForCall := fake.listSnapshotsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScbeDataModel) ListSnapshotsReturns(result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	fake.listSnapshotsReturns = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModel) ListSnapshotsReturnsOnCall(i int, result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	if fake.listSnapshotsReturnsOnCall == nil {
+		fake.listSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []resources.Snapshot
+			result2 error
+		})
+	}
+	fake.listSnapshotsReturnsOnCall[i] = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModel) ListVolumes(arg1 resources.ListVolumesRequest) ([]scbe.ScbeVolume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
 	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListVolumes", []interface{}{})
+		arg1 resources.ListVolumesRequest
+	}{arg1})
+	fake.recordInvocation("ListVolumes", []interface{}{arg1})
 	fake.listVolumesMutex.Unlock()
 	if fake.ListVolumesStub != nil {
-		return fake.ListVolumesStub()
+		return fake.ListVolumesStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -274,12 +762,19 @@ func (fake *FakeScbeDataModel) ListVolumesCallCount() int {
 	return len(fake.listVolumesArgsForCall)
 }
 
-func (fake *FakeScbeDataModel) ListVolumesCalls(stub func() ([]scbe.ScbeVolume, error)) {
+func (fake *FakeScbeDataModel) ListVolumesCalls(stub func(resources.ListVolumesRequest) ([]scbe.ScbeVolume, error)) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
 	fake.ListVolumesStub = stub
 }
 
+func (fake *FakeScbeDataModel) ListVolumesArgsForCall(i int) resources.ListVolumesRequest {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	argsForCall := fake.listVolumesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeScbeDataModel) ListVolumesReturns(result1 []scbe.ScbeVolume, result2 error) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
@@ -306,17 +801,92 @@ func (fake *FakeScbeDataModel) ListVolumesReturnsOnCall(i int, result1 []scbe.Sc
 	}{result1, result2}
 }
 
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHost(arg1 string, arg2 string) error {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	ret, specificReturn := fake.updateVolumeAttachedHostReturnsOnCall[len(fake.updateVolumeAttachedHostArgsForCall)]
+	fake.updateVolumeAttachedHostArgsForCall = append(fake.updateVolumeAttachedHostArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateVolumeAttachedHost", []interface{}{arg1, arg2})
+	fake.updateVolumeAttachedHostMutex.Unlock()
+	if fake.UpdateVolumeAttachedHostStub != nil {
+		return fake.UpdateVolumeAttachedHostStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateVolumeAttachedHostReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHostCallCount() int {
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
+	return len(fake.updateVolumeAttachedHostArgsForCall)
+}
+
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHostCalls(stub func(string, string) error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = stub
+}
+
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHostArgsForCall(i int) (string, string) {
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
+	argsForCall := fake.updateVolumeAttachedHostArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHostReturns(result1 error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = nil
+	fake.updateVolumeAttachedHostReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModel) UpdateVolumeAttachedHostReturnsOnCall(i int, result1 error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = nil
+	if fake.updateVolumeAttachedHostReturnsOnCall == nil {
+		fake.updateVolumeAttachedHostReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVolumeAttachedHostReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScbeDataModel) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
 	fake.insertVolumeMutex.RLock()
 	defer fake.insertVolumeMutex.RUnlock()
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -9,14 +9,16 @@ import (
 )
 
 type FakeSpectrumDataModel struct {
-	CreateVolumeTableStub        func() error
-	createVolumeTableMutex       sync.RWMutex
-	createVolumeTableArgsForCall []struct {
+	DeleteSnapshotStub        func(string, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-	createVolumeTableReturns struct {
+	deleteSnapshotReturns struct {
 		result1 error
 	}
-	createVolumeTableReturnsOnCall map[int]struct {
+	deleteSnapshotReturnsOnCall map[int]struct {
 		result1 error
 	}
 	DeleteVolumeStub        func(string) error
@@ -30,6 +32,22 @@ type FakeSpectrumDataModel struct {
 	deleteVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetSnapshotStub        func(string, string) (resources.Snapshot, bool, error)
+	getSnapshotMutex       sync.RWMutex
+	getSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getSnapshotReturns struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}
+	getSnapshotReturnsOnCall map[int]struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}
 	GetVolumeStub        func(string) (spectrumscale.SpectrumScaleVolume, bool, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -76,9 +94,48 @@ type FakeSpectrumDataModel struct {
 	insertFilesetVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListVolumesStub        func() ([]resources.Volume, error)
+	InsertSnapshotStub        func(string, string, string) error
+	insertSnapshotMutex       sync.RWMutex
+	insertSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	insertSnapshotReturns struct {
+		result1 error
+	}
+	insertSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListSnapshotsStub        func(string) ([]resources.Snapshot, error)
+	listSnapshotsMutex       sync.RWMutex
+	listSnapshotsArgsForCall []struct {
+		arg1 string
+	}
+	listSnapshotsReturns struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	listSnapshotsReturnsOnCall map[int]struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	ListSpectrumScaleVolumesStub        func() ([]spectrumscale.SpectrumScaleVolume, error)
+	listSpectrumScaleVolumesMutex       sync.RWMutex
+	listSpectrumScaleVolumesArgsForCall []struct {
+	}
+	listSpectrumScaleVolumesReturns struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}
+	listSpectrumScaleVolumesReturnsOnCall map[int]struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}
+	ListVolumesStub        func(resources.ListVolumesRequest) ([]resources.Volume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
+		arg1 resources.ListVolumesRequest
 	}
 	listVolumesReturns struct {
 		result1 []resources.Volume
@@ -100,58 +157,79 @@ type FakeSpectrumDataModel struct {
 	updateVolumeMountpointReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateVolumeQuotaStub        func(string, string) error
+	updateVolumeQuotaMutex       sync.RWMutex
+	updateVolumeQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateVolumeQuotaReturns struct {
+		result1 error
+	}
+	updateVolumeQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSpectrumDataModel) CreateVolumeTable() error {
-	fake.createVolumeTableMutex.Lock()
-	ret, specificReturn := fake.createVolumeTableReturnsOnCall[len(fake.createVolumeTableArgsForCall)]
-	fake.createVolumeTableArgsForCall = append(fake.createVolumeTableArgsForCall, struct {
-	}{})
-	fake.recordInvocation("CreateVolumeTable", []interface{}{})
-	fake.createVolumeTableMutex.Unlock()
-	if fake.CreateVolumeTableStub != nil {
-		return fake.CreateVolumeTableStub()
+func (fake *FakeSpectrumDataModel) DeleteSnapshot(arg1 string, arg2 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.createVolumeTableReturns
+	fakeReturns := fake.deleteSnapshotReturns
 	return fakeReturns.result1
 }
 
-func (fake *FakeSpectrumDataModel) CreateVolumeTableCallCount() int {
-	fake.createVolumeTableMutex.RLock()
-	defer fake.createVolumeTableMutex.RUnlock()
-	return len(fake.createVolumeTableArgsForCall)
+func (fake *FakeSpectrumDataModel) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModel) CreateVolumeTableCalls(stub func() error) {
-	fake.createVolumeTableMutex.Lock()
-	defer fake.createVolumeTableMutex.Unlock()
-	fake.CreateVolumeTableStub = stub
+func (fake *FakeSpectrumDataModel) DeleteSnapshotCalls(stub func(string, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
 }
 
-func (fake *FakeSpectrumDataModel) CreateVolumeTableReturns(result1 error) {
-	fake.createVolumeTableMutex.Lock()
-	defer fake.createVolumeTableMutex.Unlock()
-	fake.CreateVolumeTableStub = nil
-	fake.createVolumeTableReturns = struct {
+func (fake *FakeSpectrumDataModel) DeleteSnapshotArgsForCall(i int) (string, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumDataModel) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModel) CreateVolumeTableReturnsOnCall(i int, result1 error) {
-	fake.createVolumeTableMutex.Lock()
-	defer fake.createVolumeTableMutex.Unlock()
-	fake.CreateVolumeTableStub = nil
-	if fake.createVolumeTableReturnsOnCall == nil {
-		fake.createVolumeTableReturnsOnCall = make(map[int]struct {
+func (fake *FakeSpectrumDataModel) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.createVolumeTableReturnsOnCall[i] = struct {
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -216,6 +294,73 @@ func (fake *FakeSpectrumDataModel) DeleteVolumeReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeSpectrumDataModel) GetSnapshot(arg1 string, arg2 string) (resources.Snapshot, bool, error) {
+	fake.getSnapshotMutex.Lock()
+	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
+	fake.getSnapshotArgsForCall = append(fake.getSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetSnapshot", []interface{}{arg1, arg2})
+	fake.getSnapshotMutex.Unlock()
+	if fake.GetSnapshotStub != nil {
+		return fake.GetSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeSpectrumDataModel) GetSnapshotCallCount() int {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	return len(fake.getSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModel) GetSnapshotCalls(stub func(string, string) (resources.Snapshot, bool, error)) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = stub
+}
+
+func (fake *FakeSpectrumDataModel) GetSnapshotArgsForCall(i int) (string, string) {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	argsForCall := fake.getSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumDataModel) GetSnapshotReturns(result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	fake.getSnapshotReturns = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSpectrumDataModel) GetSnapshotReturnsOnCall(i int, result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	if fake.getSnapshotReturnsOnCall == nil {
+		fake.getSnapshotReturnsOnCall = make(map[int]struct {
+			result1 resources.Snapshot
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getSnapshotReturnsOnCall[i] = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeSpectrumDataModel) GetVolume(arg1 string) (spectrumscale.SpectrumScaleVolume, bool, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -411,15 +556,196 @@ func (fake *FakeSpectrumDataModel) InsertFilesetVolumeReturnsOnCall(i int, resul
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModel) ListVolumes() ([]resources.Volume, error) {
+func (fake *FakeSpectrumDataModel) InsertSnapshot(arg1 string, arg2 string, arg3 string) error {
+	fake.insertSnapshotMutex.Lock()
+	ret, specificReturn := fake.insertSnapshotReturnsOnCall[len(fake.insertSnapshotArgsForCall)]
+	fake.insertSnapshotArgsForCall = append(fake.insertSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("InsertSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.insertSnapshotMutex.Unlock()
+	if fake.InsertSnapshotStub != nil {
+		return fake.InsertSnapshotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumDataModel) InsertSnapshotCallCount() int {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	return len(fake.insertSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModel) InsertSnapshotCalls(stub func(string, string, string) error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = stub
+}
+
+func (fake *FakeSpectrumDataModel) InsertSnapshotArgsForCall(i int) (string, string, string) {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	argsForCall := fake.insertSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSpectrumDataModel) InsertSnapshotReturns(result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	fake.insertSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModel) InsertSnapshotReturnsOnCall(i int, result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	if fake.insertSnapshotReturnsOnCall == nil {
+		fake.insertSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshots(arg1 string) ([]resources.Snapshot, error) {
+	fake.listSnapshotsMutex.Lock()
+	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
+	fake.listSnapshotsArgsForCall = append(fake.listSnapshotsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListSnapshots", []interface{}{arg1})
+	fake.listSnapshotsMutex.Unlock()
+	if fake.ListSnapshotsStub != nil {
+		return fake.ListSnapshotsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSnapshotsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshotsCallCount() int {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	return len(fake.listSnapshotsArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshotsCalls(stub func(string) ([]resources.Snapshot, error)) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = stub
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshotsArgsForCall(i int) string {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	argsForCall := fake.listSnapshotsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshotsReturns(result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	fake.listSnapshotsReturns = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModel) ListSnapshotsReturnsOnCall(i int, result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	if fake.listSnapshotsReturnsOnCall == nil {
+		fake.listSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []resources.Snapshot
+			result2 error
+		})
+	}
+	fake.listSnapshotsReturnsOnCall[i] = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModel) ListSpectrumScaleVolumes() ([]spectrumscale.SpectrumScaleVolume, error) {
+	fake.listSpectrumScaleVolumesMutex.Lock()
+	ret, specificReturn := fake.listSpectrumScaleVolumesReturnsOnCall[len(fake.listSpectrumScaleVolumesArgsForCall)]
+	fake.listSpectrumScaleVolumesArgsForCall = append(fake.listSpectrumScaleVolumesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListSpectrumScaleVolumes", []interface{}{})
+	fake.listSpectrumScaleVolumesMutex.Unlock()
+	if fake.ListSpectrumScaleVolumesStub != nil {
+		return fake.ListSpectrumScaleVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSpectrumScaleVolumesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpectrumDataModel) ListSpectrumScaleVolumesCallCount() int {
+	fake.listSpectrumScaleVolumesMutex.RLock()
+	defer fake.listSpectrumScaleVolumesMutex.RUnlock()
+	return len(fake.listSpectrumScaleVolumesArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModel) ListSpectrumScaleVolumesCalls(stub func() ([]spectrumscale.SpectrumScaleVolume, error)) {
+	fake.listSpectrumScaleVolumesMutex.Lock()
+	defer fake.listSpectrumScaleVolumesMutex.Unlock()
+	fake.ListSpectrumScaleVolumesStub = stub
+}
+
+func (fake *FakeSpectrumDataModel) ListSpectrumScaleVolumesReturns(result1 []spectrumscale.SpectrumScaleVolume, result2 error) {
+	fake.listSpectrumScaleVolumesMutex.Lock()
+	defer fake.listSpectrumScaleVolumesMutex.Unlock()
+	fake.ListSpectrumScaleVolumesStub = nil
+	fake.listSpectrumScaleVolumesReturns = struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModel) ListSpectrumScaleVolumesReturnsOnCall(i int, result1 []spectrumscale.SpectrumScaleVolume, result2 error) {
+	fake.listSpectrumScaleVolumesMutex.Lock()
+	defer fake.listSpectrumScaleVolumesMutex.Unlock()
+	fake.ListSpectrumScaleVolumesStub = nil
+	if fake.listSpectrumScaleVolumesReturnsOnCall == nil {
+		fake.listSpectrumScaleVolumesReturnsOnCall = make(map[int]struct {
+			result1 []spectrumscale.SpectrumScaleVolume
+			result2 error
+		})
+	}
+	fake.listSpectrumScaleVolumesReturnsOnCall[i] = struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModel) ListVolumes(arg1 resources.ListVolumesRequest) ([]resources.Volume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
 	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListVolumes", []interface{}{})
+		arg1 resources.ListVolumesRequest
+	}{arg1})
+	fake.recordInvocation("ListVolumes", []interface{}{arg1})
 	fake.listVolumesMutex.Unlock()
 	if fake.ListVolumesStub != nil {
-		return fake.ListVolumesStub()
+		return fake.ListVolumesStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -434,12 +760,19 @@ func (fake *FakeSpectrumDataModel) ListVolumesCallCount() int {
 	return len(fake.listVolumesArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModel) ListVolumesCalls(stub func() ([]resources.Volume, error)) {
+func (fake *FakeSpectrumDataModel) ListVolumesCalls(stub func(resources.ListVolumesRequest) ([]resources.Volume, error)) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
 	fake.ListVolumesStub = stub
 }
 
+func (fake *FakeSpectrumDataModel) ListVolumesArgsForCall(i int) resources.ListVolumesRequest {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	argsForCall := fake.listVolumesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeSpectrumDataModel) ListVolumesReturns(result1 []resources.Volume, result2 error) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
@@ -527,23 +860,94 @@ func (fake *FakeSpectrumDataModel) UpdateVolumeMountpointReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuota(arg1 string, arg2 string) error {
+	fake.updateVolumeQuotaMutex.Lock()
+	ret, specificReturn := fake.updateVolumeQuotaReturnsOnCall[len(fake.updateVolumeQuotaArgsForCall)]
+	fake.updateVolumeQuotaArgsForCall = append(fake.updateVolumeQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateVolumeQuota", []interface{}{arg1, arg2})
+	fake.updateVolumeQuotaMutex.Unlock()
+	if fake.UpdateVolumeQuotaStub != nil {
+		return fake.UpdateVolumeQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateVolumeQuotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuotaCallCount() int {
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
+	return len(fake.updateVolumeQuotaArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuotaCalls(stub func(string, string) error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = stub
+}
+
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuotaArgsForCall(i int) (string, string) {
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
+	argsForCall := fake.updateVolumeQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuotaReturns(result1 error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = nil
+	fake.updateVolumeQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModel) UpdateVolumeQuotaReturnsOnCall(i int, result1 error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = nil
+	if fake.updateVolumeQuotaReturnsOnCall == nil {
+		fake.updateVolumeQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVolumeQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSpectrumDataModel) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createVolumeTableMutex.RLock()
-	defer fake.createVolumeTableMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
 	fake.insertFilesetQuotaVolumeMutex.RLock()
 	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
 	fake.insertFilesetVolumeMutex.RLock()
 	defer fake.insertFilesetVolumeMutex.RUnlock()
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	fake.listSpectrumScaleVolumesMutex.RLock()
+	defer fake.listSpectrumScaleVolumesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
 	fake.updateVolumeMountpointMutex.RLock()
 	defer fake.updateVolumeMountpointMutex.RUnlock()
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
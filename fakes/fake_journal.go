@@ -0,0 +1,414 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/IBM/ubiquity/database"
+)
+
+type FakeJournal struct {
+	BeginStub        func(*database.Operation) error
+	beginMutex       sync.RWMutex
+	beginArgsForCall []struct {
+		arg1 *database.Operation
+	}
+	beginReturns struct {
+		result1 error
+	}
+	beginReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CompleteStub        func(*database.Operation) error
+	completeMutex       sync.RWMutex
+	completeArgsForCall []struct {
+		arg1 *database.Operation
+	}
+	completeReturns struct {
+		result1 error
+	}
+	completeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListPendingStub        func() ([]database.Operation, error)
+	listPendingMutex       sync.RWMutex
+	listPendingArgsForCall []struct {
+	}
+	listPendingReturns struct {
+		result1 []database.Operation
+		result2 error
+	}
+	listPendingReturnsOnCall map[int]struct {
+		result1 []database.Operation
+		result2 error
+	}
+	RollBackStub        func(*database.Operation) error
+	rollBackMutex       sync.RWMutex
+	rollBackArgsForCall []struct {
+		arg1 *database.Operation
+	}
+	rollBackReturns struct {
+		result1 error
+	}
+	rollBackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateStub        func(*database.Operation) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 *database.Operation
+	}
+	updateReturns struct {
+		result1 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeJournal) Begin(arg1 *database.Operation) error {
+	fake.beginMutex.Lock()
+	ret, specificReturn := fake.beginReturnsOnCall[len(fake.beginArgsForCall)]
+	fake.beginArgsForCall = append(fake.beginArgsForCall, struct {
+		arg1 *database.Operation
+	}{arg1})
+	fake.recordInvocation("Begin", []interface{}{arg1})
+	fake.beginMutex.Unlock()
+	if fake.BeginStub != nil {
+		return fake.BeginStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.beginReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJournal) BeginCallCount() int {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	return len(fake.beginArgsForCall)
+}
+
+func (fake *FakeJournal) BeginCalls(stub func(*database.Operation) error) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = stub
+}
+
+func (fake *FakeJournal) BeginArgsForCall(i int) *database.Operation {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	argsForCall := fake.beginArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJournal) BeginReturns(result1 error) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = nil
+	fake.beginReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) BeginReturnsOnCall(i int, result1 error) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = nil
+	if fake.beginReturnsOnCall == nil {
+		fake.beginReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.beginReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) Complete(arg1 *database.Operation) error {
+	fake.completeMutex.Lock()
+	ret, specificReturn := fake.completeReturnsOnCall[len(fake.completeArgsForCall)]
+	fake.completeArgsForCall = append(fake.completeArgsForCall, struct {
+		arg1 *database.Operation
+	}{arg1})
+	fake.recordInvocation("Complete", []interface{}{arg1})
+	fake.completeMutex.Unlock()
+	if fake.CompleteStub != nil {
+		return fake.CompleteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.completeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJournal) CompleteCallCount() int {
+	fake.completeMutex.RLock()
+	defer fake.completeMutex.RUnlock()
+	return len(fake.completeArgsForCall)
+}
+
+func (fake *FakeJournal) CompleteCalls(stub func(*database.Operation) error) {
+	fake.completeMutex.Lock()
+	defer fake.completeMutex.Unlock()
+	fake.CompleteStub = stub
+}
+
+func (fake *FakeJournal) CompleteArgsForCall(i int) *database.Operation {
+	fake.completeMutex.RLock()
+	defer fake.completeMutex.RUnlock()
+	argsForCall := fake.completeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJournal) CompleteReturns(result1 error) {
+	fake.completeMutex.Lock()
+	defer fake.completeMutex.Unlock()
+	fake.CompleteStub = nil
+	fake.completeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) CompleteReturnsOnCall(i int, result1 error) {
+	fake.completeMutex.Lock()
+	defer fake.completeMutex.Unlock()
+	fake.CompleteStub = nil
+	if fake.completeReturnsOnCall == nil {
+		fake.completeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.completeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) ListPending() ([]database.Operation, error) {
+	fake.listPendingMutex.Lock()
+	ret, specificReturn := fake.listPendingReturnsOnCall[len(fake.listPendingArgsForCall)]
+	fake.listPendingArgsForCall = append(fake.listPendingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListPending", []interface{}{})
+	fake.listPendingMutex.Unlock()
+	if fake.ListPendingStub != nil {
+		return fake.ListPendingStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listPendingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJournal) ListPendingCallCount() int {
+	fake.listPendingMutex.RLock()
+	defer fake.listPendingMutex.RUnlock()
+	return len(fake.listPendingArgsForCall)
+}
+
+func (fake *FakeJournal) ListPendingCalls(stub func() ([]database.Operation, error)) {
+	fake.listPendingMutex.Lock()
+	defer fake.listPendingMutex.Unlock()
+	fake.ListPendingStub = stub
+}
+
+func (fake *FakeJournal) ListPendingReturns(result1 []database.Operation, result2 error) {
+	fake.listPendingMutex.Lock()
+	defer fake.listPendingMutex.Unlock()
+	fake.ListPendingStub = nil
+	fake.listPendingReturns = struct {
+		result1 []database.Operation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJournal) ListPendingReturnsOnCall(i int, result1 []database.Operation, result2 error) {
+	fake.listPendingMutex.Lock()
+	defer fake.listPendingMutex.Unlock()
+	fake.ListPendingStub = nil
+	if fake.listPendingReturnsOnCall == nil {
+		fake.listPendingReturnsOnCall = make(map[int]struct {
+			result1 []database.Operation
+			result2 error
+		})
+	}
+	fake.listPendingReturnsOnCall[i] = struct {
+		result1 []database.Operation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJournal) RollBack(arg1 *database.Operation) error {
+	fake.rollBackMutex.Lock()
+	ret, specificReturn := fake.rollBackReturnsOnCall[len(fake.rollBackArgsForCall)]
+	fake.rollBackArgsForCall = append(fake.rollBackArgsForCall, struct {
+		arg1 *database.Operation
+	}{arg1})
+	fake.recordInvocation("RollBack", []interface{}{arg1})
+	fake.rollBackMutex.Unlock()
+	if fake.RollBackStub != nil {
+		return fake.RollBackStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rollBackReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJournal) RollBackCallCount() int {
+	fake.rollBackMutex.RLock()
+	defer fake.rollBackMutex.RUnlock()
+	return len(fake.rollBackArgsForCall)
+}
+
+func (fake *FakeJournal) RollBackCalls(stub func(*database.Operation) error) {
+	fake.rollBackMutex.Lock()
+	defer fake.rollBackMutex.Unlock()
+	fake.RollBackStub = stub
+}
+
+func (fake *FakeJournal) RollBackArgsForCall(i int) *database.Operation {
+	fake.rollBackMutex.RLock()
+	defer fake.rollBackMutex.RUnlock()
+	argsForCall := fake.rollBackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJournal) RollBackReturns(result1 error) {
+	fake.rollBackMutex.Lock()
+	defer fake.rollBackMutex.Unlock()
+	fake.RollBackStub = nil
+	fake.rollBackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) RollBackReturnsOnCall(i int, result1 error) {
+	fake.rollBackMutex.Lock()
+	defer fake.rollBackMutex.Unlock()
+	fake.RollBackStub = nil
+	if fake.rollBackReturnsOnCall == nil {
+		fake.rollBackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rollBackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) Update(arg1 *database.Operation) error {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 *database.Operation
+	}{arg1})
+	fake.recordInvocation("Update", []interface{}{arg1})
+	fake.updateMutex.Unlock()
+	if fake.UpdateStub != nil {
+		return fake.UpdateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJournal) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeJournal) UpdateCalls(stub func(*database.Operation) error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeJournal) UpdateArgsForCall(i int) *database.Operation {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJournal) UpdateReturns(result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) UpdateReturnsOnCall(i int, result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	fake.completeMutex.RLock()
+	defer fake.completeMutex.RUnlock()
+	fake.listPendingMutex.RLock()
+	defer fake.listPendingMutex.RUnlock()
+	fake.rollBackMutex.RLock()
+	defer fake.rollBackMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeJournal) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ database.Journal = new(FakeJournal)
@@ -14,98 +14,156 @@
  * limitations under the License.
  */
 
-// This file was generated by counterfeiter
+// Code generated by counterfeiter. DO NOT EDIT.
 package fakes
 
 import (
+	"context"
 	"sync"
 
+	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
 )
 
 type FakeLocker struct {
-	WriteLockStub        func(name string)
-	writeLockMutex       sync.RWMutex
-	writeLockArgsForCall []struct {
-		name string
+	HeldLocksStub        func() []resources.LockInfo
+	heldLocksMutex       sync.RWMutex
+	heldLocksArgsForCall []struct {
 	}
-	WriteUnlockStub        func(name string)
-	writeUnlockMutex       sync.RWMutex
-	writeUnlockArgsForCall []struct {
-		name string
+	heldLocksReturns struct {
+		result1 []resources.LockInfo
+	}
+	heldLocksReturnsOnCall map[int]struct {
+		result1 []resources.LockInfo
 	}
-	ReadLockStub        func(name string)
+	ReadLockStub        func(string) error
 	readLockMutex       sync.RWMutex
 	readLockArgsForCall []struct {
-		name string
+		arg1 string
 	}
-	ReadUnlockStub        func(name string)
+	readLockReturns struct {
+		result1 error
+	}
+	readLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReadUnlockStub        func(string)
 	readUnlockMutex       sync.RWMutex
 	readUnlockArgsForCall []struct {
-		name string
+		arg1 string
+	}
+	TryReadLockStub        func(context.Context, string) error
+	tryReadLockMutex       sync.RWMutex
+	tryReadLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	tryReadLockReturns struct {
+		result1 error
+	}
+	tryReadLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TryWriteLockStub        func(context.Context, string) error
+	tryWriteLockMutex       sync.RWMutex
+	tryWriteLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	tryWriteLockReturns struct {
+		result1 error
+	}
+	tryWriteLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteLockStub        func(string) error
+	writeLockMutex       sync.RWMutex
+	writeLockArgsForCall []struct {
+		arg1 string
+	}
+	writeLockReturns struct {
+		result1 error
+	}
+	writeLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteUnlockStub        func(string)
+	writeUnlockMutex       sync.RWMutex
+	writeUnlockArgsForCall []struct {
+		arg1 string
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLocker) WriteLock(name string) {
-	fake.writeLockMutex.Lock()
-	fake.writeLockArgsForCall = append(fake.writeLockArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("WriteLock", []interface{}{name})
-	fake.writeLockMutex.Unlock()
-	if fake.WriteLockStub != nil {
-		fake.WriteLockStub(name)
+func (fake *FakeLocker) HeldLocks() []resources.LockInfo {
+	fake.heldLocksMutex.Lock()
+	ret, specificReturn := fake.heldLocksReturnsOnCall[len(fake.heldLocksArgsForCall)]
+	fake.heldLocksArgsForCall = append(fake.heldLocksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("HeldLocks", []interface{}{})
+	fake.heldLocksMutex.Unlock()
+	if fake.HeldLocksStub != nil {
+		return fake.HeldLocksStub()
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.heldLocksReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeLocker) WriteLockCallCount() int {
-	fake.writeLockMutex.RLock()
-	defer fake.writeLockMutex.RUnlock()
-	return len(fake.writeLockArgsForCall)
+func (fake *FakeLocker) HeldLocksCallCount() int {
+	fake.heldLocksMutex.RLock()
+	defer fake.heldLocksMutex.RUnlock()
+	return len(fake.heldLocksArgsForCall)
 }
 
-func (fake *FakeLocker) WriteLockArgsForCall(i int) string {
-	fake.writeLockMutex.RLock()
-	defer fake.writeLockMutex.RUnlock()
-	return fake.writeLockArgsForCall[i].name
+func (fake *FakeLocker) HeldLocksCalls(stub func() []resources.LockInfo) {
+	fake.heldLocksMutex.Lock()
+	defer fake.heldLocksMutex.Unlock()
+	fake.HeldLocksStub = stub
 }
 
-func (fake *FakeLocker) WriteUnlock(name string) {
-	fake.writeUnlockMutex.Lock()
-	fake.writeUnlockArgsForCall = append(fake.writeUnlockArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("WriteUnlock", []interface{}{name})
-	fake.writeUnlockMutex.Unlock()
-	if fake.WriteUnlockStub != nil {
-		fake.WriteUnlockStub(name)
-	}
+func (fake *FakeLocker) HeldLocksReturns(result1 []resources.LockInfo) {
+	fake.heldLocksMutex.Lock()
+	defer fake.heldLocksMutex.Unlock()
+	fake.HeldLocksStub = nil
+	fake.heldLocksReturns = struct {
+		result1 []resources.LockInfo
+	}{result1}
 }
 
-func (fake *FakeLocker) WriteUnlockCallCount() int {
-	fake.writeUnlockMutex.RLock()
-	defer fake.writeUnlockMutex.RUnlock()
-	return len(fake.writeUnlockArgsForCall)
-}
-
-func (fake *FakeLocker) WriteUnlockArgsForCall(i int) string {
-	fake.writeUnlockMutex.RLock()
-	defer fake.writeUnlockMutex.RUnlock()
-	return fake.writeUnlockArgsForCall[i].name
+func (fake *FakeLocker) HeldLocksReturnsOnCall(i int, result1 []resources.LockInfo) {
+	fake.heldLocksMutex.Lock()
+	defer fake.heldLocksMutex.Unlock()
+	fake.HeldLocksStub = nil
+	if fake.heldLocksReturnsOnCall == nil {
+		fake.heldLocksReturnsOnCall = make(map[int]struct {
+			result1 []resources.LockInfo
+		})
+	}
+	fake.heldLocksReturnsOnCall[i] = struct {
+		result1 []resources.LockInfo
+	}{result1}
 }
 
-func (fake *FakeLocker) ReadLock(name string) {
+func (fake *FakeLocker) ReadLock(arg1 string) error {
 	fake.readLockMutex.Lock()
+	ret, specificReturn := fake.readLockReturnsOnCall[len(fake.readLockArgsForCall)]
 	fake.readLockArgsForCall = append(fake.readLockArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("ReadLock", []interface{}{name})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ReadLock", []interface{}{arg1})
 	fake.readLockMutex.Unlock()
 	if fake.ReadLockStub != nil {
-		fake.ReadLockStub(name)
+		return fake.ReadLockStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.readLockReturns
+	return fakeReturns.result1
 }
 
 func (fake *FakeLocker) ReadLockCallCount() int {
@@ -114,21 +172,51 @@ func (fake *FakeLocker) ReadLockCallCount() int {
 	return len(fake.readLockArgsForCall)
 }
 
+func (fake *FakeLocker) ReadLockCalls(stub func(string) error) {
+	fake.readLockMutex.Lock()
+	defer fake.readLockMutex.Unlock()
+	fake.ReadLockStub = stub
+}
+
 func (fake *FakeLocker) ReadLockArgsForCall(i int) string {
 	fake.readLockMutex.RLock()
 	defer fake.readLockMutex.RUnlock()
-	return fake.readLockArgsForCall[i].name
+	argsForCall := fake.readLockArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeLocker) ReadUnlock(name string) {
+func (fake *FakeLocker) ReadLockReturns(result1 error) {
+	fake.readLockMutex.Lock()
+	defer fake.readLockMutex.Unlock()
+	fake.ReadLockStub = nil
+	fake.readLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReadLockReturnsOnCall(i int, result1 error) {
+	fake.readLockMutex.Lock()
+	defer fake.readLockMutex.Unlock()
+	fake.ReadLockStub = nil
+	if fake.readLockReturnsOnCall == nil {
+		fake.readLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.readLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReadUnlock(arg1 string) {
 	fake.readUnlockMutex.Lock()
 	fake.readUnlockArgsForCall = append(fake.readUnlockArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("ReadUnlock", []interface{}{name})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ReadUnlock", []interface{}{arg1})
 	fake.readUnlockMutex.Unlock()
 	if fake.ReadUnlockStub != nil {
-		fake.ReadUnlockStub(name)
+		fake.ReadUnlockStub(arg1)
 	}
 }
 
@@ -138,24 +226,254 @@ func (fake *FakeLocker) ReadUnlockCallCount() int {
 	return len(fake.readUnlockArgsForCall)
 }
 
+func (fake *FakeLocker) ReadUnlockCalls(stub func(string)) {
+	fake.readUnlockMutex.Lock()
+	defer fake.readUnlockMutex.Unlock()
+	fake.ReadUnlockStub = stub
+}
+
 func (fake *FakeLocker) ReadUnlockArgsForCall(i int) string {
 	fake.readUnlockMutex.RLock()
 	defer fake.readUnlockMutex.RUnlock()
-	return fake.readUnlockArgsForCall[i].name
+	argsForCall := fake.readUnlockArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeLocker) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
+func (fake *FakeLocker) TryReadLock(arg1 context.Context, arg2 string) error {
+	fake.tryReadLockMutex.Lock()
+	ret, specificReturn := fake.tryReadLockReturnsOnCall[len(fake.tryReadLockArgsForCall)]
+	fake.tryReadLockArgsForCall = append(fake.tryReadLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("TryReadLock", []interface{}{arg1, arg2})
+	fake.tryReadLockMutex.Unlock()
+	if fake.TryReadLockStub != nil {
+		return fake.TryReadLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tryReadLockReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLocker) TryReadLockCallCount() int {
+	fake.tryReadLockMutex.RLock()
+	defer fake.tryReadLockMutex.RUnlock()
+	return len(fake.tryReadLockArgsForCall)
+}
+
+func (fake *FakeLocker) TryReadLockCalls(stub func(context.Context, string) error) {
+	fake.tryReadLockMutex.Lock()
+	defer fake.tryReadLockMutex.Unlock()
+	fake.TryReadLockStub = stub
+}
+
+func (fake *FakeLocker) TryReadLockArgsForCall(i int) (context.Context, string) {
+	fake.tryReadLockMutex.RLock()
+	defer fake.tryReadLockMutex.RUnlock()
+	argsForCall := fake.tryReadLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLocker) TryReadLockReturns(result1 error) {
+	fake.tryReadLockMutex.Lock()
+	defer fake.tryReadLockMutex.Unlock()
+	fake.TryReadLockStub = nil
+	fake.tryReadLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) TryReadLockReturnsOnCall(i int, result1 error) {
+	fake.tryReadLockMutex.Lock()
+	defer fake.tryReadLockMutex.Unlock()
+	fake.TryReadLockStub = nil
+	if fake.tryReadLockReturnsOnCall == nil {
+		fake.tryReadLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.tryReadLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) TryWriteLock(arg1 context.Context, arg2 string) error {
+	fake.tryWriteLockMutex.Lock()
+	ret, specificReturn := fake.tryWriteLockReturnsOnCall[len(fake.tryWriteLockArgsForCall)]
+	fake.tryWriteLockArgsForCall = append(fake.tryWriteLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("TryWriteLock", []interface{}{arg1, arg2})
+	fake.tryWriteLockMutex.Unlock()
+	if fake.TryWriteLockStub != nil {
+		return fake.TryWriteLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tryWriteLockReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLocker) TryWriteLockCallCount() int {
+	fake.tryWriteLockMutex.RLock()
+	defer fake.tryWriteLockMutex.RUnlock()
+	return len(fake.tryWriteLockArgsForCall)
+}
+
+func (fake *FakeLocker) TryWriteLockCalls(stub func(context.Context, string) error) {
+	fake.tryWriteLockMutex.Lock()
+	defer fake.tryWriteLockMutex.Unlock()
+	fake.TryWriteLockStub = stub
+}
+
+func (fake *FakeLocker) TryWriteLockArgsForCall(i int) (context.Context, string) {
+	fake.tryWriteLockMutex.RLock()
+	defer fake.tryWriteLockMutex.RUnlock()
+	argsForCall := fake.tryWriteLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLocker) TryWriteLockReturns(result1 error) {
+	fake.tryWriteLockMutex.Lock()
+	defer fake.tryWriteLockMutex.Unlock()
+	fake.TryWriteLockStub = nil
+	fake.tryWriteLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) TryWriteLockReturnsOnCall(i int, result1 error) {
+	fake.tryWriteLockMutex.Lock()
+	defer fake.tryWriteLockMutex.Unlock()
+	fake.TryWriteLockStub = nil
+	if fake.tryWriteLockReturnsOnCall == nil {
+		fake.tryWriteLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.tryWriteLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) WriteLock(arg1 string) error {
+	fake.writeLockMutex.Lock()
+	ret, specificReturn := fake.writeLockReturnsOnCall[len(fake.writeLockArgsForCall)]
+	fake.writeLockArgsForCall = append(fake.writeLockArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("WriteLock", []interface{}{arg1})
+	fake.writeLockMutex.Unlock()
+	if fake.WriteLockStub != nil {
+		return fake.WriteLockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.writeLockReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLocker) WriteLockCallCount() int {
+	fake.writeLockMutex.RLock()
+	defer fake.writeLockMutex.RUnlock()
+	return len(fake.writeLockArgsForCall)
+}
+
+func (fake *FakeLocker) WriteLockCalls(stub func(string) error) {
+	fake.writeLockMutex.Lock()
+	defer fake.writeLockMutex.Unlock()
+	fake.WriteLockStub = stub
+}
+
+func (fake *FakeLocker) WriteLockArgsForCall(i int) string {
 	fake.writeLockMutex.RLock()
 	defer fake.writeLockMutex.RUnlock()
+	argsForCall := fake.writeLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLocker) WriteLockReturns(result1 error) {
+	fake.writeLockMutex.Lock()
+	defer fake.writeLockMutex.Unlock()
+	fake.WriteLockStub = nil
+	fake.writeLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) WriteLockReturnsOnCall(i int, result1 error) {
+	fake.writeLockMutex.Lock()
+	defer fake.writeLockMutex.Unlock()
+	fake.WriteLockStub = nil
+	if fake.writeLockReturnsOnCall == nil {
+		fake.writeLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) WriteUnlock(arg1 string) {
+	fake.writeUnlockMutex.Lock()
+	fake.writeUnlockArgsForCall = append(fake.writeUnlockArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("WriteUnlock", []interface{}{arg1})
+	fake.writeUnlockMutex.Unlock()
+	if fake.WriteUnlockStub != nil {
+		fake.WriteUnlockStub(arg1)
+	}
+}
+
+func (fake *FakeLocker) WriteUnlockCallCount() int {
 	fake.writeUnlockMutex.RLock()
 	defer fake.writeUnlockMutex.RUnlock()
+	return len(fake.writeUnlockArgsForCall)
+}
+
+func (fake *FakeLocker) WriteUnlockCalls(stub func(string)) {
+	fake.writeUnlockMutex.Lock()
+	defer fake.writeUnlockMutex.Unlock()
+	fake.WriteUnlockStub = stub
+}
+
+func (fake *FakeLocker) WriteUnlockArgsForCall(i int) string {
+	fake.writeUnlockMutex.RLock()
+	defer fake.writeUnlockMutex.RUnlock()
+	argsForCall := fake.writeUnlockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLocker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.heldLocksMutex.RLock()
+	defer fake.heldLocksMutex.RUnlock()
 	fake.readLockMutex.RLock()
 	defer fake.readLockMutex.RUnlock()
 	fake.readUnlockMutex.RLock()
 	defer fake.readUnlockMutex.RUnlock()
-	return fake.invocations
+	fake.tryReadLockMutex.RLock()
+	defer fake.tryReadLockMutex.RUnlock()
+	fake.tryWriteLockMutex.RLock()
+	defer fake.tryWriteLockMutex.RUnlock()
+	fake.writeLockMutex.RLock()
+	defer fake.writeLockMutex.RUnlock()
+	fake.writeUnlockMutex.RLock()
+	defer fake.writeUnlockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
 }
 
 func (fake *FakeLocker) recordInvocation(key string, args []interface{}) {
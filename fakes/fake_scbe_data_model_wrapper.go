@@ -5,9 +5,22 @@ import (
 	"sync"
 
 	"github.com/IBM/ubiquity/local/scbe"
+	"github.com/IBM/ubiquity/resources"
 )
 
 type FakeScbeDataModelWrapper struct {
+	DeleteSnapshotStub        func(string, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteSnapshotReturns struct {
+		result1 error
+	}
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteVolumeStub        func(string) error
 	deleteVolumeMutex       sync.RWMutex
 	deleteVolumeArgsForCall []struct {
@@ -19,6 +32,21 @@ type FakeScbeDataModelWrapper struct {
 	deleteVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetSnapshotStub        func(string, string, bool) (resources.Snapshot, error)
+	getSnapshotMutex       sync.RWMutex
+	getSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	getSnapshotReturns struct {
+		result1 resources.Snapshot
+		result2 error
+	}
+	getSnapshotReturnsOnCall map[int]struct {
+		result1 resources.Snapshot
+		result2 error
+	}
 	GetVolumeStub        func(string, bool) (scbe.ScbeVolume, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -33,12 +61,58 @@ type FakeScbeDataModelWrapper struct {
 		result1 scbe.ScbeVolume
 		result2 error
 	}
-	InsertVolumeStub        func(string, string, string) error
+	GetVolumeByWwnStub        func(string) (scbe.ScbeVolume, bool, error)
+	getVolumeByWwnMutex       sync.RWMutex
+	getVolumeByWwnArgsForCall []struct {
+		arg1 string
+	}
+	getVolumeByWwnReturns struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}
+	getVolumeByWwnReturnsOnCall map[int]struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}
+	InsertClonedVolumeStub        func(string, string, string, string, string, map[string]string) error
+	insertClonedVolumeMutex       sync.RWMutex
+	insertClonedVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]string
+	}
+	insertClonedVolumeReturns struct {
+		result1 error
+	}
+	insertClonedVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertSnapshotStub        func(string, string, string) error
+	insertSnapshotMutex       sync.RWMutex
+	insertSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	insertSnapshotReturns struct {
+		result1 error
+	}
+	insertSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertVolumeStub        func(string, string, string, bool, map[string]string) error
 	insertVolumeMutex       sync.RWMutex
 	insertVolumeArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 bool
+		arg5 map[string]string
 	}
 	insertVolumeReturns struct {
 		result1 error
@@ -46,9 +120,35 @@ type FakeScbeDataModelWrapper struct {
 	insertVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListVolumesStub        func() ([]scbe.ScbeVolume, error)
+	ListSnapshotsStub        func(string) ([]resources.Snapshot, error)
+	listSnapshotsMutex       sync.RWMutex
+	listSnapshotsArgsForCall []struct {
+		arg1 string
+	}
+	listSnapshotsReturns struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	listSnapshotsReturnsOnCall map[int]struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	ListStoredVolumesStub        func() ([]scbe.ScbeVolume, error)
+	listStoredVolumesMutex       sync.RWMutex
+	listStoredVolumesArgsForCall []struct {
+	}
+	listStoredVolumesReturns struct {
+		result1 []scbe.ScbeVolume
+		result2 error
+	}
+	listStoredVolumesReturnsOnCall map[int]struct {
+		result1 []scbe.ScbeVolume
+		result2 error
+	}
+	ListVolumesStub        func(resources.ListVolumesRequest) ([]scbe.ScbeVolume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
+		arg1 resources.ListVolumesRequest
 	}
 	listVolumesReturns struct {
 		result1 []scbe.ScbeVolume
@@ -63,10 +163,83 @@ type FakeScbeDataModelWrapper struct {
 	updateDatabaseVolumeArgsForCall []struct {
 		arg1 *scbe.ScbeVolume
 	}
+	UpdateVolumeAttachedHostStub        func(string, string) error
+	updateVolumeAttachedHostMutex       sync.RWMutex
+	updateVolumeAttachedHostArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateVolumeAttachedHostReturns struct {
+		result1 error
+	}
+	updateVolumeAttachedHostReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshot(arg1 string, arg2 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshotCalls(stub func(string, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshotArgsForCall(i int) (string, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScbeDataModelWrapper) DeleteVolume(arg1 string) error {
 	fake.deleteVolumeMutex.Lock()
 	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
@@ -127,6 +300,71 @@ func (fake *FakeScbeDataModelWrapper) DeleteVolumeReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeScbeDataModelWrapper) GetSnapshot(arg1 string, arg2 string, arg3 bool) (resources.Snapshot, error) {
+	fake.getSnapshotMutex.Lock()
+	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
+	fake.getSnapshotArgsForCall = append(fake.getSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.getSnapshotMutex.Unlock()
+	if fake.GetSnapshotStub != nil {
+		return fake.GetSnapshotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeDataModelWrapper) GetSnapshotCallCount() int {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	return len(fake.getSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) GetSnapshotCalls(stub func(string, string, bool) (resources.Snapshot, error)) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) GetSnapshotArgsForCall(i int) (string, string, bool) {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	argsForCall := fake.getSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScbeDataModelWrapper) GetSnapshotReturns(result1 resources.Snapshot, result2 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	fake.getSnapshotReturns = struct {
+		result1 resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModelWrapper) GetSnapshotReturnsOnCall(i int, result1 resources.Snapshot, result2 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	if fake.getSnapshotReturnsOnCall == nil {
+		fake.getSnapshotReturnsOnCall = make(map[int]struct {
+			result1 resources.Snapshot
+			result2 error
+		})
+	}
+	fake.getSnapshotReturnsOnCall[i] = struct {
+		result1 resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeScbeDataModelWrapper) GetVolume(arg1 string, arg2 bool) (scbe.ScbeVolume, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -191,18 +429,213 @@ func (fake *FakeScbeDataModelWrapper) GetVolumeReturnsOnCall(i int, result1 scbe
 	}{result1, result2}
 }
 
-func (fake *FakeScbeDataModelWrapper) InsertVolume(arg1 string, arg2 string, arg3 string) error {
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwn(arg1 string) (scbe.ScbeVolume, bool, error) {
+	fake.getVolumeByWwnMutex.Lock()
+	ret, specificReturn := fake.getVolumeByWwnReturnsOnCall[len(fake.getVolumeByWwnArgsForCall)]
+	fake.getVolumeByWwnArgsForCall = append(fake.getVolumeByWwnArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetVolumeByWwn", []interface{}{arg1})
+	fake.getVolumeByWwnMutex.Unlock()
+	if fake.GetVolumeByWwnStub != nil {
+		return fake.GetVolumeByWwnStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getVolumeByWwnReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwnCallCount() int {
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	return len(fake.getVolumeByWwnArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwnCalls(stub func(string) (scbe.ScbeVolume, bool, error)) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwnArgsForCall(i int) string {
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	argsForCall := fake.getVolumeByWwnArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwnReturns(result1 scbe.ScbeVolume, result2 bool, result3 error) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = nil
+	fake.getVolumeByWwnReturns = struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeScbeDataModelWrapper) GetVolumeByWwnReturnsOnCall(i int, result1 scbe.ScbeVolume, result2 bool, result3 error) {
+	fake.getVolumeByWwnMutex.Lock()
+	defer fake.getVolumeByWwnMutex.Unlock()
+	fake.GetVolumeByWwnStub = nil
+	if fake.getVolumeByWwnReturnsOnCall == nil {
+		fake.getVolumeByWwnReturnsOnCall = make(map[int]struct {
+			result1 scbe.ScbeVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getVolumeByWwnReturnsOnCall[i] = struct {
+		result1 scbe.ScbeVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolume(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 map[string]string) error {
+	fake.insertClonedVolumeMutex.Lock()
+	ret, specificReturn := fake.insertClonedVolumeReturnsOnCall[len(fake.insertClonedVolumeArgsForCall)]
+	fake.insertClonedVolumeArgsForCall = append(fake.insertClonedVolumeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("InsertClonedVolume", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.insertClonedVolumeMutex.Unlock()
+	if fake.InsertClonedVolumeStub != nil {
+		return fake.InsertClonedVolumeStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertClonedVolumeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolumeCallCount() int {
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	return len(fake.insertClonedVolumeArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolumeCalls(stub func(string, string, string, string, string, map[string]string) error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolumeArgsForCall(i int) (string, string, string, string, string, map[string]string) {
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	argsForCall := fake.insertClonedVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolumeReturns(result1 error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = nil
+	fake.insertClonedVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertClonedVolumeReturnsOnCall(i int, result1 error) {
+	fake.insertClonedVolumeMutex.Lock()
+	defer fake.insertClonedVolumeMutex.Unlock()
+	fake.InsertClonedVolumeStub = nil
+	if fake.insertClonedVolumeReturnsOnCall == nil {
+		fake.insertClonedVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertClonedVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshot(arg1 string, arg2 string, arg3 string) error {
+	fake.insertSnapshotMutex.Lock()
+	ret, specificReturn := fake.insertSnapshotReturnsOnCall[len(fake.insertSnapshotArgsForCall)]
+	fake.insertSnapshotArgsForCall = append(fake.insertSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("InsertSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.insertSnapshotMutex.Unlock()
+	if fake.InsertSnapshotStub != nil {
+		return fake.InsertSnapshotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshotCallCount() int {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	return len(fake.insertSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshotCalls(stub func(string, string, string) error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshotArgsForCall(i int) (string, string, string) {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	argsForCall := fake.insertSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshotReturns(result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	fake.insertSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertSnapshotReturnsOnCall(i int, result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	if fake.insertSnapshotReturnsOnCall == nil {
+		fake.insertSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) InsertVolume(arg1 string, arg2 string, arg3 string, arg4 bool, arg5 map[string]string) error {
 	fake.insertVolumeMutex.Lock()
 	ret, specificReturn := fake.insertVolumeReturnsOnCall[len(fake.insertVolumeArgsForCall)]
 	fake.insertVolumeArgsForCall = append(fake.insertVolumeArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("InsertVolume", []interface{}{arg1, arg2, arg3})
+		arg4 bool
+		arg5 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("InsertVolume", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.insertVolumeMutex.Unlock()
 	if fake.InsertVolumeStub != nil {
-		return fake.InsertVolumeStub(arg1, arg2, arg3)
+		return fake.InsertVolumeStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -217,17 +650,17 @@ func (fake *FakeScbeDataModelWrapper) InsertVolumeCallCount() int {
 	return len(fake.insertVolumeArgsForCall)
 }
 
-func (fake *FakeScbeDataModelWrapper) InsertVolumeCalls(stub func(string, string, string) error) {
+func (fake *FakeScbeDataModelWrapper) InsertVolumeCalls(stub func(string, string, string, bool, map[string]string) error) {
 	fake.insertVolumeMutex.Lock()
 	defer fake.insertVolumeMutex.Unlock()
 	fake.InsertVolumeStub = stub
 }
 
-func (fake *FakeScbeDataModelWrapper) InsertVolumeArgsForCall(i int) (string, string, string) {
+func (fake *FakeScbeDataModelWrapper) InsertVolumeArgsForCall(i int) (string, string, string, bool, map[string]string) {
 	fake.insertVolumeMutex.RLock()
 	defer fake.insertVolumeMutex.RUnlock()
 	argsForCall := fake.insertVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeScbeDataModelWrapper) InsertVolumeReturns(result1 error) {
@@ -253,15 +686,134 @@ func (fake *FakeScbeDataModelWrapper) InsertVolumeReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeScbeDataModelWrapper) ListVolumes() ([]scbe.ScbeVolume, error) {
+func (fake *FakeScbeDataModelWrapper) ListSnapshots(arg1 string) ([]resources.Snapshot, error) {
+	fake.listSnapshotsMutex.Lock()
+	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
+	fake.listSnapshotsArgsForCall = append(fake.listSnapshotsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListSnapshots", []interface{}{arg1})
+	fake.listSnapshotsMutex.Unlock()
+	if fake.ListSnapshotsStub != nil {
+		return fake.ListSnapshotsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSnapshotsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeDataModelWrapper) ListSnapshotsCallCount() int {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	return len(fake.listSnapshotsArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) ListSnapshotsCalls(stub func(string) ([]resources.Snapshot, error)) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) ListSnapshotsArgsForCall(i int) string {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	argsForCall := fake.listSnapshotsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScbeDataModelWrapper) ListSnapshotsReturns(result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	fake.listSnapshotsReturns = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModelWrapper) ListSnapshotsReturnsOnCall(i int, result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	if fake.listSnapshotsReturnsOnCall == nil {
+		fake.listSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []resources.Snapshot
+			result2 error
+		})
+	}
+	fake.listSnapshotsReturnsOnCall[i] = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModelWrapper) ListStoredVolumes() ([]scbe.ScbeVolume, error) {
+	fake.listStoredVolumesMutex.Lock()
+	ret, specificReturn := fake.listStoredVolumesReturnsOnCall[len(fake.listStoredVolumesArgsForCall)]
+	fake.listStoredVolumesArgsForCall = append(fake.listStoredVolumesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStoredVolumes", []interface{}{})
+	fake.listStoredVolumesMutex.Unlock()
+	if fake.ListStoredVolumesStub != nil {
+		return fake.ListStoredVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStoredVolumesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeDataModelWrapper) ListStoredVolumesCallCount() int {
+	fake.listStoredVolumesMutex.RLock()
+	defer fake.listStoredVolumesMutex.RUnlock()
+	return len(fake.listStoredVolumesArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) ListStoredVolumesCalls(stub func() ([]scbe.ScbeVolume, error)) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) ListStoredVolumesReturns(result1 []scbe.ScbeVolume, result2 error) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = nil
+	fake.listStoredVolumesReturns = struct {
+		result1 []scbe.ScbeVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModelWrapper) ListStoredVolumesReturnsOnCall(i int, result1 []scbe.ScbeVolume, result2 error) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = nil
+	if fake.listStoredVolumesReturnsOnCall == nil {
+		fake.listStoredVolumesReturnsOnCall = make(map[int]struct {
+			result1 []scbe.ScbeVolume
+			result2 error
+		})
+	}
+	fake.listStoredVolumesReturnsOnCall[i] = struct {
+		result1 []scbe.ScbeVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeDataModelWrapper) ListVolumes(arg1 resources.ListVolumesRequest) ([]scbe.ScbeVolume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
 	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListVolumes", []interface{}{})
+		arg1 resources.ListVolumesRequest
+	}{arg1})
+	fake.recordInvocation("ListVolumes", []interface{}{arg1})
 	fake.listVolumesMutex.Unlock()
 	if fake.ListVolumesStub != nil {
-		return fake.ListVolumesStub()
+		return fake.ListVolumesStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -276,12 +828,19 @@ func (fake *FakeScbeDataModelWrapper) ListVolumesCallCount() int {
 	return len(fake.listVolumesArgsForCall)
 }
 
-func (fake *FakeScbeDataModelWrapper) ListVolumesCalls(stub func() ([]scbe.ScbeVolume, error)) {
+func (fake *FakeScbeDataModelWrapper) ListVolumesCalls(stub func(resources.ListVolumesRequest) ([]scbe.ScbeVolume, error)) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
 	fake.ListVolumesStub = stub
 }
 
+func (fake *FakeScbeDataModelWrapper) ListVolumesArgsForCall(i int) resources.ListVolumesRequest {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	argsForCall := fake.listVolumesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeScbeDataModelWrapper) ListVolumesReturns(result1 []scbe.ScbeVolume, result2 error) {
 	fake.listVolumesMutex.Lock()
 	defer fake.listVolumesMutex.Unlock()
@@ -339,19 +898,96 @@ func (fake *FakeScbeDataModelWrapper) UpdateDatabaseVolumeArgsForCall(i int) *sc
 	return argsForCall.arg1
 }
 
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHost(arg1 string, arg2 string) error {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	ret, specificReturn := fake.updateVolumeAttachedHostReturnsOnCall[len(fake.updateVolumeAttachedHostArgsForCall)]
+	fake.updateVolumeAttachedHostArgsForCall = append(fake.updateVolumeAttachedHostArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateVolumeAttachedHost", []interface{}{arg1, arg2})
+	fake.updateVolumeAttachedHostMutex.Unlock()
+	if fake.UpdateVolumeAttachedHostStub != nil {
+		return fake.UpdateVolumeAttachedHostStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateVolumeAttachedHostReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHostCallCount() int {
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
+	return len(fake.updateVolumeAttachedHostArgsForCall)
+}
+
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHostCalls(stub func(string, string) error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = stub
+}
+
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHostArgsForCall(i int) (string, string) {
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
+	argsForCall := fake.updateVolumeAttachedHostArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHostReturns(result1 error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = nil
+	fake.updateVolumeAttachedHostReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeDataModelWrapper) UpdateVolumeAttachedHostReturnsOnCall(i int, result1 error) {
+	fake.updateVolumeAttachedHostMutex.Lock()
+	defer fake.updateVolumeAttachedHostMutex.Unlock()
+	fake.UpdateVolumeAttachedHostStub = nil
+	if fake.updateVolumeAttachedHostReturnsOnCall == nil {
+		fake.updateVolumeAttachedHostReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVolumeAttachedHostReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScbeDataModelWrapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
+	fake.getVolumeByWwnMutex.RLock()
+	defer fake.getVolumeByWwnMutex.RUnlock()
+	fake.insertClonedVolumeMutex.RLock()
+	defer fake.insertClonedVolumeMutex.RUnlock()
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
 	fake.insertVolumeMutex.RLock()
 	defer fake.insertVolumeMutex.RUnlock()
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	fake.listStoredVolumesMutex.RLock()
+	defer fake.listStoredVolumesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
 	fake.updateDatabaseVolumeMutex.RLock()
 	defer fake.updateDatabaseVolumeMutex.RUnlock()
+	fake.updateVolumeAttachedHostMutex.RLock()
+	defer fake.updateVolumeAttachedHostMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,18 +2,51 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/IBM/ubiquity/local/scbe"
 )
 
 type FakeScbeRestClient struct {
-	CreateVolumeStub        func(string, string, int) (scbe.ScbeVolumeInfo, error)
+	CloneVolumeStub        func(context.Context, string, string, string) (scbe.ScbeVolumeInfo, error)
+	cloneVolumeMutex       sync.RWMutex
+	cloneVolumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	cloneVolumeReturns struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}
+	cloneVolumeReturnsOnCall map[int]struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}
+	CreateSnapshotStub        func(context.Context, string, string) (scbe.ScbeResponseSnapshot, error)
+	createSnapshotMutex       sync.RWMutex
+	createSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	createSnapshotReturns struct {
+		result1 scbe.ScbeResponseSnapshot
+		result2 error
+	}
+	createSnapshotReturnsOnCall map[int]struct {
+		result1 scbe.ScbeResponseSnapshot
+		result2 error
+	}
+	CreateVolumeStub        func(context.Context, string, string, int) (scbe.ScbeVolumeInfo, error)
 	createVolumeMutex       sync.RWMutex
 	createVolumeArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 int
+		arg3 string
+		arg4 int
 	}
 	createVolumeReturns struct {
 		result1 scbe.ScbeVolumeInfo
@@ -23,10 +56,23 @@ type FakeScbeRestClient struct {
 		result1 scbe.ScbeVolumeInfo
 		result2 error
 	}
-	DeleteVolumeStub        func(string) error
+	DeleteSnapshotStub        func(context.Context, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteSnapshotReturns struct {
+		result1 error
+	}
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteVolumeStub        func(context.Context, string) error
 	deleteVolumeMutex       sync.RWMutex
 	deleteVolumeArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	deleteVolumeReturns struct {
 		result1 error
@@ -34,10 +80,11 @@ type FakeScbeRestClient struct {
 	deleteVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetVolMappingStub        func(string) (scbe.ScbeVolumeMapInfo, error)
+	GetVolMappingStub        func(context.Context, string) (scbe.ScbeVolumeMapInfo, error)
 	getVolMappingMutex       sync.RWMutex
 	getVolMappingArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getVolMappingReturns struct {
 		result1 scbe.ScbeVolumeMapInfo
@@ -47,10 +94,11 @@ type FakeScbeRestClient struct {
 		result1 scbe.ScbeVolumeMapInfo
 		result2 error
 	}
-	GetVolumesStub        func(string) ([]scbe.ScbeVolumeInfo, error)
+	GetVolumesStub        func(context.Context, string) ([]scbe.ScbeVolumeInfo, error)
 	getVolumesMutex       sync.RWMutex
 	getVolumesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getVolumesReturns struct {
 		result1 []scbe.ScbeVolumeInfo
@@ -60,9 +108,23 @@ type FakeScbeRestClient struct {
 		result1 []scbe.ScbeVolumeInfo
 		result2 error
 	}
-	LoginStub        func() error
+	ListServicesStub        func(context.Context) ([]scbe.ScbeStorageService, error)
+	listServicesMutex       sync.RWMutex
+	listServicesArgsForCall []struct {
+		arg1 context.Context
+	}
+	listServicesReturns struct {
+		result1 []scbe.ScbeStorageService
+		result2 error
+	}
+	listServicesReturnsOnCall map[int]struct {
+		result1 []scbe.ScbeStorageService
+		result2 error
+	}
+	LoginStub        func(context.Context) error
 	loginMutex       sync.RWMutex
 	loginArgsForCall []struct {
+		arg1 context.Context
 	}
 	loginReturns struct {
 		result1 error
@@ -70,11 +132,12 @@ type FakeScbeRestClient struct {
 	loginReturnsOnCall map[int]struct {
 		result1 error
 	}
-	MapVolumeStub        func(string, string) (scbe.ScbeResponseMapping, error)
+	MapVolumeStub        func(context.Context, string, string) (scbe.ScbeResponseMapping, error)
 	mapVolumeMutex       sync.RWMutex
 	mapVolumeArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	mapVolumeReturns struct {
 		result1 scbe.ScbeResponseMapping
@@ -84,10 +147,26 @@ type FakeScbeRestClient struct {
 		result1 scbe.ScbeResponseMapping
 		result2 error
 	}
-	ServiceExistStub        func(string) (bool, error)
+	ResizeVolumeStub        func(context.Context, string, int) (scbe.ScbeVolumeInfo, error)
+	resizeVolumeMutex       sync.RWMutex
+	resizeVolumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	resizeVolumeReturns struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}
+	resizeVolumeReturnsOnCall map[int]struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}
+	ServiceExistStub        func(context.Context, string) (bool, error)
 	serviceExistMutex       sync.RWMutex
 	serviceExistArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	serviceExistReturns struct {
 		result1 bool
@@ -97,11 +176,12 @@ type FakeScbeRestClient struct {
 		result1 bool
 		result2 error
 	}
-	UnmapVolumeStub        func(string, string) error
+	UnmapVolumeStub        func(context.Context, string, string) error
 	unmapVolumeMutex       sync.RWMutex
 	unmapVolumeArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	unmapVolumeReturns struct {
 		result1 error
@@ -113,18 +193,150 @@ type FakeScbeRestClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeScbeRestClient) CreateVolume(arg1 string, arg2 string, arg3 int) (scbe.ScbeVolumeInfo, error) {
+func (fake *FakeScbeRestClient) CloneVolume(arg1 context.Context, arg2 string, arg3 string, arg4 string) (scbe.ScbeVolumeInfo, error) {
+	fake.cloneVolumeMutex.Lock()
+	ret, specificReturn := fake.cloneVolumeReturnsOnCall[len(fake.cloneVolumeArgsForCall)]
+	fake.cloneVolumeArgsForCall = append(fake.cloneVolumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CloneVolume", []interface{}{arg1, arg2, arg3, arg4})
+	fake.cloneVolumeMutex.Unlock()
+	if fake.CloneVolumeStub != nil {
+		return fake.CloneVolumeStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.cloneVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeRestClient) CloneVolumeCallCount() int {
+	fake.cloneVolumeMutex.RLock()
+	defer fake.cloneVolumeMutex.RUnlock()
+	return len(fake.cloneVolumeArgsForCall)
+}
+
+func (fake *FakeScbeRestClient) CloneVolumeCalls(stub func(context.Context, string, string, string) (scbe.ScbeVolumeInfo, error)) {
+	fake.cloneVolumeMutex.Lock()
+	defer fake.cloneVolumeMutex.Unlock()
+	fake.CloneVolumeStub = stub
+}
+
+func (fake *FakeScbeRestClient) CloneVolumeArgsForCall(i int) (context.Context, string, string, string) {
+	fake.cloneVolumeMutex.RLock()
+	defer fake.cloneVolumeMutex.RUnlock()
+	argsForCall := fake.cloneVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeScbeRestClient) CloneVolumeReturns(result1 scbe.ScbeVolumeInfo, result2 error) {
+	fake.cloneVolumeMutex.Lock()
+	defer fake.cloneVolumeMutex.Unlock()
+	fake.CloneVolumeStub = nil
+	fake.cloneVolumeReturns = struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) CloneVolumeReturnsOnCall(i int, result1 scbe.ScbeVolumeInfo, result2 error) {
+	fake.cloneVolumeMutex.Lock()
+	defer fake.cloneVolumeMutex.Unlock()
+	fake.CloneVolumeStub = nil
+	if fake.cloneVolumeReturnsOnCall == nil {
+		fake.cloneVolumeReturnsOnCall = make(map[int]struct {
+			result1 scbe.ScbeVolumeInfo
+			result2 error
+		})
+	}
+	fake.cloneVolumeReturnsOnCall[i] = struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshot(arg1 context.Context, arg2 string, arg3 string) (scbe.ScbeResponseSnapshot, error) {
+	fake.createSnapshotMutex.Lock()
+	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
+	fake.createSnapshotArgsForCall = append(fake.createSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.createSnapshotMutex.Unlock()
+	if fake.CreateSnapshotStub != nil {
+		return fake.CreateSnapshotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshotCallCount() int {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	return len(fake.createSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshotCalls(stub func(context.Context, string, string) (scbe.ScbeResponseSnapshot, error)) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = stub
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshotArgsForCall(i int) (context.Context, string, string) {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	argsForCall := fake.createSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshotReturns(result1 scbe.ScbeResponseSnapshot, result2 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	fake.createSnapshotReturns = struct {
+		result1 scbe.ScbeResponseSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) CreateSnapshotReturnsOnCall(i int, result1 scbe.ScbeResponseSnapshot, result2 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	if fake.createSnapshotReturnsOnCall == nil {
+		fake.createSnapshotReturnsOnCall = make(map[int]struct {
+			result1 scbe.ScbeResponseSnapshot
+			result2 error
+		})
+	}
+	fake.createSnapshotReturnsOnCall[i] = struct {
+		result1 scbe.ScbeResponseSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) CreateVolume(arg1 context.Context, arg2 string, arg3 string, arg4 int) (scbe.ScbeVolumeInfo, error) {
 	fake.createVolumeMutex.Lock()
 	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
 	fake.createVolumeArgsForCall = append(fake.createVolumeArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 int
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3})
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3, arg4})
 	fake.createVolumeMutex.Unlock()
 	if fake.CreateVolumeStub != nil {
-		return fake.CreateVolumeStub(arg1, arg2, arg3)
+		return fake.CreateVolumeStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -139,17 +351,17 @@ func (fake *FakeScbeRestClient) CreateVolumeCallCount() int {
 	return len(fake.createVolumeArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) CreateVolumeCalls(stub func(string, string, int) (scbe.ScbeVolumeInfo, error)) {
+func (fake *FakeScbeRestClient) CreateVolumeCalls(stub func(context.Context, string, string, int) (scbe.ScbeVolumeInfo, error)) {
 	fake.createVolumeMutex.Lock()
 	defer fake.createVolumeMutex.Unlock()
 	fake.CreateVolumeStub = stub
 }
 
-func (fake *FakeScbeRestClient) CreateVolumeArgsForCall(i int) (string, string, int) {
+func (fake *FakeScbeRestClient) CreateVolumeArgsForCall(i int) (context.Context, string, string, int) {
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
 	argsForCall := fake.createVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeScbeRestClient) CreateVolumeReturns(result1 scbe.ScbeVolumeInfo, result2 error) {
@@ -178,16 +390,78 @@ func (fake *FakeScbeRestClient) CreateVolumeReturnsOnCall(i int, result1 scbe.Sc
 	}{result1, result2}
 }
 
-func (fake *FakeScbeRestClient) DeleteVolume(arg1 string) error {
+func (fake *FakeScbeRestClient) DeleteSnapshot(arg1 context.Context, arg2 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScbeRestClient) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeScbeRestClient) DeleteSnapshotCalls(stub func(context.Context, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeScbeRestClient) DeleteSnapshotArgsForCall(i int) (context.Context, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeScbeRestClient) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeRestClient) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScbeRestClient) DeleteVolume(arg1 context.Context, arg2 string) error {
 	fake.deleteVolumeMutex.Lock()
 	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
 	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteVolume", []interface{}{arg1, arg2})
 	fake.deleteVolumeMutex.Unlock()
 	if fake.DeleteVolumeStub != nil {
-		return fake.DeleteVolumeStub(arg1)
+		return fake.DeleteVolumeStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -202,17 +476,17 @@ func (fake *FakeScbeRestClient) DeleteVolumeCallCount() int {
 	return len(fake.deleteVolumeArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) DeleteVolumeCalls(stub func(string) error) {
+func (fake *FakeScbeRestClient) DeleteVolumeCalls(stub func(context.Context, string) error) {
 	fake.deleteVolumeMutex.Lock()
 	defer fake.deleteVolumeMutex.Unlock()
 	fake.DeleteVolumeStub = stub
 }
 
-func (fake *FakeScbeRestClient) DeleteVolumeArgsForCall(i int) string {
+func (fake *FakeScbeRestClient) DeleteVolumeArgsForCall(i int) (context.Context, string) {
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
 	argsForCall := fake.deleteVolumeArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeScbeRestClient) DeleteVolumeReturns(result1 error) {
@@ -238,16 +512,17 @@ func (fake *FakeScbeRestClient) DeleteVolumeReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeScbeRestClient) GetVolMapping(arg1 string) (scbe.ScbeVolumeMapInfo, error) {
+func (fake *FakeScbeRestClient) GetVolMapping(arg1 context.Context, arg2 string) (scbe.ScbeVolumeMapInfo, error) {
 	fake.getVolMappingMutex.Lock()
 	ret, specificReturn := fake.getVolMappingReturnsOnCall[len(fake.getVolMappingArgsForCall)]
 	fake.getVolMappingArgsForCall = append(fake.getVolMappingArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("GetVolMapping", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetVolMapping", []interface{}{arg1, arg2})
 	fake.getVolMappingMutex.Unlock()
 	if fake.GetVolMappingStub != nil {
-		return fake.GetVolMappingStub(arg1)
+		return fake.GetVolMappingStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -262,17 +537,17 @@ func (fake *FakeScbeRestClient) GetVolMappingCallCount() int {
 	return len(fake.getVolMappingArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) GetVolMappingCalls(stub func(string) (scbe.ScbeVolumeMapInfo, error)) {
+func (fake *FakeScbeRestClient) GetVolMappingCalls(stub func(context.Context, string) (scbe.ScbeVolumeMapInfo, error)) {
 	fake.getVolMappingMutex.Lock()
 	defer fake.getVolMappingMutex.Unlock()
 	fake.GetVolMappingStub = stub
 }
 
-func (fake *FakeScbeRestClient) GetVolMappingArgsForCall(i int) string {
+func (fake *FakeScbeRestClient) GetVolMappingArgsForCall(i int) (context.Context, string) {
 	fake.getVolMappingMutex.RLock()
 	defer fake.getVolMappingMutex.RUnlock()
 	argsForCall := fake.getVolMappingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeScbeRestClient) GetVolMappingReturns(result1 scbe.ScbeVolumeMapInfo, result2 error) {
@@ -301,16 +576,17 @@ func (fake *FakeScbeRestClient) GetVolMappingReturnsOnCall(i int, result1 scbe.S
 	}{result1, result2}
 }
 
-func (fake *FakeScbeRestClient) GetVolumes(arg1 string) ([]scbe.ScbeVolumeInfo, error) {
+func (fake *FakeScbeRestClient) GetVolumes(arg1 context.Context, arg2 string) ([]scbe.ScbeVolumeInfo, error) {
 	fake.getVolumesMutex.Lock()
 	ret, specificReturn := fake.getVolumesReturnsOnCall[len(fake.getVolumesArgsForCall)]
 	fake.getVolumesArgsForCall = append(fake.getVolumesArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("GetVolumes", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetVolumes", []interface{}{arg1, arg2})
 	fake.getVolumesMutex.Unlock()
 	if fake.GetVolumesStub != nil {
-		return fake.GetVolumesStub(arg1)
+		return fake.GetVolumesStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -325,17 +601,17 @@ func (fake *FakeScbeRestClient) GetVolumesCallCount() int {
 	return len(fake.getVolumesArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) GetVolumesCalls(stub func(string) ([]scbe.ScbeVolumeInfo, error)) {
+func (fake *FakeScbeRestClient) GetVolumesCalls(stub func(context.Context, string) ([]scbe.ScbeVolumeInfo, error)) {
 	fake.getVolumesMutex.Lock()
 	defer fake.getVolumesMutex.Unlock()
 	fake.GetVolumesStub = stub
 }
 
-func (fake *FakeScbeRestClient) GetVolumesArgsForCall(i int) string {
+func (fake *FakeScbeRestClient) GetVolumesArgsForCall(i int) (context.Context, string) {
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
 	argsForCall := fake.getVolumesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeScbeRestClient) GetVolumesReturns(result1 []scbe.ScbeVolumeInfo, result2 error) {
@@ -364,15 +640,79 @@ func (fake *FakeScbeRestClient) GetVolumesReturnsOnCall(i int, result1 []scbe.Sc
 	}{result1, result2}
 }
 
-func (fake *FakeScbeRestClient) Login() error {
+func (fake *FakeScbeRestClient) ListServices(arg1 context.Context) ([]scbe.ScbeStorageService, error) {
+	fake.listServicesMutex.Lock()
+	ret, specificReturn := fake.listServicesReturnsOnCall[len(fake.listServicesArgsForCall)]
+	fake.listServicesArgsForCall = append(fake.listServicesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("ListServices", []interface{}{arg1})
+	fake.listServicesMutex.Unlock()
+	if fake.ListServicesStub != nil {
+		return fake.ListServicesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listServicesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeRestClient) ListServicesCallCount() int {
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
+	return len(fake.listServicesArgsForCall)
+}
+
+func (fake *FakeScbeRestClient) ListServicesCalls(stub func(context.Context) ([]scbe.ScbeStorageService, error)) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = stub
+}
+
+func (fake *FakeScbeRestClient) ListServicesArgsForCall(i int) context.Context {
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
+	argsForCall := fake.listServicesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScbeRestClient) ListServicesReturns(result1 []scbe.ScbeStorageService, result2 error) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = nil
+	fake.listServicesReturns = struct {
+		result1 []scbe.ScbeStorageService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) ListServicesReturnsOnCall(i int, result1 []scbe.ScbeStorageService, result2 error) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = nil
+	if fake.listServicesReturnsOnCall == nil {
+		fake.listServicesReturnsOnCall = make(map[int]struct {
+			result1 []scbe.ScbeStorageService
+			result2 error
+		})
+	}
+	fake.listServicesReturnsOnCall[i] = struct {
+		result1 []scbe.ScbeStorageService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) Login(arg1 context.Context) error {
 	fake.loginMutex.Lock()
 	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
 	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Login", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("Login", []interface{}{arg1})
 	fake.loginMutex.Unlock()
 	if fake.LoginStub != nil {
-		return fake.LoginStub()
+		return fake.LoginStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -387,12 +727,19 @@ func (fake *FakeScbeRestClient) LoginCallCount() int {
 	return len(fake.loginArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) LoginCalls(stub func() error) {
+func (fake *FakeScbeRestClient) LoginCalls(stub func(context.Context) error) {
 	fake.loginMutex.Lock()
 	defer fake.loginMutex.Unlock()
 	fake.LoginStub = stub
 }
 
+func (fake *FakeScbeRestClient) LoginArgsForCall(i int) context.Context {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeScbeRestClient) LoginReturns(result1 error) {
 	fake.loginMutex.Lock()
 	defer fake.loginMutex.Unlock()
@@ -416,17 +763,18 @@ func (fake *FakeScbeRestClient) LoginReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeScbeRestClient) MapVolume(arg1 string, arg2 string) (scbe.ScbeResponseMapping, error) {
+func (fake *FakeScbeRestClient) MapVolume(arg1 context.Context, arg2 string, arg3 string) (scbe.ScbeResponseMapping, error) {
 	fake.mapVolumeMutex.Lock()
 	ret, specificReturn := fake.mapVolumeReturnsOnCall[len(fake.mapVolumeArgsForCall)]
 	fake.mapVolumeArgsForCall = append(fake.mapVolumeArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("MapVolume", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("MapVolume", []interface{}{arg1, arg2, arg3})
 	fake.mapVolumeMutex.Unlock()
 	if fake.MapVolumeStub != nil {
-		return fake.MapVolumeStub(arg1, arg2)
+		return fake.MapVolumeStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -441,17 +789,17 @@ func (fake *FakeScbeRestClient) MapVolumeCallCount() int {
 	return len(fake.mapVolumeArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) MapVolumeCalls(stub func(string, string) (scbe.ScbeResponseMapping, error)) {
+func (fake *FakeScbeRestClient) MapVolumeCalls(stub func(context.Context, string, string) (scbe.ScbeResponseMapping, error)) {
 	fake.mapVolumeMutex.Lock()
 	defer fake.mapVolumeMutex.Unlock()
 	fake.MapVolumeStub = stub
 }
 
-func (fake *FakeScbeRestClient) MapVolumeArgsForCall(i int) (string, string) {
+func (fake *FakeScbeRestClient) MapVolumeArgsForCall(i int) (context.Context, string, string) {
 	fake.mapVolumeMutex.RLock()
 	defer fake.mapVolumeMutex.RUnlock()
 	argsForCall := fake.mapVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeScbeRestClient) MapVolumeReturns(result1 scbe.ScbeResponseMapping, result2 error) {
@@ -480,16 +828,82 @@ func (fake *FakeScbeRestClient) MapVolumeReturnsOnCall(i int, result1 scbe.ScbeR
 	}{result1, result2}
 }
 
-func (fake *FakeScbeRestClient) ServiceExist(arg1 string) (bool, error) {
+func (fake *FakeScbeRestClient) ResizeVolume(arg1 context.Context, arg2 string, arg3 int) (scbe.ScbeVolumeInfo, error) {
+	fake.resizeVolumeMutex.Lock()
+	ret, specificReturn := fake.resizeVolumeReturnsOnCall[len(fake.resizeVolumeArgsForCall)]
+	fake.resizeVolumeArgsForCall = append(fake.resizeVolumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ResizeVolume", []interface{}{arg1, arg2, arg3})
+	fake.resizeVolumeMutex.Unlock()
+	if fake.ResizeVolumeStub != nil {
+		return fake.ResizeVolumeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resizeVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeScbeRestClient) ResizeVolumeCallCount() int {
+	fake.resizeVolumeMutex.RLock()
+	defer fake.resizeVolumeMutex.RUnlock()
+	return len(fake.resizeVolumeArgsForCall)
+}
+
+func (fake *FakeScbeRestClient) ResizeVolumeCalls(stub func(context.Context, string, int) (scbe.ScbeVolumeInfo, error)) {
+	fake.resizeVolumeMutex.Lock()
+	defer fake.resizeVolumeMutex.Unlock()
+	fake.ResizeVolumeStub = stub
+}
+
+func (fake *FakeScbeRestClient) ResizeVolumeArgsForCall(i int) (context.Context, string, int) {
+	fake.resizeVolumeMutex.RLock()
+	defer fake.resizeVolumeMutex.RUnlock()
+	argsForCall := fake.resizeVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScbeRestClient) ResizeVolumeReturns(result1 scbe.ScbeVolumeInfo, result2 error) {
+	fake.resizeVolumeMutex.Lock()
+	defer fake.resizeVolumeMutex.Unlock()
+	fake.ResizeVolumeStub = nil
+	fake.resizeVolumeReturns = struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) ResizeVolumeReturnsOnCall(i int, result1 scbe.ScbeVolumeInfo, result2 error) {
+	fake.resizeVolumeMutex.Lock()
+	defer fake.resizeVolumeMutex.Unlock()
+	fake.ResizeVolumeStub = nil
+	if fake.resizeVolumeReturnsOnCall == nil {
+		fake.resizeVolumeReturnsOnCall = make(map[int]struct {
+			result1 scbe.ScbeVolumeInfo
+			result2 error
+		})
+	}
+	fake.resizeVolumeReturnsOnCall[i] = struct {
+		result1 scbe.ScbeVolumeInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScbeRestClient) ServiceExist(arg1 context.Context, arg2 string) (bool, error) {
 	fake.serviceExistMutex.Lock()
 	ret, specificReturn := fake.serviceExistReturnsOnCall[len(fake.serviceExistArgsForCall)]
 	fake.serviceExistArgsForCall = append(fake.serviceExistArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("ServiceExist", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ServiceExist", []interface{}{arg1, arg2})
 	fake.serviceExistMutex.Unlock()
 	if fake.ServiceExistStub != nil {
-		return fake.ServiceExistStub(arg1)
+		return fake.ServiceExistStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -504,17 +918,17 @@ func (fake *FakeScbeRestClient) ServiceExistCallCount() int {
 	return len(fake.serviceExistArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) ServiceExistCalls(stub func(string) (bool, error)) {
+func (fake *FakeScbeRestClient) ServiceExistCalls(stub func(context.Context, string) (bool, error)) {
 	fake.serviceExistMutex.Lock()
 	defer fake.serviceExistMutex.Unlock()
 	fake.ServiceExistStub = stub
 }
 
-func (fake *FakeScbeRestClient) ServiceExistArgsForCall(i int) string {
+func (fake *FakeScbeRestClient) ServiceExistArgsForCall(i int) (context.Context, string) {
 	fake.serviceExistMutex.RLock()
 	defer fake.serviceExistMutex.RUnlock()
 	argsForCall := fake.serviceExistArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeScbeRestClient) ServiceExistReturns(result1 bool, result2 error) {
@@ -543,17 +957,18 @@ func (fake *FakeScbeRestClient) ServiceExistReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
-func (fake *FakeScbeRestClient) UnmapVolume(arg1 string, arg2 string) error {
+func (fake *FakeScbeRestClient) UnmapVolume(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.unmapVolumeMutex.Lock()
 	ret, specificReturn := fake.unmapVolumeReturnsOnCall[len(fake.unmapVolumeArgsForCall)]
 	fake.unmapVolumeArgsForCall = append(fake.unmapVolumeArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("UnmapVolume", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UnmapVolume", []interface{}{arg1, arg2, arg3})
 	fake.unmapVolumeMutex.Unlock()
 	if fake.UnmapVolumeStub != nil {
-		return fake.UnmapVolumeStub(arg1, arg2)
+		return fake.UnmapVolumeStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -568,17 +983,17 @@ func (fake *FakeScbeRestClient) UnmapVolumeCallCount() int {
 	return len(fake.unmapVolumeArgsForCall)
 }
 
-func (fake *FakeScbeRestClient) UnmapVolumeCalls(stub func(string, string) error) {
+func (fake *FakeScbeRestClient) UnmapVolumeCalls(stub func(context.Context, string, string) error) {
 	fake.unmapVolumeMutex.Lock()
 	defer fake.unmapVolumeMutex.Unlock()
 	fake.UnmapVolumeStub = stub
 }
 
-func (fake *FakeScbeRestClient) UnmapVolumeArgsForCall(i int) (string, string) {
+func (fake *FakeScbeRestClient) UnmapVolumeArgsForCall(i int) (context.Context, string, string) {
 	fake.unmapVolumeMutex.RLock()
 	defer fake.unmapVolumeMutex.RUnlock()
 	argsForCall := fake.unmapVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeScbeRestClient) UnmapVolumeReturns(result1 error) {
@@ -607,18 +1022,28 @@ func (fake *FakeScbeRestClient) UnmapVolumeReturnsOnCall(i int, result1 error) {
 func (fake *FakeScbeRestClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cloneVolumeMutex.RLock()
+	defer fake.cloneVolumeMutex.RUnlock()
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
 	fake.getVolMappingMutex.RLock()
 	defer fake.getVolMappingMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
 	fake.loginMutex.RLock()
 	defer fake.loginMutex.RUnlock()
 	fake.mapVolumeMutex.RLock()
 	defer fake.mapVolumeMutex.RUnlock()
+	fake.resizeVolumeMutex.RLock()
+	defer fake.resizeVolumeMutex.RUnlock()
 	fake.serviceExistMutex.RLock()
 	defer fake.serviceExistMutex.RUnlock()
 	fake.unmapVolumeMutex.RLock()
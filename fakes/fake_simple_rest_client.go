@@ -2,18 +2,20 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/IBM/ubiquity/local/scbe"
 )
 
 type FakeSimpleRestClient struct {
-	DeleteStub        func(string, []byte, int) error
+	DeleteStub        func(context.Context, string, []byte, int) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 string
-		arg2 []byte
-		arg3 int
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
 	}
 	deleteReturns struct {
 		result1 error
@@ -21,13 +23,14 @@ type FakeSimpleRestClient struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetStub        func(string, map[string]string, int, interface{}) error
+	GetStub        func(context.Context, string, map[string]string, int, interface{}) error
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
-		arg1 string
-		arg2 map[string]string
-		arg3 int
-		arg4 interface{}
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]string
+		arg4 int
+		arg5 interface{}
 	}
 	getReturns struct {
 		result1 error
@@ -35,9 +38,10 @@ type FakeSimpleRestClient struct {
 	getReturnsOnCall map[int]struct {
 		result1 error
 	}
-	LoginStub        func() error
+	LoginStub        func(context.Context) error
 	loginMutex       sync.RWMutex
 	loginArgsForCall []struct {
+		arg1 context.Context
 	}
 	loginReturns struct {
 		result1 error
@@ -45,13 +49,14 @@ type FakeSimpleRestClient struct {
 	loginReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PostStub        func(string, []byte, int, interface{}) error
+	PostStub        func(context.Context, string, []byte, int, interface{}) error
 	postMutex       sync.RWMutex
 	postArgsForCall []struct {
-		arg1 string
-		arg2 []byte
-		arg3 int
-		arg4 interface{}
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
+		arg5 interface{}
 	}
 	postReturns struct {
 		result1 error
@@ -59,27 +64,43 @@ type FakeSimpleRestClient struct {
 	postReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PutStub        func(context.Context, string, []byte, int, interface{}) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
+		arg5 interface{}
+	}
+	putReturns struct {
+		result1 error
+	}
+	putReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSimpleRestClient) Delete(arg1 string, arg2 []byte, arg3 int) error {
-	var arg2Copy []byte
-	if arg2 != nil {
-		arg2Copy = make([]byte, len(arg2))
-		copy(arg2Copy, arg2)
+func (fake *FakeSimpleRestClient) Delete(arg1 context.Context, arg2 string, arg3 []byte, arg4 int) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
 	}
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 string
-		arg2 []byte
-		arg3 int
-	}{arg1, arg2Copy, arg3})
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2Copy, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
+	}{arg1, arg2, arg3Copy, arg4})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3Copy, arg4})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2, arg3)
+		return fake.DeleteStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -94,17 +115,17 @@ func (fake *FakeSimpleRestClient) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeSimpleRestClient) DeleteCalls(stub func(string, []byte, int) error) {
+func (fake *FakeSimpleRestClient) DeleteCalls(stub func(context.Context, string, []byte, int) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeSimpleRestClient) DeleteArgsForCall(i int) (string, []byte, int) {
+func (fake *FakeSimpleRestClient) DeleteArgsForCall(i int) (context.Context, string, []byte, int) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeSimpleRestClient) DeleteReturns(result1 error) {
@@ -130,19 +151,20 @@ func (fake *FakeSimpleRestClient) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSimpleRestClient) Get(arg1 string, arg2 map[string]string, arg3 int, arg4 interface{}) error {
+func (fake *FakeSimpleRestClient) Get(arg1 context.Context, arg2 string, arg3 map[string]string, arg4 int, arg5 interface{}) error {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
-		arg1 string
-		arg2 map[string]string
-		arg3 int
-		arg4 interface{}
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4})
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]string
+		arg4 int
+		arg5 interface{}
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.getMutex.Unlock()
 	if fake.GetStub != nil {
-		return fake.GetStub(arg1, arg2, arg3, arg4)
+		return fake.GetStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -157,17 +179,17 @@ func (fake *FakeSimpleRestClient) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeSimpleRestClient) GetCalls(stub func(string, map[string]string, int, interface{}) error) {
+func (fake *FakeSimpleRestClient) GetCalls(stub func(context.Context, string, map[string]string, int, interface{}) error) {
 	fake.getMutex.Lock()
 	defer fake.getMutex.Unlock()
 	fake.GetStub = stub
 }
 
-func (fake *FakeSimpleRestClient) GetArgsForCall(i int) (string, map[string]string, int, interface{}) {
+func (fake *FakeSimpleRestClient) GetArgsForCall(i int) (context.Context, string, map[string]string, int, interface{}) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	argsForCall := fake.getArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeSimpleRestClient) GetReturns(result1 error) {
@@ -193,15 +215,16 @@ func (fake *FakeSimpleRestClient) GetReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSimpleRestClient) Login() error {
+func (fake *FakeSimpleRestClient) Login(arg1 context.Context) error {
 	fake.loginMutex.Lock()
 	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
 	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Login", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("Login", []interface{}{arg1})
 	fake.loginMutex.Unlock()
 	if fake.LoginStub != nil {
-		return fake.LoginStub()
+		return fake.LoginStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -216,12 +239,19 @@ func (fake *FakeSimpleRestClient) LoginCallCount() int {
 	return len(fake.loginArgsForCall)
 }
 
-func (fake *FakeSimpleRestClient) LoginCalls(stub func() error) {
+func (fake *FakeSimpleRestClient) LoginCalls(stub func(context.Context) error) {
 	fake.loginMutex.Lock()
 	defer fake.loginMutex.Unlock()
 	fake.LoginStub = stub
 }
 
+func (fake *FakeSimpleRestClient) LoginArgsForCall(i int) context.Context {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeSimpleRestClient) LoginReturns(result1 error) {
 	fake.loginMutex.Lock()
 	defer fake.loginMutex.Unlock()
@@ -245,24 +275,25 @@ func (fake *FakeSimpleRestClient) LoginReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSimpleRestClient) Post(arg1 string, arg2 []byte, arg3 int, arg4 interface{}) error {
-	var arg2Copy []byte
-	if arg2 != nil {
-		arg2Copy = make([]byte, len(arg2))
-		copy(arg2Copy, arg2)
+func (fake *FakeSimpleRestClient) Post(arg1 context.Context, arg2 string, arg3 []byte, arg4 int, arg5 interface{}) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
 	}
 	fake.postMutex.Lock()
 	ret, specificReturn := fake.postReturnsOnCall[len(fake.postArgsForCall)]
 	fake.postArgsForCall = append(fake.postArgsForCall, struct {
-		arg1 string
-		arg2 []byte
-		arg3 int
-		arg4 interface{}
-	}{arg1, arg2Copy, arg3, arg4})
-	fake.recordInvocation("Post", []interface{}{arg1, arg2Copy, arg3, arg4})
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
+		arg5 interface{}
+	}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.recordInvocation("Post", []interface{}{arg1, arg2, arg3Copy, arg4, arg5})
 	fake.postMutex.Unlock()
 	if fake.PostStub != nil {
-		return fake.PostStub(arg1, arg2, arg3, arg4)
+		return fake.PostStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -277,17 +308,17 @@ func (fake *FakeSimpleRestClient) PostCallCount() int {
 	return len(fake.postArgsForCall)
 }
 
-func (fake *FakeSimpleRestClient) PostCalls(stub func(string, []byte, int, interface{}) error) {
+func (fake *FakeSimpleRestClient) PostCalls(stub func(context.Context, string, []byte, int, interface{}) error) {
 	fake.postMutex.Lock()
 	defer fake.postMutex.Unlock()
 	fake.PostStub = stub
 }
 
-func (fake *FakeSimpleRestClient) PostArgsForCall(i int) (string, []byte, int, interface{}) {
+func (fake *FakeSimpleRestClient) PostArgsForCall(i int) (context.Context, string, []byte, int, interface{}) {
 	fake.postMutex.RLock()
 	defer fake.postMutex.RUnlock()
 	argsForCall := fake.postArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeSimpleRestClient) PostReturns(result1 error) {
@@ -313,6 +344,75 @@ func (fake *FakeSimpleRestClient) PostReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSimpleRestClient) Put(arg1 context.Context, arg2 string, arg3 []byte, arg4 int, arg5 interface{}) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.putMutex.Lock()
+	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []byte
+		arg4 int
+		arg5 interface{}
+	}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.putMutex.Unlock()
+	if fake.PutStub != nil {
+		return fake.PutStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.putReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSimpleRestClient) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *FakeSimpleRestClient) PutCalls(stub func(context.Context, string, []byte, int, interface{}) error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = stub
+}
+
+func (fake *FakeSimpleRestClient) PutArgsForCall(i int) (context.Context, string, []byte, int, interface{}) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	argsForCall := fake.putArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeSimpleRestClient) PutReturns(result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimpleRestClient) PutReturnsOnCall(i int, result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	if fake.putReturnsOnCall == nil {
+		fake.putReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.putReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSimpleRestClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -324,6 +424,8 @@ func (fake *FakeSimpleRestClient) Invocations() map[string][][]interface{} {
 	defer fake.loginMutex.RUnlock()
 	fake.postMutex.RLock()
 	defer fake.postMutex.RUnlock()
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/IBM/ubiquity/local/spectrumscale/connectors"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeSpectrumScaleConnector struct {
-	CheckIfFSQuotaEnabledStub        func(string) error
+	CheckIfFSQuotaEnabledStub        func(context.Context, string) error
 	checkIfFSQuotaEnabledMutex       sync.RWMutex
 	checkIfFSQuotaEnabledArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	checkIfFSQuotaEnabledReturns struct {
 		result1 error
@@ -20,12 +22,42 @@ type FakeSpectrumScaleConnector struct {
 	checkIfFSQuotaEnabledReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateFilesetStub        func(string, string, map[string]interface{}) error
+	CopyDirectoryStub        func(context.Context, string, string, string) error
+	copyDirectoryMutex       sync.RWMutex
+	copyDirectoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	copyDirectoryReturns struct {
+		result1 error
+	}
+	copyDirectoryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CopyFilesetSnapshotStub        func(context.Context, string, string, string, string) error
+	copyFilesetSnapshotMutex       sync.RWMutex
+	copyFilesetSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	copyFilesetSnapshotReturns struct {
+		result1 error
+	}
+	copyFilesetSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateFilesetStub        func(context.Context, string, string, map[string]interface{}) error
 	createFilesetMutex       sync.RWMutex
 	createFilesetArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 map[string]interface{}
+		arg3 string
+		arg4 map[string]interface{}
 	}
 	createFilesetReturns struct {
 		result1 error
@@ -33,11 +65,26 @@ type FakeSpectrumScaleConnector struct {
 	createFilesetReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteFilesetStub        func(string, string) error
+	CreateSnapshotStub        func(context.Context, string, string, string) error
+	createSnapshotMutex       sync.RWMutex
+	createSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	createSnapshotReturns struct {
+		result1 error
+	}
+	createSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteFilesetStub        func(context.Context, string, string) error
 	deleteFilesetMutex       sync.RWMutex
 	deleteFilesetArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	deleteFilesetReturns struct {
 		result1 error
@@ -45,9 +92,24 @@ type FakeSpectrumScaleConnector struct {
 	deleteFilesetReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetClusterIdStub        func() (string, error)
+	DeleteSnapshotStub        func(context.Context, string, string, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	deleteSnapshotReturns struct {
+		result1 error
+	}
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetClusterIdStub        func(context.Context) (string, error)
 	getClusterIdMutex       sync.RWMutex
 	getClusterIdArgsForCall []struct {
+		arg1 context.Context
 	}
 	getClusterIdReturns struct {
 		result1 string
@@ -57,10 +119,25 @@ type FakeSpectrumScaleConnector struct {
 		result1 string
 		result2 error
 	}
-	GetFilesystemMountpointStub        func(string) (string, error)
+	GetFilesystemCapacityStub        func(context.Context, string) (connectors.FilesystemCapacity, error)
+	getFilesystemCapacityMutex       sync.RWMutex
+	getFilesystemCapacityArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getFilesystemCapacityReturns struct {
+		result1 connectors.FilesystemCapacity
+		result2 error
+	}
+	getFilesystemCapacityReturnsOnCall map[int]struct {
+		result1 connectors.FilesystemCapacity
+		result2 error
+	}
+	GetFilesystemMountpointStub        func(context.Context, string) (string, error)
 	getFilesystemMountpointMutex       sync.RWMutex
 	getFilesystemMountpointArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getFilesystemMountpointReturns struct {
 		result1 string
@@ -70,11 +147,12 @@ type FakeSpectrumScaleConnector struct {
 		result1 string
 		result2 error
 	}
-	IsFilesetLinkedStub        func(string, string) (bool, error)
+	IsFilesetLinkedStub        func(context.Context, string, string) (bool, error)
 	isFilesetLinkedMutex       sync.RWMutex
 	isFilesetLinkedArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	isFilesetLinkedReturns struct {
 		result1 bool
@@ -84,10 +162,11 @@ type FakeSpectrumScaleConnector struct {
 		result1 bool
 		result2 error
 	}
-	IsFilesystemMountedStub        func(string) (bool, error)
+	IsFilesystemMountedStub        func(context.Context, string) (bool, error)
 	isFilesystemMountedMutex       sync.RWMutex
 	isFilesystemMountedArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	isFilesystemMountedReturns struct {
 		result1 bool
@@ -97,11 +176,12 @@ type FakeSpectrumScaleConnector struct {
 		result1 bool
 		result2 error
 	}
-	LinkFilesetStub        func(string, string) error
+	LinkFilesetStub        func(context.Context, string, string) error
 	linkFilesetMutex       sync.RWMutex
 	linkFilesetArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	linkFilesetReturns struct {
 		result1 error
@@ -109,11 +189,12 @@ type FakeSpectrumScaleConnector struct {
 	linkFilesetReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListFilesetStub        func(string, string) (resources.Volume, error)
+	ListFilesetStub        func(context.Context, string, string) (resources.Volume, error)
 	listFilesetMutex       sync.RWMutex
 	listFilesetArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	listFilesetReturns struct {
 		result1 resources.Volume
@@ -123,11 +204,12 @@ type FakeSpectrumScaleConnector struct {
 		result1 resources.Volume
 		result2 error
 	}
-	ListFilesetQuotaStub        func(string, string) (string, error)
+	ListFilesetQuotaStub        func(context.Context, string, string) (string, error)
 	listFilesetQuotaMutex       sync.RWMutex
 	listFilesetQuotaArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	listFilesetQuotaReturns struct {
 		result1 string
@@ -137,10 +219,11 @@ type FakeSpectrumScaleConnector struct {
 		result1 string
 		result2 error
 	}
-	ListFilesetsStub        func(string) ([]resources.Volume, error)
+	ListFilesetsStub        func(context.Context, string) ([]resources.Volume, error)
 	listFilesetsMutex       sync.RWMutex
 	listFilesetsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	listFilesetsReturns struct {
 		result1 []resources.Volume
@@ -150,9 +233,10 @@ type FakeSpectrumScaleConnector struct {
 		result1 []resources.Volume
 		result2 error
 	}
-	ListFilesystemsStub        func() ([]string, error)
+	ListFilesystemsStub        func(context.Context) ([]string, error)
 	listFilesystemsMutex       sync.RWMutex
 	listFilesystemsArgsForCall []struct {
+		arg1 context.Context
 	}
 	listFilesystemsReturns struct {
 		result1 []string
@@ -162,12 +246,13 @@ type FakeSpectrumScaleConnector struct {
 		result1 []string
 		result2 error
 	}
-	SetFilesetQuotaStub        func(string, string, string) error
+	SetFilesetQuotaStub        func(context.Context, string, string, string) error
 	setFilesetQuotaMutex       sync.RWMutex
 	setFilesetQuotaArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
+		arg4 string
 	}
 	setFilesetQuotaReturns struct {
 		result1 error
@@ -175,11 +260,12 @@ type FakeSpectrumScaleConnector struct {
 	setFilesetQuotaReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UnlinkFilesetStub        func(string, string) error
+	UnlinkFilesetStub        func(context.Context, string, string) error
 	unlinkFilesetMutex       sync.RWMutex
 	unlinkFilesetArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	unlinkFilesetReturns struct {
 		result1 error
@@ -191,16 +277,17 @@ type FakeSpectrumScaleConnector struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabled(arg1 string) error {
+func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabled(arg1 context.Context, arg2 string) error {
 	fake.checkIfFSQuotaEnabledMutex.Lock()
 	ret, specificReturn := fake.checkIfFSQuotaEnabledReturnsOnCall[len(fake.checkIfFSQuotaEnabledArgsForCall)]
 	fake.checkIfFSQuotaEnabledArgsForCall = append(fake.checkIfFSQuotaEnabledArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("CheckIfFSQuotaEnabled", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CheckIfFSQuotaEnabled", []interface{}{arg1, arg2})
 	fake.checkIfFSQuotaEnabledMutex.Unlock()
 	if fake.CheckIfFSQuotaEnabledStub != nil {
-		return fake.CheckIfFSQuotaEnabledStub(arg1)
+		return fake.CheckIfFSQuotaEnabledStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -215,17 +302,17 @@ func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledCallCount() int {
 	return len(fake.checkIfFSQuotaEnabledArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledCalls(stub func(string) error) {
+func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledCalls(stub func(context.Context, string) error) {
 	fake.checkIfFSQuotaEnabledMutex.Lock()
 	defer fake.checkIfFSQuotaEnabledMutex.Unlock()
 	fake.CheckIfFSQuotaEnabledStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledArgsForCall(i int) string {
+func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledArgsForCall(i int) (context.Context, string) {
 	fake.checkIfFSQuotaEnabledMutex.RLock()
 	defer fake.checkIfFSQuotaEnabledMutex.RUnlock()
 	argsForCall := fake.checkIfFSQuotaEnabledArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledReturns(result1 error) {
@@ -251,18 +338,146 @@ func (fake *FakeSpectrumScaleConnector) CheckIfFSQuotaEnabledReturnsOnCall(i int
 	}{result1}
 }
 
-func (fake *FakeSpectrumScaleConnector) CreateFileset(arg1 string, arg2 string, arg3 map[string]interface{}) error {
+func (fake *FakeSpectrumScaleConnector) CopyDirectory(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.copyDirectoryMutex.Lock()
+	ret, specificReturn := fake.copyDirectoryReturnsOnCall[len(fake.copyDirectoryArgsForCall)]
+	fake.copyDirectoryArgsForCall = append(fake.copyDirectoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CopyDirectory", []interface{}{arg1, arg2, arg3, arg4})
+	fake.copyDirectoryMutex.Unlock()
+	if fake.CopyDirectoryStub != nil {
+		return fake.CopyDirectoryStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.copyDirectoryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyDirectoryCallCount() int {
+	fake.copyDirectoryMutex.RLock()
+	defer fake.copyDirectoryMutex.RUnlock()
+	return len(fake.copyDirectoryArgsForCall)
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyDirectoryCalls(stub func(context.Context, string, string, string) error) {
+	fake.copyDirectoryMutex.Lock()
+	defer fake.copyDirectoryMutex.Unlock()
+	fake.CopyDirectoryStub = stub
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyDirectoryArgsForCall(i int) (context.Context, string, string, string) {
+	fake.copyDirectoryMutex.RLock()
+	defer fake.copyDirectoryMutex.RUnlock()
+	argsForCall := fake.copyDirectoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyDirectoryReturns(result1 error) {
+	fake.copyDirectoryMutex.Lock()
+	defer fake.copyDirectoryMutex.Unlock()
+	fake.CopyDirectoryStub = nil
+	fake.copyDirectoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyDirectoryReturnsOnCall(i int, result1 error) {
+	fake.copyDirectoryMutex.Lock()
+	defer fake.copyDirectoryMutex.Unlock()
+	fake.CopyDirectoryStub = nil
+	if fake.copyDirectoryReturnsOnCall == nil {
+		fake.copyDirectoryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.copyDirectoryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshot(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.copyFilesetSnapshotMutex.Lock()
+	ret, specificReturn := fake.copyFilesetSnapshotReturnsOnCall[len(fake.copyFilesetSnapshotArgsForCall)]
+	fake.copyFilesetSnapshotArgsForCall = append(fake.copyFilesetSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CopyFilesetSnapshot", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.copyFilesetSnapshotMutex.Unlock()
+	if fake.CopyFilesetSnapshotStub != nil {
+		return fake.CopyFilesetSnapshotStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.copyFilesetSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshotCallCount() int {
+	fake.copyFilesetSnapshotMutex.RLock()
+	defer fake.copyFilesetSnapshotMutex.RUnlock()
+	return len(fake.copyFilesetSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshotCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.copyFilesetSnapshotMutex.Lock()
+	defer fake.copyFilesetSnapshotMutex.Unlock()
+	fake.CopyFilesetSnapshotStub = stub
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshotArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.copyFilesetSnapshotMutex.RLock()
+	defer fake.copyFilesetSnapshotMutex.RUnlock()
+	argsForCall := fake.copyFilesetSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshotReturns(result1 error) {
+	fake.copyFilesetSnapshotMutex.Lock()
+	defer fake.copyFilesetSnapshotMutex.Unlock()
+	fake.CopyFilesetSnapshotStub = nil
+	fake.copyFilesetSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) CopyFilesetSnapshotReturnsOnCall(i int, result1 error) {
+	fake.copyFilesetSnapshotMutex.Lock()
+	defer fake.copyFilesetSnapshotMutex.Unlock()
+	fake.CopyFilesetSnapshotStub = nil
+	if fake.copyFilesetSnapshotReturnsOnCall == nil {
+		fake.copyFilesetSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.copyFilesetSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateFileset(arg1 context.Context, arg2 string, arg3 string, arg4 map[string]interface{}) error {
 	fake.createFilesetMutex.Lock()
 	ret, specificReturn := fake.createFilesetReturnsOnCall[len(fake.createFilesetArgsForCall)]
 	fake.createFilesetArgsForCall = append(fake.createFilesetArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 map[string]interface{}
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("CreateFileset", []interface{}{arg1, arg2, arg3})
+		arg3 string
+		arg4 map[string]interface{}
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateFileset", []interface{}{arg1, arg2, arg3, arg4})
 	fake.createFilesetMutex.Unlock()
 	if fake.CreateFilesetStub != nil {
-		return fake.CreateFilesetStub(arg1, arg2, arg3)
+		return fake.CreateFilesetStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -277,17 +492,17 @@ func (fake *FakeSpectrumScaleConnector) CreateFilesetCallCount() int {
 	return len(fake.createFilesetArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) CreateFilesetCalls(stub func(string, string, map[string]interface{}) error) {
+func (fake *FakeSpectrumScaleConnector) CreateFilesetCalls(stub func(context.Context, string, string, map[string]interface{}) error) {
 	fake.createFilesetMutex.Lock()
 	defer fake.createFilesetMutex.Unlock()
 	fake.CreateFilesetStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) CreateFilesetArgsForCall(i int) (string, string, map[string]interface{}) {
+func (fake *FakeSpectrumScaleConnector) CreateFilesetArgsForCall(i int) (context.Context, string, string, map[string]interface{}) {
 	fake.createFilesetMutex.RLock()
 	defer fake.createFilesetMutex.RUnlock()
 	argsForCall := fake.createFilesetArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeSpectrumScaleConnector) CreateFilesetReturns(result1 error) {
@@ -313,17 +528,81 @@ func (fake *FakeSpectrumScaleConnector) CreateFilesetReturnsOnCall(i int, result
 	}{result1}
 }
 
-func (fake *FakeSpectrumScaleConnector) DeleteFileset(arg1 string, arg2 string) error {
+func (fake *FakeSpectrumScaleConnector) CreateSnapshot(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.createSnapshotMutex.Lock()
+	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
+	fake.createSnapshotArgsForCall = append(fake.createSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateSnapshot", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createSnapshotMutex.Unlock()
+	if fake.CreateSnapshotStub != nil {
+		return fake.CreateSnapshotStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateSnapshotCallCount() int {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	return len(fake.createSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateSnapshotCalls(stub func(context.Context, string, string, string) error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = stub
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateSnapshotArgsForCall(i int) (context.Context, string, string, string) {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	argsForCall := fake.createSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateSnapshotReturns(result1 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	fake.createSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) CreateSnapshotReturnsOnCall(i int, result1 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	if fake.createSnapshotReturnsOnCall == nil {
+		fake.createSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteFileset(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.deleteFilesetMutex.Lock()
 	ret, specificReturn := fake.deleteFilesetReturnsOnCall[len(fake.deleteFilesetArgsForCall)]
 	fake.deleteFilesetArgsForCall = append(fake.deleteFilesetArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("DeleteFileset", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteFileset", []interface{}{arg1, arg2, arg3})
 	fake.deleteFilesetMutex.Unlock()
 	if fake.DeleteFilesetStub != nil {
-		return fake.DeleteFilesetStub(arg1, arg2)
+		return fake.DeleteFilesetStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -338,17 +617,17 @@ func (fake *FakeSpectrumScaleConnector) DeleteFilesetCallCount() int {
 	return len(fake.deleteFilesetArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) DeleteFilesetCalls(stub func(string, string) error) {
+func (fake *FakeSpectrumScaleConnector) DeleteFilesetCalls(stub func(context.Context, string, string) error) {
 	fake.deleteFilesetMutex.Lock()
 	defer fake.deleteFilesetMutex.Unlock()
 	fake.DeleteFilesetStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) DeleteFilesetArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) DeleteFilesetArgsForCall(i int) (context.Context, string, string) {
 	fake.deleteFilesetMutex.RLock()
 	defer fake.deleteFilesetMutex.RUnlock()
 	argsForCall := fake.deleteFilesetArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) DeleteFilesetReturns(result1 error) {
@@ -374,15 +653,79 @@ func (fake *FakeSpectrumScaleConnector) DeleteFilesetReturnsOnCall(i int, result
 	}{result1}
 }
 
-func (fake *FakeSpectrumScaleConnector) GetClusterId() (string, error) {
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshot(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshotCalls(stub func(context.Context, string, string, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshotArgsForCall(i int) (context.Context, string, string, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumScaleConnector) GetClusterId(arg1 context.Context) (string, error) {
 	fake.getClusterIdMutex.Lock()
 	ret, specificReturn := fake.getClusterIdReturnsOnCall[len(fake.getClusterIdArgsForCall)]
 	fake.getClusterIdArgsForCall = append(fake.getClusterIdArgsForCall, struct {
-	}{})
-	fake.recordInvocation("GetClusterId", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("GetClusterId", []interface{}{arg1})
 	fake.getClusterIdMutex.Unlock()
 	if fake.GetClusterIdStub != nil {
-		return fake.GetClusterIdStub()
+		return fake.GetClusterIdStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -397,12 +740,19 @@ func (fake *FakeSpectrumScaleConnector) GetClusterIdCallCount() int {
 	return len(fake.getClusterIdArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) GetClusterIdCalls(stub func() (string, error)) {
+func (fake *FakeSpectrumScaleConnector) GetClusterIdCalls(stub func(context.Context) (string, error)) {
 	fake.getClusterIdMutex.Lock()
 	defer fake.getClusterIdMutex.Unlock()
 	fake.GetClusterIdStub = stub
 }
 
+func (fake *FakeSpectrumScaleConnector) GetClusterIdArgsForCall(i int) context.Context {
+	fake.getClusterIdMutex.RLock()
+	defer fake.getClusterIdMutex.RUnlock()
+	argsForCall := fake.getClusterIdArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeSpectrumScaleConnector) GetClusterIdReturns(result1 string, result2 error) {
 	fake.getClusterIdMutex.Lock()
 	defer fake.getClusterIdMutex.Unlock()
@@ -429,16 +779,81 @@ func (fake *FakeSpectrumScaleConnector) GetClusterIdReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpoint(arg1 string) (string, error) {
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacity(arg1 context.Context, arg2 string) (connectors.FilesystemCapacity, error) {
+	fake.getFilesystemCapacityMutex.Lock()
+	ret, specificReturn := fake.getFilesystemCapacityReturnsOnCall[len(fake.getFilesystemCapacityArgsForCall)]
+	fake.getFilesystemCapacityArgsForCall = append(fake.getFilesystemCapacityArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetFilesystemCapacity", []interface{}{arg1, arg2})
+	fake.getFilesystemCapacityMutex.Unlock()
+	if fake.GetFilesystemCapacityStub != nil {
+		return fake.GetFilesystemCapacityStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getFilesystemCapacityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacityCallCount() int {
+	fake.getFilesystemCapacityMutex.RLock()
+	defer fake.getFilesystemCapacityMutex.RUnlock()
+	return len(fake.getFilesystemCapacityArgsForCall)
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacityCalls(stub func(context.Context, string) (connectors.FilesystemCapacity, error)) {
+	fake.getFilesystemCapacityMutex.Lock()
+	defer fake.getFilesystemCapacityMutex.Unlock()
+	fake.GetFilesystemCapacityStub = stub
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacityArgsForCall(i int) (context.Context, string) {
+	fake.getFilesystemCapacityMutex.RLock()
+	defer fake.getFilesystemCapacityMutex.RUnlock()
+	argsForCall := fake.getFilesystemCapacityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacityReturns(result1 connectors.FilesystemCapacity, result2 error) {
+	fake.getFilesystemCapacityMutex.Lock()
+	defer fake.getFilesystemCapacityMutex.Unlock()
+	fake.GetFilesystemCapacityStub = nil
+	fake.getFilesystemCapacityReturns = struct {
+		result1 connectors.FilesystemCapacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemCapacityReturnsOnCall(i int, result1 connectors.FilesystemCapacity, result2 error) {
+	fake.getFilesystemCapacityMutex.Lock()
+	defer fake.getFilesystemCapacityMutex.Unlock()
+	fake.GetFilesystemCapacityStub = nil
+	if fake.getFilesystemCapacityReturnsOnCall == nil {
+		fake.getFilesystemCapacityReturnsOnCall = make(map[int]struct {
+			result1 connectors.FilesystemCapacity
+			result2 error
+		})
+	}
+	fake.getFilesystemCapacityReturnsOnCall[i] = struct {
+		result1 connectors.FilesystemCapacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpoint(arg1 context.Context, arg2 string) (string, error) {
 	fake.getFilesystemMountpointMutex.Lock()
 	ret, specificReturn := fake.getFilesystemMountpointReturnsOnCall[len(fake.getFilesystemMountpointArgsForCall)]
 	fake.getFilesystemMountpointArgsForCall = append(fake.getFilesystemMountpointArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("GetFilesystemMountpoint", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetFilesystemMountpoint", []interface{}{arg1, arg2})
 	fake.getFilesystemMountpointMutex.Unlock()
 	if fake.GetFilesystemMountpointStub != nil {
-		return fake.GetFilesystemMountpointStub(arg1)
+		return fake.GetFilesystemMountpointStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -453,17 +868,17 @@ func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointCallCount() int {
 	return len(fake.getFilesystemMountpointArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointCalls(stub func(string) (string, error)) {
+func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointCalls(stub func(context.Context, string) (string, error)) {
 	fake.getFilesystemMountpointMutex.Lock()
 	defer fake.getFilesystemMountpointMutex.Unlock()
 	fake.GetFilesystemMountpointStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointArgsForCall(i int) string {
+func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointArgsForCall(i int) (context.Context, string) {
 	fake.getFilesystemMountpointMutex.RLock()
 	defer fake.getFilesystemMountpointMutex.RUnlock()
 	argsForCall := fake.getFilesystemMountpointArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointReturns(result1 string, result2 error) {
@@ -492,17 +907,18 @@ func (fake *FakeSpectrumScaleConnector) GetFilesystemMountpointReturnsOnCall(i i
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesetLinked(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeSpectrumScaleConnector) IsFilesetLinked(arg1 context.Context, arg2 string, arg3 string) (bool, error) {
 	fake.isFilesetLinkedMutex.Lock()
 	ret, specificReturn := fake.isFilesetLinkedReturnsOnCall[len(fake.isFilesetLinkedArgsForCall)]
 	fake.isFilesetLinkedArgsForCall = append(fake.isFilesetLinkedArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("IsFilesetLinked", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("IsFilesetLinked", []interface{}{arg1, arg2, arg3})
 	fake.isFilesetLinkedMutex.Unlock()
 	if fake.IsFilesetLinkedStub != nil {
-		return fake.IsFilesetLinkedStub(arg1, arg2)
+		return fake.IsFilesetLinkedStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -517,17 +933,17 @@ func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedCallCount() int {
 	return len(fake.isFilesetLinkedArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedCalls(stub func(context.Context, string, string) (bool, error)) {
 	fake.isFilesetLinkedMutex.Lock()
 	defer fake.isFilesetLinkedMutex.Unlock()
 	fake.IsFilesetLinkedStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedArgsForCall(i int) (context.Context, string, string) {
 	fake.isFilesetLinkedMutex.RLock()
 	defer fake.isFilesetLinkedMutex.RUnlock()
 	argsForCall := fake.isFilesetLinkedArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedReturns(result1 bool, result2 error) {
@@ -556,16 +972,17 @@ func (fake *FakeSpectrumScaleConnector) IsFilesetLinkedReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesystemMounted(arg1 string) (bool, error) {
+func (fake *FakeSpectrumScaleConnector) IsFilesystemMounted(arg1 context.Context, arg2 string) (bool, error) {
 	fake.isFilesystemMountedMutex.Lock()
 	ret, specificReturn := fake.isFilesystemMountedReturnsOnCall[len(fake.isFilesystemMountedArgsForCall)]
 	fake.isFilesystemMountedArgsForCall = append(fake.isFilesystemMountedArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("IsFilesystemMounted", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("IsFilesystemMounted", []interface{}{arg1, arg2})
 	fake.isFilesystemMountedMutex.Unlock()
 	if fake.IsFilesystemMountedStub != nil {
-		return fake.IsFilesystemMountedStub(arg1)
+		return fake.IsFilesystemMountedStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -580,17 +997,17 @@ func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedCallCount() int {
 	return len(fake.isFilesystemMountedArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedCalls(stub func(string) (bool, error)) {
+func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedCalls(stub func(context.Context, string) (bool, error)) {
 	fake.isFilesystemMountedMutex.Lock()
 	defer fake.isFilesystemMountedMutex.Unlock()
 	fake.IsFilesystemMountedStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedArgsForCall(i int) string {
+func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedArgsForCall(i int) (context.Context, string) {
 	fake.isFilesystemMountedMutex.RLock()
 	defer fake.isFilesystemMountedMutex.RUnlock()
 	argsForCall := fake.isFilesystemMountedArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedReturns(result1 bool, result2 error) {
@@ -619,17 +1036,18 @@ func (fake *FakeSpectrumScaleConnector) IsFilesystemMountedReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) LinkFileset(arg1 string, arg2 string) error {
+func (fake *FakeSpectrumScaleConnector) LinkFileset(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.linkFilesetMutex.Lock()
 	ret, specificReturn := fake.linkFilesetReturnsOnCall[len(fake.linkFilesetArgsForCall)]
 	fake.linkFilesetArgsForCall = append(fake.linkFilesetArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("LinkFileset", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("LinkFileset", []interface{}{arg1, arg2, arg3})
 	fake.linkFilesetMutex.Unlock()
 	if fake.LinkFilesetStub != nil {
-		return fake.LinkFilesetStub(arg1, arg2)
+		return fake.LinkFilesetStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -644,17 +1062,17 @@ func (fake *FakeSpectrumScaleConnector) LinkFilesetCallCount() int {
 	return len(fake.linkFilesetArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) LinkFilesetCalls(stub func(string, string) error) {
+func (fake *FakeSpectrumScaleConnector) LinkFilesetCalls(stub func(context.Context, string, string) error) {
 	fake.linkFilesetMutex.Lock()
 	defer fake.linkFilesetMutex.Unlock()
 	fake.LinkFilesetStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) LinkFilesetArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) LinkFilesetArgsForCall(i int) (context.Context, string, string) {
 	fake.linkFilesetMutex.RLock()
 	defer fake.linkFilesetMutex.RUnlock()
 	argsForCall := fake.linkFilesetArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) LinkFilesetReturns(result1 error) {
@@ -680,17 +1098,18 @@ func (fake *FakeSpectrumScaleConnector) LinkFilesetReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFileset(arg1 string, arg2 string) (resources.Volume, error) {
+func (fake *FakeSpectrumScaleConnector) ListFileset(arg1 context.Context, arg2 string, arg3 string) (resources.Volume, error) {
 	fake.listFilesetMutex.Lock()
 	ret, specificReturn := fake.listFilesetReturnsOnCall[len(fake.listFilesetArgsForCall)]
 	fake.listFilesetArgsForCall = append(fake.listFilesetArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("ListFileset", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ListFileset", []interface{}{arg1, arg2, arg3})
 	fake.listFilesetMutex.Unlock()
 	if fake.ListFilesetStub != nil {
-		return fake.ListFilesetStub(arg1, arg2)
+		return fake.ListFilesetStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -705,17 +1124,17 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetCallCount() int {
 	return len(fake.listFilesetArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetCalls(stub func(string, string) (resources.Volume, error)) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetCalls(stub func(context.Context, string, string) (resources.Volume, error)) {
 	fake.listFilesetMutex.Lock()
 	defer fake.listFilesetMutex.Unlock()
 	fake.ListFilesetStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetArgsForCall(i int) (context.Context, string, string) {
 	fake.listFilesetMutex.RLock()
 	defer fake.listFilesetMutex.RUnlock()
 	argsForCall := fake.listFilesetArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) ListFilesetReturns(result1 resources.Volume, result2 error) {
@@ -744,17 +1163,18 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetQuota(arg1 string, arg2 string) (string, error) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetQuota(arg1 context.Context, arg2 string, arg3 string) (string, error) {
 	fake.listFilesetQuotaMutex.Lock()
 	ret, specificReturn := fake.listFilesetQuotaReturnsOnCall[len(fake.listFilesetQuotaArgsForCall)]
 	fake.listFilesetQuotaArgsForCall = append(fake.listFilesetQuotaArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("ListFilesetQuota", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ListFilesetQuota", []interface{}{arg1, arg2, arg3})
 	fake.listFilesetQuotaMutex.Unlock()
 	if fake.ListFilesetQuotaStub != nil {
-		return fake.ListFilesetQuotaStub(arg1, arg2)
+		return fake.ListFilesetQuotaStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -769,17 +1189,17 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaCallCount() int {
 	return len(fake.listFilesetQuotaArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaCalls(stub func(string, string) (string, error)) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaCalls(stub func(context.Context, string, string) (string, error)) {
 	fake.listFilesetQuotaMutex.Lock()
 	defer fake.listFilesetQuotaMutex.Unlock()
 	fake.ListFilesetQuotaStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaArgsForCall(i int) (context.Context, string, string) {
 	fake.listFilesetQuotaMutex.RLock()
 	defer fake.listFilesetQuotaMutex.RUnlock()
 	argsForCall := fake.listFilesetQuotaArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaReturns(result1 string, result2 error) {
@@ -808,16 +1228,17 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetQuotaReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesets(arg1 string) ([]resources.Volume, error) {
+func (fake *FakeSpectrumScaleConnector) ListFilesets(arg1 context.Context, arg2 string) ([]resources.Volume, error) {
 	fake.listFilesetsMutex.Lock()
 	ret, specificReturn := fake.listFilesetsReturnsOnCall[len(fake.listFilesetsArgsForCall)]
 	fake.listFilesetsArgsForCall = append(fake.listFilesetsArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("ListFilesets", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ListFilesets", []interface{}{arg1, arg2})
 	fake.listFilesetsMutex.Unlock()
 	if fake.ListFilesetsStub != nil {
-		return fake.ListFilesetsStub(arg1)
+		return fake.ListFilesetsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -832,17 +1253,17 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetsCallCount() int {
 	return len(fake.listFilesetsArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetsCalls(stub func(string) ([]resources.Volume, error)) {
+func (fake *FakeSpectrumScaleConnector) ListFilesetsCalls(stub func(context.Context, string) ([]resources.Volume, error)) {
 	fake.listFilesetsMutex.Lock()
 	defer fake.listFilesetsMutex.Unlock()
 	fake.ListFilesetsStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesetsArgsForCall(i int) string {
+func (fake *FakeSpectrumScaleConnector) ListFilesetsArgsForCall(i int) (context.Context, string) {
 	fake.listFilesetsMutex.RLock()
 	defer fake.listFilesetsMutex.RUnlock()
 	argsForCall := fake.listFilesetsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeSpectrumScaleConnector) ListFilesetsReturns(result1 []resources.Volume, result2 error) {
@@ -871,15 +1292,16 @@ func (fake *FakeSpectrumScaleConnector) ListFilesetsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesystems() ([]string, error) {
+func (fake *FakeSpectrumScaleConnector) ListFilesystems(arg1 context.Context) ([]string, error) {
 	fake.listFilesystemsMutex.Lock()
 	ret, specificReturn := fake.listFilesystemsReturnsOnCall[len(fake.listFilesystemsArgsForCall)]
 	fake.listFilesystemsArgsForCall = append(fake.listFilesystemsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListFilesystems", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("ListFilesystems", []interface{}{arg1})
 	fake.listFilesystemsMutex.Unlock()
 	if fake.ListFilesystemsStub != nil {
-		return fake.ListFilesystemsStub()
+		return fake.ListFilesystemsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -894,12 +1316,19 @@ func (fake *FakeSpectrumScaleConnector) ListFilesystemsCallCount() int {
 	return len(fake.listFilesystemsArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) ListFilesystemsCalls(stub func() ([]string, error)) {
+func (fake *FakeSpectrumScaleConnector) ListFilesystemsCalls(stub func(context.Context) ([]string, error)) {
 	fake.listFilesystemsMutex.Lock()
 	defer fake.listFilesystemsMutex.Unlock()
 	fake.ListFilesystemsStub = stub
 }
 
+func (fake *FakeSpectrumScaleConnector) ListFilesystemsArgsForCall(i int) context.Context {
+	fake.listFilesystemsMutex.RLock()
+	defer fake.listFilesystemsMutex.RUnlock()
+	argsForCall := fake.listFilesystemsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeSpectrumScaleConnector) ListFilesystemsReturns(result1 []string, result2 error) {
 	fake.listFilesystemsMutex.Lock()
 	defer fake.listFilesystemsMutex.Unlock()
@@ -926,18 +1355,19 @@ func (fake *FakeSpectrumScaleConnector) ListFilesystemsReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumScaleConnector) SetFilesetQuota(arg1 string, arg2 string, arg3 string) error {
+func (fake *FakeSpectrumScaleConnector) SetFilesetQuota(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
 	fake.setFilesetQuotaMutex.Lock()
 	ret, specificReturn := fake.setFilesetQuotaReturnsOnCall[len(fake.setFilesetQuotaArgsForCall)]
 	fake.setFilesetQuotaArgsForCall = append(fake.setFilesetQuotaArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("SetFilesetQuota", []interface{}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SetFilesetQuota", []interface{}{arg1, arg2, arg3, arg4})
 	fake.setFilesetQuotaMutex.Unlock()
 	if fake.SetFilesetQuotaStub != nil {
-		return fake.SetFilesetQuotaStub(arg1, arg2, arg3)
+		return fake.SetFilesetQuotaStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -952,17 +1382,17 @@ func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaCallCount() int {
 	return len(fake.setFilesetQuotaArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaCalls(stub func(string, string, string) error) {
+func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaCalls(stub func(context.Context, string, string, string) error) {
 	fake.setFilesetQuotaMutex.Lock()
 	defer fake.setFilesetQuotaMutex.Unlock()
 	fake.SetFilesetQuotaStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaArgsForCall(i int) (string, string, string) {
+func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaArgsForCall(i int) (context.Context, string, string, string) {
 	fake.setFilesetQuotaMutex.RLock()
 	defer fake.setFilesetQuotaMutex.RUnlock()
 	argsForCall := fake.setFilesetQuotaArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaReturns(result1 error) {
@@ -988,17 +1418,18 @@ func (fake *FakeSpectrumScaleConnector) SetFilesetQuotaReturnsOnCall(i int, resu
 	}{result1}
 }
 
-func (fake *FakeSpectrumScaleConnector) UnlinkFileset(arg1 string, arg2 string) error {
+func (fake *FakeSpectrumScaleConnector) UnlinkFileset(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.unlinkFilesetMutex.Lock()
 	ret, specificReturn := fake.unlinkFilesetReturnsOnCall[len(fake.unlinkFilesetArgsForCall)]
 	fake.unlinkFilesetArgsForCall = append(fake.unlinkFilesetArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("UnlinkFileset", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UnlinkFileset", []interface{}{arg1, arg2, arg3})
 	fake.unlinkFilesetMutex.Unlock()
 	if fake.UnlinkFilesetStub != nil {
-		return fake.UnlinkFilesetStub(arg1, arg2)
+		return fake.UnlinkFilesetStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1013,17 +1444,17 @@ func (fake *FakeSpectrumScaleConnector) UnlinkFilesetCallCount() int {
 	return len(fake.unlinkFilesetArgsForCall)
 }
 
-func (fake *FakeSpectrumScaleConnector) UnlinkFilesetCalls(stub func(string, string) error) {
+func (fake *FakeSpectrumScaleConnector) UnlinkFilesetCalls(stub func(context.Context, string, string) error) {
 	fake.unlinkFilesetMutex.Lock()
 	defer fake.unlinkFilesetMutex.Unlock()
 	fake.UnlinkFilesetStub = stub
 }
 
-func (fake *FakeSpectrumScaleConnector) UnlinkFilesetArgsForCall(i int) (string, string) {
+func (fake *FakeSpectrumScaleConnector) UnlinkFilesetArgsForCall(i int) (context.Context, string, string) {
 	fake.unlinkFilesetMutex.RLock()
 	defer fake.unlinkFilesetMutex.RUnlock()
 	argsForCall := fake.unlinkFilesetArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpectrumScaleConnector) UnlinkFilesetReturns(result1 error) {
@@ -1054,12 +1485,22 @@ func (fake *FakeSpectrumScaleConnector) Invocations() map[string][][]interface{}
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkIfFSQuotaEnabledMutex.RLock()
 	defer fake.checkIfFSQuotaEnabledMutex.RUnlock()
+	fake.copyDirectoryMutex.RLock()
+	defer fake.copyDirectoryMutex.RUnlock()
+	fake.copyFilesetSnapshotMutex.RLock()
+	defer fake.copyFilesetSnapshotMutex.RUnlock()
 	fake.createFilesetMutex.RLock()
 	defer fake.createFilesetMutex.RUnlock()
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
 	fake.deleteFilesetMutex.RLock()
 	defer fake.deleteFilesetMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.getClusterIdMutex.RLock()
 	defer fake.getClusterIdMutex.RUnlock()
+	fake.getFilesystemCapacityMutex.RLock()
+	defer fake.getFilesystemCapacityMutex.RUnlock()
 	fake.getFilesystemMountpointMutex.RLock()
 	defer fake.getFilesystemMountpointMutex.RUnlock()
 	fake.isFilesetLinkedMutex.RLock()
@@ -25,32 +25,22 @@ import (
 )
 
 type FakeSpectrumDataModelWrapper struct {
-	GetDbNameStub        func() string
-	getDbNameMutex       sync.RWMutex
-	getDbNameArgsForCall []struct{}
-	getDbNameReturns     struct {
-		result1 string
-	}
-	getDbNameReturnsOnCall map[int]struct {
-		result1 string
+	DeleteSnapshotStub        func(string, string) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-
-
-	IsDbVolumeStub        func(name string) bool
-	isDbVolumeMutex       sync.RWMutex
-	isDbVolumeArgsForCall []struct{name string}
-	isDbVolumeReturns     struct {
-		result1 bool
+	deleteSnapshotReturns struct {
+		result1 error
 	}
-
-	isDbVolumeReturnsOnCall map[int]struct {
-		result1 bool
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
 	}
-
-	DeleteVolumeStub        func(name string) error
+	DeleteVolumeStub        func(string) error
 	deleteVolumeMutex       sync.RWMutex
 	deleteVolumeArgsForCall []struct {
-		name string
+		arg1 string
 	}
 	deleteVolumeReturns struct {
 		result1 error
@@ -58,41 +48,36 @@ type FakeSpectrumDataModelWrapper struct {
 	deleteVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InsertFilesetVolumeStub        func(fileset, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
-	insertFilesetVolumeMutex       sync.RWMutex
-	insertFilesetVolumeArgsForCall []struct {
-		fileset       string
-		volumeName    string
-		filesystem    string
-		isPreexisting bool
-		opts          map[string]interface{}
+	GetDbNameStub        func() string
+	getDbNameMutex       sync.RWMutex
+	getDbNameArgsForCall []struct {
 	}
-	insertFilesetVolumeReturns struct {
-		result1 error
+	getDbNameReturns struct {
+		result1 string
 	}
-	insertFilesetVolumeReturnsOnCall map[int]struct {
-		result1 error
+	getDbNameReturnsOnCall map[int]struct {
+		result1 string
 	}
-	InsertFilesetQuotaVolumeStub        func(fileset, quota, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
-	insertFilesetQuotaVolumeMutex       sync.RWMutex
-	insertFilesetQuotaVolumeArgsForCall []struct {
-		fileset       string
-		quota         string
-		volumeName    string
-		filesystem    string
-		isPreexisting bool
-		opts          map[string]interface{}
+	GetSnapshotStub        func(string, string) (resources.Snapshot, bool, error)
+	getSnapshotMutex       sync.RWMutex
+	getSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-	insertFilesetQuotaVolumeReturns struct {
-		result1 error
+	getSnapshotReturns struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
 	}
-	insertFilesetQuotaVolumeReturnsOnCall map[int]struct {
-		result1 error
+	getSnapshotReturnsOnCall map[int]struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
 	}
-	GetVolumeStub        func(name string) (spectrumscale.SpectrumScaleVolume, bool, error)
+	GetVolumeStub        func(string) (spectrumscale.SpectrumScaleVolume, bool, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
-		name string
+		arg1 string
 	}
 	getVolumeReturns struct {
 		result1 spectrumscale.SpectrumScaleVolume
@@ -104,10 +89,92 @@ type FakeSpectrumDataModelWrapper struct {
 		result2 bool
 		result3 error
 	}
-	ListVolumesStub        func() ([]resources.Volume, error)
+	InsertFilesetQuotaVolumeStub        func(string, string, string, string, bool, map[string]interface{}) error
+	insertFilesetQuotaVolumeMutex       sync.RWMutex
+	insertFilesetQuotaVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+		arg6 map[string]interface{}
+	}
+	insertFilesetQuotaVolumeReturns struct {
+		result1 error
+	}
+	insertFilesetQuotaVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertFilesetVolumeStub        func(string, string, string, bool, map[string]interface{}) error
+	insertFilesetVolumeMutex       sync.RWMutex
+	insertFilesetVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+		arg5 map[string]interface{}
+	}
+	insertFilesetVolumeReturns struct {
+		result1 error
+	}
+	insertFilesetVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertSnapshotStub        func(string, string, string) error
+	insertSnapshotMutex       sync.RWMutex
+	insertSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	insertSnapshotReturns struct {
+		result1 error
+	}
+	insertSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IsDbVolumeStub        func(string) bool
+	isDbVolumeMutex       sync.RWMutex
+	isDbVolumeArgsForCall []struct {
+		arg1 string
+	}
+	isDbVolumeReturns struct {
+		result1 bool
+	}
+	isDbVolumeReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ListSnapshotsStub        func(string) ([]resources.Snapshot, error)
+	listSnapshotsMutex       sync.RWMutex
+	listSnapshotsArgsForCall []struct {
+		arg1 string
+	}
+	listSnapshotsReturns struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	listSnapshotsReturnsOnCall map[int]struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	ListStoredVolumesStub        func() ([]spectrumscale.SpectrumScaleVolume, error)
+	listStoredVolumesMutex       sync.RWMutex
+	listStoredVolumesArgsForCall []struct {
+	}
+	listStoredVolumesReturns struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}
+	listStoredVolumesReturnsOnCall map[int]struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}
+	ListVolumesStub        func(resources.ListVolumesRequest) ([]resources.Volume, error)
 	listVolumesMutex       sync.RWMutex
-	listVolumesArgsForCall []struct{}
-	listVolumesReturns     struct {
+	listVolumesArgsForCall []struct {
+		arg1 resources.ListVolumesRequest
+	}
+	listVolumesReturns struct {
 		result1 []resources.Volume
 		result2 error
 	}
@@ -115,22 +182,153 @@ type FakeSpectrumDataModelWrapper struct {
 		result1 []resources.Volume
 		result2 error
 	}
-    UpdateDatabaseVolumeStub        func(newVolume *spectrumscale.SpectrumScaleVolume)
-    updateDatabaseVolumeMutex       sync.RWMutex
-    updateDatabaseVolumeArgsForCall []struct {
-        newVolume *spectrumscale.SpectrumScaleVolume
-    }
+	UpdateDatabaseVolumeStub        func(*spectrumscale.SpectrumScaleVolume)
+	updateDatabaseVolumeMutex       sync.RWMutex
+	updateDatabaseVolumeArgsForCall []struct {
+		arg1 *spectrumscale.SpectrumScaleVolume
+	}
+	UpdateVolumeQuotaStub        func(string, string) error
+	updateVolumeQuotaMutex       sync.RWMutex
+	updateVolumeQuotaArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateVolumeQuotaReturns struct {
+		result1 error
+	}
+	updateVolumeQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshot(arg1 string, arg2 string) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1, arg2})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
 
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
 
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshotCalls(stub func(string, string) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
 
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshotArgsForCall(i int) (string, string) {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolume(arg1 string) error {
+	fake.deleteVolumeMutex.Lock()
+	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
+	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
+	fake.deleteVolumeMutex.Unlock()
+	if fake.DeleteVolumeStub != nil {
+		return fake.DeleteVolumeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteVolumeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeCallCount() int {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	return len(fake.deleteVolumeArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeCalls(stub func(string) error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = stub
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeArgsForCall(i int) string {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	argsForCall := fake.deleteVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeReturns(result1 error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = nil
+	fake.deleteVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeReturnsOnCall(i int, result1 error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = nil
+	if fake.deleteVolumeReturnsOnCall == nil {
+		fake.deleteVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeSpectrumDataModelWrapper) GetDbName() string {
 	fake.getDbNameMutex.Lock()
 	ret, specificReturn := fake.getDbNameReturnsOnCall[len(fake.getDbNameArgsForCall)]
-	fake.getDbNameArgsForCall = append(fake.getDbNameArgsForCall, struct{}{})
+	fake.getDbNameArgsForCall = append(fake.getDbNameArgsForCall, struct {
+	}{})
 	fake.recordInvocation("GetDbName", []interface{}{})
 	fake.getDbNameMutex.Unlock()
 	if fake.GetDbNameStub != nil {
@@ -139,7 +337,8 @@ func (fake *FakeSpectrumDataModelWrapper) GetDbName() string {
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.getDbNameReturns.result1
+	fakeReturns := fake.getDbNameReturns
+	return fakeReturns.result1
 }
 
 func (fake *FakeSpectrumDataModelWrapper) GetDbNameCallCount() int {
@@ -148,7 +347,15 @@ func (fake *FakeSpectrumDataModelWrapper) GetDbNameCallCount() int {
 	return len(fake.getDbNameArgsForCall)
 }
 
+func (fake *FakeSpectrumDataModelWrapper) GetDbNameCalls(stub func() string) {
+	fake.getDbNameMutex.Lock()
+	defer fake.getDbNameMutex.Unlock()
+	fake.GetDbNameStub = stub
+}
+
 func (fake *FakeSpectrumDataModelWrapper) GetDbNameReturns(result1 string) {
+	fake.getDbNameMutex.Lock()
+	defer fake.getDbNameMutex.Unlock()
 	fake.GetDbNameStub = nil
 	fake.getDbNameReturns = struct {
 		result1 string
@@ -156,6 +363,8 @@ func (fake *FakeSpectrumDataModelWrapper) GetDbNameReturns(result1 string) {
 }
 
 func (fake *FakeSpectrumDataModelWrapper) GetDbNameReturnsOnCall(i int, result1 string) {
+	fake.getDbNameMutex.Lock()
+	defer fake.getDbNameMutex.Unlock()
 	fake.GetDbNameStub = nil
 	if fake.getDbNameReturnsOnCall == nil {
 		fake.getDbNameReturnsOnCall = make(map[int]struct {
@@ -167,119 +376,224 @@ func (fake *FakeSpectrumDataModelWrapper) GetDbNameReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshot(arg1 string, arg2 string) (resources.Snapshot, bool, error) {
+	fake.getSnapshotMutex.Lock()
+	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
+	fake.getSnapshotArgsForCall = append(fake.getSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetSnapshot", []interface{}{arg1, arg2})
+	fake.getSnapshotMutex.Unlock()
+	if fake.GetSnapshotStub != nil {
+		return fake.GetSnapshotStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
 
-func (fake *FakeSpectrumDataModelWrapper) IsDbVolume(name string) bool {
-	fake.isDbVolumeMutex.Lock()
-	ret, specificReturn := fake.isDbVolumeReturnsOnCall[len(fake.isDbVolumeArgsForCall)]
-	fake.isDbVolumeArgsForCall = append(fake.isDbVolumeArgsForCall, struct{ 
-		name string
-	 }{name})
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshotCallCount() int {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	return len(fake.getSnapshotArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshotCalls(stub func(string, string) (resources.Snapshot, bool, error)) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = stub
+}
 
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshotArgsForCall(i int) (string, string) {
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
+	argsForCall := fake.getSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
 
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshotReturns(result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	fake.getSnapshotReturns = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
 
-	fake.recordInvocation("IsDbVolume", []interface{}{})
-	fake.isDbVolumeMutex.Unlock()
-	if fake.IsDbVolumeStub != nil {
-		return fake.IsDbVolumeStub(name)
+func (fake *FakeSpectrumDataModelWrapper) GetSnapshotReturnsOnCall(i int, result1 resources.Snapshot, result2 bool, result3 error) {
+	fake.getSnapshotMutex.Lock()
+	defer fake.getSnapshotMutex.Unlock()
+	fake.GetSnapshotStub = nil
+	if fake.getSnapshotReturnsOnCall == nil {
+		fake.getSnapshotReturnsOnCall = make(map[int]struct {
+			result1 resources.Snapshot
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getSnapshotReturnsOnCall[i] = struct {
+		result1 resources.Snapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) GetVolume(arg1 string) (spectrumscale.SpectrumScaleVolume, bool, error) {
+	fake.getVolumeMutex.Lock()
+	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
+	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetVolume", []interface{}{arg1})
+	fake.getVolumeMutex.Unlock()
+	if fake.GetVolumeStub != nil {
+		return fake.GetVolumeStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.isDbVolumeReturns.result1
+	fakeReturns := fake.getVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
-func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeCallCount() int {
-	fake.isDbVolumeMutex.RLock()
-	defer fake.isDbVolumeMutex.RUnlock()
-	return len(fake.isDbVolumeArgsForCall)
+func (fake *FakeSpectrumDataModelWrapper) GetVolumeCallCount() int {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	return len(fake.getVolumeArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeReturns(result1 bool) {
-	fake.IsDbVolumeStub = nil
-	fake.isDbVolumeReturns = struct {
-		result1 bool
-	}{result1}
+func (fake *FakeSpectrumDataModelWrapper) GetVolumeCalls(stub func(string) (spectrumscale.SpectrumScaleVolume, bool, error)) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = stub
 }
 
-func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeReturnsOnCall(i int, result1 bool) {
-	fake.IsDbVolumeStub = nil
-	if fake.isDbVolumeReturnsOnCall == nil {
-		fake.isDbVolumeReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakeSpectrumDataModelWrapper) GetVolumeArgsForCall(i int) string {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	argsForCall := fake.getVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) GetVolumeReturns(result1 spectrumscale.SpectrumScaleVolume, result2 bool, result3 error) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = nil
+	fake.getVolumeReturns = struct {
+		result1 spectrumscale.SpectrumScaleVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) GetVolumeReturnsOnCall(i int, result1 spectrumscale.SpectrumScaleVolume, result2 bool, result3 error) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = nil
+	if fake.getVolumeReturnsOnCall == nil {
+		fake.getVolumeReturnsOnCall = make(map[int]struct {
+			result1 spectrumscale.SpectrumScaleVolume
+			result2 bool
+			result3 error
 		})
 	}
-	fake.isDbVolumeReturnsOnCall[i] = struct {
-		result1 bool
-	}{result1}
+	fake.getVolumeReturnsOnCall[i] = struct {
+		result1 spectrumscale.SpectrumScaleVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) DeleteVolume(name string) error {
-	fake.deleteVolumeMutex.Lock()
-	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
-	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("DeleteVolume", []interface{}{name})
-	fake.deleteVolumeMutex.Unlock()
-	if fake.DeleteVolumeStub != nil {
-		return fake.DeleteVolumeStub(name)
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolume(arg1 string, arg2 string, arg3 string, arg4 string, arg5 bool, arg6 map[string]interface{}) error {
+	fake.insertFilesetQuotaVolumeMutex.Lock()
+	ret, specificReturn := fake.insertFilesetQuotaVolumeReturnsOnCall[len(fake.insertFilesetQuotaVolumeArgsForCall)]
+	fake.insertFilesetQuotaVolumeArgsForCall = append(fake.insertFilesetQuotaVolumeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+		arg6 map[string]interface{}
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("InsertFilesetQuotaVolume", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.insertFilesetQuotaVolumeMutex.Unlock()
+	if fake.InsertFilesetQuotaVolumeStub != nil {
+		return fake.InsertFilesetQuotaVolumeStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteVolumeReturns.result1
+	fakeReturns := fake.insertFilesetQuotaVolumeReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeCallCount() int {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	return len(fake.deleteVolumeArgsForCall)
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeCallCount() int {
+	fake.insertFilesetQuotaVolumeMutex.RLock()
+	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
+	return len(fake.insertFilesetQuotaVolumeArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeArgsForCall(i int) string {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	return fake.deleteVolumeArgsForCall[i].name
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeCalls(stub func(string, string, string, string, bool, map[string]interface{}) error) {
+	fake.insertFilesetQuotaVolumeMutex.Lock()
+	defer fake.insertFilesetQuotaVolumeMutex.Unlock()
+	fake.InsertFilesetQuotaVolumeStub = stub
 }
 
-func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeReturns(result1 error) {
-	fake.DeleteVolumeStub = nil
-	fake.deleteVolumeReturns = struct {
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeArgsForCall(i int) (string, string, string, string, bool, map[string]interface{}) {
+	fake.insertFilesetQuotaVolumeMutex.RLock()
+	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
+	argsForCall := fake.insertFilesetQuotaVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeReturns(result1 error) {
+	fake.insertFilesetQuotaVolumeMutex.Lock()
+	defer fake.insertFilesetQuotaVolumeMutex.Unlock()
+	fake.InsertFilesetQuotaVolumeStub = nil
+	fake.insertFilesetQuotaVolumeReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) DeleteVolumeReturnsOnCall(i int, result1 error) {
-	fake.DeleteVolumeStub = nil
-	if fake.deleteVolumeReturnsOnCall == nil {
-		fake.deleteVolumeReturnsOnCall = make(map[int]struct {
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeReturnsOnCall(i int, result1 error) {
+	fake.insertFilesetQuotaVolumeMutex.Lock()
+	defer fake.insertFilesetQuotaVolumeMutex.Unlock()
+	fake.InsertFilesetQuotaVolumeStub = nil
+	if fake.insertFilesetQuotaVolumeReturnsOnCall == nil {
+		fake.insertFilesetQuotaVolumeReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteVolumeReturnsOnCall[i] = struct {
+	fake.insertFilesetQuotaVolumeReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolume(fileset string, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error {
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolume(arg1 string, arg2 string, arg3 string, arg4 bool, arg5 map[string]interface{}) error {
 	fake.insertFilesetVolumeMutex.Lock()
 	ret, specificReturn := fake.insertFilesetVolumeReturnsOnCall[len(fake.insertFilesetVolumeArgsForCall)]
 	fake.insertFilesetVolumeArgsForCall = append(fake.insertFilesetVolumeArgsForCall, struct {
-		fileset       string
-		volumeName    string
-		filesystem    string
-		isPreexisting bool
-		opts          map[string]interface{}
-	}{fileset, volumeName, filesystem, isPreexisting, opts})
-	fake.recordInvocation("InsertFilesetVolume", []interface{}{fileset, volumeName, filesystem, isPreexisting, opts})
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+		arg5 map[string]interface{}
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("InsertFilesetVolume", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.insertFilesetVolumeMutex.Unlock()
 	if fake.InsertFilesetVolumeStub != nil {
-		return fake.InsertFilesetVolumeStub(fileset, volumeName, filesystem, isPreexisting, opts)
+		return fake.InsertFilesetVolumeStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.insertFilesetVolumeReturns.result1
+	fakeReturns := fake.insertFilesetVolumeReturns
+	return fakeReturns.result1
 }
 
 func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeCallCount() int {
@@ -288,13 +602,22 @@ func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeCallCount() int {
 	return len(fake.insertFilesetVolumeArgsForCall)
 }
 
+func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeCalls(stub func(string, string, string, bool, map[string]interface{}) error) {
+	fake.insertFilesetVolumeMutex.Lock()
+	defer fake.insertFilesetVolumeMutex.Unlock()
+	fake.InsertFilesetVolumeStub = stub
+}
+
 func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeArgsForCall(i int) (string, string, string, bool, map[string]interface{}) {
 	fake.insertFilesetVolumeMutex.RLock()
 	defer fake.insertFilesetVolumeMutex.RUnlock()
-	return fake.insertFilesetVolumeArgsForCall[i].fileset, fake.insertFilesetVolumeArgsForCall[i].volumeName, fake.insertFilesetVolumeArgsForCall[i].filesystem, fake.insertFilesetVolumeArgsForCall[i].isPreexisting, fake.insertFilesetVolumeArgsForCall[i].opts
+	argsForCall := fake.insertFilesetVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeReturns(result1 error) {
+	fake.insertFilesetVolumeMutex.Lock()
+	defer fake.insertFilesetVolumeMutex.Unlock()
 	fake.InsertFilesetVolumeStub = nil
 	fake.insertFilesetVolumeReturns = struct {
 		result1 error
@@ -302,6 +625,8 @@ func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeReturns(result1 err
 }
 
 func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeReturnsOnCall(i int, result1 error) {
+	fake.insertFilesetVolumeMutex.Lock()
+	defer fake.insertFilesetVolumeMutex.Unlock()
 	fake.InsertFilesetVolumeStub = nil
 	if fake.insertFilesetVolumeReturnsOnCall == nil {
 		fake.insertFilesetVolumeReturnsOnCall = make(map[int]struct {
@@ -313,126 +638,262 @@ func (fake *FakeSpectrumDataModelWrapper) InsertFilesetVolumeReturnsOnCall(i int
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolume(fileset string, quota string, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error {
-	fake.insertFilesetQuotaVolumeMutex.Lock()
-	ret, specificReturn := fake.insertFilesetQuotaVolumeReturnsOnCall[len(fake.insertFilesetQuotaVolumeArgsForCall)]
-	fake.insertFilesetQuotaVolumeArgsForCall = append(fake.insertFilesetQuotaVolumeArgsForCall, struct {
-		fileset       string
-		quota         string
-		volumeName    string
-		filesystem    string
-		isPreexisting bool
-		opts          map[string]interface{}
-	}{fileset, quota, volumeName, filesystem, isPreexisting, opts})
-	fake.recordInvocation("InsertFilesetQuotaVolume", []interface{}{fileset, quota, volumeName, filesystem, isPreexisting, opts})
-	fake.insertFilesetQuotaVolumeMutex.Unlock()
-	if fake.InsertFilesetQuotaVolumeStub != nil {
-		return fake.InsertFilesetQuotaVolumeStub(fileset, quota, volumeName, filesystem, isPreexisting, opts)
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshot(arg1 string, arg2 string, arg3 string) error {
+	fake.insertSnapshotMutex.Lock()
+	ret, specificReturn := fake.insertSnapshotReturnsOnCall[len(fake.insertSnapshotArgsForCall)]
+	fake.insertSnapshotArgsForCall = append(fake.insertSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("InsertSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.insertSnapshotMutex.Unlock()
+	if fake.InsertSnapshotStub != nil {
+		return fake.InsertSnapshotStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.insertFilesetQuotaVolumeReturns.result1
+	fakeReturns := fake.insertSnapshotReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeCallCount() int {
-	fake.insertFilesetQuotaVolumeMutex.RLock()
-	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
-	return len(fake.insertFilesetQuotaVolumeArgsForCall)
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshotCallCount() int {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	return len(fake.insertSnapshotArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeArgsForCall(i int) (string, string, string, string, bool, map[string]interface{}) {
-	fake.insertFilesetQuotaVolumeMutex.RLock()
-	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
-	return fake.insertFilesetQuotaVolumeArgsForCall[i].fileset, fake.insertFilesetQuotaVolumeArgsForCall[i].quota, fake.insertFilesetQuotaVolumeArgsForCall[i].volumeName, fake.insertFilesetQuotaVolumeArgsForCall[i].filesystem, fake.insertFilesetQuotaVolumeArgsForCall[i].isPreexisting, fake.insertFilesetQuotaVolumeArgsForCall[i].opts
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshotCalls(stub func(string, string, string) error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = stub
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeReturns(result1 error) {
-	fake.InsertFilesetQuotaVolumeStub = nil
-	fake.insertFilesetQuotaVolumeReturns = struct {
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshotArgsForCall(i int) (string, string, string) {
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	argsForCall := fake.insertSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshotReturns(result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	fake.insertSnapshotReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) InsertFilesetQuotaVolumeReturnsOnCall(i int, result1 error) {
-	fake.InsertFilesetQuotaVolumeStub = nil
-	if fake.insertFilesetQuotaVolumeReturnsOnCall == nil {
-		fake.insertFilesetQuotaVolumeReturnsOnCall = make(map[int]struct {
+func (fake *FakeSpectrumDataModelWrapper) InsertSnapshotReturnsOnCall(i int, result1 error) {
+	fake.insertSnapshotMutex.Lock()
+	defer fake.insertSnapshotMutex.Unlock()
+	fake.InsertSnapshotStub = nil
+	if fake.insertSnapshotReturnsOnCall == nil {
+		fake.insertSnapshotReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.insertFilesetQuotaVolumeReturnsOnCall[i] = struct {
+	fake.insertSnapshotReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) GetVolume(name string) (spectrumscale.SpectrumScaleVolume, bool, error) {
-	fake.getVolumeMutex.Lock()
-	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
-	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
-		name string
-	}{name})
-	fake.recordInvocation("GetVolume", []interface{}{name})
-	fake.getVolumeMutex.Unlock()
-	if fake.GetVolumeStub != nil {
-		return fake.GetVolumeStub(name)
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolume(arg1 string) bool {
+	fake.isDbVolumeMutex.Lock()
+	ret, specificReturn := fake.isDbVolumeReturnsOnCall[len(fake.isDbVolumeArgsForCall)]
+	fake.isDbVolumeArgsForCall = append(fake.isDbVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("IsDbVolume", []interface{}{arg1})
+	fake.isDbVolumeMutex.Unlock()
+	if fake.IsDbVolumeStub != nil {
+		return fake.IsDbVolumeStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getVolumeReturns.result1, fake.getVolumeReturns.result2, fake.getVolumeReturns.result3
+	fakeReturns := fake.isDbVolumeReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeSpectrumDataModelWrapper) GetVolumeCallCount() int {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	return len(fake.getVolumeArgsForCall)
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeCallCount() int {
+	fake.isDbVolumeMutex.RLock()
+	defer fake.isDbVolumeMutex.RUnlock()
+	return len(fake.isDbVolumeArgsForCall)
 }
 
-func (fake *FakeSpectrumDataModelWrapper) GetVolumeArgsForCall(i int) string {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	return fake.getVolumeArgsForCall[i].name
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeCalls(stub func(string) bool) {
+	fake.isDbVolumeMutex.Lock()
+	defer fake.isDbVolumeMutex.Unlock()
+	fake.IsDbVolumeStub = stub
 }
 
-func (fake *FakeSpectrumDataModelWrapper) GetVolumeReturns(result1 spectrumscale.SpectrumScaleVolume, result2 bool, result3 error) {
-	fake.GetVolumeStub = nil
-	fake.getVolumeReturns = struct {
-		result1 spectrumscale.SpectrumScaleVolume
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeArgsForCall(i int) string {
+	fake.isDbVolumeMutex.RLock()
+	defer fake.isDbVolumeMutex.RUnlock()
+	argsForCall := fake.isDbVolumeArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeSpectrumDataModelWrapper) GetVolumeReturnsOnCall(i int, result1 spectrumscale.SpectrumScaleVolume, result2 bool, result3 error) {
-	fake.GetVolumeStub = nil
-	if fake.getVolumeReturnsOnCall == nil {
-		fake.getVolumeReturnsOnCall = make(map[int]struct {
-			result1 spectrumscale.SpectrumScaleVolume
-			result2 bool
-			result3 error
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeReturns(result1 bool) {
+	fake.isDbVolumeMutex.Lock()
+	defer fake.isDbVolumeMutex.Unlock()
+	fake.IsDbVolumeStub = nil
+	fake.isDbVolumeReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) IsDbVolumeReturnsOnCall(i int, result1 bool) {
+	fake.isDbVolumeMutex.Lock()
+	defer fake.isDbVolumeMutex.Unlock()
+	fake.IsDbVolumeStub = nil
+	if fake.isDbVolumeReturnsOnCall == nil {
+		fake.isDbVolumeReturnsOnCall = make(map[int]struct {
+			result1 bool
 		})
 	}
-	fake.getVolumeReturnsOnCall[i] = struct {
-		result1 spectrumscale.SpectrumScaleVolume
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.isDbVolumeReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshots(arg1 string) ([]resources.Snapshot, error) {
+	fake.listSnapshotsMutex.Lock()
+	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
+	fake.listSnapshotsArgsForCall = append(fake.listSnapshotsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListSnapshots", []interface{}{arg1})
+	fake.listSnapshotsMutex.Unlock()
+	if fake.ListSnapshotsStub != nil {
+		return fake.ListSnapshotsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSnapshotsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshotsCallCount() int {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	return len(fake.listSnapshotsArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshotsCalls(stub func(string) ([]resources.Snapshot, error)) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = stub
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshotsArgsForCall(i int) string {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	argsForCall := fake.listSnapshotsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshotsReturns(result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	fake.listSnapshotsReturns = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListSnapshotsReturnsOnCall(i int, result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	if fake.listSnapshotsReturnsOnCall == nil {
+		fake.listSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []resources.Snapshot
+			result2 error
+		})
+	}
+	fake.listSnapshotsReturnsOnCall[i] = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListStoredVolumes() ([]spectrumscale.SpectrumScaleVolume, error) {
+	fake.listStoredVolumesMutex.Lock()
+	ret, specificReturn := fake.listStoredVolumesReturnsOnCall[len(fake.listStoredVolumesArgsForCall)]
+	fake.listStoredVolumesArgsForCall = append(fake.listStoredVolumesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStoredVolumes", []interface{}{})
+	fake.listStoredVolumesMutex.Unlock()
+	if fake.ListStoredVolumesStub != nil {
+		return fake.ListStoredVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStoredVolumesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListStoredVolumesCallCount() int {
+	fake.listStoredVolumesMutex.RLock()
+	defer fake.listStoredVolumesMutex.RUnlock()
+	return len(fake.listStoredVolumesArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListStoredVolumesCalls(stub func() ([]spectrumscale.SpectrumScaleVolume, error)) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = stub
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListStoredVolumesReturns(result1 []spectrumscale.SpectrumScaleVolume, result2 error) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = nil
+	fake.listStoredVolumesReturns = struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) ListVolumes() ([]resources.Volume, error) {
+func (fake *FakeSpectrumDataModelWrapper) ListStoredVolumesReturnsOnCall(i int, result1 []spectrumscale.SpectrumScaleVolume, result2 error) {
+	fake.listStoredVolumesMutex.Lock()
+	defer fake.listStoredVolumesMutex.Unlock()
+	fake.ListStoredVolumesStub = nil
+	if fake.listStoredVolumesReturnsOnCall == nil {
+		fake.listStoredVolumesReturnsOnCall = make(map[int]struct {
+			result1 []spectrumscale.SpectrumScaleVolume
+			result2 error
+		})
+	}
+	fake.listStoredVolumesReturnsOnCall[i] = struct {
+		result1 []spectrumscale.SpectrumScaleVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListVolumes(arg1 resources.ListVolumesRequest) ([]resources.Volume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
-	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct{}{})
-	fake.recordInvocation("ListVolumes", []interface{}{})
+	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
+		arg1 resources.ListVolumesRequest
+	}{arg1})
+	fake.recordInvocation("ListVolumes", []interface{}{arg1})
 	fake.listVolumesMutex.Unlock()
 	if fake.ListVolumesStub != nil {
-		return fake.ListVolumesStub()
+		return fake.ListVolumesStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.listVolumesReturns.result1, fake.listVolumesReturns.result2
+	fakeReturns := fake.listVolumesReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeSpectrumDataModelWrapper) ListVolumesCallCount() int {
@@ -441,7 +902,22 @@ func (fake *FakeSpectrumDataModelWrapper) ListVolumesCallCount() int {
 	return len(fake.listVolumesArgsForCall)
 }
 
+func (fake *FakeSpectrumDataModelWrapper) ListVolumesCalls(stub func(resources.ListVolumesRequest) ([]resources.Volume, error)) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	fake.ListVolumesStub = stub
+}
+
+func (fake *FakeSpectrumDataModelWrapper) ListVolumesArgsForCall(i int) resources.ListVolumesRequest {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	argsForCall := fake.listVolumesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeSpectrumDataModelWrapper) ListVolumesReturns(result1 []resources.Volume, result2 error) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
 	fake.ListVolumesStub = nil
 	fake.listVolumesReturns = struct {
 		result1 []resources.Volume
@@ -450,6 +926,8 @@ func (fake *FakeSpectrumDataModelWrapper) ListVolumesReturns(result1 []resources
 }
 
 func (fake *FakeSpectrumDataModelWrapper) ListVolumesReturnsOnCall(i int, result1 []resources.Volume, result2 error) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
 	fake.ListVolumesStub = nil
 	if fake.listVolumesReturnsOnCall == nil {
 		fake.listVolumesReturnsOnCall = make(map[int]struct {
@@ -463,50 +941,134 @@ func (fake *FakeSpectrumDataModelWrapper) ListVolumesReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeSpectrumDataModelWrapper) UpdateDatabaseVolume(newVolume *spectrumscale.SpectrumScaleVolume) {
-    fake.updateDatabaseVolumeMutex.Lock()
-    fake.updateDatabaseVolumeArgsForCall = append(fake.updateDatabaseVolumeArgsForCall, struct {
-        newVolume *spectrumscale.SpectrumScaleVolume
-    }{newVolume})
-    fake.recordInvocation("UpdateDatabaseVolume", []interface{}{newVolume})
-    fake.updateDatabaseVolumeMutex.Unlock()
-    if fake.UpdateDatabaseVolumeStub != nil {
-        fake.UpdateDatabaseVolumeStub(newVolume)
-    }
+func (fake *FakeSpectrumDataModelWrapper) UpdateDatabaseVolume(arg1 *spectrumscale.SpectrumScaleVolume) {
+	fake.updateDatabaseVolumeMutex.Lock()
+	fake.updateDatabaseVolumeArgsForCall = append(fake.updateDatabaseVolumeArgsForCall, struct {
+		arg1 *spectrumscale.SpectrumScaleVolume
+	}{arg1})
+	fake.recordInvocation("UpdateDatabaseVolume", []interface{}{arg1})
+	fake.updateDatabaseVolumeMutex.Unlock()
+	if fake.UpdateDatabaseVolumeStub != nil {
+		fake.UpdateDatabaseVolumeStub(arg1)
+	}
 }
 
 func (fake *FakeSpectrumDataModelWrapper) UpdateDatabaseVolumeCallCount() int {
-    fake.updateDatabaseVolumeMutex.RLock()
-    defer fake.updateDatabaseVolumeMutex.RUnlock()
-    return len(fake.updateDatabaseVolumeArgsForCall)
+	fake.updateDatabaseVolumeMutex.RLock()
+	defer fake.updateDatabaseVolumeMutex.RUnlock()
+	return len(fake.updateDatabaseVolumeArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateDatabaseVolumeCalls(stub func(*spectrumscale.SpectrumScaleVolume)) {
+	fake.updateDatabaseVolumeMutex.Lock()
+	defer fake.updateDatabaseVolumeMutex.Unlock()
+	fake.UpdateDatabaseVolumeStub = stub
 }
 
 func (fake *FakeSpectrumDataModelWrapper) UpdateDatabaseVolumeArgsForCall(i int) *spectrumscale.SpectrumScaleVolume {
-    fake.updateDatabaseVolumeMutex.RLock()
-    defer fake.updateDatabaseVolumeMutex.RUnlock()
-    return fake.updateDatabaseVolumeArgsForCall[i].newVolume
+	fake.updateDatabaseVolumeMutex.RLock()
+	defer fake.updateDatabaseVolumeMutex.RUnlock()
+	argsForCall := fake.updateDatabaseVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuota(arg1 string, arg2 string) error {
+	fake.updateVolumeQuotaMutex.Lock()
+	ret, specificReturn := fake.updateVolumeQuotaReturnsOnCall[len(fake.updateVolumeQuotaArgsForCall)]
+	fake.updateVolumeQuotaArgsForCall = append(fake.updateVolumeQuotaArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateVolumeQuota", []interface{}{arg1, arg2})
+	fake.updateVolumeQuotaMutex.Unlock()
+	if fake.UpdateVolumeQuotaStub != nil {
+		return fake.UpdateVolumeQuotaStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateVolumeQuotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuotaCallCount() int {
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
+	return len(fake.updateVolumeQuotaArgsForCall)
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuotaCalls(stub func(string, string) error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = stub
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuotaArgsForCall(i int) (string, string) {
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
+	argsForCall := fake.updateVolumeQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuotaReturns(result1 error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = nil
+	fake.updateVolumeQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSpectrumDataModelWrapper) UpdateVolumeQuotaReturnsOnCall(i int, result1 error) {
+	fake.updateVolumeQuotaMutex.Lock()
+	defer fake.updateVolumeQuotaMutex.Unlock()
+	fake.UpdateVolumeQuotaStub = nil
+	if fake.updateVolumeQuotaReturnsOnCall == nil {
+		fake.updateVolumeQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVolumeQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeSpectrumDataModelWrapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
 	defer fake.deleteVolumeMutex.RUnlock()
-	fake.insertFilesetVolumeMutex.RLock()
-	defer fake.insertFilesetVolumeMutex.RUnlock()
-	fake.insertFilesetQuotaVolumeMutex.RLock()
-	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
+	fake.getDbNameMutex.RLock()
+	defer fake.getDbNameMutex.RUnlock()
+	fake.getSnapshotMutex.RLock()
+	defer fake.getSnapshotMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
+	fake.insertFilesetQuotaVolumeMutex.RLock()
+	defer fake.insertFilesetQuotaVolumeMutex.RUnlock()
+	fake.insertFilesetVolumeMutex.RLock()
+	defer fake.insertFilesetVolumeMutex.RUnlock()
+	fake.insertSnapshotMutex.RLock()
+	defer fake.insertSnapshotMutex.RUnlock()
+	fake.isDbVolumeMutex.RLock()
+	defer fake.isDbVolumeMutex.RUnlock()
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	fake.listStoredVolumesMutex.RLock()
+	defer fake.listStoredVolumesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
-    fake.updateDatabaseVolumeMutex.RLock()
-    defer fake.updateDatabaseVolumeMutex.RUnlock()
-    fake.getDbNameMutex.RLock()
-    defer fake.getDbNameMutex.RUnlock()
-    fake.isDbVolumeMutex.RLock()
-    defer fake.isDbVolumeMutex.RUnlock()
-	return fake.invocations
+	fake.updateDatabaseVolumeMutex.RLock()
+	defer fake.updateDatabaseVolumeMutex.RUnlock()
+	fake.updateVolumeQuotaMutex.RLock()
+	defer fake.updateVolumeQuotaMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
 }
 
 func (fake *FakeSpectrumDataModelWrapper) recordInvocation(key string, args []interface{}) {
@@ -521,4 +1083,4 @@ func (fake *FakeSpectrumDataModelWrapper) recordInvocation(key string, args []in
 	fake.invocations[key] = append(fake.invocations[key], args)
 }
 
-var _ spectrumscale.SpectrumDataModel = new(FakeSpectrumDataModel)
+var _ spectrumscale.SpectrumDataModelWrapper = new(FakeSpectrumDataModelWrapper)
@@ -32,6 +32,17 @@ type FakeStorageClient struct {
 		result1 string
 		result2 error
 	}
+	CreateSnapshotStub        func(resources.CreateSnapshotRequest) error
+	createSnapshotMutex       sync.RWMutex
+	createSnapshotArgsForCall []struct {
+		arg1 resources.CreateSnapshotRequest
+	}
+	createSnapshotReturns struct {
+		result1 error
+	}
+	createSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateVolumeStub        func(resources.CreateVolumeRequest) error
 	createVolumeMutex       sync.RWMutex
 	createVolumeArgsForCall []struct {
@@ -43,6 +54,17 @@ type FakeStorageClient struct {
 	createVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteSnapshotStub        func(resources.DeleteSnapshotRequest) error
+	deleteSnapshotMutex       sync.RWMutex
+	deleteSnapshotArgsForCall []struct {
+		arg1 resources.DeleteSnapshotRequest
+	}
+	deleteSnapshotReturns struct {
+		result1 error
+	}
+	deleteSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DetachStub        func(resources.DetachRequest) error
 	detachMutex       sync.RWMutex
 	detachArgsForCall []struct {
@@ -54,6 +76,17 @@ type FakeStorageClient struct {
 	detachReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExpandVolumeStub        func(resources.ExpandVolumeRequest) error
+	expandVolumeMutex       sync.RWMutex
+	expandVolumeArgsForCall []struct {
+		arg1 resources.ExpandVolumeRequest
+	}
+	expandVolumeReturns struct {
+		result1 error
+	}
+	expandVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetVolumeStub        func(resources.GetVolumeRequest) (resources.Volume, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -80,6 +113,32 @@ type FakeStorageClient struct {
 		result1 map[string]interface{}
 		result2 error
 	}
+	ListServicesStub        func(resources.ListServicesRequest) ([]resources.StorageService, error)
+	listServicesMutex       sync.RWMutex
+	listServicesArgsForCall []struct {
+		arg1 resources.ListServicesRequest
+	}
+	listServicesReturns struct {
+		result1 []resources.StorageService
+		result2 error
+	}
+	listServicesReturnsOnCall map[int]struct {
+		result1 []resources.StorageService
+		result2 error
+	}
+	ListSnapshotsStub        func(resources.ListSnapshotsRequest) ([]resources.Snapshot, error)
+	listSnapshotsMutex       sync.RWMutex
+	listSnapshotsArgsForCall []struct {
+		arg1 resources.ListSnapshotsRequest
+	}
+	listSnapshotsReturns struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
+	listSnapshotsReturnsOnCall map[int]struct {
+		result1 []resources.Snapshot
+		result2 error
+	}
 	ListVolumesStub        func(resources.ListVolumesRequest) ([]resources.Volume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -93,6 +152,19 @@ type FakeStorageClient struct {
 		result1 []resources.Volume
 		result2 error
 	}
+	ReconcileStub        func(resources.ReconcileRequest) (resources.ReconcileReport, error)
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		arg1 resources.ReconcileRequest
+	}
+	reconcileReturns struct {
+		result1 resources.ReconcileReport
+		result2 error
+	}
+	reconcileReturnsOnCall map[int]struct {
+		result1 resources.ReconcileReport
+		result2 error
+	}
 	RemoveVolumeStub        func(resources.RemoveVolumeRequest) error
 	removeVolumeMutex       sync.RWMutex
 	removeVolumeArgsForCall []struct {
@@ -231,6 +303,66 @@ func (fake *FakeStorageClient) AttachReturnsOnCall(i int, result1 string, result
 	}{result1, result2}
 }
 
+func (fake *FakeStorageClient) CreateSnapshot(arg1 resources.CreateSnapshotRequest) error {
+	fake.createSnapshotMutex.Lock()
+	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
+	fake.createSnapshotArgsForCall = append(fake.createSnapshotArgsForCall, struct {
+		arg1 resources.CreateSnapshotRequest
+	}{arg1})
+	fake.recordInvocation("CreateSnapshot", []interface{}{arg1})
+	fake.createSnapshotMutex.Unlock()
+	if fake.CreateSnapshotStub != nil {
+		return fake.CreateSnapshotStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorageClient) CreateSnapshotCallCount() int {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	return len(fake.createSnapshotArgsForCall)
+}
+
+func (fake *FakeStorageClient) CreateSnapshotCalls(stub func(resources.CreateSnapshotRequest) error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = stub
+}
+
+func (fake *FakeStorageClient) CreateSnapshotArgsForCall(i int) resources.CreateSnapshotRequest {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	argsForCall := fake.createSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) CreateSnapshotReturns(result1 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	fake.createSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorageClient) CreateSnapshotReturnsOnCall(i int, result1 error) {
+	fake.createSnapshotMutex.Lock()
+	defer fake.createSnapshotMutex.Unlock()
+	fake.CreateSnapshotStub = nil
+	if fake.createSnapshotReturnsOnCall == nil {
+		fake.createSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorageClient) CreateVolume(arg1 resources.CreateVolumeRequest) error {
 	fake.createVolumeMutex.Lock()
 	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
@@ -291,6 +423,66 @@ func (fake *FakeStorageClient) CreateVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStorageClient) DeleteSnapshot(arg1 resources.DeleteSnapshotRequest) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 resources.DeleteSnapshotRequest
+	}{arg1})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteSnapshotReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorageClient) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeStorageClient) DeleteSnapshotCalls(stub func(resources.DeleteSnapshotRequest) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeStorageClient) DeleteSnapshotArgsForCall(i int) resources.DeleteSnapshotRequest {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorageClient) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorageClient) Detach(arg1 resources.DetachRequest) error {
 	fake.detachMutex.Lock()
 	ret, specificReturn := fake.detachReturnsOnCall[len(fake.detachArgsForCall)]
@@ -351,6 +543,66 @@ func (fake *FakeStorageClient) DetachReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStorageClient) ExpandVolume(arg1 resources.ExpandVolumeRequest) error {
+	fake.expandVolumeMutex.Lock()
+	ret, specificReturn := fake.expandVolumeReturnsOnCall[len(fake.expandVolumeArgsForCall)]
+	fake.expandVolumeArgsForCall = append(fake.expandVolumeArgsForCall, struct {
+		arg1 resources.ExpandVolumeRequest
+	}{arg1})
+	fake.recordInvocation("ExpandVolume", []interface{}{arg1})
+	fake.expandVolumeMutex.Unlock()
+	if fake.ExpandVolumeStub != nil {
+		return fake.ExpandVolumeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.expandVolumeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorageClient) ExpandVolumeCallCount() int {
+	fake.expandVolumeMutex.RLock()
+	defer fake.expandVolumeMutex.RUnlock()
+	return len(fake.expandVolumeArgsForCall)
+}
+
+func (fake *FakeStorageClient) ExpandVolumeCalls(stub func(resources.ExpandVolumeRequest) error) {
+	fake.expandVolumeMutex.Lock()
+	defer fake.expandVolumeMutex.Unlock()
+	fake.ExpandVolumeStub = stub
+}
+
+func (fake *FakeStorageClient) ExpandVolumeArgsForCall(i int) resources.ExpandVolumeRequest {
+	fake.expandVolumeMutex.RLock()
+	defer fake.expandVolumeMutex.RUnlock()
+	argsForCall := fake.expandVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) ExpandVolumeReturns(result1 error) {
+	fake.expandVolumeMutex.Lock()
+	defer fake.expandVolumeMutex.Unlock()
+	fake.ExpandVolumeStub = nil
+	fake.expandVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorageClient) ExpandVolumeReturnsOnCall(i int, result1 error) {
+	fake.expandVolumeMutex.Lock()
+	defer fake.expandVolumeMutex.Unlock()
+	fake.ExpandVolumeStub = nil
+	if fake.expandVolumeReturnsOnCall == nil {
+		fake.expandVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.expandVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorageClient) GetVolume(arg1 resources.GetVolumeRequest) (resources.Volume, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -477,6 +729,132 @@ func (fake *FakeStorageClient) GetVolumeConfigReturnsOnCall(i int, result1 map[s
 	}{result1, result2}
 }
 
+func (fake *FakeStorageClient) ListServices(arg1 resources.ListServicesRequest) ([]resources.StorageService, error) {
+	fake.listServicesMutex.Lock()
+	ret, specificReturn := fake.listServicesReturnsOnCall[len(fake.listServicesArgsForCall)]
+	fake.listServicesArgsForCall = append(fake.listServicesArgsForCall, struct {
+		arg1 resources.ListServicesRequest
+	}{arg1})
+	fake.recordInvocation("ListServices", []interface{}{arg1})
+	fake.listServicesMutex.Unlock()
+	if fake.ListServicesStub != nil {
+		return fake.ListServicesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listServicesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorageClient) ListServicesCallCount() int {
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
+	return len(fake.listServicesArgsForCall)
+}
+
+func (fake *FakeStorageClient) ListServicesCalls(stub func(resources.ListServicesRequest) ([]resources.StorageService, error)) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = stub
+}
+
+func (fake *FakeStorageClient) ListServicesArgsForCall(i int) resources.ListServicesRequest {
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
+	argsForCall := fake.listServicesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) ListServicesReturns(result1 []resources.StorageService, result2 error) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = nil
+	fake.listServicesReturns = struct {
+		result1 []resources.StorageService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorageClient) ListServicesReturnsOnCall(i int, result1 []resources.StorageService, result2 error) {
+	fake.listServicesMutex.Lock()
+	defer fake.listServicesMutex.Unlock()
+	fake.ListServicesStub = nil
+	if fake.listServicesReturnsOnCall == nil {
+		fake.listServicesReturnsOnCall = make(map[int]struct {
+			result1 []resources.StorageService
+			result2 error
+		})
+	}
+	fake.listServicesReturnsOnCall[i] = struct {
+		result1 []resources.StorageService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorageClient) ListSnapshots(arg1 resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	fake.listSnapshotsMutex.Lock()
+	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
+	fake.listSnapshotsArgsForCall = append(fake.listSnapshotsArgsForCall, struct {
+		arg1 resources.ListSnapshotsRequest
+	}{arg1})
+	fake.recordInvocation("ListSnapshots", []interface{}{arg1})
+	fake.listSnapshotsMutex.Unlock()
+	if fake.ListSnapshotsStub != nil {
+		return fake.ListSnapshotsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSnapshotsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorageClient) ListSnapshotsCallCount() int {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	return len(fake.listSnapshotsArgsForCall)
+}
+
+func (fake *FakeStorageClient) ListSnapshotsCalls(stub func(resources.ListSnapshotsRequest) ([]resources.Snapshot, error)) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = stub
+}
+
+func (fake *FakeStorageClient) ListSnapshotsArgsForCall(i int) resources.ListSnapshotsRequest {
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
+	argsForCall := fake.listSnapshotsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) ListSnapshotsReturns(result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	fake.listSnapshotsReturns = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorageClient) ListSnapshotsReturnsOnCall(i int, result1 []resources.Snapshot, result2 error) {
+	fake.listSnapshotsMutex.Lock()
+	defer fake.listSnapshotsMutex.Unlock()
+	fake.ListSnapshotsStub = nil
+	if fake.listSnapshotsReturnsOnCall == nil {
+		fake.listSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []resources.Snapshot
+			result2 error
+		})
+	}
+	fake.listSnapshotsReturnsOnCall[i] = struct {
+		result1 []resources.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorageClient) ListVolumes(arg1 resources.ListVolumesRequest) ([]resources.Volume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
@@ -540,6 +918,69 @@ func (fake *FakeStorageClient) ListVolumesReturnsOnCall(i int, result1 []resourc
 	}{result1, result2}
 }
 
+func (fake *FakeStorageClient) Reconcile(arg1 resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	fake.reconcileMutex.Lock()
+	ret, specificReturn := fake.reconcileReturnsOnCall[len(fake.reconcileArgsForCall)]
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		arg1 resources.ReconcileRequest
+	}{arg1})
+	fake.recordInvocation("Reconcile", []interface{}{arg1})
+	fake.reconcileMutex.Unlock()
+	if fake.ReconcileStub != nil {
+		return fake.ReconcileStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reconcileReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorageClient) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *FakeStorageClient) ReconcileCalls(stub func(resources.ReconcileRequest) (resources.ReconcileReport, error)) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = stub
+}
+
+func (fake *FakeStorageClient) ReconcileArgsForCall(i int) resources.ReconcileRequest {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	argsForCall := fake.reconcileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStorageClient) ReconcileReturns(result1 resources.ReconcileReport, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	fake.reconcileReturns = struct {
+		result1 resources.ReconcileReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorageClient) ReconcileReturnsOnCall(i int, result1 resources.ReconcileReport, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	if fake.reconcileReturnsOnCall == nil {
+		fake.reconcileReturnsOnCall = make(map[int]struct {
+			result1 resources.ReconcileReport
+			result2 error
+		})
+	}
+	fake.reconcileReturnsOnCall[i] = struct {
+		result1 resources.ReconcileReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorageClient) RemoveVolume(arg1 resources.RemoveVolumeRequest) error {
 	fake.removeVolumeMutex.Lock()
 	ret, specificReturn := fake.removeVolumeReturnsOnCall[len(fake.removeVolumeArgsForCall)]
@@ -607,16 +1048,28 @@ func (fake *FakeStorageClient) Invocations() map[string][][]interface{} {
 	defer fake.activateMutex.RUnlock()
 	fake.attachMutex.RLock()
 	defer fake.attachMutex.RUnlock()
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.detachMutex.RLock()
 	defer fake.detachMutex.RUnlock()
+	fake.expandVolumeMutex.RLock()
+	defer fake.expandVolumeMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
 	fake.getVolumeConfigMutex.RLock()
 	defer fake.getVolumeConfigMutex.RUnlock()
+	fake.listServicesMutex.RLock()
+	defer fake.listServicesMutex.RUnlock()
+	fake.listSnapshotsMutex.RLock()
+	defer fake.listSnapshotsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
 	fake.removeVolumeMutex.RLock()
 	defer fake.removeVolumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
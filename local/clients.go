@@ -18,33 +18,42 @@ package local
 
 import (
 	"fmt"
-	"github.com/IBM/ubiquity/local/scbe"
-	"github.com/IBM/ubiquity/local/spectrumscale"
+
+	_ "github.com/IBM/ubiquity/local/scbe"
+	_ "github.com/IBM/ubiquity/local/spectrumscale"
+	"github.com/IBM/ubiquity/registry"
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils/logs"
 )
 
-var NewScbeLocalClient = scbe.NewScbeLocalClient
-var NewSpectrumLocalClient = spectrumscale.NewSpectrumLocalClient
-
-func GetLocalClients(logger logs.Logger, config resources.UbiquityServerConfig) (map[string]resources.StorageClient, error) {
-	// TODO need to refactor and load all the existing clients automatically (instead of hardcore each one here)
+// GetLocalClients creates the storage client of every registered backend that is set in the configuration,
+// and of every named instance of the backends. The clients are keyed by backend (or instance) name.
+// A backend with an invalid configuration fails the startup, but a backend that fails to initialize does not: it is
+// unavailable, and its initialization is retried in the background (see UbiquityServerConfig.BackendInit) until stop is closed.
+func GetLocalClients(logger logs.Logger, config resources.UbiquityServerConfig, stop <-chan struct{}) (map[string]resources.StorageClient, error) {
 	clients := make(map[string]resources.StorageClient)
-	if (config.ScbeConfig.ConnectionInfo.ManagementIP != "") {
-		ScbeClient, err := NewScbeLocalClient(config.ScbeConfig)
-	  	if err != nil {
-			return nil, &resources.BackendInitializationError{BackendName: resources.SCBE, Err: err}
-	  	} else {
-			clients[resources.SCBE] = ScbeClient
-		}
-	}
-
-	if (config.SpectrumScaleConfig.RestConfig.ManagementIP != "") {
-		spectrumClient, err := NewSpectrumLocalClient(config)
-		if err != nil {
-			return nil, &resources.BackendInitializationError{BackendName: resources.SpectrumScale, Err: err}
+	for _, backend := range registry.GetBackends() {
+		if backend.IsConfigured(config) {
+			client, err := newLocalClient(logger, backend, backend.Name, config, config.BackendInit, stop)
+			if err != nil {
+				return nil, err
+			}
+			clients[backend.Name] = client
 		} else {
-			clients[resources.SpectrumScale] = spectrumClient
+			logger.Debug("Backend is not configured, skipping it", logs.Args{{"backend", backend.Name}})
+		}
+		if backend.Instances == nil {
+			continue
+		}
+		for name, instanceConfig := range backend.Instances(config) {
+			if _, exists := clients[name]; exists {
+				return nil, logger.ErrorRet(&resources.DuplicateBackendInstanceError{Name: name}, "failed")
+			}
+			client, err := newLocalClient(logger, backend, name, instanceConfig, config.BackendInit, stop)
+			if err != nil {
+				return nil, err
+			}
+			clients[name] = client
 		}
 	}
 
@@ -54,3 +63,37 @@ func GetLocalClients(logger logs.Logger, config resources.UbiquityServerConfig)
 	}
 	return clients, nil
 }
+
+// newLocalClient validates the config of the backend instance and creates its storage client,
+// the client of a backend that fails to initialize is unavailable until a retry succeeds
+func newLocalClient(logger logs.Logger, backend registry.Backend, name string, config resources.UbiquityServerConfig, retryConfig resources.BackendInitConfig, stop <-chan struct{}) (resources.StorageClient, error) {
+	logger.Debug("Initializing backend client", logs.Args{{"backend", backend.Name}, {"instance", name}})
+	if backend.ValidateConfig != nil {
+		if err := backend.ValidateConfig(config); err != nil {
+			return nil, &resources.BackendInitializationError{BackendName: name, Err: err}
+		}
+	}
+	client, err := backend.NewStorageClient(logger, name, config)
+	if err != nil {
+		err = &resources.BackendInitializationError{BackendName: name, Err: err}
+		logger.Warning("Backend failed to initialize, it is unavailable until a retry succeeds", logs.Args{{"backend", name}, {"error", err}})
+		pendingClient := newPendingStorageClient(logger, backend, name, config, err)
+		go pendingClient.initialize(retryConfig, stop)
+		return pendingClient, nil
+	}
+	return client, nil
+}
+
+// RecoverOperations resumes or rolls back the journaled operations of the clients that a restart interrupted.
+// A failure is logged and the operation stays pending, so the server still starts and the next start retries it.
+func RecoverOperations(logger logs.Logger, clients map[string]resources.StorageClient) {
+	for name, client := range clients {
+		recoverer, ok := client.(resources.OperationRecoverer)
+		if !ok {
+			continue
+		}
+		if err := recoverer.RecoverOperations(); err != nil {
+			logger.Warning("Failed to recover the interrupted operations of the backend", logs.Args{{"backend", name}, {"error", err}})
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package local_test
 
 import (
+	"context"
 	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/IBM/ubiquity/resources"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/IBM/ubiquity/local"
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/registry"
 	"fmt"
+	"sync/atomic"
+	"time"
 )
 
 var _ = Describe("Clients", func() {
@@ -19,10 +24,16 @@ var _ = Describe("Clients", func() {
 		err                 error
 		logger              logs.Logger
 		client		    map[string]resources.StorageClient
+		stop                chan struct{}
 
 	)
 	BeforeEach(func() {
 		logger = logs.GetLogger()
+		stop = make(chan struct{})
+	})
+	AfterEach(func() {
+		// stop the initialization retries of the unavailable backends
+		close(stop)
 	})
 
 	Context(".GetLocalClients", func() {
@@ -38,19 +49,15 @@ var _ = Describe("Clients", func() {
 		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig, SpectrumScaleConfig: fakeSpectrumScaleConfig}
 
 
-		oldNewScbeLocalClient := local.NewScbeLocalClient
-
-		defer func () { local.NewScbeLocalClient = oldNewScbeLocalClient }()
-
-		local.NewScbeLocalClient = func (scbeConfig resources.ScbeConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SCBE, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, nil
-		}
+		})()
 
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
-	It("Should Fail when ManagementIP present for SCBE backend, not present for SpectrumScale backend and SCBE backend intialization fails", func() {
+	It("Should start the SCBE backend as unavailable when ManagementIP present for SCBE backend, not present for SpectrumScale backend and SCBE backend intialization fails", func() {
 		fakeConnectionInfo = resources.ConnectionInfo{}
 		fakeScbeConfig	   = resources.ScbeConfig{ConnectionInfo: fakeConnectionInfo,}
 		fakeScbeConfig.ConnectionInfo.ManagementIP="1.1.1.1"
@@ -62,17 +69,16 @@ var _ = Describe("Clients", func() {
 		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig, SpectrumScaleConfig: fakeSpectrumScaleConfig}
 
 
-		oldNewScbeLocalClient := local.NewScbeLocalClient
-
-		defer func () { local.NewScbeLocalClient = oldNewScbeLocalClient }()
-
-		local.NewScbeLocalClient = func (scbeConfig resources.ScbeConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SCBE, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, fmt.Errorf("SCBE Initialization failed")
-		}
+		})()
 
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveKey(resources.SCBE))
 
-		Expect(err.Error()).To(Equal("Error while initializing scbe client:[SCBE Initialization failed]"))
+		err = client[resources.SCBE].CreateVolume(resources.CreateVolumeRequest{Name: "vol1"})
+		Expect(err).To(Equal(&resources.BackendUnavailableError{Backend: resources.SCBE, Reason: "Error while initializing scbe client:[SCBE Initialization failed]"}))
 	})
 
 	It("Should Pass when ManagementIP present for SpectrumScale backend, not present for SCBE backend and SpectrumScale initialization is successful", func() {
@@ -87,19 +93,15 @@ var _ = Describe("Clients", func() {
 		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig, SpectrumScaleConfig: fakeSpectrumScaleConfig}
 
 
-		oldNewSpectrumScaleLocalClient := local.NewSpectrumLocalClient
-
-		defer func () { local.NewSpectrumLocalClient = oldNewSpectrumScaleLocalClient }()
-
-		local.NewSpectrumLocalClient = func (Config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SpectrumScale, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, nil
-		}
+		})()
 
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
-	It("Should Fail when ManagementIP present for SpectrumScale backend, not present for SCBE backend and SpectrumScale Backend Initialization fails", func() {
+	It("Should start the SpectrumScale backend as unavailable when ManagementIP present for SpectrumScale backend, not present for SCBE backend and SpectrumScale Backend Initialization fails", func() {
 		fakeConnectionInfo = resources.ConnectionInfo{}
 		fakeScbeConfig	   = resources.ScbeConfig{ConnectionInfo: fakeConnectionInfo,}
 		fakeScbeConfig.ConnectionInfo.ManagementIP=""
@@ -111,17 +113,16 @@ var _ = Describe("Clients", func() {
 		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig, SpectrumScaleConfig: fakeSpectrumScaleConfig}
 
 
-		oldNewSpectrumScaleLocalClient := local.NewSpectrumLocalClient
-
-		defer func () { local.NewSpectrumLocalClient = oldNewSpectrumScaleLocalClient }()
-
-		local.NewSpectrumLocalClient = func (Config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SpectrumScale, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, fmt.Errorf("SpectrumScale Initialization failed") 
-		}
+		})()
 
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveKey(resources.SpectrumScale))
 
-		Expect(err.Error()).To(Equal("Error while initializing spectrum-scale client:[SpectrumScale Initialization failed]"))
+		_, err = client[resources.SpectrumScale].ListVolumes(resources.ListVolumesRequest{})
+		Expect(err).To(Equal(&resources.BackendUnavailableError{Backend: resources.SpectrumScale, Reason: "Error while initializing spectrum-scale client:[SpectrumScale Initialization failed]"}))
 	})
 
 	It("Should Pass when ManagementIP present for both backends and Initialization is successfull for both backends", func() {
@@ -136,23 +137,15 @@ var _ = Describe("Clients", func() {
 		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig, SpectrumScaleConfig: fakeSpectrumScaleConfig}
 
 
-		oldNewSpectrumScaleLocalClient := local.NewSpectrumLocalClient
-
-		defer func () { local.NewSpectrumLocalClient = oldNewSpectrumScaleLocalClient }()
-
-		local.NewSpectrumLocalClient = func (Config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SpectrumScale, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, nil
-		}
+		})()
 
-		oldNewScbeLocalClient := local.NewScbeLocalClient
-
-		defer func () { local.NewScbeLocalClient = oldNewScbeLocalClient }()
-
-		local.NewScbeLocalClient = func (scbeConfig resources.ScbeConfig) (resources.StorageClient, error) {
+		defer replaceStorageClientFactory(resources.SCBE, func (logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
 			return  nil, nil
-		}
+		})()
 
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
@@ -163,10 +156,159 @@ var _ = Describe("Clients", func() {
 		fakeRestConfig = resources.RestConfig{}
 		fakeSpectrumScaleConfig = resources.SpectrumScaleConfig{RestConfig: fakeRestConfig,}
 		fakeConfig	   = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig,SpectrumScaleConfig: fakeSpectrumScaleConfig,}
-		client, err = local.GetLocalClients(logger, fakeConfig)
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
 		Expect(client).To(BeNil())
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(Equal(resources.ClientInitializationErrorStr))
 	})
+
+	It("Should create a registered backend when its config section is set", func() {
+		fakeConfig = resources.UbiquityServerConfig{BackendsConfig: map[string]map[string]string{"fake-backend": {"URL": "http://fake"}}}
+		registry.RegisterBackend(registry.Backend{
+			Name:          "fake-backend",
+			ConfigSection: "FAKE_BACKEND",
+			IsConfigured: func(config resources.UbiquityServerConfig) bool {
+				return config.BackendsConfig["fake-backend"]["URL"] != ""
+			},
+			NewStorageClient: func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+				return new(fakes.FakeStorageClient), nil
+			},
+		})
+		defer registry.UnregisterBackend("fake-backend")
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveLen(1))
+		Expect(client).To(HaveKey("fake-backend"))
+	})
+
+	It("Should create a client for every named instance of a backend", func() {
+		fakeScbeConfig = resources.ScbeConfig{ConnectionInfo: resources.ConnectionInfo{ManagementIP: "1.1.1.1"}}
+		fakeConfig = resources.UbiquityServerConfig{
+			ScbeConfig: fakeScbeConfig,
+			ScbeInstances: map[string]resources.ScbeConfig{
+				"scbe-prod": {ConnectionInfo: resources.ConnectionInfo{ManagementIP: "2.2.2.2"}},
+				"scbe-dev":  {ConnectionInfo: resources.ConnectionInfo{ManagementIP: "3.3.3.3"}},
+			},
+		}
+		managementIPs := make(map[string]string)
+		defer replaceStorageClientFactory(resources.SCBE, func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			managementIPs[name] = config.ScbeConfig.ConnectionInfo.ManagementIP
+			return new(fakes.FakeStorageClient), nil
+		})()
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveLen(3))
+		Expect(managementIPs).To(Equal(map[string]string{resources.SCBE: "1.1.1.1", "scbe-prod": "2.2.2.2", "scbe-dev": "3.3.3.3"}))
+	})
+
+	It("Should create the named instances of a backend without its default instance", func() {
+		fakeConfig = resources.UbiquityServerConfig{
+			SpectrumScaleInstances: map[string]resources.SpectrumScaleConfig{"scale-dev": {DefaultFilesystemName: "gold"}},
+		}
+		defer replaceStorageClientFactory(resources.SpectrumScale, func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			return new(fakes.FakeStorageClient), nil
+		})()
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveLen(1))
+		Expect(client).To(HaveKey("scale-dev"))
+	})
+
+	It("Should start the other instances when a named instance fails to initialize", func() {
+		fakeConfig = resources.UbiquityServerConfig{
+			ScbeConfig:    resources.ScbeConfig{ConnectionInfo: resources.ConnectionInfo{ManagementIP: "1.1.1.1"}},
+			ScbeInstances: map[string]resources.ScbeConfig{"scbe-dev": {}},
+		}
+		defaultClient := new(fakes.FakeStorageClient)
+		defer replaceStorageClientFactory(resources.SCBE, func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			if name == "scbe-dev" {
+				return nil, fmt.Errorf("SCBE Initialization failed")
+			}
+			return defaultClient, nil
+		})()
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).To(HaveLen(2))
+		Expect(client[resources.SCBE]).To(Equal(defaultClient))
+		_, err = client["scbe-dev"].ListVolumes(resources.ListVolumesRequest{})
+		Expect(err).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+	})
+
+	It("Should retry the initialization of an unavailable backend until it succeeds", func() {
+		fakeConfig = resources.UbiquityServerConfig{
+			ScbeConfig:  resources.ScbeConfig{ConnectionInfo: resources.ConnectionInfo{ManagementIP: "1.1.1.1"}},
+			BackendInit: resources.BackendInitConfig{RetryInterval: time.Millisecond, MaxRetryInterval: 4 * time.Millisecond},
+		}
+		fakeClient := new(fakes.FakeStorageClient)
+		fakeClient.ListVolumesReturns([]resources.Volume{{Name: "vol1"}}, nil)
+		var attempts int32
+		defer replaceStorageClientFactory(resources.SCBE, func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			if atomic.AddInt32(&attempts, 1) <= 3 {
+				return nil, fmt.Errorf("SCBE Initialization failed")
+			}
+			return fakeClient, nil
+		})()
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(func() error {
+			_, err := client[resources.SCBE].ListVolumes(resources.ListVolumesRequest{})
+			return err
+		}).Should(Succeed())
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(4))
+		Expect(fakeClient.ListVolumesCallCount()).To(Equal(1))
+		Expect(client[resources.SCBE].(resources.HealthChecker).CheckHealth(context.Background())).To(Succeed())
+	})
+
+	It("Should stop retrying the initialization of an unavailable backend once stopped", func() {
+		fakeConfig = resources.UbiquityServerConfig{
+			ScbeConfig:  resources.ScbeConfig{ConnectionInfo: resources.ConnectionInfo{ManagementIP: "1.1.1.1"}},
+			BackendInit: resources.BackendInitConfig{RetryInterval: time.Millisecond, MaxRetryInterval: time.Millisecond},
+		}
+		var attempts int32
+		defer replaceStorageClientFactory(resources.SCBE, func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			atomic.AddInt32(&attempts, 1)
+			return nil, fmt.Errorf("SCBE Initialization failed")
+		})()
+
+		initStop := make(chan struct{})
+		client, err = local.GetLocalClients(logger, fakeConfig, initStop)
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(func() int32 { return atomic.LoadInt32(&attempts) }).Should(BeNumerically(">", 2))
+		close(initStop)
+
+		// an attempt that was running when stopped may still end
+		time.Sleep(10 * time.Millisecond)
+		stoppedAttempts := atomic.LoadInt32(&attempts)
+		Consistently(func() int32 { return atomic.LoadInt32(&attempts) }, 50*time.Millisecond).Should(Equal(stoppedAttempts))
+		Expect(client[resources.SCBE].(resources.HealthChecker).CheckHealth(context.Background())).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+	})
+
+	It("Should Fail when the config section of a backend is invalid", func() {
+		fakeConnectionInfo = resources.ConnectionInfo{}
+		fakeScbeConfig	   = resources.ScbeConfig{ConnectionInfo: fakeConnectionInfo, DefaultVolumeSize: "aaa"}
+		fakeScbeConfig.ConnectionInfo.ManagementIP="1.1.1.1"
+		fakeConfig = resources.UbiquityServerConfig{ScbeConfig: fakeScbeConfig}
+
+		client, err = local.GetLocalClients(logger, fakeConfig, stop)
+		Expect(err).To(HaveOccurred())
+		initializationError, ok := err.(*resources.BackendInitializationError)
+		Expect(ok).To(BeTrue())
+		Expect(initializationError.BackendName).To(Equal(resources.SCBE))
+	})
 	})
 })
+
+// replaceStorageClientFactory registers the backend with the given factory (and without config validation), it returns the function that restores the backend
+func replaceStorageClientFactory(name string, factory registry.StorageClientFactory) func() {
+	backend, _ := registry.GetBackend(name)
+	replaced := backend
+	replaced.ValidateConfig = nil
+	replaced.NewStorageClient = factory
+	registry.RegisterBackend(replaced)
+	return func() { registry.RegisterBackend(backend) }
+}
@@ -0,0 +1,215 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+// pendingStorageClient stands for a backend whose storage client failed to initialize on startup (e.g its storage system
+// is unreachable). It retries the initialization in the background, and answers with a BackendUnavailableError until it succeeds.
+type pendingStorageClient struct {
+	logger  logs.Logger
+	backend registry.Backend
+	name    string
+	config  resources.UbiquityServerConfig
+	lock    sync.RWMutex
+	ready   bool
+	client  resources.StorageClient
+	err     error
+}
+
+func newPendingStorageClient(logger logs.Logger, backend registry.Backend, name string, config resources.UbiquityServerConfig, err error) *pendingStorageClient {
+	return &pendingStorageClient{logger: logger, backend: backend, name: name, config: config, err: err}
+}
+
+// initialize retries the creation of the storage client until it succeeds or stop is closed, the retry interval doubles after
+// every failure up to its maximum. The interrupted operations of the backend are recovered before it serves requests.
+func (c *pendingStorageClient) initialize(retryConfig resources.BackendInitConfig, stop <-chan struct{}) {
+	interval := retryConfig.RetryInterval
+	if interval <= 0 {
+		interval = resources.DefaultBackendInitRetryInterval * time.Second
+	}
+	maxInterval := retryConfig.MaxRetryInterval
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	for {
+		select {
+		case <-stop:
+			c.logger.Info("Backend initialization stopped", logs.Args{{"backend", c.name}})
+			return
+		case <-time.After(interval):
+		}
+		client, err := c.backend.NewStorageClient(c.logger, c.name, c.config)
+		if err == nil {
+			c.logger.Info("Backend initialized", logs.Args{{"backend", c.name}})
+			RecoverOperations(c.logger, map[string]resources.StorageClient{c.name: client})
+			c.lock.Lock()
+			c.ready, c.client, c.err = true, client, nil
+			c.lock.Unlock()
+			return
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+		c.logger.Warning("Backend initialization failed, retrying", logs.Args{{"backend", c.name}, {"error", err}, {"retryInterval", interval}})
+		c.lock.Lock()
+		c.err = &resources.BackendInitializationError{BackendName: c.name, Err: err}
+		c.lock.Unlock()
+	}
+}
+
+func (c *pendingStorageClient) getClient() (resources.StorageClient, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if !c.ready {
+		return nil, &resources.BackendUnavailableError{Backend: c.name, Reason: c.err.Error()}
+	}
+	return c.client, nil
+}
+
+// CheckHealth reports the backend as unhealthy until it is initialized, and then checks its storage client
+func (c *pendingStorageClient) CheckHealth(ctx context.Context) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	healthChecker, ok := client.(resources.HealthChecker)
+	if !ok {
+		return nil
+	}
+	return healthChecker.CheckHealth(ctx)
+}
+
+func (c *pendingStorageClient) Activate(activateRequest resources.ActivateRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.Activate(activateRequest)
+}
+
+func (c *pendingStorageClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateVolume(createVolumeRequest)
+}
+
+func (c *pendingStorageClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.RemoveVolume(removeVolumeRequest)
+}
+
+func (c *pendingStorageClient) ListVolumes(listVolumeRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListVolumes(listVolumeRequest)
+}
+
+func (c *pendingStorageClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (resources.Volume, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return resources.Volume{}, err
+	}
+	return client.GetVolume(getVolumeRequest)
+}
+
+func (c *pendingStorageClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (map[string]interface{}, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetVolumeConfig(getVolumeConfigRequest)
+}
+
+func (c *pendingStorageClient) Attach(attachRequest resources.AttachRequest) (string, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return "", err
+	}
+	return client.Attach(attachRequest)
+}
+
+func (c *pendingStorageClient) Detach(detachRequest resources.DetachRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.Detach(detachRequest)
+}
+
+func (c *pendingStorageClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.ExpandVolume(expandVolumeRequest)
+}
+
+func (c *pendingStorageClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateSnapshot(createSnapshotRequest)
+}
+
+func (c *pendingStorageClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListSnapshots(listSnapshotsRequest)
+}
+
+func (c *pendingStorageClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	return client.DeleteSnapshot(deleteSnapshotRequest)
+}
+
+func (c *pendingStorageClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListServices(listServicesRequest)
+}
+
+func (c *pendingStorageClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return resources.ReconcileReport{}, err
+	}
+	return client.Reconcile(reconcileRequest)
+}
@@ -0,0 +1,75 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scbe
+
+import (
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+// hostLockNamespace scopes the names of the host locks of an SCBE instance, the LUN IDs are allocated per storage system
+const hostLockNamespace = "scbe-host/"
+
+// scbeVolumeV1 is a frozen copy of ScbeVolume at version 1 of the schema, so the migration keeps creating the same table
+type scbeVolumeV1 struct {
+	ID            uint
+	VolumeID      uint
+	WWN           string
+	FSType        string
+	IsPreexisting bool
+}
+
+func (scbeVolumeV1) TableName() string {
+	return "scbe_volumes"
+}
+
+func init() {
+	registry.RegisterBackend(registry.Backend{
+		Name: resources.SCBE,
+		IsConfigured: func(config resources.UbiquityServerConfig) bool {
+			return config.ScbeConfig.ConnectionInfo.ManagementIP != ""
+		},
+		ValidateConfig: func(config resources.UbiquityServerConfig) error {
+			scbeConfig := config.ScbeConfig
+			return validateScbeConfig(&scbeConfig)
+		},
+		Instances: func(config resources.UbiquityServerConfig) map[string]resources.UbiquityServerConfig {
+			instances := make(map[string]resources.UbiquityServerConfig)
+			for name, scbeConfig := range config.ScbeInstances {
+				instanceConfig := config
+				instanceConfig.ScbeConfig = scbeConfig
+				instances[name] = instanceConfig
+			}
+			return instances
+		},
+		NewStorageClient: func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			return newScbeLocalClient(config.ScbeConfig, name, database.NewLocker(hostLockNamespace+name, config.Locker))
+		},
+		VolumeTable: &ScbeVolume{},
+	})
+
+	database.RegisterMigrations(resources.SCBE, database.Migration{
+		Version:     1,
+		Description: "create the scbe_volumes table",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&scbeVolumeV1{}).Error
+		},
+	})
+}
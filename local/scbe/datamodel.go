@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/IBM/ubiquity/model"
 	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/jinzhu/gorm"
 )
@@ -27,9 +28,16 @@ import (
 //go:generate counterfeiter -o ../../fakes/fake_ScbeDataModel.go . ScbeDataModel
 type ScbeDataModel interface {
 	DeleteVolume(name string) error
-	InsertVolume(volumeName string, wwn string, fstype string) error
+	InsertVolume(volumeName string, wwn string, fstype string, isPreexisting bool, labels map[string]string) error
+	InsertClonedVolume(volumeName string, wwn string, fstype string, sourceVolume string, sourceSnapshot string, labels map[string]string) error
 	GetVolume(name string) (ScbeVolume, bool, error)
-	ListVolumes() ([]ScbeVolume, error)
+	GetVolumeByWwn(wwn string) (ScbeVolume, bool, error)
+	UpdateVolumeAttachedHost(name string, host string) error
+	ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]ScbeVolume, error)
+	InsertSnapshot(volumeName string, name string, storageId string) error
+	GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error)
+	ListSnapshots(volumeName string) ([]resources.Snapshot, error)
+	DeleteSnapshot(volumeName string, name string) error
 }
 
 type scbeDataModel struct {
@@ -39,15 +47,16 @@ type scbeDataModel struct {
 }
 
 type ScbeVolume struct {
-	ID       uint
-	Volume   resources.Volume
-	VolumeID uint
-	WWN      string
-	FSType   string
+	ID            uint
+	Volume        resources.Volume
+	VolumeID      uint
+	WWN           string
+	FSType        string
+	IsPreexisting bool
 }
 
-func NewScbeDataModel(db *gorm.DB) ScbeDataModel {
-	return &scbeDataModel{logger: logs.GetLogger(), database: db, backend: resources.SCBE}
+func NewScbeDataModel(db *gorm.DB, backend string) ScbeDataModel {
+	return &scbeDataModel{logger: logs.GetLogger(), database: db, backend: backend}
 }
 
 // DeleteVolume if vol exist in DB then delete it (both in the generic table and the specific one)
@@ -73,20 +82,61 @@ func (d *scbeDataModel) DeleteVolume(name string) error {
 	return nil
 }
 
-// InsertVolume volume name and its details given in opts
-func (d *scbeDataModel) InsertVolume(volumeName string, wwn string, fstype string) error {
+// InsertVolume volume name and its details given in opts, a preexisting volume was adopted rather than provisioned by ubiquity
+func (d *scbeDataModel) InsertVolume(volumeName string, wwn string, fstype string, isPreexisting bool, labels map[string]string) error {
 	defer d.logger.Trace(logs.DEBUG)()
 
 	volume := ScbeVolume{
 		Volume: resources.Volume{Name: volumeName,
-			Backend: fmt.Sprintf("%s", d.backend)},
+			Backend:     fmt.Sprintf("%s", d.backend),
+			BackendType: resources.SCBE,
+			Labels:      utils.EncodeLabels(labels)},
+		WWN:           wwn,
+		FSType:        fstype,
+		IsPreexisting: isPreexisting,
+	}
+
+	return d.insertVolume(volume)
+}
+
+// InsertClonedVolume volume name and its details, together with the volume (or the volume snapshot) it was cloned from
+func (d *scbeDataModel) InsertClonedVolume(volumeName string, wwn string, fstype string, sourceVolume string, sourceSnapshot string, labels map[string]string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	volume := ScbeVolume{
+		Volume: resources.Volume{Name: volumeName,
+			Backend:        d.backend,
+			BackendType:    resources.SCBE,
+			SourceVolume:   sourceVolume,
+			SourceSnapshot: sourceSnapshot,
+			Labels:         utils.EncodeLabels(labels)},
 		WWN:    wwn,
 		FSType: fstype,
 	}
 
-	if err := d.database.Create(&volume).Error; err != nil {
+	return d.insertVolume(volume)
+}
+
+// insertVolume inserts the volume, in place of its creating record if there is one.
+// Both are done in a single transaction, so a creating record is never claimed without its scbe volume.
+func (d *scbeDataModel) insertVolume(volume ScbeVolume) error {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	tx := d.database.Begin()
+	if tx.Error != nil {
+		return d.logger.ErrorRet(tx.Error, "database.Begin failed")
+	}
+	if err := model.ClaimCreatingVolume(tx, &volume.Volume); err != nil {
+		tx.Rollback()
+		return d.logger.ErrorRet(err, "model.ClaimCreatingVolume failed")
+	}
+	if err := tx.Create(&volume).Error; err != nil {
+		tx.Rollback()
 		return d.logger.ErrorRet(err, "database.Create failed")
 	}
+	if err := tx.Commit().Error; err != nil {
+		return d.logger.ErrorRet(err, "database.Commit failed")
+	}
 	return nil
 }
 
@@ -114,20 +164,108 @@ func (d *scbeDataModel) GetVolume(name string) (ScbeVolume, bool, error) {
 	return scbeVolume, true, nil
 }
 
-func (d *scbeDataModel) ListVolumes() ([]ScbeVolume, error) {
+// GetVolumeByWwn returns the volume of the WWN (compared case insensitively) if it exists in the DB, in any SCBE backend,
+// since several backends may manage the same storage system.
+func (d *scbeDataModel) GetVolumeByWwn(wwn string) (ScbeVolume, bool, error) {
 	defer d.logger.Trace(logs.DEBUG)()
 
-	var volumesInDb []ScbeVolume
-	if err := d.database.Preload("Volume").Find(&volumesInDb).Error; err != nil {
-		return nil, d.logger.ErrorRet(err, "failed")
+	var scbeVolume ScbeVolume
+	if err := d.database.Where("lower(wwn) = lower(?)", wwn).Preload("Volume").First(&scbeVolume).Error; err != nil {
+		if err.Error() == "record not found" {
+			return ScbeVolume{}, false, nil
+		}
+		return ScbeVolume{}, false, d.logger.ErrorRet(err, "failed")
+	}
+	return scbeVolume, true, nil
+}
+
+// ListVolumes returns the volumes of the backend that match the filter of the request, sorted and paged as requested
+func (d *scbeDataModel) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]ScbeVolume, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	query := d.database.Select("scbe_volumes.*").Joins("JOIN volumes ON volumes.id = scbe_volumes.volume_id")
+	query, err := model.FilterVolumes(query, d.backend, listVolumesRequest)
+	if err != nil {
+		return nil, d.logger.ErrorRet(err, "model.FilterVolumes failed")
 	}
-	// hack: to be replaced by proper DB filtering (joins)
+
 	var volumes []ScbeVolume
-	for _, volume := range volumesInDb {
-		if volume.Volume.Backend == d.backend {
-			volumes = append(volumes, volume)
+	if err := query.Preload("Volume").Find(&volumes).Error; err != nil {
+		return nil, d.logger.ErrorRet(err, "failed")
+	}
+	return volumes, nil
+}
+
+// UpdateVolumeAttachedHost records the host the volume is attached to, an empty host means the volume is detached
+func (d *scbeDataModel) UpdateVolumeAttachedHost(name string, host string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	volume, err := model.GetVolume(d.database, name, d.backend)
+	if err != nil {
+		return d.logger.ErrorRet(err, "model.GetVolume failed")
+	}
+	if err = model.UpdateVolumeAttachedHost(d.database, &volume, host); err != nil {
+		return d.logger.ErrorRet(err, "model.UpdateVolumeAttachedHost failed")
+	}
+	return nil
+}
+
+// InsertSnapshot snapshot name of the given volume and its id on the storage
+func (d *scbeDataModel) InsertSnapshot(volumeName string, name string, storageId string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	snapshot := resources.Snapshot{
+		Name:       name,
+		VolumeName: volumeName,
+		Backend:    d.backend,
+		StorageId:  storageId,
+	}
+
+	if err := d.database.Create(&snapshot).Error; err != nil {
+		return d.logger.ErrorRet(err, "database.Create failed")
+	}
+	return nil
+}
+
+// GetSnapshot return the snapshot of the volume if exist in DB,
+// if snapshot not found then return false\nil, but if failed to find it due to error return false\error.
+func (d *scbeDataModel) GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	snapshot, err := model.GetSnapshot(d.database, volumeName, name, d.backend)
+	if err != nil {
+		if err.Error() == "record not found" {
+			return resources.Snapshot{}, false, nil
 		}
+		return resources.Snapshot{}, false, d.logger.ErrorRet(err, "model.GetSnapshot failed")
 	}
+	return snapshot, true, nil
+}
 
-	return volumes, nil
+func (d *scbeDataModel) ListSnapshots(volumeName string) ([]resources.Snapshot, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	snapshots, err := model.ListSnapshots(d.database, volumeName, d.backend)
+	if err != nil {
+		return nil, d.logger.ErrorRet(err, "model.ListSnapshots failed")
+	}
+	return snapshots, nil
+}
+
+// DeleteSnapshot if the snapshot exist in DB then delete it
+func (d *scbeDataModel) DeleteSnapshot(volumeName string, name string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	snapshot, exists, err := d.GetSnapshot(volumeName, name)
+	if err != nil {
+		return err
+	}
+	if exists == false {
+		return d.logger.ErrorRet(&resources.SnapshotNotFoundError{VolName: volumeName, SnapName: name}, "failed")
+	}
+
+	if err := model.DeleteSnapshot(d.database, &snapshot).Error; err != nil {
+		return d.logger.ErrorRet(err, "model.DeleteSnapshot failed")
+	}
+	return nil
 }
@@ -0,0 +1,103 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scbe_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/local/scbe"
+	"github.com/IBM/ubiquity/model"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScbeDataModel", func() {
+	var (
+		dir          string
+		cleanup      func()
+		dbConnection database.Connection
+		db           *gorm.DB
+		datamodel    scbe.ScbeDataModel
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ubiquity-scbe")
+		Expect(err).ToNot(HaveOccurred())
+		cleanup = database.InitSqlite(filepath.Join(dir, "ubiquity.db"))
+		dbConnection = database.NewConnection()
+		Expect(dbConnection.Open()).To(Succeed())
+		db = dbConnection.GetDb()
+		datamodel = scbe.NewScbeDataModel(db, resources.SCBE)
+	})
+	AfterEach(func() {
+		dbConnection.Close()
+		cleanup()
+		os.RemoveAll(dir)
+	})
+
+	Context(".InsertVolume", func() {
+		It("should replace the creating record of the volume", func() {
+			Expect(model.InsertCreatingVolume(db, &resources.Volume{Name: "vol1", Backend: resources.SCBE})).To(Succeed())
+			Expect(datamodel.InsertVolume("vol1", "wwn1", "ext4", false, nil)).To(Succeed())
+
+			volume, exists, err := datamodel.GetVolume("vol1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(volume.WWN).To(Equal("wwn1"))
+			Expect(volume.Volume.Status).To(Equal(resources.VolumeStatusAvailable))
+		})
+		It("should not claim the creating record if the scbe volume insert fails", func() {
+			Expect(model.InsertCreatingVolume(db, &resources.Volume{Name: "vol1", Backend: resources.SCBE})).To(Succeed())
+			Expect(db.DropTable(&scbe.ScbeVolume{}).Error).ToNot(HaveOccurred())
+			Expect(datamodel.InsertVolume("vol1", "wwn1", "ext4", false, nil)).ToNot(Succeed())
+
+			var volumes []resources.Volume
+			Expect(db.Where("name = ?", "vol1").Find(&volumes).Error).ToNot(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Status).To(Equal(resources.VolumeStatusCreating))
+		})
+		It("should not leave a volume behind if the scbe volume insert fails", func() {
+			Expect(db.DropTable(&scbe.ScbeVolume{}).Error).ToNot(HaveOccurred())
+			Expect(datamodel.InsertVolume("vol1", "wwn1", "ext4", false, nil)).ToNot(Succeed())
+
+			var count int
+			Expect(db.Model(&resources.Volume{}).Where("name = ?", "vol1").Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+	})
+
+	Context(".GetVolumeByWwn", func() {
+		It("should find the volume of the wwn in any scbe backend", func() {
+			Expect(scbe.NewScbeDataModel(db, "scbe-other").InsertVolume("vol1", "WWN1", "ext4", false, nil)).To(Succeed())
+
+			volume, exists, err := datamodel.GetVolumeByWwn("wwn1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(volume.Volume.Name).To(Equal("vol1"))
+			Expect(volume.Volume.Backend).To(Equal("scbe-other"))
+
+			_, exists, err = datamodel.GetVolumeByWwn("wwn2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+})
@@ -17,8 +17,11 @@
 package scbe
 
 import (
+	"strings"
+
 	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
 )
 
@@ -26,25 +29,35 @@ import (
 type ScbeDataModelWrapper interface {
 	GetVolume(name string, mustExist bool) (ScbeVolume, error)
 	DeleteVolume(name string) error
-	InsertVolume(volumeName string, wwn string, fstype string) error
-	ListVolumes() ([]ScbeVolume, error)
+	InsertVolume(volumeName string, wwn string, fstype string, isPreexisting bool, labels map[string]string) error
+	InsertClonedVolume(volumeName string, wwn string, fstype string, sourceVolume string, sourceSnapshot string, labels map[string]string) error
+	UpdateVolumeAttachedHost(name string, host string) error
+	ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]ScbeVolume, error)
+	ListStoredVolumes() ([]ScbeVolume, error)
+	GetVolumeByWwn(wwn string) (ScbeVolume, bool, error)
 	UpdateDatabaseVolume(newVolume *ScbeVolume)
+	InsertSnapshot(volumeName string, name string, storageId string) error
+	GetSnapshot(volumeName string, name string, mustExist bool) (resources.Snapshot, error)
+	ListSnapshots(volumeName string) ([]resources.Snapshot, error)
+	DeleteSnapshot(volumeName string, name string) error
 }
 
 type scbeDataModelWrapper struct {
 	logger   logs.Logger
 	dbVolume *ScbeVolume
+	backend  string
 }
 
-func NewScbeDataModelWrapper() ScbeDataModelWrapper {
-	database.RegisterMigration(resources.Volume{})
-	database.RegisterMigration(&ScbeVolume{})
-	return &scbeDataModelWrapper{logger: logs.GetLogger()}
+func NewScbeDataModelWrapper(backend string) ScbeDataModelWrapper {
+	return &scbeDataModelWrapper{logger: logs.GetLogger(), backend: backend}
 }
 
 func (d *scbeDataModelWrapper) UpdateDatabaseVolume(newVolume *ScbeVolume) {
 	defer d.logger.Trace(logs.DEBUG)()
 	d.logger.Debug("", logs.Args{{"dbVolume", d.dbVolume}, {"newVolume", newVolume}})
+	if newVolume != nil && newVolume.Volume.Status == "" {
+		newVolume.Volume.Status = resources.VolumeStatusAvailable
+	}
 	d.dbVolume = newVolume
 }
 
@@ -72,7 +85,7 @@ func (d *scbeDataModelWrapper) GetVolume(name string, mustExist bool) (ScbeVolum
 		defer dbConnection.Close()
 
 		// get volume
-		dataModel := NewScbeDataModel(dbConnection.GetDb())
+		dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
 		if volume, exists, err = dataModel.GetVolume(name); err != nil {
 			return ScbeVolume{}, d.logger.ErrorRet(err, "dataModel.GetVolume failed")
 		}
@@ -114,7 +127,7 @@ func (d *scbeDataModelWrapper) DeleteVolume(name string) error {
 		defer dbConnection.Close()
 
 		// delete volume
-		dataModel := NewScbeDataModel(dbConnection.GetDb())
+		dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
 		if err = dataModel.DeleteVolume(name); err != nil {
 			return d.logger.ErrorRet(err, "dataModel.DeleteVolume failed")
 		}
@@ -123,7 +136,7 @@ func (d *scbeDataModelWrapper) DeleteVolume(name string) error {
 	return nil
 }
 
-func (d *scbeDataModelWrapper) InsertVolume(volumeName string, wwn string, fstype string) error {
+func (d *scbeDataModelWrapper) InsertVolume(volumeName string, wwn string, fstype string, isPreexisting bool, labels map[string]string) error {
 	defer d.logger.Trace(logs.DEBUG)()
 	var err error
 
@@ -135,7 +148,7 @@ func (d *scbeDataModelWrapper) InsertVolume(volumeName string, wwn string, fstyp
 		}
 
 		// work with memory object
-		d.UpdateDatabaseVolume(&ScbeVolume{Volume: resources.Volume{Name: volumeName, Backend: resources.SCBE}, WWN: wwn, FSType: fstype})
+		d.UpdateDatabaseVolume(&ScbeVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend, BackendType: resources.SCBE, Labels: utils.EncodeLabels(labels)}, WWN: wwn, FSType: fstype, IsPreexisting: isPreexisting})
 
 	} else {
 
@@ -147,8 +160,8 @@ func (d *scbeDataModelWrapper) InsertVolume(volumeName string, wwn string, fstyp
 		defer dbConnection.Close()
 
 		// insert volume
-		dataModel := NewScbeDataModel(dbConnection.GetDb())
-		if err = dataModel.InsertVolume(volumeName, wwn, fstype); err != nil {
+		dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+		if err = dataModel.InsertVolume(volumeName, wwn, fstype, isPreexisting, labels); err != nil {
 			return d.logger.ErrorRet(err, "dataModel.InsertVolume failed")
 		}
 	}
@@ -156,7 +169,67 @@ func (d *scbeDataModelWrapper) InsertVolume(volumeName string, wwn string, fstyp
 	return nil
 }
 
-func (d *scbeDataModelWrapper) ListVolumes() ([]ScbeVolume, error) {
+func (d *scbeDataModelWrapper) InsertClonedVolume(volumeName string, wwn string, fstype string, sourceVolume string, sourceSnapshot string, labels map[string]string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	// the db volume is kept in memory and cannot be a clone
+	if database.IsDatabaseVolume(volumeName) {
+		return d.logger.ErrorRet(&resources.CloneNotSupportedForVolumeError{VolName: volumeName}, "failed")
+	}
+
+	// open db connection
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	// insert volume
+	dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+	if err = dataModel.InsertClonedVolume(volumeName, wwn, fstype, sourceVolume, sourceSnapshot, labels); err != nil {
+		return d.logger.ErrorRet(err, "dataModel.InsertClonedVolume failed")
+	}
+
+	return nil
+}
+
+func (d *scbeDataModelWrapper) UpdateVolumeAttachedHost(name string, host string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	if database.IsDatabaseVolume(name) {
+
+		// work with memory object
+		if d.dbVolume == nil {
+			return d.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: name}, "failed")
+		}
+		d.dbVolume.Volume.AttachedHost = host
+		d.dbVolume.Volume.Status = resources.VolumeStatusAvailable
+		if host != "" {
+			d.dbVolume.Volume.Status = resources.VolumeStatusAttached
+		}
+
+	} else {
+
+		// open db connection
+		dbConnection := database.NewConnection()
+		if err = dbConnection.Open(); err != nil {
+			return d.logger.ErrorRet(err, "dbConnection.Open failed")
+		}
+		defer dbConnection.Close()
+
+		// update volume
+		dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+		if err = dataModel.UpdateVolumeAttachedHost(name, host); err != nil {
+			return d.logger.ErrorRet(err, "dataModel.UpdateVolumeAttachedHost failed")
+		}
+	}
+
+	return nil
+}
+
+func (d *scbeDataModelWrapper) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]ScbeVolume, error) {
 	defer d.logger.Trace(logs.DEBUG)()
 	var err error
 	var volumes []ScbeVolume
@@ -168,15 +241,166 @@ func (d *scbeDataModelWrapper) ListVolumes() ([]ScbeVolume, error) {
 		defer dbConnection.Close()
 
 		// list volumes
-		dataModel := NewScbeDataModel(dbConnection.GetDb())
-		if volumes, err = dataModel.ListVolumes(); err != nil {
+		dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+		if volumes, err = dataModel.ListVolumes(listVolumesRequest); err != nil {
 			return nil, d.logger.ErrorRet(err, "dataModel.ListVolumes failed")
 		}
 	}
 
-	if d.dbVolume != nil {
+	// the db volume is kept in memory, so it is filtered here
+	if d.dbVolume != nil && utils.VolumeMatchesListRequest(d.dbVolume.Volume, listVolumesRequest) {
 		volumes = append(volumes, *d.dbVolume)
 	}
 
 	return volumes, nil
 }
+
+// ListStoredVolumes returns all the volumes of the backend in the Ubiquity DB, without the in-memory db volume.
+// Unlike ListVolumes it fails if the DB cannot be opened, since its callers compare the volumes with the storage.
+func (d *scbeDataModelWrapper) ListStoredVolumes() ([]ScbeVolume, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return nil, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	volumes, err := NewScbeDataModel(dbConnection.GetDb(), d.backend).ListVolumes(resources.ListVolumesRequest{})
+	if err != nil {
+		return nil, d.logger.ErrorRet(err, "dataModel.ListVolumes failed")
+	}
+	return volumes, nil
+}
+
+// GetVolumeByWwn returns the volume of the WWN in any SCBE backend, or the db volume of this backend.
+// It fails if the DB cannot be opened, since its callers must not take a volume that may be managed already.
+func (d *scbeDataModelWrapper) GetVolumeByWwn(wwn string) (ScbeVolume, bool, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	if d.dbVolume != nil && strings.EqualFold(d.dbVolume.WWN, wwn) {
+		return *d.dbVolume, true, nil
+	}
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return ScbeVolume{}, false, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	volume, exists, err := NewScbeDataModel(dbConnection.GetDb(), d.backend).GetVolumeByWwn(wwn)
+	if err != nil {
+		return ScbeVolume{}, false, d.logger.ErrorRet(err, "dataModel.GetVolumeByWwn failed")
+	}
+	return volume, exists, nil
+}
+
+func (d *scbeDataModelWrapper) InsertSnapshot(volumeName string, name string, storageId string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	// the db volume is kept in memory and has no snapshots
+	if database.IsDatabaseVolume(volumeName) {
+		return d.logger.ErrorRet(&resources.SnapshotNotSupportedForVolumeError{VolName: volumeName}, "failed")
+	}
+
+	// open db connection
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	// insert snapshot
+	dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+	if err = dataModel.InsertSnapshot(volumeName, name, storageId); err != nil {
+		return d.logger.ErrorRet(err, "dataModel.InsertSnapshot failed")
+	}
+
+	return nil
+}
+
+func (d *scbeDataModelWrapper) GetSnapshot(volumeName string, name string, mustExist bool) (resources.Snapshot, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+	var snapshot resources.Snapshot
+	var exists bool
+
+	if database.IsDatabaseVolume(volumeName) {
+		return resources.Snapshot{}, d.logger.ErrorRet(&resources.SnapshotNotSupportedForVolumeError{VolName: volumeName}, "failed")
+	}
+
+	// open db connection
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return resources.Snapshot{}, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	// get snapshot
+	dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+	if snapshot, exists, err = dataModel.GetSnapshot(volumeName, name); err != nil {
+		return resources.Snapshot{}, d.logger.ErrorRet(err, "dataModel.GetSnapshot failed")
+	}
+
+	// verify existence
+	if mustExist != exists {
+		if exists {
+			err = &resources.SnapshotAlreadyExistsError{VolName: volumeName, SnapName: name}
+		} else {
+			err = &resources.SnapshotNotFoundError{VolName: volumeName, SnapName: name}
+		}
+		return resources.Snapshot{}, d.logger.ErrorRet(err, "failed", logs.Args{{"mustExist", mustExist}, {"exists", exists}})
+	}
+
+	return snapshot, nil
+}
+
+func (d *scbeDataModelWrapper) ListSnapshots(volumeName string) ([]resources.Snapshot, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+	var snapshots []resources.Snapshot
+
+	if database.IsDatabaseVolume(volumeName) {
+		return snapshots, nil
+	}
+
+	// open db connection
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return nil, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	// list snapshots
+	dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+	if snapshots, err = dataModel.ListSnapshots(volumeName); err != nil {
+		return nil, d.logger.ErrorRet(err, "dataModel.ListSnapshots failed")
+	}
+
+	return snapshots, nil
+}
+
+func (d *scbeDataModelWrapper) DeleteSnapshot(volumeName string, name string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	if database.IsDatabaseVolume(volumeName) {
+		return d.logger.ErrorRet(&resources.SnapshotNotSupportedForVolumeError{VolName: volumeName}, "failed")
+	}
+
+	// open db connection
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	// delete snapshot
+	dataModel := NewScbeDataModel(dbConnection.GetDb(), d.backend)
+	if err = dataModel.DeleteSnapshot(volumeName, name); err != nil {
+		return d.logger.ErrorRet(err, "dataModel.DeleteSnapshot failed")
+	}
+
+	return nil
+}
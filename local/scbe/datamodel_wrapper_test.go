@@ -19,6 +19,7 @@ package scbe_test
 import (
 	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/local/scbe"
+	"github.com/IBM/ubiquity/resources"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -38,24 +39,21 @@ var _ = Describe("ScbeDataModelWrapper test", func() {
 	)
 
 	BeforeEach(func() {
-		dataModelWrapper = scbe.NewScbeDataModelWrapper()
-	})
-	AfterEach(func() {
-		database.UnregisterAllMigrations()
+		dataModelWrapper = scbe.NewScbeDataModelWrapper(resources.SCBE)
 	})
 
 	Context("Database cannot be accessed yet", func() {
 		Context("InsertVolume", func() {
 			It("succeed for db volume", func() {
 				defer database.InitTestError()()
-				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb)
+				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb, false, nil)
 				Expect(err).To(Not(HaveOccurred()))
 				scbeVolume, err = dataModelWrapper.GetVolume(volumeNameDb, true)
 				Expect(err).To(Not(HaveOccurred()))
 			})
 			It("fail for non db volume", func() {
 				defer database.InitTestError()()
-				err = dataModelWrapper.InsertVolume(volumeName, volumeWwn, volumeFsType)
+				err = dataModelWrapper.InsertVolume(volumeName, volumeWwn, volumeFsType, false, nil)
 				Expect(err).To(HaveOccurred())
 				scbeVolume, err = dataModelWrapper.GetVolume(volumeName, true)
 				Expect(err).To(HaveOccurred())
@@ -64,7 +62,7 @@ var _ = Describe("ScbeDataModelWrapper test", func() {
 		Context("DeleteVolume", func() {
 			It("succeed for db volume", func() {
 				defer database.InitTestError()()
-				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb)
+				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb, false, nil)
 				Expect(err).To(Not(HaveOccurred()))
 				scbeVolume, err = dataModelWrapper.GetVolume(volumeNameDb, true)
 				Expect(err).To(Not(HaveOccurred()))
@@ -78,10 +76,28 @@ var _ = Describe("ScbeDataModelWrapper test", func() {
 				Expect(err).To(Not(HaveOccurred()))
 			})
 		})
+		Context("ListVolumes", func() {
+			It("filters the db volume by the request", func() {
+				defer database.InitTestError()()
+				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb, false, map[string]string{"app": "ubiquity"})
+				Expect(err).To(Not(HaveOccurred()))
+				err = dataModelWrapper.UpdateVolumeAttachedHost(volumeNameDb, "host1")
+				Expect(err).To(Not(HaveOccurred()))
+				volumes, err := dataModelWrapper.ListVolumes(resources.ListVolumesRequest{NamePrefix: volumeName, Labels: map[string]string{"app": "ubiquity"}, Host: "host1"})
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(len(volumes)).To(Equal(1))
+				volumes, err = dataModelWrapper.ListVolumes(resources.ListVolumesRequest{Labels: map[string]string{"app": "other"}})
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(len(volumes)).To(Equal(0))
+				volumes, err = dataModelWrapper.ListVolumes(resources.ListVolumesRequest{Host: "host2"})
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(len(volumes)).To(Equal(0))
+			})
+		})
 		Context("UpdateDatabaseVolume", func() {
 			It("succeed", func() {
 				defer database.InitTestError()()
-				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb)
+				err = dataModelWrapper.InsertVolume(volumeNameDb, volumeWwnDb, volumeFsTypeDb, false, nil)
 				Expect(err).To(Not(HaveOccurred()))
 				scbeVolume, err = dataModelWrapper.GetVolume(volumeNameDb, true)
 				Expect(err).To(Not(HaveOccurred()))
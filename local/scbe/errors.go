@@ -18,6 +18,9 @@ package scbe
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
+
 	"github.com/IBM/ubiquity/resources"
 )
 
@@ -81,6 +84,112 @@ func (e *FsTypeNotSupportedError) Error() string {
 		e.volName, e.supportedTypes, e.wrongFStype)
 }
 
+func (e *FsTypeNotSupportedError) ErrorCode() string { return resources.ErrorCodeFsTypeNotSupported }
+func (e *FsTypeNotSupportedError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *FsTypeNotSupportedError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "fstype": e.wrongFStype}
+}
+
+type cloneFsTypeMismatchError struct {
+	volName         string
+	fstype          string
+	sourceVolName   string
+	sourceVolFstype string
+}
+
+func (e *cloneFsTypeMismatchError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure due to file system type [%s], the source volume [%s] has file system type [%s]",
+		e.volName, e.fstype, e.sourceVolName, e.sourceVolFstype)
+}
+
+func (e *cloneFsTypeMismatchError) ErrorCode() string { return resources.ErrorCodeFsTypeNotSupported }
+func (e *cloneFsTypeMismatchError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *cloneFsTypeMismatchError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "fstype": e.fstype, "source-volume": e.sourceVolName}
+}
+
+type adoptConflictingOptionsError struct {
+	volName string
+	option  string
+}
+
+func (e *adoptConflictingOptionsError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure, an existing volume is adopted either by option [%s] or by option [%s] and cannot be combined with option [%s]",
+		e.volName, OptionNameForVolumeWwn, OptionNameForScVolume, e.option)
+}
+
+func (e *adoptConflictingOptionsError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *adoptConflictingOptionsError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *adoptConflictingOptionsError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "param": e.option}
+}
+
+type adoptNotSupportedError struct {
+	volName string
+}
+
+func (e *adoptNotSupportedError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure, the volume cannot be adopted from an existing volume", e.volName)
+}
+
+func (e *adoptNotSupportedError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *adoptNotSupportedError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *adoptNotSupportedError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName}
+}
+
+type adoptAmbiguousVolumeError struct {
+	volName    string
+	identifier string
+	found      int
+}
+
+func (e *adoptAmbiguousVolumeError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure, [%d] volumes named [%s] were found on the "+ScName+" interface",
+		e.volName, e.found, e.identifier)
+}
+
+func (e *adoptAmbiguousVolumeError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *adoptAmbiguousVolumeError) HttpStatus() int   { return http.StatusConflict }
+func (e *adoptAmbiguousVolumeError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "storage-volume": e.identifier}
+}
+
+type adoptServiceMismatchError struct {
+	volName         string
+	scVolName       string
+	scVolProfile    string
+	expectedProfile string
+}
+
+func (e *adoptServiceMismatchError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure, the existing volume [%s] belongs to service [%s] and not to service [%s]",
+		e.volName, e.scVolName, e.scVolProfile, e.expectedProfile)
+}
+
+func (e *adoptServiceMismatchError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *adoptServiceMismatchError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *adoptServiceMismatchError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "storage-volume": e.scVolName, "profile": e.scVolProfile}
+}
+
+type adoptAlreadyManagedError struct {
+	volName        string
+	wwn            string
+	managedVolName string
+}
+
+func (e *adoptAlreadyManagedError) Error() string {
+	return fmt.Sprintf("Volume [%s] provisioning failure, the existing volume [%s] is already managed as volume [%s]",
+		e.volName, e.wwn, e.managedVolName)
+}
+
+func (e *adoptAlreadyManagedError) ErrorCode() string { return resources.ErrorCodeVolumeAlreadyExists }
+func (e *adoptAlreadyManagedError) HttpStatus() int   { return http.StatusConflict }
+func (e *adoptAlreadyManagedError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "wwn": e.wwn, "managed-volume": e.managedVolName}
+}
+
 type provisionParamIsNotNumberError struct {
 	volName string
 	param   string
@@ -91,6 +200,23 @@ func (e *provisionParamIsNotNumberError) Error() string {
 		e.volName, e.param)
 }
 
+func (e *provisionParamIsNotNumberError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *provisionParamIsNotNumberError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *provisionParamIsNotNumberError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "param": e.param}
+}
+
+type expandSizeNotBiggerError struct {
+	volName     string
+	size        int
+	currentSize uint64
+}
+
+func (e *expandSizeNotBiggerError) Error() string {
+	return fmt.Sprintf("Volume [%s] expansion failure. The requested size [%d%s] must be bigger than the current size [%d bytes]",
+		e.volName, e.size, DefaultSizeUnit, e.currentSize)
+}
+
 type volAlreadyAttachedError struct {
 	volName  string
 	hostName string
@@ -100,6 +226,12 @@ func (e *volAlreadyAttachedError) Error() string {
 	return fmt.Sprintf("Volume [%s] already attached to [%s]", e.volName, e.hostName)
 }
 
+func (e *volAlreadyAttachedError) ErrorCode() string { return resources.ErrorCodeVolumeAlreadyAttached }
+func (e *volAlreadyAttachedError) HttpStatus() int   { return http.StatusConflict }
+func (e *volAlreadyAttachedError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "host": e.hostName}
+}
+
 type CannotDeleteVolWhichAttachedToHostError struct {
 	volName  string
 	hostName string
@@ -110,6 +242,14 @@ func (e *CannotDeleteVolWhichAttachedToHostError) Error() string {
 		e.volName, e.hostName)
 }
 
+func (e *CannotDeleteVolWhichAttachedToHostError) ErrorCode() string {
+	return resources.ErrorCodeVolumeAttached
+}
+func (e *CannotDeleteVolWhichAttachedToHostError) HttpStatus() int { return http.StatusConflict }
+func (e *CannotDeleteVolWhichAttachedToHostError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "host": e.hostName}
+}
+
 type volNotAttachedError struct {
 	volName string
 }
@@ -155,6 +295,33 @@ func (e *VolumeNameExceededMaxLengthError) Error() string {
 		e.volName, len(e.volName), e.maxVolumeLength)
 }
 
+func (e *VolumeNameExceededMaxLengthError) ErrorCode() string {
+	return resources.ErrorCodeInvalidRequest
+}
+func (e *VolumeNameExceededMaxLengthError) HttpStatus() int { return http.StatusBadRequest }
+func (e *VolumeNameExceededMaxLengthError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName}
+}
+
+type SnapshotNameExceededMaxLengthError struct {
+	volName           string
+	snapName          string
+	maxSnapshotLength int
+}
+
+func (e *SnapshotNameExceededMaxLengthError) Error() string {
+	return fmt.Sprintf("The snapshot name [%s] of volume [%s] is out of range. The max length of the volume and snapshot names together is [%d] characters",
+		e.snapName, e.volName, e.maxSnapshotLength)
+}
+
+func (e *SnapshotNameExceededMaxLengthError) ErrorCode() string {
+	return resources.ErrorCodeInvalidRequest
+}
+func (e *SnapshotNameExceededMaxLengthError) HttpStatus() int { return http.StatusBadRequest }
+func (e *SnapshotNameExceededMaxLengthError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.volName, "snapshot": e.snapName}
+}
+
 type InValidRequestError struct {
 	requestType       string
 	badParam          string
@@ -171,6 +338,12 @@ func (e *InValidRequestError) Error() string {
 		e.paramExpectedToBe)
 }
 
+func (e *InValidRequestError) ErrorCode() string { return resources.ErrorCodeInvalidRequest }
+func (e *InValidRequestError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *InValidRequestError) ErrorDetails() map[string]string {
+	return map[string]string{"param": e.badParam}
+}
+
 type SslModeValueInvalid struct {
 	sslModeInValid string
 }
@@ -225,3 +398,9 @@ func (e *BadHttpStatusCodeError) Error() string {
 		e.HttpUrl,
 	)
 }
+
+func (e *BadHttpStatusCodeError) ErrorCode() string { return resources.ErrorCodeStorageBadHttpStatus }
+func (e *BadHttpStatusCodeError) HttpStatus() int   { return http.StatusBadGateway }
+func (e *BadHttpStatusCodeError) ErrorDetails() map[string]string {
+	return map[string]string{"status": strconv.Itoa(e.HttpStatusCode), "action": e.HttpAction, "url": e.HttpUrl}
+}
@@ -0,0 +1,193 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scbe
+
+import (
+	"context"
+	"strings"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+// beginOperation records the intent of the operation in the journal, the db volume is not journaled since it is not in the DB
+func (s *scbeLocalClient) beginOperation(operation *database.Operation) error {
+	if database.IsDatabaseVolume(operation.VolumeName) {
+		return nil
+	}
+	return s.journal.Begin(operation)
+}
+
+// updateOperation records the WWN of the created volume, a failure is only logged since the volume is still found by its name
+func (s *scbeLocalClient) updateOperation(operation *database.Operation, wwn string) {
+	operation.StorageId = wwn
+	if database.IsDatabaseVolume(operation.VolumeName) {
+		return
+	}
+	if err := s.journal.Update(operation); err != nil {
+		s.logger.Warning("journal.Update failed", logs.Args{{"volume", operation.VolumeName}, {"wwn", wwn}, {"error", err}})
+	}
+}
+
+// completeOperation marks the operation done, a failure is only logged since the recovery of a done operation is a no-op
+func (s *scbeLocalClient) completeOperation(operation *database.Operation) {
+	if database.IsDatabaseVolume(operation.VolumeName) {
+		return
+	}
+	if err := s.journal.Complete(operation); err != nil {
+		s.logger.Warning("journal.Complete failed", logs.Args{{"volume", operation.VolumeName}, {"error", err}})
+	}
+}
+
+// rollBackOperation marks the operation undone, a failure leaves the operation pending for the next startup
+func (s *scbeLocalClient) rollBackOperation(operation *database.Operation) {
+	if database.IsDatabaseVolume(operation.VolumeName) {
+		return
+	}
+	if err := s.journal.RollBack(operation); err != nil {
+		s.logger.Warning("journal.RollBack failed", logs.Args{{"volume", operation.VolumeName}, {"error", err}})
+	}
+}
+
+// RecoverOperations resumes or rolls back the operations of the journal that a restart interrupted.
+// A create whose volume reached the DB is completed, otherwise its storage volume is deleted.
+// A remove is finished, since the storage volume may be deleted already.
+func (s *scbeLocalClient) RecoverOperations() error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	operations, err := s.journal.ListPending()
+	if err != nil {
+		return s.logger.ErrorRet(err, "journal.ListPending failed")
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+	ctx, cancel := backgroundContext()
+	defer cancel()
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, s.config.ConnectionInfo.CredentialInfo)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	var lastErr error
+	for i := range operations {
+		operation := &operations[i]
+		s.logger.Info("recovering an interrupted operation", logs.Args{{"type", operation.Type}, {"volume", operation.VolumeName}, {"wwn", operation.StorageId}})
+		switch operation.Type {
+		case database.OperationCreateVolume:
+			err = s.recoverCreateVolume(ctx, scbeRestClient, operation)
+		case database.OperationRemoveVolume:
+			err = s.recoverRemoveVolume(ctx, scbeRestClient, operation)
+		default:
+			s.logger.Warning("unknown operation type, skipping it", logs.Args{{"type", operation.Type}, {"id", operation.ID}})
+			continue
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// rollBackCreateVolume rolls back a create that failed, the SCBE calls are not bounded by the request since it may be done already
+func (s *scbeLocalClient) rollBackCreateVolume(scbeRestClient ScbeRestClient, operation *database.Operation) error {
+	ctx, cancel := backgroundContext()
+	defer cancel()
+	return s.recoverCreateVolume(ctx, scbeRestClient, operation)
+}
+
+// recoverCreateVolume completes the create if the volume is in the DB, otherwise it deletes the storage volume and rolls the create back
+func (s *scbeLocalClient) recoverCreateVolume(ctx context.Context, scbeRestClient ScbeRestClient, operation *database.Operation) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	if database.IsDatabaseVolume(operation.VolumeName) {
+		return nil
+	}
+
+	volume, err := s.dataModel.GetVolume(operation.VolumeName, false)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolume failed")
+	}
+	if volume.ID != 0 {
+		s.completeOperation(operation)
+		return nil
+	}
+
+	wwn, err := s.findCreatedVolume(ctx, scbeRestClient, operation)
+	if err != nil {
+		return s.logger.ErrorRet(err, "findCreatedVolume failed")
+	}
+	if wwn != "" {
+		if err = scbeRestClient.DeleteVolume(ctx, wwn); err != nil && !isNotFoundError(err) {
+			return s.logger.ErrorRet(err, "scbeRestClient.DeleteVolume failed", logs.Args{{"wwn", wwn}})
+		}
+	}
+	if err = s.journal.RollBack(operation); err != nil {
+		return s.logger.ErrorRet(err, "journal.RollBack failed")
+	}
+	s.logger.Info("rolled back the create", logs.Args{{"volume", operation.VolumeName}, {"wwn", wwn}})
+	return nil
+}
+
+// findCreatedVolume returns the WWN of the storage volume of a create, by the recorded WWN or else by the storage name.
+// An empty WWN means the volume was not created.
+func (s *scbeLocalClient) findCreatedVolume(ctx context.Context, scbeRestClient ScbeRestClient, operation *database.Operation) (string, error) {
+	if operation.StorageId != "" {
+		return operation.StorageId, nil
+	}
+	storageVolumes, err := scbeRestClient.GetVolumes(ctx, "")
+	if err != nil {
+		return "", s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
+	}
+	for _, storageVolume := range storageVolumes {
+		if storageVolume.Name == operation.StorageName {
+			return storageVolume.Wwn, nil
+		}
+	}
+	return "", nil
+}
+
+// recoverRemoveVolume finishes the remove, the storage volume is deleted unless it is preexisting and then the DB record is deleted
+func (s *scbeLocalClient) recoverRemoveVolume(ctx context.Context, scbeRestClient ScbeRestClient, operation *database.Operation) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	volume, err := s.dataModel.GetVolume(operation.VolumeName, false)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolume failed")
+	}
+	if volume.ID == 0 || !strings.EqualFold(volume.WWN, operation.StorageId) {
+		// the DB record is gone already (or belongs to a newer volume with the same name)
+		s.completeOperation(operation)
+		return nil
+	}
+
+	if !volume.IsPreexisting {
+		if err = scbeRestClient.DeleteVolume(ctx, volume.WWN); err != nil && !isNotFoundError(err) {
+			return s.logger.ErrorRet(err, "scbeRestClient.DeleteVolume failed", logs.Args{{"wwn", volume.WWN}})
+		}
+	}
+	if err = s.dataModel.DeleteVolume(operation.VolumeName); err != nil {
+		return s.logger.ErrorRet(err, "dataModel.DeleteVolume failed")
+	}
+	s.completeOperation(operation)
+	s.logger.Info("finished the remove", logs.Args{{"volume", operation.VolumeName}, {"wwn", volume.WWN}})
+	return nil
+}
+
+func isNotFoundError(err error) bool {
+	badStatusError, ok := err.(*BadHttpStatusCodeError)
+	return ok && badStatusError.HttpStatusCode == 404
+}
@@ -182,6 +182,31 @@ type ScbeCreateVolumePostParams struct {
 	SizeUnit string `json:"size_unit"`
 }
 
+// ScbeCloneVolumePostParams provision a volume as a copy of the Source volume or snapshot (WWN)
+type ScbeCloneVolumePostParams struct {
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+}
+
+type ScbeResizeVolumeParams struct {
+	Size     int    `json:"size"`
+	SizeUnit string `json:"size_unit"`
+}
+
+type ScbeCreateSnapshotPostParams struct {
+	VolumeId string `json:"volume_id"`
+	Name     string `json:"name"`
+}
+
+type ScbeResponseSnapshot struct {
+	Id             int    `json:"id"`
+	Name           string `json:"name"`
+	ScsiIdentifier string `json:"scsi_identifier"`
+	SourceVolume   string `json:"source_volume"`
+	CreationTime   string `json:"creation_time"`
+}
+
 type ScbeMapVolumePostParams struct {
 	VolumeId string `json:"volume_id"`
 	HostId   int    `json:"host_id"`
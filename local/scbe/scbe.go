@@ -17,11 +17,13 @@
 package scbe
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/resources"
@@ -31,24 +33,32 @@ import (
 
 type scbeLocalClient struct {
 	logger         logs.Logger
+	backend        string
 	dataModel      ScbeDataModelWrapper
 	isActivated    bool
 	config         resources.ScbeConfig
 	activationLock *sync.RWMutex
 	locker         utils.Locker
 	restClients    *sync.Map
+	journal        database.Journal
 }
 
 const (
 	OptionNameForServiceName = "profile"
 	OptionNameForVolumeSize  = "size"
+	OptionNameForVolumeWwn   = "wwn"            // the option name of the WWN of an existing volume to adopt
+	OptionNameForScVolume    = "storage-volume" // the option name of the Spectrum Connect name of an existing volume to adopt
 	volumeNamePrefix         = "u_"
 	AttachedToNothing        = "" // during provisioning the volume is not attached to any host
 	EmptyHost                = ""
 	ComposeVolumeName        = volumeNamePrefix + "%s_%s" // e.g u_instance1_volName
 	MaxVolumeNameLength      = 63                         // IBM block storage max volume name cannot exceed this length
+	snapshotNamePrefix       = "s_"
+	ComposeSnapshotName      = snapshotNamePrefix + "%s_%s_%s" // e.g s_instance1_volName_snapName
 
 	GetVolumeConfigExtraParams = 3 // number of extra params added to the VolumeConfig beyond the scbe volume struct
+
+	GibInBytes = 1024 * 1024 * 1024 // DefaultSizeUnit in bytes
 )
 
 var (
@@ -56,26 +66,38 @@ var (
 )
 
 func NewScbeLocalClient(config resources.ScbeConfig) (resources.StorageClient, error) {
-	datamodel := NewScbeDataModelWrapper()
+	return newScbeLocalClient(config, resources.SCBE, utils.NewLocker())
+}
+
+// newScbeLocalClient creates the client of the SCBE backend instance with the given name,
+// the locker serializes the attachments to the same host
+func newScbeLocalClient(config resources.ScbeConfig, backend string, locker utils.Locker) (resources.StorageClient, error) {
+	datamodel := NewScbeDataModelWrapper(backend)
 	scbeRestClient, err := NewScbeRestClient(config.ConnectionInfo)
 	if err != nil {
 		return nil, logs.GetLogger().ErrorRet(err, "NewScbeRestClient failed")
 	}
-	return NewScbeLocalClientWithNewScbeRestClientAndDataModel(config, datamodel, scbeRestClient)
+	return newScbeLocalClientWithRestClientAndDataModel(config, backend, locker, datamodel, scbeRestClient, database.NewJournal(backend))
+}
+
+func NewScbeLocalClientWithNewScbeRestClientAndDataModel(config resources.ScbeConfig, dataModel ScbeDataModelWrapper, scbeRestClient ScbeRestClient, journal database.Journal) (resources.StorageClient, error) {
+	return newScbeLocalClientWithRestClientAndDataModel(config, resources.SCBE, utils.NewLocker(), dataModel, scbeRestClient, journal)
 }
 
-func NewScbeLocalClientWithNewScbeRestClientAndDataModel(config resources.ScbeConfig, dataModel ScbeDataModelWrapper, scbeRestClient ScbeRestClient) (resources.StorageClient, error) {
+func newScbeLocalClientWithRestClientAndDataModel(config resources.ScbeConfig, backend string, locker utils.Locker, dataModel ScbeDataModelWrapper, scbeRestClient ScbeRestClient, journal database.Journal) (resources.StorageClient, error) {
 	if err := validateScbeConfig(&config); err != nil {
 		return &scbeLocalClient{}, err
 	}
 
 	client := &scbeLocalClient{
 		logger:         logs.GetLogger(),
+		backend:        backend,
 		dataModel:      dataModel,
 		config:         config,
 		activationLock: &sync.RWMutex{},
-		locker:         utils.NewLocker(),
+		locker:         locker,
 		restClients:    new(sync.Map),
+		journal:        journal,
 	}
 
 	if err := client.basicScbeLocalClientStartupAndValidation(scbeRestClient); err != nil {
@@ -88,33 +110,25 @@ func NewScbeLocalClientWithNewScbeRestClientAndDataModel(config resources.ScbeCo
 // basicScbeLocalClientStartup validate config params, login to SCBE and validate default exist
 func (s *scbeLocalClient) basicScbeLocalClientStartupAndValidation(restClient ScbeRestClient) error {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx, cancel := backgroundContext()
+	defer cancel()
 
-	// login
-	if err := restClient.Login(); err != nil {
-		return s.logger.ErrorRet(err, "scbeRestClient.Login() failed")
-	}
-
-	s.restClients.Store(s.config.ConnectionInfo.CredentialInfo, restClient)
-
-	// service existence
+	// login and service existence
 	s.logger.Info("validate scbeRestClient.ServiceExist", logs.Args{{"DefaultService", s.config.DefaultService}})
-	isExist, err := restClient.ServiceExist(s.config.DefaultService)
-	if err != nil {
-		return s.logger.ErrorRet(err, "scbeRestClient.ServiceExist failed")
-	}
-	if isExist == false {
-		return s.logger.ErrorRet(&activateDefaultServiceError{s.config.DefaultService, s.config.ConnectionInfo.ManagementIP}, "failed")
+	if err := s.checkConnectivity(ctx, restClient); err != nil {
+		return err
 	}
+	s.restClients.Store(s.config.ConnectionInfo.CredentialInfo, restClient)
 
 	// db volume
-	volumes, err := restClient.GetVolumes("")
+	volumes, err := restClient.GetVolumes(ctx, "")
 	if err != nil {
 		return s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
 	}
 	for _, volInfo := range volumes {
 		if database.IsDatabaseVolume(volInfo.Name) && s.isInstanceVolume(volInfo.Name) {
 			volume := &ScbeVolume{
-				Volume: resources.Volume{Name: database.VolumeNameSuffix, Backend: resources.SCBE},
+				Volume: resources.Volume{Name: database.VolumeNameSuffix, Backend: s.backend, BackendType: resources.SCBE},
 				WWN:    volInfo.Wwn,
 				FSType: s.config.DefaultFilesystemType,
 			}
@@ -127,7 +141,7 @@ func (s *scbeLocalClient) basicScbeLocalClientStartupAndValidation(restClient Sc
 	return nil
 }
 
-func (s *scbeLocalClient) getAuthenticatedScbeRestClient(credential resources.CredentialInfo) (ScbeRestClient, error) {
+func (s *scbeLocalClient) getAuthenticatedScbeRestClient(ctx context.Context, credential resources.CredentialInfo) (ScbeRestClient, error) {
 	restClient, exists := s.restClients.Load(credential)
 	if !exists {
 		newClient, err := newScbeRestClientGen(resources.ConnectionInfo{
@@ -135,7 +149,7 @@ func (s *scbeLocalClient) getAuthenticatedScbeRestClient(credential resources.Cr
 		if err != nil {
 			return nil, s.logger.ErrorRet(err, "newScbeRestClientGen failed")
 		}
-		if err := newClient.Login(); err != nil {
+		if err := newClient.Login(ctx); err != nil {
 			return nil, s.logger.ErrorRet(err, "newClient.Login() failed")
 		}
 		restClient, _ = s.restClients.LoadOrStore(credential, newClient)
@@ -143,6 +157,11 @@ func (s *scbeLocalClient) getAuthenticatedScbeRestClient(credential resources.Cr
 	return restClient.(ScbeRestClient), nil
 }
 
+// backgroundContext bounds the SCBE calls that no request bounds (e.g the startup), or that must complete after the request is done (e.g a rollback)
+func backgroundContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), resources.DefaultOperationDeadline*time.Second)
+}
+
 func (s *scbeLocalClient) isInstanceVolume(volName string) bool {
 	defer s.logger.Trace(logs.DEBUG)()
 	isInstanceVolume := strings.HasPrefix(volName, fmt.Sprintf(ComposeVolumeName, s.config.UbiquityInstanceName, ""))
@@ -181,11 +200,114 @@ func validateScbeConfig(config *resources.ScbeConfig) error {
 	return nil
 }
 
+// ListServices returns the SCBE storage services with their capacity
+func (s *scbeLocalClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := listServicesRequest.Context.GetCtx()
+
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, listServicesRequest.CredentialInfo)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	scbeServices, err := scbeRestClient.ListServices(ctx)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "scbeRestClient.ListServices failed")
+	}
+
+	services := make([]resources.StorageService, 0, len(scbeServices))
+	for _, scbeService := range scbeServices {
+		services = append(services, resources.StorageService{
+			Name:          scbeService.Name,
+			Backend:       s.backend,
+			TotalCapacity: int64(scbeService.TotalCapacity),
+			UsedCapacity:  int64(scbeService.UsedCapacity),
+			FreeCapacity:  int64(scbeService.PhysicalFree),
+		})
+	}
+	return services, nil
+}
+
+// Reconcile compares the volumes of the backend in the Ubiquity DB with the volumes of the instance in SCBE, by WWN.
+// Only the SCBE volumes with the prefix of the instance are reported as unmanaged, the other volumes may belong to other users.
+func (s *scbeLocalClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := reconcileRequest.Context.GetCtx()
+	report := resources.ReconcileReport{Backend: s.backend, DanglingVolumes: []resources.Orphan{}, UnmanagedStorage: []resources.Orphan{}}
+
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, reconcileRequest.CredentialInfo)
+	if err != nil {
+		return report, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+	storageVolumes, err := scbeRestClient.GetVolumes(ctx, "")
+	if err != nil {
+		return report, s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
+	}
+	volumes, err := s.dataModel.ListStoredVolumes()
+	if err != nil {
+		return report, s.logger.ErrorRet(err, "dataModel.ListStoredVolumes failed")
+	}
+
+	storageWwns := make(map[string]bool)
+	for _, storageVolume := range storageVolumes {
+		storageWwns[strings.ToLower(storageVolume.Wwn)] = true
+	}
+	volumeWwns := make(map[string]bool)
+	for _, volume := range volumes {
+		volumeWwns[strings.ToLower(volume.WWN)] = true
+		if !storageWwns[strings.ToLower(volume.WWN)] {
+			report.DanglingVolumes = append(report.DanglingVolumes, resources.Orphan{Name: volume.Volume.Name, StorageId: volume.WWN})
+		}
+	}
+	for _, storageVolume := range storageVolumes {
+		// the db volume is not in the DB, it is found on startup by its name
+		if !s.isInstanceVolume(storageVolume.Name) || database.IsDatabaseVolume(storageVolume.Name) {
+			continue
+		}
+		if !volumeWwns[strings.ToLower(storageVolume.Wwn)] {
+			report.UnmanagedStorage = append(report.UnmanagedStorage, resources.Orphan{Name: storageVolume.Name, StorageId: storageVolume.Wwn})
+		}
+	}
+
+	s.logger.Info("reconciled", logs.Args{{"backend", s.backend}, {"danglingVolumes", len(report.DanglingVolumes)}, {"unmanagedStorage", len(report.UnmanagedStorage)}})
+	return report, nil
+}
+
+// CheckHealth verifies that SCBE is reachable with the configured credentials and that the default service still exists
+func (s *scbeLocalClient) CheckHealth(ctx context.Context) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, s.config.ConnectionInfo.CredentialInfo)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+	return s.checkConnectivity(ctx, scbeRestClient)
+}
+
+// checkConnectivity logs in to SCBE and checks that the default service exists, on startup and on every health check
+func (s *scbeLocalClient) checkConnectivity(ctx context.Context, restClient ScbeRestClient) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	if err := restClient.Login(ctx); err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.Login() failed")
+	}
+
+	isExist, err := restClient.ServiceExist(ctx, s.config.DefaultService)
+	if err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.ServiceExist failed")
+	}
+	if isExist == false {
+		return s.logger.ErrorRet(&activateDefaultServiceError{s.config.DefaultService, s.config.ConnectionInfo.ManagementIP}, "failed")
+	}
+	return nil
+}
+
 func (s *scbeLocalClient) Activate(activateRequest resources.ActivateRequest) error {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := activateRequest.Context.GetCtx()
 
 	// authenticate
-	_, err := s.getAuthenticatedScbeRestClient(activateRequest.CredentialInfo)
+	_, err := s.getAuthenticatedScbeRestClient(ctx, activateRequest.CredentialInfo)
 	if err != nil {
 		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -208,9 +330,10 @@ func (s *scbeLocalClient) Activate(activateRequest resources.ActivateRequest) er
 // CreateVolume parse and validate the given options and trigger the volume creation
 func (s *scbeLocalClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) (err error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := createVolumeRequest.Context.GetCtx()
 
 	// authenticate
-	scbeRestClient, err := s.getAuthenticatedScbeRestClient(createVolumeRequest.CredentialInfo)
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, createVolumeRequest.CredentialInfo)
 	if err != nil {
 		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -266,27 +389,216 @@ func (s *scbeLocalClient) CreateVolume(createVolumeRequest resources.CreateVolum
 		return s.logger.ErrorRet(&VolumeNameExceededMaxLengthError{createVolumeRequest.Name, maxVolLength}, "failed")
 	}
 
-	// Provision the volume on SCBE service
+	// validate labels option given
+	labels, err := utils.GetVolumeLabels(createVolumeRequest.Opts)
+	if err != nil {
+		return s.logger.ErrorRet(err, "failed")
+	}
+
+	// Clone the volume if a source is given
+	sourceVolume, sourceSnapshot, err := utils.GetCloneSource(createVolumeRequest)
+	if err != nil {
+		return s.logger.ErrorRet(err, "failed")
+	}
+
+	// Adopt an existing volume of the SCBE service if one is given
+	preexistingWwn, preexistingName, err := getPreexistingVolume(createVolumeRequest.Opts)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getPreexistingVolume failed")
+	}
+	if preexistingWwn != "" || preexistingName != "" {
+		if sourceVolume != "" {
+			return s.logger.ErrorRet(&adoptConflictingOptionsError{createVolumeRequest.Name, resources.OptionNameForSourceVolume}, "failed")
+		}
+		if preexistingWwn != "" && preexistingName != "" {
+			return s.logger.ErrorRet(&adoptConflictingOptionsError{createVolumeRequest.Name, OptionNameForScVolume}, "failed")
+		}
+		return s.adoptVolume(ctx, scbeRestClient, createVolumeRequest, preexistingWwn, preexistingName, fstype, profile, labels)
+	}
+
+	if sourceVolume != "" {
+		return s.cloneVolume(ctx, scbeRestClient, createVolumeRequest, volNameToCreate, profile, sourceVolume, sourceSnapshot, labels)
+	}
+
+	// Provision the volume on SCBE service, the journal tells a restart to roll back a volume that was not inserted
+	operation := &database.Operation{Type: database.OperationCreateVolume, VolumeName: createVolumeRequest.Name, StorageName: volNameToCreate}
+	if err = s.beginOperation(operation); err != nil {
+		return s.logger.ErrorRet(err, "beginOperation failed")
+	}
 	volInfo := ScbeVolumeInfo{}
-	volInfo, err = scbeRestClient.CreateVolume(volNameToCreate, profile, size)
+	volInfo, err = scbeRestClient.CreateVolume(ctx, volNameToCreate, profile, size)
 	if err != nil {
+		// the request may have failed after SCBE created the volume (e.g at its deadline), then it is found by its name and deleted
+		s.rollBackCreateVolume(scbeRestClient, operation)
 		return s.logger.ErrorRet(err, "scbeRestClient.CreateVolume failed")
 	}
+	s.updateOperation(operation, volInfo.Wwn)
 
-	err = s.dataModel.InsertVolume(createVolumeRequest.Name, volInfo.Wwn, fstype)
+	err = s.dataModel.InsertVolume(createVolumeRequest.Name, volInfo.Wwn, fstype, false, labels)
 	if err != nil {
+		s.rollBackCreateVolume(scbeRestClient, operation)
 		return s.logger.ErrorRet(err, "dataModel.InsertVolume failed")
 	}
+	s.completeOperation(operation)
 
 	s.logger.Info("succeeded", logs.Args{{"volume", createVolumeRequest.Name}, {"profile", profile}})
 	return nil
 }
 
+// getPreexistingVolume returns the WWN or the Spectrum Connect name of the existing volume to adopt, if given in opts
+func getPreexistingVolume(opts map[string]interface{}) (string, string, error) {
+	wwn, err := getStringOption(opts, OptionNameForVolumeWwn)
+	if err != nil {
+		return "", "", err
+	}
+	name, err := getStringOption(opts, OptionNameForScVolume)
+	if err != nil {
+		return "", "", err
+	}
+	return wwn, name, nil
+}
+
+// getStringOption returns the option if given in opts, it fails with InValidRequestError if the option is not a string
+func getStringOption(opts map[string]interface{}, option string) (string, error) {
+	value, ok := opts[option]
+	if !ok || value == nil {
+		return "", nil
+	}
+	stringValue, ok := value.(string)
+	if !ok {
+		return "", &InValidRequestError{"createVolumeRequest", option, fmt.Sprintf("%v", value), "string"}
+	}
+	return stringValue, nil
+}
+
+// adoptVolume inserts a volume that already exists on the SCBE service (found by its WWN or by its name) into the DB.
+// The adopted volume is marked as preexisting, so removing it from ubiquity keeps it on the storage system.
+func (s *scbeLocalClient) adoptVolume(ctx context.Context, scbeRestClient ScbeRestClient, createVolumeRequest resources.CreateVolumeRequest, wwn string, scVolumeName string, fstype string, profile string, labels map[string]string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	// the db volume is kept in memory and cannot be adopted
+	if database.IsDatabaseVolume(createVolumeRequest.Name) {
+		return s.logger.ErrorRet(&adoptNotSupportedError{createVolumeRequest.Name}, "failed")
+	}
+	if _, ok := createVolumeRequest.Opts[OptionNameForVolumeSize]; ok {
+		s.logger.Debug("The size option is ignored, an adopted volume keeps its size",
+			logs.Args{{"volume", createVolumeRequest.Name}})
+	}
+
+	// find the volume on the SCBE service
+	storageVolumes, err := scbeRestClient.GetVolumes(ctx, wwn)
+	if err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
+	}
+	identifier := wwn
+	if scVolumeName != "" {
+		identifier = scVolumeName
+		var matching []ScbeVolumeInfo
+		for _, storageVolume := range storageVolumes {
+			if storageVolume.Name == scVolumeName {
+				matching = append(matching, storageVolume)
+			}
+		}
+		storageVolumes = matching
+	}
+	if len(storageVolumes) == 0 {
+		return s.logger.ErrorRet(&VolumeNotFoundOnArrayError{VolName: identifier}, "failed")
+	}
+	if len(storageVolumes) > 1 {
+		return s.logger.ErrorRet(&adoptAmbiguousVolumeError{createVolumeRequest.Name, identifier, len(storageVolumes)}, "failed")
+	}
+	storageVolume := storageVolumes[0]
+
+	// validate the volume belongs to the requested service
+	if storageVolume.Profile != profile {
+		return s.logger.ErrorRet(&adoptServiceMismatchError{createVolumeRequest.Name, storageVolume.Name, storageVolume.Profile, profile}, "failed")
+	}
+
+	// the adoption is not journaled, it changes only the DB and a rollback must never delete the storage volume it adopts
+
+	// validate the volume is not managed by ubiquity already, by any backend of the same storage system
+	managedVolume, managed, err := s.dataModel.GetVolumeByWwn(storageVolume.Wwn)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolumeByWwn failed")
+	}
+	if managed {
+		return s.logger.ErrorRet(&adoptAlreadyManagedError{createVolumeRequest.Name, storageVolume.Wwn, managedVolume.Volume.Name}, "failed")
+	}
+
+	err = s.dataModel.InsertVolume(createVolumeRequest.Name, storageVolume.Wwn, fstype, true, labels)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.InsertVolume failed")
+	}
+
+	s.logger.Info("succeeded", logs.Args{{"volume", createVolumeRequest.Name}, {"profile", profile}, {"wwn", storageVolume.Wwn}, {"storage-volume", storageVolume.Name}})
+	return nil
+}
+
+// cloneVolume provisions volNameToCreate on the SCBE service as a copy of the source volume, or of its snapshot if sourceSnapshot is given.
+// The clone keeps the size and the fstype of its source.
+func (s *scbeLocalClient) cloneVolume(ctx context.Context, scbeRestClient ScbeRestClient, createVolumeRequest resources.CreateVolumeRequest, volNameToCreate string, profile string, sourceVolume string, sourceSnapshot string, labels map[string]string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	// the db volume is kept in memory and can neither be cloned nor be a clone, checked before a LUN is created for nothing
+	if database.IsDatabaseVolume(createVolumeRequest.Name) {
+		return s.logger.ErrorRet(&resources.CloneNotSupportedForVolumeError{VolName: createVolumeRequest.Name}, "failed")
+	}
+	if database.IsDatabaseVolume(sourceVolume) {
+		return s.logger.ErrorRet(&resources.CloneNotSupportedForVolumeError{VolName: sourceVolume}, "failed")
+	}
+
+	existingVolume, err := s.dataModel.GetVolume(sourceVolume, true)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolume failed", logs.Args{{"source-volume", sourceVolume}})
+	}
+
+	fstype := existingVolume.FSType
+	if fstypeInt, ok := createVolumeRequest.Opts[resources.OptionNameForVolumeFsType]; ok && fstypeInt.(string) != fstype {
+		return s.logger.ErrorRet(&cloneFsTypeMismatchError{createVolumeRequest.Name, fstypeInt.(string), sourceVolume, fstype}, "failed")
+	}
+	if _, ok := createVolumeRequest.Opts[OptionNameForVolumeSize]; ok {
+		s.logger.Debug("The size option is ignored, a clone gets the size of its source",
+			logs.Args{{"volume", createVolumeRequest.Name}, {"source-volume", sourceVolume}})
+	}
+
+	sourceWwn := existingVolume.WWN
+	if sourceSnapshot != "" {
+		snapshot, err := s.dataModel.GetSnapshot(sourceVolume, sourceSnapshot, true)
+		if err != nil {
+			return s.logger.ErrorRet(err, "dataModel.GetSnapshot failed", logs.Args{{"source-volume", sourceVolume}, {"source-snapshot", sourceSnapshot}})
+		}
+		sourceWwn = snapshot.StorageId
+	}
+
+	operation := &database.Operation{Type: database.OperationCreateVolume, VolumeName: createVolumeRequest.Name, StorageName: volNameToCreate}
+	if err = s.beginOperation(operation); err != nil {
+		return s.logger.ErrorRet(err, "beginOperation failed")
+	}
+	volInfo, err := scbeRestClient.CloneVolume(ctx, volNameToCreate, profile, sourceWwn)
+	if err != nil {
+		// the request may have failed after SCBE created the clone, then it is found by its name and deleted
+		s.rollBackCreateVolume(scbeRestClient, operation)
+		return s.logger.ErrorRet(err, "scbeRestClient.CloneVolume failed")
+	}
+	s.updateOperation(operation, volInfo.Wwn)
+
+	err = s.dataModel.InsertClonedVolume(createVolumeRequest.Name, volInfo.Wwn, fstype, sourceVolume, sourceSnapshot, labels)
+	if err != nil {
+		s.rollBackCreateVolume(scbeRestClient, operation)
+		return s.logger.ErrorRet(err, "dataModel.InsertClonedVolume failed")
+	}
+	s.completeOperation(operation)
+
+	s.logger.Info("succeeded", logs.Args{{"volume", createVolumeRequest.Name}, {"profile", profile}, {"source-volume", sourceVolume}, {"source-snapshot", sourceSnapshot}})
+	return nil
+}
+
 func (s *scbeLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) (err error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := removeVolumeRequest.Context.GetCtx()
 
 	// authenticate
-	scbeRestClient, err := s.getAuthenticatedScbeRestClient(removeVolumeRequest.CredentialInfo)
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, removeVolumeRequest.CredentialInfo)
 	if err != nil {
 		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -302,7 +614,16 @@ func (s *scbeLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveVolum
 		}
 	}
 
-	volMapInfo, err := scbeRestClient.GetVolMapping(existingVolume.WWN)
+	// a volume with snapshots cannot be removed, otherwise the snapshots are left behind
+	snapshots, err := s.dataModel.ListSnapshots(removeVolumeRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.ListSnapshots failed")
+	}
+	if len(snapshots) > 0 {
+		return s.logger.ErrorRet(&resources.VolumeHasSnapshotsError{VolName: removeVolumeRequest.Name, Snapshots: len(snapshots)}, "failed")
+	}
+
+	volMapInfo, err := scbeRestClient.GetVolMapping(ctx, existingVolume.WWN)
 	// get vol mapping will not return an error but an empty host in case the vol does no exist so no idempotent issue here.
 	if err != nil {
 		return s.logger.ErrorRet(err, "scbeRestClient.GetVolMapping failed")
@@ -312,19 +633,30 @@ func (s *scbeLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveVolum
 		return s.logger.ErrorRet(&CannotDeleteVolWhichAttachedToHostError{removeVolumeRequest.Name, volMapInfo.Host}, "failed")
 	}
 
-	err = scbeRestClient.DeleteVolume(existingVolume.WWN)
-	if err != nil {
+	// the journal tells a restart to finish a remove that deleted the storage volume but not the DB record
+	operation := &database.Operation{Type: database.OperationRemoveVolume, VolumeName: removeVolumeRequest.Name, StorageId: existingVolume.WWN}
+	if err = s.beginOperation(operation); err != nil {
+		return s.logger.ErrorRet(err, "beginOperation failed")
+	}
+
+	// a preexisting volume was adopted, not provisioned by ubiquity, so it is kept on the storage system
+	if existingVolume.IsPreexisting {
+		s.logger.Info("The volume is preexisting, so it is not deleted from the storage system", logs.Args{{"volume", removeVolumeRequest.Name}, {"wwn", existingVolume.WWN}})
+	} else if err = scbeRestClient.DeleteVolume(ctx, existingVolume.WWN); err != nil {
 		switch err.(type) {
 		case *BadHttpStatusCodeError:
 			if err.(*BadHttpStatusCodeError).HttpStatusCode == 404 {
 				s.logger.Warning("Idempotent issue encountered: volume was not found in SC during remove request.", logs.Args{{"volume", removeVolumeRequest.Name}})
+				s.completeOperation(operation)
 				return nil
 
 			} else {
+				s.rollBackOperation(operation)
 				return s.logger.ErrorRet(err, "scbeRestClient.DeleteVolume failed")
 			}
 
 		default:
+			s.rollBackOperation(operation)
 			return s.logger.ErrorRet(err, "scbeRestClient.DeleteVolume failed")
 		}
 	}
@@ -334,21 +666,183 @@ func (s *scbeLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveVolum
 		switch err.(type) {
 		case *resources.VolumeNotFoundError:
 			s.logger.Warning("Idempotent issue encountered: volume was not found in DB during remove request.", logs.Args{{"volume", removeVolumeRequest.Name}})
+			s.completeOperation(operation)
 			return nil
 		default:
+			// the operation stays pending, so the DB record is removed on the next startup
 			return s.logger.ErrorRet(err, "dataModel.DeleteVolume failed")
 		}
 
 	}
+	s.completeOperation(operation)
+
+	return nil
+}
+
+// ExpandVolume grows an existing volume to the size given in the request options.
+// Shrinking is not supported, so the new size must be bigger than the current volume size.
+func (s *scbeLocalClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := expandVolumeRequest.Context.GetCtx()
+
+	// authenticate
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, expandVolumeRequest.CredentialInfo)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	existingVolume, err := s.dataModel.GetVolume(expandVolumeRequest.Name, true)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolume failed")
+	}
+
+	// validate size option given
+	sizeStr, ok := expandVolumeRequest.Opts[OptionNameForVolumeSize]
+	if !ok {
+		return s.logger.ErrorRet(&provisionParamMissingError{expandVolumeRequest.Name, OptionNameForVolumeSize}, "failed")
+	}
+
+	// validate size is a number
+	size, err := strconv.Atoi(sizeStr.(string))
+	if err != nil {
+		return s.logger.ErrorRet(&provisionParamIsNotNumberError{expandVolumeRequest.Name, OptionNameForVolumeSize}, "failed")
+	}
+
+	// get the current size of the volume from scbe
+	volumeInfo, err := scbeRestClient.GetVolumes(ctx, existingVolume.WWN)
+	if err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
+	}
+	if len(volumeInfo) != 1 {
+		return s.logger.ErrorRet(&VolumeNotFoundOnArrayError{VolName: expandVolumeRequest.Name}, "failed", logs.Args{{"volumeInfo", volumeInfo}})
+	}
+	currentSize, err := strconv.ParseUint(volumeInfo[0].LogicalCapacity, 10, 64)
+	if err != nil {
+		return s.logger.ErrorRet(err, "strconv.ParseUint failed", logs.Args{{"LogicalCapacity", volumeInfo[0].LogicalCapacity}})
+	}
+
+	// validate the volume is really growing
+	if uint64(size)*GibInBytes <= currentSize {
+		return s.logger.ErrorRet(&expandSizeNotBiggerError{expandVolumeRequest.Name, size, currentSize}, "failed")
+	}
+
+	if _, err = scbeRestClient.ResizeVolume(ctx, existingVolume.WWN, size); err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.ResizeVolume failed")
+	}
+
+	s.logger.Info("succeeded", logs.Args{{"volume", expandVolumeRequest.Name}, {"size", size}})
+	return nil
+}
+
+// CreateSnapshot takes a point-in-time copy of an existing volume on the storage system
+func (s *scbeLocalClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := createSnapshotRequest.Context.GetCtx()
+
+	// authenticate
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, createSnapshotRequest.CredentialInfo)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	existingVolume, err := s.dataModel.GetVolume(createSnapshotRequest.VolumeName, true)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetVolume failed")
+	}
+
+	// verify snapshot does not exist
+	if _, err = s.dataModel.GetSnapshot(createSnapshotRequest.VolumeName, createSnapshotRequest.Name, false); err != nil {
+		return s.logger.ErrorRet(err, "dataModel.GetSnapshot failed")
+	}
+
+	// Generate the designated snapshot name by template and validate its length
+	snapNameToCreate := fmt.Sprintf(ComposeSnapshotName, s.config.UbiquityInstanceName, createSnapshotRequest.VolumeName, createSnapshotRequest.Name)
+	if len(snapNameToCreate) > MaxVolumeNameLength {
+		maxSnapLength := MaxVolumeNameLength - len(fmt.Sprintf(ComposeSnapshotName, s.config.UbiquityInstanceName, "", ""))
+		return s.logger.ErrorRet(&SnapshotNameExceededMaxLengthError{createSnapshotRequest.VolumeName, createSnapshotRequest.Name, maxSnapLength}, "failed")
+	}
+
+	snapInfo, err := scbeRestClient.CreateSnapshot(ctx, existingVolume.WWN, snapNameToCreate)
+	if err != nil {
+		return s.logger.ErrorRet(err, "scbeRestClient.CreateSnapshot failed")
+	}
+
+	err = s.dataModel.InsertSnapshot(createSnapshotRequest.VolumeName, createSnapshotRequest.Name, snapInfo.ScsiIdentifier)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.InsertSnapshot failed")
+	}
+
+	s.logger.Info("succeeded", logs.Args{{"volume", createSnapshotRequest.VolumeName}, {"snapshot", createSnapshotRequest.Name}})
+	return nil
+}
+
+func (s *scbeLocalClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := listSnapshotsRequest.Context.GetCtx()
+
+	// authenticate
+	_, err := s.getAuthenticatedScbeRestClient(ctx, listSnapshotsRequest.CredentialInfo)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	if _, err = s.dataModel.GetVolume(listSnapshotsRequest.VolumeName, true); err != nil {
+		return nil, s.logger.ErrorRet(err, "dataModel.GetVolume failed")
+	}
+
+	snapshots, err := s.dataModel.ListSnapshots(listSnapshotsRequest.VolumeName)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "dataModel.ListSnapshots failed")
+	}
+
+	return snapshots, nil
+}
+
+func (s *scbeLocalClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := deleteSnapshotRequest.Context.GetCtx()
+
+	// authenticate
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, deleteSnapshotRequest.CredentialInfo)
+	if err != nil {
+		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
+	}
+
+	existingSnapshot, err := s.dataModel.GetSnapshot(deleteSnapshotRequest.VolumeName, deleteSnapshotRequest.Name, true)
+	if err != nil {
+		switch err.(type) {
+		case *resources.SnapshotNotFoundError:
+			s.logger.Warning("Idempotent issue encountered: snapshot was not found in DB during delete request.", logs.Args{{"volume", deleteSnapshotRequest.VolumeName}, {"snapshot", deleteSnapshotRequest.Name}})
+			return nil
+		default:
+			return s.logger.ErrorRet(err, "dataModel.GetSnapshot failed")
+		}
+	}
+
+	err = scbeRestClient.DeleteSnapshot(ctx, existingSnapshot.StorageId)
+	if err != nil {
+		badStatusErr, ok := err.(*BadHttpStatusCodeError)
+		if !ok || badStatusErr.HttpStatusCode != 404 {
+			return s.logger.ErrorRet(err, "scbeRestClient.DeleteSnapshot failed")
+		}
+		s.logger.Warning("Idempotent issue encountered: snapshot was not found in SC during delete request.", logs.Args{{"volume", deleteSnapshotRequest.VolumeName}, {"snapshot", deleteSnapshotRequest.Name}})
+	}
+
+	err = s.dataModel.DeleteSnapshot(deleteSnapshotRequest.VolumeName, deleteSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "dataModel.DeleteSnapshot failed")
+	}
 
+	s.logger.Info("succeeded", logs.Args{{"volume", deleteSnapshotRequest.VolumeName}, {"snapshot", deleteSnapshotRequest.Name}})
 	return nil
 }
 
 func (s *scbeLocalClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (resources.Volume, error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := getVolumeRequest.Context.GetCtx()
 
 	// authenticate
-	_, err := s.getAuthenticatedScbeRestClient(getVolumeRequest.CredentialInfo)
+	_, err := s.getAuthenticatedScbeRestClient(ctx, getVolumeRequest.CredentialInfo)
 	if err != nil {
 		return resources.Volume{}, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -359,16 +853,19 @@ func (s *scbeLocalClient) GetVolume(getVolumeRequest resources.GetVolumeRequest)
 	}
 
 	return resources.Volume{
-		Name:       existingVolume.Volume.Name,
-		Backend:    existingVolume.Volume.Backend,
-		Mountpoint: existingVolume.Volume.Mountpoint}, nil
+		Name:        existingVolume.Volume.Name,
+		Backend:     existingVolume.Volume.Backend,
+		BackendType: resources.SCBE,
+		Mountpoint:  existingVolume.Volume.Mountpoint,
+		Status:      existingVolume.Volume.Status}, nil
 }
 
 func (s *scbeLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (map[string]interface{}, error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := getVolumeConfigRequest.Context.GetCtx()
 
 	// authenticate
-	scbeRestClient, err := s.getAuthenticatedScbeRestClient(getVolumeConfigRequest.CredentialInfo)
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, getVolumeConfigRequest.CredentialInfo)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -380,7 +877,7 @@ func (s *scbeLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVo
 	}
 
 	// get volume full info from scbe
-	volumeInfo, err := scbeRestClient.GetVolumes(scbeVolume.WWN)
+	volumeInfo, err := scbeRestClient.GetVolumes(ctx, scbeVolume.WWN)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "scbeRestClient.GetVolumes failed")
 	}
@@ -406,7 +903,7 @@ func (s *scbeLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVo
 	volConfig[resources.OptionNameForVolumeFsType] = scbeVolume.FSType
 
 	// The ubiquity remote will use this extra info to determine is-attached
-	volMapInfo, err := scbeRestClient.GetVolMapping(scbeVolume.WWN)
+	volMapInfo, err := scbeRestClient.GetVolMapping(ctx, scbeVolume.WWN)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "scbeRestClient.GetVolMapping failed")
 	}
@@ -419,9 +916,10 @@ func (s *scbeLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVo
 
 func (s *scbeLocalClient) Attach(attachRequest resources.AttachRequest) (string, error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := attachRequest.Context.GetCtx()
 
 	// authenticate
-	scbeRestClient, err := s.getAuthenticatedScbeRestClient(attachRequest.CredentialInfo)
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, attachRequest.CredentialInfo)
 	if err != nil {
 		return "", s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -440,7 +938,7 @@ func (s *scbeLocalClient) Attach(attachRequest resources.AttachRequest) (string,
 		return "", s.logger.ErrorRet(err, "dataModel.GetVolume failed")
 	}
 
-	volMapInfo, err := scbeRestClient.GetVolMapping(existingVolume.WWN)
+	volMapInfo, err := scbeRestClient.GetVolMapping(ctx, existingVolume.WWN)
 	if err != nil {
 		return "", s.logger.ErrorRet(err, "scbeRestClient.GetVolMapping failed")
 	}
@@ -448,6 +946,9 @@ func (s *scbeLocalClient) Attach(attachRequest resources.AttachRequest) (string,
 	if hostAttach == attachRequest.Host {
 		// if already map to the given host then just ignore and succeed to attach
 		s.logger.Info("Volume already attached, skip backend attach", logs.Args{{"volume", attachRequest.Name}, {"host", attachRequest.Host}})
+		if err = s.dataModel.UpdateVolumeAttachedHost(attachRequest.Name, attachRequest.Host); err != nil {
+			return "", s.logger.ErrorRet(err, "dataModel.UpdateVolumeAttachedHost failed")
+		}
 		volumeMountpoint := fmt.Sprintf(resources.PathToMountUbiquityBlockDevices, existingVolume.WWN)
 		return volumeMountpoint, nil
 	} else if hostAttach != "" {
@@ -455,24 +956,31 @@ func (s *scbeLocalClient) Attach(attachRequest resources.AttachRequest) (string,
 	}
 
 	// Lock will ensure no other caller attach a volume from the same host concurrently, Prevent SCBE race condition on get next available lun ID
-	s.locker.WriteLock(attachRequest.Host)
+	if err = s.locker.TryWriteLock(ctx, attachRequest.Host); err != nil {
+		return "", s.logger.ErrorRet(err, "locker.TryWriteLock failed")
+	}
 	s.logger.Debug("Attaching", logs.Args{{"volume", existingVolume}})
-	if _, err = scbeRestClient.MapVolume(existingVolume.WWN, attachRequest.Host); err != nil {
+	if _, err = scbeRestClient.MapVolume(ctx, existingVolume.WWN, attachRequest.Host); err != nil {
 		s.locker.WriteUnlock(attachRequest.Host)
 		return "", s.logger.ErrorRet(err, "scbeRestClient.MapVolume failed")
 	}
 	s.locker.WriteUnlock(attachRequest.Host)
 
+	if err = s.dataModel.UpdateVolumeAttachedHost(attachRequest.Name, attachRequest.Host); err != nil {
+		return "", s.logger.ErrorRet(err, "dataModel.UpdateVolumeAttachedHost failed")
+	}
+
 	volumeMountpoint := fmt.Sprintf(resources.PathToMountUbiquityBlockDevices, existingVolume.WWN)
 	return volumeMountpoint, nil
 }
 
 func (s *scbeLocalClient) Detach(detachRequest resources.DetachRequest) (err error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := detachRequest.Context.GetCtx()
 	host2detach := detachRequest.Host
 
 	// authenticate
-	scbeRestClient, err := s.getAuthenticatedScbeRestClient(detachRequest.CredentialInfo)
+	scbeRestClient, err := s.getAuthenticatedScbeRestClient(ctx, detachRequest.CredentialInfo)
 	if err != nil {
 		return s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
@@ -482,7 +990,7 @@ func (s *scbeLocalClient) Detach(detachRequest resources.DetachRequest) (err err
 		return s.logger.ErrorRet(err, "dataModel.GetVolume failed")
 	}
 
-	volMapInfo, err := scbeRestClient.GetVolMapping(existingVolume.WWN)
+	volMapInfo, err := scbeRestClient.GetVolMapping(ctx, existingVolume.WWN)
 	if err != nil {
 		return s.logger.ErrorRet(err, "scbeRestClient.GetVolMapping failed")
 	}
@@ -490,29 +998,37 @@ func (s *scbeLocalClient) Detach(detachRequest resources.DetachRequest) (err err
 	hostAttach := volMapInfo.Host
 	if hostAttach == EmptyHost {
 		s.logger.Warning("Volume is already detached from host.", logs.Args{{"volume", existingVolume.WWN}})
-		return nil
+		return s.updateVolumeDetached(detachRequest.Name)
 	}
 
 	// TODO idempotent, if volume attach to different host, then we should also return succeed.
 	s.logger.Debug("Detaching", logs.Args{{"volume", existingVolume}})
-	if err = scbeRestClient.UnmapVolume(existingVolume.WWN, host2detach); err != nil {
+	if err = scbeRestClient.UnmapVolume(ctx, existingVolume.WWN, host2detach); err != nil {
 		return s.logger.ErrorRet(err, "scbeRestClient.UnmapVolume failed")
 	}
 
+	return s.updateVolumeDetached(detachRequest.Name)
+}
+
+func (s *scbeLocalClient) updateVolumeDetached(name string) error {
+	if err := s.dataModel.UpdateVolumeAttachedHost(name, EmptyHost); err != nil {
+		return s.logger.ErrorRet(err, "dataModel.UpdateVolumeAttachedHost failed")
+	}
 	return nil
 }
 
 func (s *scbeLocalClient) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
 	defer s.logger.Trace(logs.DEBUG)()
+	ctx := listVolumesRequest.Context.GetCtx()
 	var err error
 
 	// authenticate
-	_, err = s.getAuthenticatedScbeRestClient(listVolumesRequest.CredentialInfo)
+	_, err = s.getAuthenticatedScbeRestClient(ctx, listVolumesRequest.CredentialInfo)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "getAuthenticatedScbeRestClient failed")
 	}
 
-	volumesInDb, err := s.dataModel.ListVolumes()
+	volumesInDb, err := s.dataModel.ListVolumes(listVolumesRequest)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "dataModel.ListVolumes failed")
 	}
@@ -17,6 +17,7 @@
 package scbe
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -27,14 +28,19 @@ import (
 
 //go:generate counterfeiter -o ../../fakes/fake_scbe_rest_client.go . ScbeRestClient
 type ScbeRestClient interface {
-	Login() error
-	CreateVolume(volName string, serviceName string, size int) (ScbeVolumeInfo, error)
-	GetVolumes(wwn string) ([]ScbeVolumeInfo, error)
-	DeleteVolume(wwn string) error
-	MapVolume(wwn string, host string) (ScbeResponseMapping, error)
-	UnmapVolume(wwn string, host string) error
-	GetVolMapping(wwn string) (ScbeVolumeMapInfo, error)
-	ServiceExist(serviceName string) (bool, error)
+	Login(ctx context.Context) error
+	CreateVolume(ctx context.Context, volName string, serviceName string, size int) (ScbeVolumeInfo, error)
+	CloneVolume(ctx context.Context, volName string, serviceName string, sourceWwn string) (ScbeVolumeInfo, error)
+	GetVolumes(ctx context.Context, wwn string) ([]ScbeVolumeInfo, error)
+	DeleteVolume(ctx context.Context, wwn string) error
+	ResizeVolume(ctx context.Context, wwn string, size int) (ScbeVolumeInfo, error)
+	CreateSnapshot(ctx context.Context, wwn string, snapshotName string) (ScbeResponseSnapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotWwn string) error
+	MapVolume(ctx context.Context, wwn string, host string) (ScbeResponseMapping, error)
+	UnmapVolume(ctx context.Context, wwn string, host string) error
+	GetVolMapping(ctx context.Context, wwn string) (ScbeVolumeMapInfo, error)
+	ServiceExist(ctx context.Context, serviceName string) (bool, error)
+	ListServices(ctx context.Context) ([]ScbeStorageService, error)
 }
 
 type scbeRestClient struct {
@@ -51,6 +57,7 @@ const (
 	ScbeContainersGroupParam = "containers"
 	UrlScbeResourceService   = "services"
 	UrlScbeResourceVolume    = "volumes"
+	UrlScbeResourceSnapshot  = "snapshots"
 	UrlScbeResourceMapping   = "mappings"
 	UrlScbeResourceHost      = "hosts"
 	DefaultSizeUnit          = "gib"
@@ -85,9 +92,9 @@ func newScbeRestClient(conInfo resources.ConnectionInfo, simpleClient SimpleRest
 	return &scbeRestClient{logs.GetLogger(), conInfo, simpleClient}, nil
 }
 
-func (s *scbeRestClient) Login() error {
+func (s *scbeRestClient) Login(ctx context.Context) error {
 	defer s.logger.Trace(logs.DEBUG)()
-	return s.client.Login()
+	return s.client.Login(ctx)
 }
 
 // CreateVolume provision new volume on SCBE storage service.
@@ -95,10 +102,10 @@ func (s *scbeRestClient) Login() error {
 // Errors:
 //	if service don't exist
 //	if fail to create the volume
-func (s *scbeRestClient) CreateVolume(volName string, serviceName string, size int) (ScbeVolumeInfo, error) {
+func (s *scbeRestClient) CreateVolume(ctx context.Context, volName string, serviceName string, size int) (ScbeVolumeInfo, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	// find the service in order to validate and also to get the service id
-	services, err := s.serviceList(serviceName)
+	services, err := s.serviceList(ctx, serviceName)
 	if err != nil {
 		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "failed")
 	}
@@ -119,16 +126,45 @@ func (s *scbeRestClient) CreateVolume(volName string, serviceName string, size i
 		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
 	}
 	volResponse := ScbeResponseVolume{}
-	if err = s.client.Post(UrlScbeResourceVolume, payloadMarshaled, HTTP_SUCCEED_POST, &volResponse); err != nil {
+	if err = s.client.Post(ctx, UrlScbeResourceVolume, payloadMarshaled, HTTP_SUCCEED_POST, &volResponse); err != nil {
 		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "client.Post failed", logs.Args{{"payload", payload}})
 	}
 
 	return NewScbeVolumeInfo(&volResponse), nil
 }
 
-func (s *scbeRestClient) GetVolumes(wwn string) ([]ScbeVolumeInfo, error) {
+// CloneVolume provision new volume on SCBE storage service as a copy of the given volume or snapshot (sourceWwn).
+// The new volume gets the size of its source.
+// Return ScbeVolumeInfo of the new volume that was created
+// Errors:
+//	if service don't exist
+//	if fail to clone the volume
+func (s *scbeRestClient) CloneVolume(ctx context.Context, volName string, serviceName string, sourceWwn string) (ScbeVolumeInfo, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	services, err := s.serviceList(ctx, serviceName)
+	if err != nil {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "failed")
+	}
+	if len(services) <= 0 || services[0].Name != serviceName {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(&serviceDoesntExistError{volName, serviceName, s.connectionInfo.ManagementIP}, "failed")
+	}
+
+	payload := ScbeCloneVolumePostParams{Service: services[0].Id, Name: volName, Source: sourceWwn}
+	payloadMarshaled, err := json.Marshal(payload)
+	if err != nil {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
+	}
+	volResponse := ScbeResponseVolume{}
+	if err = s.client.Post(ctx, UrlScbeResourceVolume, payloadMarshaled, HTTP_SUCCEED_POST, &volResponse); err != nil {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "client.Post failed", logs.Args{{"payload", payload}})
+	}
+
+	return NewScbeVolumeInfo(&volResponse), nil
+}
+
+func (s *scbeRestClient) GetVolumes(ctx context.Context, wwn string) ([]ScbeVolumeInfo, error) {
 	defer s.logger.Trace(logs.DEBUG)()
-	vols, err := s.volumeList(wwn)
+	vols, err := s.volumeList(ctx, wwn)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "volumeList failed", logs.Args{{"wwn", wwn}})
 	}
@@ -140,18 +176,65 @@ func (s *scbeRestClient) GetVolumes(wwn string) ([]ScbeVolumeInfo, error) {
 	return scbeVolumes, nil
 }
 
-func (s *scbeRestClient) DeleteVolume(wwn string) error {
+func (s *scbeRestClient) DeleteVolume(ctx context.Context, wwn string) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	urlToDelete := fmt.Sprintf("%s/%s", UrlScbeResourceVolume, wwn)
-	if err := s.client.Delete(urlToDelete, []byte{}, HTTP_SUCCEED_DELETED); err != nil {
+	if err := s.client.Delete(ctx, urlToDelete, []byte{}, HTTP_SUCCEED_DELETED); err != nil {
 		return s.logger.ErrorRet(err, "client.Delete failed", logs.Args{{"url", urlToDelete}})
 	}
 	return nil
 }
 
-func (s *scbeRestClient) MapVolume(wwn string, host string) (ScbeResponseMapping, error) {
+// ResizeVolume grows an existing volume on the storage system to the given size (in gib).
+// Return ScbeVolumeInfo of the volume after the resize
+func (s *scbeRestClient) ResizeVolume(ctx context.Context, wwn string, size int) (ScbeVolumeInfo, error) {
 	defer s.logger.Trace(logs.DEBUG)()
-	hostId, err := s.getHostIdByVol(wwn, host)
+	payload := ScbeResizeVolumeParams{
+		Size:     size,
+		SizeUnit: DefaultSizeUnit,
+	}
+	payloadMarshaled, err := json.Marshal(payload)
+	if err != nil {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
+	}
+	urlToResize := fmt.Sprintf("%s/%s", UrlScbeResourceVolume, wwn)
+	volResponse := ScbeResponseVolume{}
+	if err = s.client.Put(ctx, urlToResize, payloadMarshaled, HTTP_SUCCEED, &volResponse); err != nil {
+		return ScbeVolumeInfo{}, s.logger.ErrorRet(err, "client.Put failed", logs.Args{{"url", urlToResize}, {"payload", payload}})
+	}
+
+	return NewScbeVolumeInfo(&volResponse), nil
+}
+
+// CreateSnapshot takes a snapshot of the given volume(wwn) on the storage system.
+// Return ScbeResponseSnapshot of the new snapshot that was created
+func (s *scbeRestClient) CreateSnapshot(ctx context.Context, wwn string, snapshotName string) (ScbeResponseSnapshot, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	payload := ScbeCreateSnapshotPostParams{VolumeId: wwn, Name: snapshotName}
+	payloadMarshaled, err := json.Marshal(payload)
+	if err != nil {
+		return ScbeResponseSnapshot{}, s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
+	}
+	snapshotResponse := ScbeResponseSnapshot{}
+	if err = s.client.Post(ctx, UrlScbeResourceSnapshot, payloadMarshaled, HTTP_SUCCEED_POST, &snapshotResponse); err != nil {
+		return ScbeResponseSnapshot{}, s.logger.ErrorRet(err, "client.Post failed", logs.Args{{"payload", payload}})
+	}
+
+	return snapshotResponse, nil
+}
+
+func (s *scbeRestClient) DeleteSnapshot(ctx context.Context, snapshotWwn string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	urlToDelete := fmt.Sprintf("%s/%s", UrlScbeResourceSnapshot, snapshotWwn)
+	if err := s.client.Delete(ctx, urlToDelete, []byte{}, HTTP_SUCCEED_DELETED); err != nil {
+		return s.logger.ErrorRet(err, "client.Delete failed", logs.Args{{"url", urlToDelete}})
+	}
+	return nil
+}
+
+func (s *scbeRestClient) MapVolume(ctx context.Context, wwn string, host string) (ScbeResponseMapping, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	hostId, err := s.getHostIdByVol(ctx, wwn, host)
 	if err != nil {
 		return ScbeResponseMapping{}, s.logger.ErrorRet(err, "getHostIdByVol failed")
 	}
@@ -161,7 +244,7 @@ func (s *scbeRestClient) MapVolume(wwn string, host string) (ScbeResponseMapping
 		return ScbeResponseMapping{}, s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
 	}
 	mappingsResponse := ScbeResponseMappings{}
-	if err = s.client.Post(UrlScbeResourceMapping, payloadMarshal, HTTP_SUCCEED_POST, &mappingsResponse); err != nil {
+	if err = s.client.Post(ctx, UrlScbeResourceMapping, payloadMarshal, HTTP_SUCCEED_POST, &mappingsResponse); err != nil {
 		return ScbeResponseMapping{}, s.logger.ErrorRet(err, "client.Post failed", logs.Args{{"payload", payload}})
 	}
 	if len(mappingsResponse.Mappings) != 1 {
@@ -170,10 +253,10 @@ func (s *scbeRestClient) MapVolume(wwn string, host string) (ScbeResponseMapping
 	return mappingsResponse.Mappings[0], nil
 }
 
-func (s *scbeRestClient) UnmapVolume(wwn string, host string) error {
+func (s *scbeRestClient) UnmapVolume(ctx context.Context, wwn string, host string) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	// TODO consider to return the unmap SCBE response
-	hostId, err := s.getHostIdByVol(wwn, host)
+	hostId, err := s.getHostIdByVol(ctx, wwn, host)
 	if err != nil {
 		return err
 	}
@@ -182,13 +265,13 @@ func (s *scbeRestClient) UnmapVolume(wwn string, host string) error {
 	if err != nil {
 		return s.logger.ErrorRet(err, "json.Marshal failed", logs.Args{{"payload", payload}})
 	}
-	if err = s.client.Delete(UrlScbeResourceMapping, payloadMarshal, HTTP_SUCCEED_DELETED); err != nil {
+	if err = s.client.Delete(ctx, UrlScbeResourceMapping, payloadMarshal, HTTP_SUCCEED_DELETED); err != nil {
 		return s.logger.ErrorRet(err, "client.Delete failed", logs.Args{{"url", UrlScbeResourceMapping}})
 	}
 	return nil
 }
 
-func (s *scbeRestClient) GetVolMapping(wwn string) (ScbeVolumeMapInfo, error) {
+func (s *scbeRestClient) GetVolMapping(ctx context.Context, wwn string) (ScbeVolumeMapInfo, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	var err error
 	var host string
@@ -199,7 +282,7 @@ func (s *scbeRestClient) GetVolMapping(wwn string) (ScbeVolumeMapInfo, error) {
 	s.logger.Debug("get", logs.Args{{"payload", payload}})
 
 	var mappings []ScbeResponseMapping
-	if err = s.client.Get(UrlScbeResourceMapping, payload, HTTP_SUCCEED, &mappings); err != nil {
+	if err = s.client.Get(ctx, UrlScbeResourceMapping, payload, HTTP_SUCCEED, &mappings); err != nil {
 		return ScbeVolumeMapInfo{}, s.logger.ErrorRet(err, "client.Get failed")
 	}
 	s.logger.Debug("", logs.Args{{"mappings", mappings}})
@@ -212,7 +295,7 @@ func (s *scbeRestClient) GetVolMapping(wwn string) (ScbeVolumeMapInfo, error) {
 		lunNumber = mappings[0].LunNumber
 		var hostResponse ScbeResponseHost
 		hostUrl := fmt.Sprintf("%s/%s", UrlScbeResourceHost, strconv.Itoa(mappings[0].Host))
-		err := s.client.Get(hostUrl, nil, -1, &hostResponse)
+		err := s.client.Get(ctx, hostUrl, nil, -1, &hostResponse)
 		if err != nil {
 			return ScbeVolumeMapInfo{}, s.logger.ErrorRet(err, "client.Get failed")
 		}
@@ -231,17 +314,23 @@ func (s *scbeRestClient) GetVolMapping(wwn string) (ScbeVolumeMapInfo, error) {
 	return ScbeVolumeMapInfo{host, lunNumber}, nil
 }
 
-func (s *scbeRestClient) ServiceExist(serviceName string) (exist bool, err error) {
+func (s *scbeRestClient) ServiceExist(ctx context.Context, serviceName string) (exist bool, err error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	var services []ScbeStorageService
-	services, err = s.serviceList(serviceName)
+	services, err = s.serviceList(ctx, serviceName)
 	if err == nil {
 		return len(services) > 0, err
 	}
 	return false, err
 }
 
-func (s *scbeRestClient) serviceList(serviceName string) ([]ScbeStorageService, error) {
+// ListServices returns the storage services of the Ubiquity interface, with their capacity
+func (s *scbeRestClient) ListServices(ctx context.Context) ([]ScbeStorageService, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	return s.serviceList(ctx, "")
+}
+
+func (s *scbeRestClient) serviceList(ctx context.Context, serviceName string) ([]ScbeStorageService, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	payload := map[string]string{}
 	if serviceName != "" {
@@ -249,30 +338,30 @@ func (s *scbeRestClient) serviceList(serviceName string) ([]ScbeStorageService,
 	}
 
 	var services []ScbeStorageService
-	if err := s.client.Get(UrlScbeResourceService, payload, -1, &services); err != nil {
+	if err := s.client.Get(ctx, UrlScbeResourceService, payload, -1, &services); err != nil {
 		return nil, s.logger.ErrorRet(err, "client.Get failed")
 	}
 
 	return services, nil
 }
-func (s *scbeRestClient) volumeList(wwn string) ([]ScbeResponseVolume, error) {
+func (s *scbeRestClient) volumeList(ctx context.Context, wwn string) ([]ScbeResponseVolume, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	payload := map[string]string{}
 	if wwn != "" {
 		payload["scsi_identifier"] = wwn
 	}
 	var volumes []ScbeResponseVolume
-	if err := s.client.Get(UrlScbeResourceVolume, payload, -1, &volumes); err != nil {
+	if err := s.client.Get(ctx, UrlScbeResourceVolume, payload, -1, &volumes); err != nil {
 		return nil, s.logger.ErrorRet(err, "client.Get failed")
 	}
 
 	return volumes, nil
 }
 
-func (s *scbeRestClient) hostList(payload map[string]string) ([]ScbeResponseHost, error) {
+func (s *scbeRestClient) hostList(ctx context.Context, payload map[string]string) ([]ScbeResponseHost, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 	var hosts []ScbeResponseHost
-	err := s.client.Get(UrlScbeResourceHost, payload, -1, &hosts)
+	err := s.client.Get(ctx, UrlScbeResourceHost, payload, -1, &hosts)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "client.Get failed")
 	}
@@ -280,9 +369,9 @@ func (s *scbeRestClient) hostList(payload map[string]string) ([]ScbeResponseHost
 }
 
 //getHostIdByVol return the host ID from the storage system of the given volume(wwn)
-func (s *scbeRestClient) getHostIdByVol(wwn string, host string) (int, error) {
+func (s *scbeRestClient) getHostIdByVol(ctx context.Context, wwn string, host string) (int, error) {
 	defer s.logger.Trace(logs.DEBUG)()
-	vols, err := s.volumeList(wwn)
+	vols, err := s.volumeList(ctx, wwn)
 	if err != nil {
 		return 0, s.logger.ErrorRet(err, "volumeList failed", logs.Args{{"wwn", wwn}})
 	}
@@ -294,7 +383,7 @@ func (s *scbeRestClient) getHostIdByVol(wwn string, host string) (int, error) {
 	payload := make(map[string]string)
 	payload["array_id"] = vol.Array
 	payload["name"] = host
-	hosts, err := s.hostList(payload)
+	hosts, err := s.hostList(ctx, payload)
 	if err != nil {
 		return 0, s.logger.ErrorRet(err, "hostList failed")
 	}
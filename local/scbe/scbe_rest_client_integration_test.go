@@ -17,6 +17,7 @@
 package scbe_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/local/scbe"
@@ -52,7 +53,7 @@ var _ = Describe("restClient integration testing with existing SCBE instance", f
 
 	Context(".Login", func() {
 		It("Should succeed to login to SCBE", func() {
-			err := client.Login()
+			err := client.Login(context.Background())
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
@@ -60,9 +61,9 @@ var _ = Describe("restClient integration testing with existing SCBE instance", f
 	Context(".Get", func() {
 		It("Succeed if there are services available in SCBE", func() {
 			var services []scbe.ScbeStorageService
-			err := client.Login()
+			err := client.Login(context.Background())
 			Expect(err).ToNot(HaveOccurred())
-			err = client.Get(scbe.UrlScbeResourceService, nil, 200, &services)
+			err = client.Get(context.Background(), scbe.UrlScbeResourceService, nil, 200, &services)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(services) > 0).To(Equal(true))
 		})
@@ -92,17 +93,17 @@ var _ = Describe("ScbeRestClient integration testing with existing SCBE instance
 	Context(".Login", func() {
 		It("Should succeed to login to SCBE", func() {
 
-			err := scbeRestClient.Login()
+			err := scbeRestClient.Login(context.Background())
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
 
 	Context(".ServiceExist", func() {
 		It(fmt.Sprintf("Should succeed if %s service exist in SCBE", profile), func() {
-			err := scbeRestClient.Login()
+			err := scbeRestClient.Login(context.Background())
 			Expect(err).ToNot(HaveOccurred())
 			var exist bool
-			exist, err = scbeRestClient.ServiceExist(profile)
+			exist, err = scbeRestClient.ServiceExist(context.Background(), profile)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(exist).To(Equal(true))
 		})
@@ -130,10 +131,10 @@ var _ = Describe("ScbeRestClient volume operations integration testing with exis
 		scbeRestClient, err = scbe.NewScbeRestClient(conInfo)
 		Expect(err).ToNot(HaveOccurred())
 
-		err = scbeRestClient.Login()
+		err = scbeRestClient.Login(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 		var exist bool
-		exist, err = scbeRestClient.ServiceExist(profile)
+		exist, err = scbeRestClient.ServiceExist(context.Background(), profile)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(exist).To(Equal(true))
 	})
@@ -141,27 +142,27 @@ var _ = Describe("ScbeRestClient volume operations integration testing with exis
 	Context(".CreateVolume", func() {
 		It(fmt.Sprintf("Should succeed if vol was created and deleted on %s service", profile), func() {
 			fakeName := "fakevol_ubiquity"
-			volInfo, err := scbeRestClient.CreateVolume(fakeName, profile, 10)
+			volInfo, err := scbeRestClient.CreateVolume(context.Background(), fakeName, profile, 10)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(volInfo.Name).To(Equal(fakeName))
 			Expect(volInfo.Profile).To(Equal(profile))
 			Expect(volInfo.Wwn).NotTo(Equal(""))
-			err = scbeRestClient.DeleteVolume(volInfo.Wwn)
+			err = scbeRestClient.DeleteVolume(context.Background(), volInfo.Wwn)
 			Expect(err).NotTo(HaveOccurred())
 		})
 		It(fmt.Sprintf("Should succeed if vol map and unmap works", profile), func() {
 			fakeName := "fakevol_ubiquity"
-			volInfo, err := scbeRestClient.CreateVolume(fakeName, profile, 10)
+			volInfo, err := scbeRestClient.CreateVolume(context.Background(), fakeName, profile, 10)
 			Expect(err).NotTo(HaveOccurred())
-			mapInfo, err := scbeRestClient.MapVolume(volInfo.Wwn, host)
+			mapInfo, err := scbeRestClient.MapVolume(context.Background(), volInfo.Wwn, host)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(mapInfo.Volume).To(Equal(volInfo.Wwn))
 			Expect(mapInfo.LunNumber > 0).To(Equal(true)) // TODO maybe not working on SVC
-			err = scbeRestClient.DeleteVolume(volInfo.Wwn)
+			err = scbeRestClient.DeleteVolume(context.Background(), volInfo.Wwn)
 			Expect(err).To(HaveOccurred()) // because the vol is mapped, so cannot delete the volume before unmapping it first
-			err = scbeRestClient.UnmapVolume(volInfo.Wwn, host)
+			err = scbeRestClient.UnmapVolume(context.Background(), volInfo.Wwn, host)
 			Expect(err).NotTo(HaveOccurred())
-			err = scbeRestClient.DeleteVolume(volInfo.Wwn)
+			err = scbeRestClient.DeleteVolume(context.Background(), volInfo.Wwn)
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
@@ -184,13 +185,11 @@ var _ = Describe("datamodel integration testing with live DB", func() {
 		// create DB
 		logs.GetLogger().Debug("Obtaining handle to DB")
 		var err error
-		database.RegisterMigration(&resources.Volume{})
-		database.RegisterMigration(&scbe.ScbeVolume{})
 		dbConnection = database.NewConnection()
 		err = dbConnection.Open()
 		Expect(err).NotTo(HaveOccurred(), "failed to connect database")
 		db = dbConnection.GetDb()
-		datamodel = scbe.NewScbeDataModel(db)
+		datamodel = scbe.NewScbeDataModel(db, resources.SCBE)
 		Expect(db.HasTable(scbe.ScbeVolume{})).To(Equal(true))
 	})
 	AfterEach(func() {
@@ -200,7 +199,7 @@ var _ = Describe("datamodel integration testing with live DB", func() {
 	Context(".table", func() {
 		It("Should to succeed to insert new volume raw and find it in DB", func() {
 			fakeVolName := "volname1"
-			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4")
+			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4", false, nil)
 			Expect(err).NotTo(HaveOccurred())
 			ScbeVolume, exist, err := datamodel.GetVolume(fakeVolName)
 			Expect(err).NotTo(HaveOccurred())
@@ -210,7 +209,7 @@ var _ = Describe("datamodel integration testing with live DB", func() {
 		})
 		It("Should to succeed to insert new volume and delete it", func() {
 			fakeVolName := "volname1"
-			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4")
+			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4", false, nil)
 			Expect(err).NotTo(HaveOccurred())
 			_, exist, err := datamodel.GetVolume(fakeVolName)
 			Expect(err).NotTo(HaveOccurred())
@@ -224,15 +223,15 @@ var _ = Describe("datamodel integration testing with live DB", func() {
 			num := 10
 			for i := 0; i < num; i++ {
 				volname = fmt.Sprintf("fakevol %d", i)
-				Expect(datamodel.InsertVolume(volname, "www1", "ext4")).NotTo(HaveOccurred())
+				Expect(datamodel.InsertVolume(volname, "www1", "ext4", false, nil)).NotTo(HaveOccurred())
 			}
-			vols, err := datamodel.ListVolumes()
+			vols, err := datamodel.ListVolumes(resources.ListVolumesRequest{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(vols)).To(Equal(num))
 		})
 		It("Should to succeed to insert and then update the attach of the volume", func() {
 			fakeVolName := "volname1"
-			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4")
+			err := datamodel.InsertVolume(fakeVolName, "www1", "ext4", false, nil)
 			Expect(err).NotTo(HaveOccurred())
 			_, exist, err := datamodel.GetVolume(fakeVolName)
 			Expect(err).NotTo(HaveOccurred())
@@ -17,6 +17,7 @@
 package scbe_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,7 +53,7 @@ var _ = Describe("ScbeRestClient", func() {
 	Context(".ServiceExist", func() {
 		It("fail upon rest call error", func() {
 			fakeSimpleRestClient.GetReturns(restErr)
-			_, err = scbeRestClient.ServiceExist(profileName)
+			_, err = scbeRestClient.ServiceExist(context.Background(), profileName)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(restErr))
 		})
@@ -60,14 +61,14 @@ var _ = Describe("ScbeRestClient", func() {
 			services := make([]scbe.ScbeStorageService, 1)
 			services[0].Name = profileName
 			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
-			exist, err := scbeRestClient.ServiceExist(profileName)
+			exist, err := scbeRestClient.ServiceExist(context.Background(), profileName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(exist).To(Equal(true))
 		})
 		It("detect service does not exists", func() {
 			services := make([]scbe.ScbeStorageService, 0)
 			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
-			exist, err := scbeRestClient.ServiceExist(profileName)
+			exist, err := scbeRestClient.ServiceExist(context.Background(), profileName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(exist).To(Equal(false))
 		})
@@ -79,7 +80,7 @@ var _ = Describe("ScbeRestClient", func() {
 			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
 			volResponse := scbe.ScbeResponseVolume{Name: volName, ScsiIdentifier: volIdentifier, ServiceName: profileName}
 			fakeSimpleRestClient.PostStub = OverridePostStub(volResponse)
-			scbeVolumeInfo, err := scbeRestClient.CreateVolume(volName, profileName, volSize)
+			scbeVolumeInfo, err := scbeRestClient.CreateVolume(context.Background(), volName, profileName, volSize)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(scbeVolumeInfo.Name).To(Equal(volName))
 			Expect(scbeVolumeInfo.Wwn).To(Equal(volIdentifier))
@@ -87,7 +88,7 @@ var _ = Describe("ScbeRestClient", func() {
 		})
 		It("fail upon service list error", func() {
 			fakeSimpleRestClient.GetReturns(restErr)
-			_, err := scbeRestClient.CreateVolume(volName, profileName, volSize)
+			_, err := scbeRestClient.CreateVolume(context.Background(), volName, profileName, volSize)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(restErr))
 		})
@@ -95,7 +96,7 @@ var _ = Describe("ScbeRestClient", func() {
 			services := make([]scbe.ScbeStorageService, 1)
 			services[0].Name = "fakeProfileName"
 			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
-			_, err := scbeRestClient.CreateVolume(volName, profileName, volSize)
+			_, err := scbeRestClient.CreateVolume(context.Background(), volName, profileName, volSize)
 			Expect(err).To(HaveOccurred())
 		})
 		It("fail upon provision volume error", func() {
@@ -103,36 +104,116 @@ var _ = Describe("ScbeRestClient", func() {
 			services[0].Name = profileName
 			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
 			fakeSimpleRestClient.PostReturns(restErr)
-			_, err := scbeRestClient.CreateVolume(volName, profileName, volSize)
+			_, err := scbeRestClient.CreateVolume(context.Background(), volName, profileName, volSize)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context(".CloneVolume", func() {
+		It("succeed and return ScbeVolumeInfo object", func() {
+			services := make([]scbe.ScbeStorageService, 1)
+			services[0].Name = profileName
+			services[0].Id = "serviceId"
+			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
+			volResponse := scbe.ScbeResponseVolume{Name: volName, ScsiIdentifier: volIdentifier, ServiceName: profileName}
+			fakeSimpleRestClient.PostStub = OverridePostStub(volResponse)
+			scbeVolumeInfo, err := scbeRestClient.CloneVolume(context.Background(), volName, profileName, "sourceWwn")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scbeVolumeInfo.Wwn).To(Equal(volIdentifier))
+			_, url, payload, status, _ := fakeSimpleRestClient.PostArgsForCall(0)
+			Expect(url).To(Equal(scbe.UrlScbeResourceVolume))
+			Expect(string(payload)).To(Equal(`{"service":"serviceId","name":"` + volName + `","source":"sourceWwn"}`))
+			Expect(status).To(Equal(scbe.HTTP_SUCCEED_POST))
+		})
+		It("fail upon service list name mismatch", func() {
+			services := make([]scbe.ScbeStorageService, 1)
+			services[0].Name = "fakeProfileName"
+			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
+			_, err := scbeRestClient.CloneVolume(context.Background(), volName, profileName, "sourceWwn")
+			Expect(err).To(HaveOccurred())
+			Expect(fakeSimpleRestClient.PostCallCount()).To(Equal(0))
+		})
+		It("fail upon clone volume error", func() {
+			services := make([]scbe.ScbeStorageService, 1)
+			services[0].Name = profileName
+			fakeSimpleRestClient.GetStub = OverrideGetStub(services)
+			fakeSimpleRestClient.PostReturns(restErr)
+			_, err := scbeRestClient.CloneVolume(context.Background(), volName, profileName, "sourceWwn")
 			Expect(err).To(HaveOccurred())
 		})
 	})
 	Context(".Login", func() {
 		It("succeed upon simple rest client success", func() {
-			err = scbeRestClient.Login()
+			err = scbeRestClient.Login(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeSimpleRestClient.LoginCallCount()).To(Equal(1))
 		})
 		It("fail upon simple rest client error", func() {
 			fakeSimpleRestClient.LoginReturns(restErr)
-			err = scbeRestClient.Login()
+			err = scbeRestClient.Login(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(restErr))
 		})
 	})
 	Context(".DeleteVolume", func() {
 		It("succeed upon simple rest client success", func() {
-			err = scbeRestClient.DeleteVolume(volName)
+			err = scbeRestClient.DeleteVolume(context.Background(), volName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeSimpleRestClient.DeleteCallCount()).To(Equal(1))
-			url, payload, status := fakeSimpleRestClient.DeleteArgsForCall(0)
+			_, url, payload, status := fakeSimpleRestClient.DeleteArgsForCall(0)
 			Expect(url).To(Equal(scbe.UrlScbeResourceVolume + "/" + volName))
 			Expect(payload).To(Equal([]byte{}))
 			Expect(status).To(Equal(scbe.HTTP_SUCCEED_DELETED))
 		})
 		It("fail upon simple rest client error", func() {
 			fakeSimpleRestClient.DeleteReturns(restErr)
-			err = scbeRestClient.DeleteVolume(volName)
+			err = scbeRestClient.DeleteVolume(context.Background(), volName)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context(".ResizeVolume", func() {
+		It("succeed upon simple rest client success", func() {
+			_, err = scbeRestClient.ResizeVolume(context.Background(), volIdentifier, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSimpleRestClient.PutCallCount()).To(Equal(1))
+			_, url, payload, status, _ := fakeSimpleRestClient.PutArgsForCall(0)
+			Expect(url).To(Equal(scbe.UrlScbeResourceVolume + "/" + volIdentifier))
+			Expect(string(payload)).To(Equal(`{"size":2,"size_unit":"` + scbe.DefaultSizeUnit + `"}`))
+			Expect(status).To(Equal(scbe.HTTP_SUCCEED))
+		})
+		It("fail upon simple rest client error", func() {
+			fakeSimpleRestClient.PutReturns(restErr)
+			_, err = scbeRestClient.ResizeVolume(context.Background(), volIdentifier, 2)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context(".CreateSnapshot", func() {
+		It("succeed upon simple rest client success", func() {
+			_, err = scbeRestClient.CreateSnapshot(context.Background(), volIdentifier, "snap1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSimpleRestClient.PostCallCount()).To(Equal(1))
+			_, url, payload, status, _ := fakeSimpleRestClient.PostArgsForCall(0)
+			Expect(url).To(Equal(scbe.UrlScbeResourceSnapshot))
+			Expect(string(payload)).To(Equal(`{"volume_id":"` + volIdentifier + `","name":"snap1"}`))
+			Expect(status).To(Equal(scbe.HTTP_SUCCEED_POST))
+		})
+		It("fail upon simple rest client error", func() {
+			fakeSimpleRestClient.PostReturns(restErr)
+			_, err = scbeRestClient.CreateSnapshot(context.Background(), volIdentifier, "snap1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context(".DeleteSnapshot", func() {
+		It("succeed upon simple rest client success", func() {
+			err = scbeRestClient.DeleteSnapshot(context.Background(), volIdentifier)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSimpleRestClient.DeleteCallCount()).To(Equal(1))
+			_, url, _, status := fakeSimpleRestClient.DeleteArgsForCall(0)
+			Expect(url).To(Equal(scbe.UrlScbeResourceSnapshot + "/" + volIdentifier))
+			Expect(status).To(Equal(scbe.HTTP_SUCCEED_DELETED))
+		})
+		It("fail upon simple rest client error", func() {
+			fakeSimpleRestClient.DeleteReturns(restErr)
+			err = scbeRestClient.DeleteSnapshot(context.Background(), volIdentifier)
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -144,7 +225,7 @@ var _ = Describe("ScbeRestClient", func() {
 				{Name: volName + "2", ScsiIdentifier: volIdentifier + "2", ServiceName: profileName + "2"},
 			}
 			fakeSimpleRestClient.GetStub = OverrideGetStub(volumes)
-			volumesInfo, err := scbeRestClient.GetVolumes("")
+			volumesInfo, err := scbeRestClient.GetVolumes(context.Background(), "")
 			Expect(err).NotTo(HaveOccurred())
 			for index, volInfo := range volumesInfo {
 				indexStr := strconv.Itoa(index)
@@ -158,7 +239,7 @@ var _ = Describe("ScbeRestClient", func() {
 				{Name: volName, ScsiIdentifier: volIdentifier, ServiceName: profileName},
 			}
 			fakeSimpleRestClient.GetStub = OverrideGetStub(volumes)
-			volumesInfo, err := scbeRestClient.GetVolumes(volIdentifier)
+			volumesInfo, err := scbeRestClient.GetVolumes(context.Background(), volIdentifier)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(volumesInfo)).To(Equal(1))
 			volInfo := volumesInfo[0]
@@ -167,13 +248,13 @@ var _ = Describe("ScbeRestClient", func() {
 			Expect(volInfo.Profile).To(Equal(profileName))
 		})
 		It("succeed and return no ScbeVolumeInfo", func() {
-			volumesInfo, err := scbeRestClient.GetVolumes(volIdentifier)
+			volumesInfo, err := scbeRestClient.GetVolumes(context.Background(), volIdentifier)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(volumesInfo)).To(Equal(0))
 		})
 		It("fail upon SimpleRestClient error", func() {
 			fakeSimpleRestClient.GetReturns(restErr)
-			_, err := scbeRestClient.GetVolumes(volIdentifier)
+			_, err := scbeRestClient.GetVolumes(context.Background(), volIdentifier)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(restErr))
 		})
@@ -181,54 +262,54 @@ var _ = Describe("ScbeRestClient", func() {
 	Context(".GetVolMapping", func() {
 		It("succeed with 1 mapping found", func() {
 			fakeSimpleRestClient.GetStub = GetVolMappingStubSuccess()
-			volMapInfo, err := scbeRestClient.GetVolMapping("fakeWwn1")
+			volMapInfo, err := scbeRestClient.GetVolMapping(context.Background(), "fakeWwn1")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(volMapInfo.Host).To(Equal(fakeHost))
 			Expect(volMapInfo.LunNumber).To(Equal(fakeLunNumber))
 		})
 		It("succeed with 0 mapping found", func() {
 			fakeSimpleRestClient.GetStub = GetVolMappingStubSuccess()
-			volMapInfo, err := scbeRestClient.GetVolMapping("fakeWwn0")
+			volMapInfo, err := scbeRestClient.GetVolMapping(context.Background(), "fakeWwn0")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(volMapInfo.Host).To(Equal(""))
 			Expect(volMapInfo.LunNumber).To(Equal(scbe.LunNumberNoMapping))
 		})
 		It("fail with 2 mapping found", func() {
 			fakeSimpleRestClient.GetStub = GetVolMappingStubSuccess()
-			_, err := scbeRestClient.GetVolMapping("fakeWwn2")
+			_, err := scbeRestClient.GetVolMapping(context.Background(), "fakeWwn2")
 			Expect(err).To(HaveOccurred())
 		})
 		It("fail if get mapping failed", func() {
 			fakeSimpleRestClient.GetStub = GetVolMappingStubSuccess()
-			_, err := scbeRestClient.GetVolMapping("fakeWwnGetMapFail")
+			_, err := scbeRestClient.GetVolMapping(context.Background(), "fakeWwnGetMapFail")
 			Expect(err).To(HaveOccurred())
 		})
 		It("fail if get host failed", func() {
 			fakeSimpleRestClient.GetStub = GetVolMappingStubSuccess()
-			_, err := scbeRestClient.GetVolMapping("fakeWwnGetHostFail")
+			_, err := scbeRestClient.GetVolMapping(context.Background(), "fakeWwnGetHostFail")
 			Expect(err).To(HaveOccurred())
 		})
 	})
 })
 
-func OverrideGetStub(override interface{}) func(resource_url string, params map[string]string, exitStatus int, v interface{}) error {
+func OverrideGetStub(override interface{}) func(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error {
 	data, err := json.Marshal(override)
 	Expect(err).NotTo(HaveOccurred())
-	return func(resource_url string, params map[string]string, exitStatus int, v interface{}) error {
+	return func(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error {
 		return json.Unmarshal(data, v)
 	}
 }
 
-func OverridePostStub(override interface{}) func(resource_url string, payload []byte, exitStatus int, v interface{}) error {
+func OverridePostStub(override interface{}) func(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error {
 	data, err := json.Marshal(override)
 	Expect(err).NotTo(HaveOccurred())
-	return func(resource_url string, payload []byte, exitStatus int, v interface{}) error {
+	return func(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error {
 		return json.Unmarshal(data, v)
 	}
 }
 
-func GetVolMappingStubSuccess() func(resource_url string, params map[string]string, exitStatus int, v interface{}) error {
-	return func(resource_url string, params map[string]string, exitStatus int, v interface{}) error {
+func GetVolMappingStubSuccess() func(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error {
+	return func(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error {
 		hostNum := 99
 		if strings.Contains(resource_url, scbe.UrlScbeResourceMapping+"") {
 			volWwn, _ := params["volume"]
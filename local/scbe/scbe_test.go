@@ -17,11 +17,14 @@
 package scbe_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 
+	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/fakes"
 	"github.com/IBM/ubiquity/local/scbe"
 	"github.com/IBM/ubiquity/resources"
@@ -50,12 +53,14 @@ var _ = Describe("scbeLocalClient init", func() {
 		client             resources.StorageClient
 		fakeScbeDataModel  *fakes.FakeScbeDataModelWrapper
 		fakeScbeRestClient *fakes.FakeScbeRestClient
+		fakeJournal        *fakes.FakeJournal
 		fakeConfig         resources.ScbeConfig
 		err                error
 	)
 	BeforeEach(func() {
 		fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 		fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+		fakeJournal = new(fakes.FakeJournal)
 	})
 	Context(".init", func() {
 		It("should fail because DefaultVolumeSize is not int", func() {
@@ -65,7 +70,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.ConfigDefaultSizeNotNumError)
 			Expect(ok).To(Equal(true))
@@ -77,7 +82,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.ConfigDefaultFilesystemTypeNotSupported)
 			Expect(ok).To(Equal(true))
@@ -90,7 +95,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.ConfigScbeUbiquityInstanceNameWrongSize)
 			Expect(ok).To(Equal(true))
@@ -106,7 +111,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).NotTo(HaveOccurred())
 		})
 		It("should succeed to init because config is ok (ext4)", func() {
@@ -121,7 +126,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).NotTo(HaveOccurred())
 		})
 		It("should succeed to init because config is ok (xsf)", func() {
@@ -134,7 +139,7 @@ var _ = Describe("scbeLocalClient init", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -146,12 +151,14 @@ var _ = Describe("scbeLocalClient", func() {
 		client             resources.StorageClient
 		fakeScbeDataModel  *fakes.FakeScbeDataModelWrapper
 		fakeScbeRestClient *fakes.FakeScbeRestClient
+		fakeJournal        *fakes.FakeJournal
 		fakeConfig         resources.ScbeConfig
 		err                error
 	)
 	BeforeEach(func() {
 		fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 		fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+		fakeJournal = new(fakes.FakeJournal)
 		fakeConfig = resources.ScbeConfig{
 			ConfigPath:           "/tmp",
 			DefaultService:       fakeDefaultProfile,
@@ -165,7 +172,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(0))
@@ -177,7 +184,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
@@ -189,7 +196,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(MatchRegexp("^Spectrum Connect backend activation error. The default service .* does not exist on Spectrum Connect"))
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
@@ -202,7 +209,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
@@ -214,7 +221,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
@@ -229,6 +236,7 @@ var _ = Describe("scbeLocalClient", func() {
 		client             resources.StorageClient
 		fakeScbeDataModel  *fakes.FakeScbeDataModelWrapper
 		fakeScbeRestClient *fakes.FakeScbeRestClient
+		fakeJournal        *fakes.FakeJournal
 		fakeConfig         resources.ScbeConfig
 		fakeErr            error = errors.New("fake error")
 		err                error
@@ -236,6 +244,7 @@ var _ = Describe("scbeLocalClient", func() {
 	BeforeEach(func() {
 		fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 		fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+		fakeJournal = new(fakes.FakeJournal)
 		fakeConfig = resources.ScbeConfig{
 			ConfigPath:           "/tmp",
 			DefaultService:       fakeDefaultProfile,
@@ -246,7 +255,7 @@ var _ = Describe("scbeLocalClient", func() {
 		client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 			fakeConfig,
 			fakeScbeDataModel,
-			fakeScbeRestClient)
+			fakeScbeRestClient, fakeJournal)
 		Expect(err).ToNot(HaveOccurred())
 	})
 	Context(".CreateVolume", func() {
@@ -280,6 +289,10 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.FsTypeNotSupportedError)
 			Expect(ok).To(Equal(true))
+			codedError, ok := err.(resources.CodedError)
+			Expect(ok).To(Equal(true))
+			Expect(codedError.ErrorCode()).To(Equal(resources.ErrorCodeFsTypeNotSupported))
+			Expect(codedError.HttpStatus()).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail create volume if vol len exeeded", func() {
@@ -325,7 +338,7 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("error"))
 			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(1))
-			volname, profile, size := fakeScbeRestClient.CreateVolumeArgsForCall(0)
+			_, volname, profile, size := fakeScbeRestClient.CreateVolumeArgsForCall(0)
 			Expect(profile).To(Equal(fakeDefaultProfile))
 			Expect(size).To(Equal(100))
 			expectedVolName := fmt.Sprintf(scbe.ComposeVolumeName, fakeConfig.UbiquityInstanceName, volFake)
@@ -344,7 +357,7 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("error"))
 			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(1))
-			volname, profile, size := fakeScbeRestClient.CreateVolumeArgsForCall(0)
+			_, volname, profile, size := fakeScbeRestClient.CreateVolumeArgsForCall(0)
 			Expect(profile).To(Equal("gold"))
 			Expect(size).To(Equal(100))
 			expectedVolName := fmt.Sprintf(scbe.ComposeVolumeName, fakeConfig.UbiquityInstanceName, volFake)
@@ -366,7 +379,7 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("error"))
 			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(1))
-			name, wwn, fstype := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			name, wwn, fstype, _, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
 			Expect(name).To(Equal(volFake))
 			Expect(wwn).To(Equal("wwn1"))
 			Expect(fstype).To(Equal("ext4"))
@@ -385,11 +398,79 @@ var _ = Describe("scbeLocalClient", func() {
 			err = client.CreateVolume(req)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(1))
-			name, wwn, fstype := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			name, wwn, fstype, _, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
 			Expect(name).To(Equal(volFake))
 			Expect(wwn).To(Equal("wwn1"))
 			Expect(fstype).To(Equal("ext4"))
 		})
+		It("should journal the create and complete it once the vol is inserted to DB", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: "gold"}, nil)
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: map[string]interface{}{scbe.OptionNameForServiceName: "gold"}}
+			err = client.CreateVolume(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeJournal.BeginCallCount()).To(Equal(1))
+			operation := fakeJournal.BeginArgsForCall(0)
+			Expect(operation.Type).To(Equal(database.OperationCreateVolume))
+			Expect(operation.VolumeName).To(Equal("fakevol"))
+			Expect(operation.StorageName).To(Equal("u_fakeInstance1_fakevol"))
+			Expect(fakeJournal.UpdateArgsForCall(0).StorageId).To(Equal("wwn1"))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(1))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(0))
+		})
+		It("should fail create volume before provisioning it if the journal cannot be written", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeJournal.BeginReturns(fakeErr)
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: nil}
+			err = client.CreateVolume(req)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(0))
+		})
+		It("should roll back the create if the vol creation failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{}, fakeErr)
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: nil}
+			err = client.CreateVolume(req)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+		})
+		It("should delete the vol from the storage and roll back the create if the vol creation failed after SCBE created it", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{}, context.DeadlineExceeded)
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{{Name: "u_fakeInstance1_fakevol", Wwn: "wwn1"}}, nil)
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: nil}
+			err = client.CreateVolume(req)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
+			_, wwn := fakeScbeRestClient.DeleteVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should delete the vol from the storage and roll back the create if the insert to DB failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: "gold"}, nil)
+			fakeScbeDataModel.InsertVolumeReturns(fakeErr)
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: nil}
+			err = client.CreateVolume(req)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
+			_, wwn := fakeScbeRestClient.DeleteVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(0))
+		})
+		It("should leave the create pending if the vol cannot be deleted from the storage after the insert to DB failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: "gold"}, nil)
+			fakeScbeDataModel.InsertVolumeReturns(fakeErr)
+			fakeScbeRestClient.DeleteVolumeReturns(errors.New("delete error"))
+			req := resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: nil}
+			err = client.CreateVolume(req)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(0))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(0))
+		})
 		It("should succeed to insert vol to DB even if size not provided", func() {
 			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
 			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: "gold"}, nil)
@@ -403,7 +484,7 @@ var _ = Describe("scbeLocalClient", func() {
 			err = client.CreateVolume(req)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(1))
-			name, wwn, fstype := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			name, wwn, fstype, _, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
 			Expect(name).To(Equal(volFake))
 			Expect(wwn).To(Equal("wwn1"))
 			Expect(fstype).To(Equal("ext4"))
@@ -421,13 +502,253 @@ var _ = Describe("scbeLocalClient", func() {
 			err = client.CreateVolume(req)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(1))
-			name, wwn, fstype := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			name, wwn, fstype, _, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
 			Expect(name).To(Equal(volFake))
 			Expect(wwn).To(Equal("wwn1"))
 			Expect(fstype).To(Equal("xfs"))
 		})
+		It("should insert the labels of the volume to DB", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.CreateVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: "gold"}, nil)
+			opts := make(map[string]interface{})
+			opts[resources.OptionNameForLabels] = "app=db, tier=gold"
+
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, labels := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			Expect(labels).To(Equal(map[string]string{"app": "db", "tier": "gold"}))
+		})
+		It("should fail to create the volume if the labels are invalid", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			opts := make(map[string]interface{})
+			opts[resources.OptionNameForLabels] = "app"
+
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.InvalidLabelsError)
+			Expect(ok).To(BeTrue())
+			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(0))
+		})
 
 	})
+	Context(".CheckHealth", func() {
+		It("should fail if login to SCBE fails", func() {
+			fakeScbeRestClient.LoginReturns(fakeErr)
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1)) // only during the client creation
+		})
+		It("should fail if the default service does not exist", func() {
+			fakeScbeRestClient.ServiceExistReturns(false, nil)
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).To(HaveOccurred())
+		})
+		It("should succeed if SCBE is reachable and the default service exists", func() {
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(2))
+			_, serviceName := fakeScbeRestClient.ServiceExistArgsForCall(1)
+			Expect(serviceName).To(Equal(fakeDefaultProfile))
+		})
+	})
+	Context(".CreateVolume clone", func() {
+		var opts map[string]interface{}
+		BeforeEach(func() {
+			opts = make(map[string]interface{})
+			opts[resources.OptionNameForSourceVolume] = "sourcevol"
+			fakeScbeDataModel.GetVolumeReturnsOnCall(0, scbe.ScbeVolume{}, nil)
+			fakeScbeDataModel.GetVolumeReturnsOnCall(1, scbe.ScbeVolume{WWN: "sourcewwn", FSType: "xfs"}, nil)
+			fakeScbeRestClient.CloneVolumeReturns(scbe.ScbeVolumeInfo{Name: "v1", Wwn: "wwn1", Profile: fakeDefaultProfile}, nil)
+		})
+		It("should fail if source snapshot is given without source volume", func() {
+			delete(opts, resources.OptionNameForSourceVolume)
+			opts[resources.OptionNameForSourceSnapshot] = "snap1"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.CloneSourceSnapshotWithoutVolumeError)
+			Expect(ok).To(BeTrue())
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the source volume does not exist", func() {
+			fakeScbeDataModel.GetVolumeReturnsOnCall(1, scbe.ScbeVolume{}, &resources.VolumeNotFoundError{VolName: "sourcevol"})
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the fstype differs from the source volume fstype", func() {
+			opts[resources.OptionNameForVolumeFsType] = "ext4"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail before creating the LUN if the clone is named like the Ubiquity DB volume", func() {
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "ibm-ubiquity-db", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(BeAssignableToTypeOf(&resources.CloneNotSupportedForVolumeError{}))
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeDataModel.InsertClonedVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if CloneVolume failed", func() {
+			fakeScbeRestClient.CloneVolumeReturns(scbe.ScbeVolumeInfo{}, fakeErr)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeDataModel.InsertClonedVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should delete the clone from the storage if CloneVolume failed after SCBE created it", func() {
+			fakeScbeRestClient.CloneVolumeReturns(scbe.ScbeVolumeInfo{}, fakeErr)
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{{Name: "u_fakeInstance1_fakevol", Wwn: "wwn1"}}, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
+			_, wwn := fakeScbeRestClient.DeleteVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should clone the source volume and record its origin", func() {
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(1))
+			_, volName, profile, sourceWwn := fakeScbeRestClient.CloneVolumeArgsForCall(0)
+			Expect(volName).To(Equal("u_fakeInstance1_fakevol"))
+			Expect(profile).To(Equal(fakeDefaultProfile))
+			Expect(sourceWwn).To(Equal("sourcewwn"))
+			Expect(fakeScbeDataModel.InsertClonedVolumeCallCount()).To(Equal(1))
+			name, wwn, fstype, sourceVolume, sourceSnapshot, _ := fakeScbeDataModel.InsertClonedVolumeArgsForCall(0)
+			Expect(name).To(Equal("fakevol"))
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fstype).To(Equal("xfs"))
+			Expect(sourceVolume).To(Equal("sourcevol"))
+			Expect(sourceSnapshot).To(Equal(""))
+		})
+		It("should clone the snapshot of the source volume", func() {
+			opts[resources.OptionNameForSourceSnapshot] = "snap1"
+			fakeScbeDataModel.GetSnapshotReturns(resources.Snapshot{Name: "snap1", VolumeName: "sourcevol", StorageId: "snapwwn"}, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).NotTo(HaveOccurred())
+			volName, snapName, mustExist := fakeScbeDataModel.GetSnapshotArgsForCall(0)
+			Expect(volName).To(Equal("sourcevol"))
+			Expect(snapName).To(Equal("snap1"))
+			Expect(mustExist).To(BeTrue())
+			_, _, _, sourceWwn := fakeScbeRestClient.CloneVolumeArgsForCall(0)
+			Expect(sourceWwn).To(Equal("snapwwn"))
+			_, _, _, sourceVolume, sourceSnapshot, _ := fakeScbeDataModel.InsertClonedVolumeArgsForCall(0)
+			Expect(sourceVolume).To(Equal("sourcevol"))
+			Expect(sourceSnapshot).To(Equal("snap1"))
+		})
+		It("should fail if the snapshot of the source volume does not exist", func() {
+			opts[resources.OptionNameForSourceSnapshot] = "snap1"
+			fakeScbeDataModel.GetSnapshotReturns(resources.Snapshot{}, &resources.SnapshotNotFoundError{VolName: "sourcevol", SnapName: "snap1"})
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+		})
+	})
+	Context(".CreateVolume adopt", func() {
+		var opts map[string]interface{}
+		BeforeEach(func() {
+			opts = make(map[string]interface{})
+			opts[scbe.OptionNameForVolumeWwn] = "wwn1"
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{{Name: "lun1", Wwn: "wwn1", Profile: fakeDefaultProfile}}, nil)
+		})
+		It("should adopt the volume by its wwn and mark it as preexisting", func() {
+			opts[resources.OptionNameForVolumeFsType] = "xfs"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.CreateVolumeCallCount()).To(Equal(0))
+			_, wwn := fakeScbeRestClient.GetVolumesArgsForCall(fakeScbeRestClient.GetVolumesCallCount() - 1)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(1))
+			name, wwn, fstype, isPreexisting, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			Expect(name).To(Equal("fakevol"))
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fstype).To(Equal("xfs"))
+			Expect(isPreexisting).To(BeTrue())
+		})
+		It("should adopt the volume by its Spectrum Connect name", func() {
+			delete(opts, scbe.OptionNameForVolumeWwn)
+			opts[scbe.OptionNameForScVolume] = "lun2"
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{
+				{Name: "lun1", Wwn: "wwn1", Profile: fakeDefaultProfile},
+				{Name: "lun2", Wwn: "wwn2", Profile: fakeDefaultProfile}}, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).NotTo(HaveOccurred())
+			_, wwn := fakeScbeRestClient.GetVolumesArgsForCall(fakeScbeRestClient.GetVolumesCallCount() - 1)
+			Expect(wwn).To(Equal(""))
+			_, wwn, fstype, isPreexisting, _ := fakeScbeDataModel.InsertVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn2"))
+			Expect(fstype).To(Equal("ext4"))
+			Expect(isPreexisting).To(BeTrue())
+		})
+		It("should fail if the volume is not found on the storage system", func() {
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{}, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*scbe.VolumeNotFoundOnArrayError)
+			Expect(ok).To(BeTrue())
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if several volumes have the given Spectrum Connect name", func() {
+			delete(opts, scbe.OptionNameForVolumeWwn)
+			opts[scbe.OptionNameForScVolume] = "lun1"
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{
+				{Name: "lun1", Wwn: "wwn1", Profile: fakeDefaultProfile},
+				{Name: "lun1", Wwn: "wwn2", Profile: fakeDefaultProfile}}, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the volume belongs to another service", func() {
+			opts[scbe.OptionNameForServiceName] = "gold"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the volume is already managed by ubiquity", func() {
+			fakeScbeDataModel.GetVolumeByWwnReturns(scbe.ScbeVolume{Volume: resources.Volume{Name: "vol1", Backend: "scbe-other"}, WWN: "WWN1"}, true, nil)
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("vol1"))
+			Expect(fakeScbeDataModel.GetVolumeByWwnArgsForCall(0)).To(Equal("wwn1"))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the managed volumes cannot be checked", func() {
+			fakeScbeDataModel.GetVolumeByWwnReturns(scbe.ScbeVolume{}, false, errors.New("fake error"))
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the wwn is not a string", func() {
+			opts[scbe.OptionNameForVolumeWwn] = 42
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(BeAssignableToTypeOf(&scbe.InValidRequestError{}))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the Spectrum Connect name is not a string", func() {
+			delete(opts, scbe.OptionNameForVolumeWwn)
+			opts[scbe.OptionNameForScVolume] = []interface{}{"lun1"}
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(BeAssignableToTypeOf(&scbe.InValidRequestError{}))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if both the wwn and the Spectrum Connect name are given", func() {
+			opts[scbe.OptionNameForScVolume] = "lun1"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(scbe.OptionNameForVolumeWwn))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if a clone source is given too", func() {
+			opts[resources.OptionNameForSourceVolume] = "sourcevol"
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "fakevol", Backend: resources.SCBE, Opts: opts})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(scbe.OptionNameForVolumeWwn))
+			Expect(fakeScbeDataModel.InsertVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeRestClient.CloneVolumeCallCount()).To(Equal(0))
+		})
+	})
 })
 
 var _ = Describe("scbeLocalClient", func() {
@@ -435,6 +756,7 @@ var _ = Describe("scbeLocalClient", func() {
 		client             resources.StorageClient
 		fakeScbeDataModel  *fakes.FakeScbeDataModelWrapper
 		fakeScbeRestClient *fakes.FakeScbeRestClient
+		fakeJournal        *fakes.FakeJournal
 		fakeConfig         resources.ScbeConfig
 		fakeErr            error = errors.New("fake error")
 		err                error
@@ -442,6 +764,7 @@ var _ = Describe("scbeLocalClient", func() {
 	BeforeEach(func() {
 		fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 		fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+		fakeJournal = new(fakes.FakeJournal)
 		fakeConfig = resources.ScbeConfig{
 			ConfigPath:     "/tmp",
 			DefaultService: fakeDefaultProfile}
@@ -451,7 +774,7 @@ var _ = Describe("scbeLocalClient", func() {
 		client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 			fakeConfig,
 			fakeScbeDataModel,
-			fakeScbeRestClient)
+			fakeScbeRestClient, fakeJournal)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 		Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
@@ -462,6 +785,58 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(Not(HaveOccurred()))
 		})
 	})
+	Context(".ListServices", func() {
+		It("should return the services with their capacity", func() {
+			fakeScbeRestClient.ListServicesReturns([]scbe.ScbeStorageService{
+				{Name: "gold", TotalCapacity: 1000, UsedCapacity: 400, PhysicalFree: 600},
+				{Name: "silver", TotalCapacity: 500, UsedCapacity: 500, PhysicalFree: 0},
+			}, nil)
+			services, err := client.ListServices(resources.ListServicesRequest{})
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(services).To(Equal([]resources.StorageService{
+				{Name: "gold", Backend: resources.SCBE, TotalCapacity: 1000, UsedCapacity: 400, FreeCapacity: 600},
+				{Name: "silver", Backend: resources.SCBE, TotalCapacity: 500, UsedCapacity: 500, FreeCapacity: 0},
+			}))
+		})
+		It("should fail if the services cannot be listed", func() {
+			fakeScbeRestClient.ListServicesReturns(nil, fakeErr)
+			_, err := client.ListServices(resources.ListServicesRequest{})
+			Expect(err).To(Equal(fakeErr))
+		})
+	})
+	Context(".Reconcile", func() {
+		It("should report the dangling volumes and the unmanaged volumes of the instance", func() {
+			// the instance name is empty in this config
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{
+				{Name: "u__vol1", Wwn: "WWN1"},
+				{Name: "u__leaked", Wwn: "wwn3"},
+				{Name: "u__ibm-ubiquity-db", Wwn: "wwndb"},
+				{Name: "u_otherInstance_vol", Wwn: "wwn4"},
+			}, nil)
+			fakeScbeDataModel.ListStoredVolumesReturns([]scbe.ScbeVolume{
+				{Volume: resources.Volume{Name: "vol1"}, WWN: "wwn1"},
+				{Volume: resources.Volume{Name: "vol2"}, WWN: "wwn2"},
+			}, nil)
+			report, err := client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(report).To(Equal(resources.ReconcileReport{
+				Backend:          resources.SCBE,
+				DanglingVolumes:  []resources.Orphan{{Name: "vol2", StorageId: "wwn2"}},
+				UnmanagedStorage: []resources.Orphan{{Name: "u__leaked", StorageId: "wwn3"}},
+			}))
+		})
+		It("should fail if the volumes in the DB cannot be listed", func() {
+			fakeScbeDataModel.ListStoredVolumesReturns(nil, fakeErr)
+			_, err := client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).To(Equal(fakeErr))
+		})
+		It("should fail if the volumes in SCBE cannot be listed", func() {
+			fakeScbeRestClient.GetVolumesReturns(nil, fakeErr)
+			_, err := client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).To(Equal(fakeErr))
+			Expect(fakeScbeDataModel.ListStoredVolumesCallCount()).To(Equal(0))
+		})
+	})
 	Context(".Attach", func() {
 		It("should fail to attach request is bad", func() {
 			_, err := client.Attach(resources.AttachRequest{Name: "AAA", Host: scbe.EmptyHost})
@@ -492,6 +867,22 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(fakeErr))
 			Expect(fakeScbeRestClient.MapVolumeCallCount()).To(Equal(1))
+			Expect(fakeScbeDataModel.UpdateVolumeAttachedHostCallCount()).To(Equal(0))
+		})
+		It("should record the host the volume is attached to", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			_, err := client.Attach(fakeAttachRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeDataModel.UpdateVolumeAttachedHostCallCount()).To(Equal(1))
+			name, host := fakeScbeDataModel.UpdateVolumeAttachedHostArgsForCall(0)
+			Expect(name).To(Equal(fakeAttachRequest.Name))
+			Expect(host).To(Equal(fakeAttachRequest.Host))
+		})
+		It("should fail to attach the volume if the attached host cannot be recorded", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			fakeScbeDataModel.UpdateVolumeAttachedHostReturns(fakeErr)
+			_, err := client.Attach(fakeAttachRequest)
+			Expect(err).To(MatchError(fakeErr))
 		})
 	})
 	Context(".Detach", func() {
@@ -518,6 +909,17 @@ var _ = Describe("scbeLocalClient", func() {
 			fmt.Println(fakeErr)
 			Expect(err).To(MatchError(fakeErr))
 			Expect(fakeScbeRestClient.UnmapVolumeCallCount()).To(Equal(1))
+			Expect(fakeScbeDataModel.UpdateVolumeAttachedHostCallCount()).To(Equal(0))
+		})
+		It("should clear the host the volume is attached to", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeRestClient.GetVolMappingReturns(fakeVolMapInfo, nil)
+			err := client.Detach(fakeDetachRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeDataModel.UpdateVolumeAttachedHostCallCount()).To(Equal(1))
+			name, host := fakeScbeDataModel.UpdateVolumeAttachedHostArgsForCall(0)
+			Expect(name).To(Equal(fakeDetachRequest.Name))
+			Expect(host).To(Equal(scbe.EmptyHost))
 		})
 	})
 	Context(".GetVolumeConfig", func() {
@@ -619,6 +1021,13 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
 			Expect(fakeScbeDataModel.DeleteVolumeCallCount()).To(Equal(1))
 		})
+		It("should keep a preexisting volume on the storage system", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1", IsPreexisting: true}, nil)
+			err := client.RemoveVolume(fakeRemoveRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeDataModel.DeleteVolumeCallCount()).To(Equal(1))
+		})
 		It("should succeed if getvolume returns volume not found", func() {
 			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, &resources.VolumeNotFoundError{VolName: "vol1"})
 			err := client.RemoveVolume(fakeRemoveRequest)
@@ -632,6 +1041,32 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(fakeScbeDataModel.GetVolumeCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
 		})
+		It("should journal the remove and complete it", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			err := client.RemoveVolume(fakeRemoveRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeJournal.BeginCallCount()).To(Equal(1))
+			operation := fakeJournal.BeginArgsForCall(0)
+			Expect(operation.Type).To(Equal(database.OperationRemoveVolume))
+			Expect(operation.StorageId).To(Equal("wwn1"))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(1))
+		})
+		It("should roll back the remove if fail to delete vol from system", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			fakeScbeRestClient.DeleteVolumeReturns(fakeErr)
+			err := client.RemoveVolume(fakeRemoveRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(0))
+		})
+		It("should leave the remove pending if fail to delete from DB", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			fakeScbeDataModel.DeleteVolumeReturns(fakeErr)
+			err := client.RemoveVolume(fakeRemoveRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(0))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(0))
+		})
 		It("should succeed if delete volume from db retuns that volume does not exists.", func() {
 			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
 			fakeScbeRestClient.DeleteVolumeReturns(nil)
@@ -644,6 +1079,235 @@ var _ = Describe("scbeLocalClient", func() {
 			Expect(fakeScbeDataModel.DeleteVolumeCallCount()).To(Equal(1))
 		})
 	})
+	Context(".RecoverOperations", func() {
+		It("should do nothing if there is no pending operation", func() {
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the pending operations cannot be listed", func() {
+			fakeJournal.ListPendingReturns(nil, fakeErr)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).To(MatchError(fakeErr))
+		})
+		It("should complete a create whose vol is in DB", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageId: "wwn1"}}, nil)
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{ID: 1, WWN: "wwn1"}, nil)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(1))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+		})
+		It("should delete the vol of a create that is not in DB and roll the create back", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageId: "wwn1"}}, nil)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			_, wwn := fakeScbeRestClient.DeleteVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should find the vol of a create by its storage name if its wwn was not recorded", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageName: "u_fakeInstance1_vol1"}}, nil)
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{{Name: "u_fakeInstance1_vol2", Wwn: "wwn2"}, {Name: "u_fakeInstance1_vol1", Wwn: "wwn1"}}, nil)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
+			_, wwn := fakeScbeRestClient.DeleteVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should roll back a create whose vol was not created", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageName: "u_fakeInstance1_vol1"}}, nil)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(1))
+		})
+		It("should leave a create pending if its vol cannot be deleted", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationCreateVolume, VolumeName: "vol1", StorageId: "wwn1"}}, nil)
+			fakeScbeRestClient.DeleteVolumeReturns(fakeErr)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeJournal.RollBackCallCount()).To(Equal(0))
+		})
+		It("should finish a remove whose vol is still in DB", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationRemoveVolume, VolumeName: "vol1", StorageId: "wwn1"}}, nil)
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{ID: 1, WWN: "wwn1"}, nil)
+			fakeScbeRestClient.DeleteVolumeReturns(&scbe.BadHttpStatusCodeError{HttpStatusCode: 404})
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(1))
+			Expect(fakeScbeDataModel.DeleteVolumeCallCount()).To(Equal(1))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(1))
+		})
+		It("should complete a remove whose vol is not in DB anymore", func() {
+			fakeJournal.ListPendingReturns([]database.Operation{{Type: database.OperationRemoveVolume, VolumeName: "vol1", StorageId: "wwn1"}}, nil)
+			err := client.(resources.OperationRecoverer).RecoverOperations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+			Expect(fakeScbeDataModel.DeleteVolumeCallCount()).To(Equal(0))
+			Expect(fakeJournal.CompleteCallCount()).To(Equal(1))
+		})
+	})
+	Context(".Remove with snapshots", func() {
+		It("should fail to remove the volume if it has snapshots", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, nil)
+			fakeScbeDataModel.ListSnapshotsReturns([]resources.Snapshot{{Name: "snap1", VolumeName: fakeVol}}, nil)
+			err := client.RemoveVolume(fakeRemoveRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.VolumeHasSnapshotsError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeScbeRestClient.DeleteVolumeCallCount()).To(Equal(0))
+		})
+	})
+	Context(".CreateSnapshot", func() {
+		var fakeCreateSnapshotRequest resources.CreateSnapshotRequest
+		BeforeEach(func() {
+			fakeCreateSnapshotRequest = resources.CreateSnapshotRequest{VolumeName: fakeVol, Name: "snap1"}
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+		})
+		It("should fail if GetVolume failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, fakeErr)
+			err := client.CreateSnapshot(fakeCreateSnapshotRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.CreateSnapshotCallCount()).To(Equal(0))
+		})
+		It("should fail if the snapshot already exists", func() {
+			fakeScbeDataModel.GetSnapshotReturns(resources.Snapshot{}, &resources.SnapshotAlreadyExistsError{VolName: fakeVol, SnapName: "snap1"})
+			err := client.CreateSnapshot(fakeCreateSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeRestClient.CreateSnapshotCallCount()).To(Equal(0))
+		})
+		It("should fail if the snapshot name is too long", func() {
+			fakeCreateSnapshotRequest.Name = strings.Repeat("s", scbe.MaxVolumeNameLength)
+			err := client.CreateSnapshot(fakeCreateSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*scbe.SnapshotNameExceededMaxLengthError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeScbeRestClient.CreateSnapshotCallCount()).To(Equal(0))
+		})
+		It("should fail if CreateSnapshot failed", func() {
+			fakeScbeRestClient.CreateSnapshotReturns(scbe.ScbeResponseSnapshot{}, fakeErr)
+			err := client.CreateSnapshot(fakeCreateSnapshotRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeDataModel.InsertSnapshotCallCount()).To(Equal(0))
+		})
+		It("should succeed to create the snapshot and insert it to DB", func() {
+			fakeScbeRestClient.CreateSnapshotReturns(scbe.ScbeResponseSnapshot{ScsiIdentifier: "snapwwn1"}, nil)
+			err := client.CreateSnapshot(fakeCreateSnapshotRequest)
+			Expect(err).NotTo(HaveOccurred())
+			_, wwn, snapName := fakeScbeRestClient.CreateSnapshotArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(snapName).To(Equal(fmt.Sprintf(scbe.ComposeSnapshotName, "", fakeVol, "snap1")))
+			Expect(fakeScbeDataModel.InsertSnapshotCallCount()).To(Equal(1))
+			volName, name, storageId := fakeScbeDataModel.InsertSnapshotArgsForCall(0)
+			Expect(volName).To(Equal(fakeVol))
+			Expect(name).To(Equal("snap1"))
+			Expect(storageId).To(Equal("snapwwn1"))
+		})
+	})
+	Context(".ListSnapshots", func() {
+		It("should fail if GetVolume failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, fakeErr)
+			_, err := client.ListSnapshots(resources.ListSnapshotsRequest{VolumeName: fakeVol})
+			Expect(err).To(MatchError(fakeErr))
+		})
+		It("should return the snapshots from DB", func() {
+			fakeScbeDataModel.ListSnapshotsReturns([]resources.Snapshot{{Name: "snap1"}, {Name: "snap2"}}, nil)
+			snapshots, err := client.ListSnapshots(resources.ListSnapshotsRequest{VolumeName: fakeVol})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(snapshots)).To(Equal(2))
+			Expect(fakeScbeDataModel.ListSnapshotsArgsForCall(0)).To(Equal(fakeVol))
+		})
+	})
+	Context(".DeleteSnapshot", func() {
+		var fakeDeleteSnapshotRequest resources.DeleteSnapshotRequest
+		BeforeEach(func() {
+			fakeDeleteSnapshotRequest = resources.DeleteSnapshotRequest{VolumeName: fakeVol, Name: "snap1"}
+			fakeScbeDataModel.GetSnapshotReturns(resources.Snapshot{Name: "snap1", StorageId: "snapwwn1"}, nil)
+		})
+		It("should succeed if the snapshot is not in DB", func() {
+			fakeScbeDataModel.GetSnapshotReturns(resources.Snapshot{}, &resources.SnapshotNotFoundError{VolName: fakeVol, SnapName: "snap1"})
+			err := client.DeleteSnapshot(fakeDeleteSnapshotRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.DeleteSnapshotCallCount()).To(Equal(0))
+		})
+		It("should fail if DeleteSnapshot failed", func() {
+			fakeScbeRestClient.DeleteSnapshotReturns(fakeErr)
+			err := client.DeleteSnapshot(fakeDeleteSnapshotRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeDataModel.DeleteSnapshotCallCount()).To(Equal(0))
+		})
+		It("should delete the snapshot from DB if it is not found in SC", func() {
+			fakeScbeRestClient.DeleteSnapshotReturns(&scbe.BadHttpStatusCodeError{HttpStatusCode: 404})
+			err := client.DeleteSnapshot(fakeDeleteSnapshotRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeDataModel.DeleteSnapshotCallCount()).To(Equal(1))
+		})
+		It("should succeed to delete the snapshot", func() {
+			err := client.DeleteSnapshot(fakeDeleteSnapshotRequest)
+			Expect(err).NotTo(HaveOccurred())
+			_, snapshotWwn := fakeScbeRestClient.DeleteSnapshotArgsForCall(0)
+			Expect(snapshotWwn).To(Equal("snapwwn1"))
+			Expect(fakeScbeDataModel.DeleteSnapshotCallCount()).To(Equal(1))
+		})
+	})
+	Context(".ExpandVolume", func() {
+		var fakeExpandRequest resources.ExpandVolumeRequest
+		BeforeEach(func() {
+			fakeExpandRequest = resources.ExpandVolumeRequest{Name: fakeVol, Opts: map[string]interface{}{"size": "2"}}
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{WWN: "wwn1"}, nil)
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{{Name: fakeVol, Wwn: "wwn1", LogicalCapacity: "1073741824"}}, nil)
+		})
+		It("should fail if GetVolume failed", func() {
+			fakeScbeDataModel.GetVolumeReturns(scbe.ScbeVolume{}, fakeErr)
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if size is not given", func() {
+			fakeExpandRequest.Opts = map[string]interface{}{}
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("size"))
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if size is not a number", func() {
+			fakeExpandRequest.Opts = map[string]interface{}{"size": "bad"}
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the volume is not found on the storage", func() {
+			fakeScbeRestClient.GetVolumesReturns([]scbe.ScbeVolumeInfo{}, nil)
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*scbe.VolumeNotFoundOnArrayError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if the new size is not bigger than the current size", func() {
+			fakeExpandRequest.Opts = map[string]interface{}{"size": "1"}
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be bigger"))
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(0))
+		})
+		It("should fail if ResizeVolume failed", func() {
+			fakeScbeRestClient.ResizeVolumeReturns(scbe.ScbeVolumeInfo{}, fakeErr)
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).To(MatchError(fakeErr))
+		})
+		It("should succeed to expand the volume", func() {
+			fakeScbeRestClient.ResizeVolumeReturns(scbe.ScbeVolumeInfo{}, nil)
+			err := client.ExpandVolume(fakeExpandRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeScbeRestClient.ResizeVolumeCallCount()).To(Equal(1))
+			_, wwn, size := fakeScbeRestClient.ResizeVolumeArgsForCall(0)
+			Expect(wwn).To(Equal("wwn1"))
+			Expect(size).To(Equal(2))
+		})
+	})
 
 })
 
@@ -652,6 +1316,7 @@ var _ = Describe("scbeLocalClient", func() {
 		client             resources.StorageClient
 		fakeScbeDataModel  *fakes.FakeScbeDataModelWrapper
 		fakeScbeRestClient *fakes.FakeScbeRestClient
+		fakeJournal        *fakes.FakeJournal
 		fakeConfig         resources.ScbeConfig
 		fakeCredentialInfo resources.CredentialInfo
 		fakeConnectionInfo resources.ConnectionInfo
@@ -670,13 +1335,14 @@ var _ = Describe("scbeLocalClient", func() {
 		It("call with same credentialInfo should not login again", func() {
 			fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 			fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+			fakeJournal = new(fakes.FakeJournal)
 			fakeScbeRestClient.LoginReturns(nil)
 			fakeScbeRestClient.ServiceExistReturns(true, nil)
 			defer scbe.InitScbeRestClientGen(GenFakeScbeRestClient(nil))()
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
@@ -690,6 +1356,7 @@ var _ = Describe("scbeLocalClient", func() {
 		It("call with other credentialInfo should login again and cache the new client", func() {
 			fakeScbeDataModel = new(fakes.FakeScbeDataModelWrapper)
 			fakeScbeRestClient = new(fakes.FakeScbeRestClient)
+			fakeJournal = new(fakes.FakeJournal)
 			fakeScbeRestClient.LoginReturns(nil)
 			fakeScbeRestClient.ServiceExistReturns(true, nil)
 			otherFakeScbeRestClient := new(fakes.FakeScbeRestClient)
@@ -699,7 +1366,7 @@ var _ = Describe("scbeLocalClient", func() {
 			client, err = scbe.NewScbeLocalClientWithNewScbeRestClientAndDataModel(
 				fakeConfig,
 				fakeScbeDataModel,
-				fakeScbeRestClient)
+				fakeScbeRestClient, fakeJournal)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fakeScbeRestClient.LoginCallCount()).To(Equal(1))
 			Expect(fakeScbeRestClient.ServiceExistCallCount()).To(Equal(1))
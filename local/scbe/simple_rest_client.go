@@ -18,6 +18,7 @@ package scbe
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -28,26 +29,32 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
 )
 
 // SimpleRestClient is an interface that wrapper the http requests to provide easy REST API operations,
+// every request is cancelled once its context is done (e.g the deadline of the operation passed),
 //go:generate counterfeiter -o ../../fakes/fake_simple_rest_client.go . SimpleRestClient
 type SimpleRestClient interface {
 	// Authenticate the server, prepare headers and save the token
-	Login() error
+	Login(ctx context.Context) error
 
 	// send POST request with optional payload and check expected status of response
-	Post(resource_url string, payload []byte, exitStatus int, v interface{}) error
+	Post(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error
 
 	// send GET request with optional params and check expected status of response
-	Get(resource_url string, params map[string]string, exitStatus int, v interface{}) error
+	Get(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error
 
 	// send DELETE request with optional payload and check expected status of response
-	Delete(resource_url string, payload []byte, exitStatus int) error
+	Delete(ctx context.Context, resource_url string, payload []byte, exitStatus int) error
+
+	// send PUT request with optional payload and check expected status of response
+	Put(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error
 }
 
 const (
@@ -56,6 +63,10 @@ const (
 	HTTP_SUCCEED_DELETED = 204
 	HTTP_AUTH_KEY        = "Authorization"
 	KEY_VERIFY_SCBE_CERT = "UBIQUITY_SERVER_VERIFY_SCBE_CERT"
+
+	// RestCallTimeout bounds a single call to SCBE whose context has no deadline (e.g of an operation configured without one),
+	// so a hung SCBE service does not hold its caller forever. It is the default operation deadline, so it does not cut them short.
+	RestCallTimeout = resources.DefaultOperationDeadline * time.Second
 )
 
 // simpleRestClient implements SimpleRestClient interface.
@@ -71,7 +82,7 @@ type simpleRestClient struct {
 }
 
 func NewSimpleRestClient(conInfo resources.ConnectionInfo, baseURL string, referrer string) (SimpleRestClient, error) {
-	client := &simpleRestClient{logger: logs.GetLogger(), connectionInfo: conInfo, baseURL: baseURL, referrer: referrer, httpClient: &http.Client{}}
+	client := &simpleRestClient{logger: logs.GetLogger(), connectionInfo: conInfo, baseURL: baseURL, referrer: referrer, httpClient: &http.Client{Timeout: RestCallTimeout}}
 	client.initHeader()
 	if err := client.initTransport(); err != nil {
 		return nil, client.logger.ErrorRet(err, "client.initTransport failed")
@@ -79,15 +90,15 @@ func NewSimpleRestClient(conInfo resources.ConnectionInfo, baseURL string, refer
 	return client, nil
 }
 
-func (s *simpleRestClient) Login() error {
+func (s *simpleRestClient) Login(ctx context.Context) error {
 	defer s.logger.Trace(logs.DEBUG)()
-	if err := s.getToken(); err != nil {
+	if err := s.getToken(ctx); err != nil {
 		return s.logger.ErrorRet(err, "getToken failed")
 	}
 	return nil
 }
 
-func (s *simpleRestClient) getToken() error {
+func (s *simpleRestClient) getToken(ctx context.Context) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	s.headers.Delete(HTTP_AUTH_KEY) // because no need token to get the token only user\password
 	var loginResponse = LoginResponse{}
@@ -95,7 +106,7 @@ func (s *simpleRestClient) getToken() error {
 	if err != nil {
 		return s.logger.ErrorRet(err, "json.Marshal failed")
 	}
-	if err = s.Post(UrlScbeResourceGetAuth, credentials, HTTP_SUCCEED, &loginResponse); err != nil {
+	if err = s.Post(ctx, UrlScbeResourceGetAuth, credentials, HTTP_SUCCEED, &loginResponse); err != nil {
 		return s.logger.ErrorRet(err, "Post failed")
 	}
 	if loginResponse.Token == "" {
@@ -110,13 +121,13 @@ func (s *simpleRestClient) getToken() error {
 // Then it append all relevant the http headers and then trigger the http action by using Do interface.
 // Then read the response, and if exist status as expacted it reads the body into the given struct(v)
 // The function return only error if accured and of cause the object(v) loaded with the response.
-func (s *simpleRestClient) genericAction(actionName string, resource_url string, payload []byte, params map[string]string, exitStatus int, v interface{}) error {
-	return s.genericActionInternal(actionName, resource_url, payload, params, exitStatus, v, true)
+func (s *simpleRestClient) genericAction(ctx context.Context, actionName string, resource_url string, payload []byte, params map[string]string, exitStatus int, v interface{}) error {
+	return s.genericActionInternal(ctx, actionName, resource_url, payload, params, exitStatus, v, true)
 }
 
-func (s *simpleRestClient) genericActionInternal(actionName string, resource_url string, payload []byte, params map[string]string, exitStatus int, v interface{}, retryUnauthorized bool) error {
+func (s *simpleRestClient) genericActionInternal(ctx context.Context, actionName string, resource_url string, payload []byte, params map[string]string, exitStatus int, v interface{}, retryUnauthorized bool) (err error) {
 	defer s.logger.Trace(logs.DEBUG)()
-	var err error
+	defer func(start time.Time) { metrics.ObserveRestCall(resources.SCBE, actionName, start, err) }(time.Now())
 	var request *http.Request
 
 	url := utils.FormatURL(s.baseURL, resource_url)
@@ -129,6 +140,7 @@ func (s *simpleRestClient) genericActionInternal(actionName string, resource_url
 	if err != nil {
 		return s.logger.ErrorRet(err, "http.NewRequest failed", logs.Args{{actionName, url}})
 	}
+	request = request.WithContext(ctx)
 	if actionName == "GET" {
 		// append all the params into the request
 		q := request.URL.Query()
@@ -153,12 +165,12 @@ func (s *simpleRestClient) genericActionInternal(actionName string, resource_url
 		if retryUnauthorized && resource_url != UrlScbeResourceGetAuth {
 
 			// login
-			if err = s.Login(); err != nil {
+			if err = s.Login(ctx); err != nil {
 				return s.logger.ErrorRet(err, "Login failed")
 			}
 
 			// retry
-			return s.genericActionInternal(actionName, resource_url, payload, params, exitStatus, v, false)
+			return s.genericActionInternal(ctx, actionName, resource_url, payload, params, exitStatus, v, false)
 		}
 	}
 
@@ -189,30 +201,39 @@ func (s *simpleRestClient) genericActionInternal(actionName string, resource_url
 }
 
 // Post http request
-func (s *simpleRestClient) Post(resource_url string, payload []byte, exitStatus int, v interface{}) error {
+func (s *simpleRestClient) Post(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	if exitStatus < 0 {
 		exitStatus = HTTP_SUCCEED_POST // Default value
 	}
-	return s.genericAction("POST", resource_url, payload, nil, exitStatus, v)
+	return s.genericAction(ctx, "POST", resource_url, payload, nil, exitStatus, v)
 }
 
 // Get http request
-func (s *simpleRestClient) Get(resource_url string, params map[string]string, exitStatus int, v interface{}) error {
+func (s *simpleRestClient) Get(ctx context.Context, resource_url string, params map[string]string, exitStatus int, v interface{}) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	if exitStatus < 0 {
 		exitStatus = HTTP_SUCCEED // Default value
 	}
-	return s.genericAction("GET", resource_url, nil, params, exitStatus, v)
+	return s.genericAction(ctx, "GET", resource_url, nil, params, exitStatus, v)
 }
 
 // Delete request
-func (s *simpleRestClient) Delete(resource_url string, payload []byte, exitStatus int) error {
+func (s *simpleRestClient) Delete(ctx context.Context, resource_url string, payload []byte, exitStatus int) error {
 	defer s.logger.Trace(logs.DEBUG)()
 	if exitStatus < 0 {
 		exitStatus = HTTP_SUCCEED_DELETED // Default value
 	}
-	return s.genericAction("DELETE", resource_url, payload, nil, exitStatus, nil)
+	return s.genericAction(ctx, "DELETE", resource_url, payload, nil, exitStatus, nil)
+}
+
+// Put http request
+func (s *simpleRestClient) Put(ctx context.Context, resource_url string, payload []byte, exitStatus int, v interface{}) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	if exitStatus < 0 {
+		exitStatus = HTTP_SUCCEED // Default value
+	}
+	return s.genericAction(ctx, "PUT", resource_url, payload, nil, exitStatus, v)
 }
 
 func (s *simpleRestClient) initTransport() error {
@@ -17,6 +17,7 @@
 package scbe_test
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/IBM/ubiquity/local/scbe"
 	"github.com/IBM/ubiquity/resources"
@@ -83,7 +84,7 @@ var _ = Describe("restClient", func() {
 				fakeScbeUrlAuthFull,
 				httpmock.NewStringResponder(http.StatusOK, string(marshalledResponse)),
 			)
-			err = client.Login()
+			err = client.Login(context.Background())
 			Expect(err).ToNot(HaveOccurred())
 		})
 		It("should fail when httpClient.Post returns an empty token", func() {
@@ -91,20 +92,24 @@ var _ = Describe("restClient", func() {
 			marshalledResponse, err := json.Marshal(loginResponse)
 			Expect(err).ToNot(HaveOccurred())
 			httpmock.RegisterResponder("POST", fakeScbeUrlAuthFull, httpmock.NewStringResponder(http.StatusOK, string(marshalledResponse)))
-			err = client.Login()
+			err = client.Login(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("Token is empty"))
 		})
 		It("should fail when httpClient.Post returns error status", func() {
 			httpmock.RegisterResponder("POST", fakeScbeUrlAuthFull, httpmock.NewStringResponder(http.StatusBadRequest, "{}"))
-			err = client.Login()
+			err = client.Login(context.Background())
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.BadHttpStatusCodeError)
 			Expect(ok).To(Equal(true))
+			codedError, ok := err.(resources.CodedError)
+			Expect(ok).To(Equal(true))
+			Expect(codedError.ErrorCode()).To(Equal(resources.ErrorCodeStorageBadHttpStatus))
+			Expect(codedError.HttpStatus()).To(Equal(http.StatusBadGateway))
 		})
 		It("should fail when httpClient.Post returns invalid json", func() {
 			httpmock.RegisterResponder("POST", fakeScbeUrlAuthFull, httpmock.NewStringResponder(http.StatusOK, "yyy"))
-			err = client.Login()
+			err = client.Login(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(MatchRegexp("^invalid character"))
 		})
@@ -128,7 +133,7 @@ var _ = Describe("restClient", func() {
 			fakeScbeUrlAuthFull,
 			httpmock.NewStringResponder(http.StatusOK, string(marshalledResponse)),
 		)
-		err = client.Login()
+		err = client.Login(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 
 	})
@@ -141,7 +146,7 @@ var _ = Describe("restClient", func() {
 				httpmock.NewStringResponder(http.StatusOK, fakeServiceJsonResponse),
 			)
 			var services []scbe.ScbeStorageService
-			err = client.Get(scbe.UrlScbeResourceService, nil, -1, &services)
+			err = client.Get(context.Background(), scbe.UrlScbeResourceService, nil, -1, &services)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(services[0].Name).To(Equal("gold"))
 		})
@@ -152,7 +157,7 @@ var _ = Describe("restClient", func() {
 				httpmock.NewStringResponder(http.StatusBadRequest, fakeServiceJsonResponse),
 			)
 			var services []scbe.ScbeStorageService
-			err = client.Get(scbe.UrlScbeResourceService, nil, -1, &services)
+			err = client.Get(context.Background(), scbe.UrlScbeResourceService, nil, -1, &services)
 			Expect(err).To(HaveOccurred())
 			_, ok := err.(*scbe.BadHttpStatusCodeError)
 			Expect(ok).To(Equal(true))
@@ -164,7 +169,7 @@ var _ = Describe("restClient", func() {
 				httpmock.NewStringResponder(http.StatusOK, "invalid data"),
 			)
 			var services []scbe.ScbeStorageService
-			err = client.Get(scbe.UrlScbeResourceService, nil, -1, &services)
+			err = client.Get(context.Background(), scbe.UrlScbeResourceService, nil, -1, &services)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(MatchRegexp("^invalid character"))
 		})
@@ -174,7 +179,7 @@ var _ = Describe("restClient", func() {
 			httpmock.RegisterResponder("POST", fakeScbeUrlAuthFull, CountLoginResponder(&numLogin, fakeTokenRetry))
 			httpmock.RegisterResponder("GET", fakeScbeUrlApi+"/"+scbe.UrlScbeResourceService, TokenExpiredResponder(&numGetServices, fakeTokenRetry))
 			var services []scbe.ScbeStorageService
-			err = client.Get(scbe.UrlScbeResourceService, nil, http.StatusOK, &services)
+			err = client.Get(context.Background(), scbe.UrlScbeResourceService, nil, http.StatusOK, &services)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(numLogin).To(Equal(1))
 			Expect(numGetServices).To(Equal(2))
@@ -0,0 +1,77 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spectrumscale
+
+import (
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/jinzhu/gorm"
+)
+
+// spectrumScaleVolumeV1 is a frozen copy of SpectrumScaleVolume at version 1 of the schema, so the migration keeps creating the same table
+type spectrumScaleVolumeV1 struct {
+	ID            uint
+	VolumeID      uint
+	Type          int
+	ClusterId     string
+	FileSystem    string
+	Fileset       string
+	Directory     string
+	UID           string
+	GID           string
+	Quota         string
+	IsPreexisting bool
+}
+
+func (spectrumScaleVolumeV1) TableName() string {
+	return "spectrum_scale_volumes"
+}
+
+func init() {
+	registry.RegisterBackend(registry.Backend{
+		Name: resources.SpectrumScale,
+		IsConfigured: func(config resources.UbiquityServerConfig) bool {
+			return config.SpectrumScaleConfig.RestConfig.ManagementIP != ""
+		},
+		ValidateConfig: func(config resources.UbiquityServerConfig) error {
+			return validateSpectrumscaleConfig(logs.GetLogger(), config.SpectrumScaleConfig)
+		},
+		Instances: func(config resources.UbiquityServerConfig) map[string]resources.UbiquityServerConfig {
+			instances := make(map[string]resources.UbiquityServerConfig)
+			for name, sscConfig := range config.SpectrumScaleInstances {
+				instanceConfig := config
+				instanceConfig.SpectrumScaleConfig = sscConfig
+				instances[name] = instanceConfig
+			}
+			return instances
+		},
+		NewStorageClient: func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+			return newSpectrumLocalClient(config.SpectrumScaleConfig, name)
+		},
+		VolumeTable: &SpectrumScaleVolume{},
+	})
+
+	database.RegisterMigrations(resources.SpectrumScale, database.Migration{
+		Version:     1,
+		Description: "create the spectrum_scale_volumes table",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&spectrumScaleVolumeV1{}).Error
+		},
+	})
+}
@@ -17,30 +17,47 @@
 package connectors
 
 import (
+	"context"
+
 	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/IBM/ubiquity/resources"
 )
 
+// SpectrumScaleConnector is the client of the Spectrum Scale management API, every call stops once its context is done
 //go:generate counterfeiter -o ../../../fakes/fake_spectrum.go . SpectrumScaleConnector
 type SpectrumScaleConnector interface {
 	//Cluster operations
-	GetClusterId() (string, error)
+	GetClusterId(ctx context.Context) (string, error)
 	//Filesystem operations
-	IsFilesystemMounted(filesystemName string) (bool, error)
-	ListFilesystems() ([]string, error)
-	GetFilesystemMountpoint(filesystemName string) (string, error)
+	IsFilesystemMounted(ctx context.Context, filesystemName string) (bool, error)
+	ListFilesystems(ctx context.Context) ([]string, error)
+	GetFilesystemMountpoint(ctx context.Context, filesystemName string) (string, error)
+	GetFilesystemCapacity(ctx context.Context, filesystemName string) (FilesystemCapacity, error)
 	//Fileset operations
-	CreateFileset(filesystemName string, filesetName string, opts map[string]interface{}) error
-	DeleteFileset(filesystemName string, filesetName string) error
-	LinkFileset(filesystemName string, filesetName string) error
-	UnlinkFileset(filesystemName string, filesetName string) error
-	ListFilesets(filesystemName string) ([]resources.Volume, error)
-	ListFileset(filesystemName string, filesetName string) (resources.Volume, error)
-	IsFilesetLinked(filesystemName string, filesetName string) (bool, error)
+	CreateFileset(ctx context.Context, filesystemName string, filesetName string, opts map[string]interface{}) error
+	DeleteFileset(ctx context.Context, filesystemName string, filesetName string) error
+	LinkFileset(ctx context.Context, filesystemName string, filesetName string) error
+	UnlinkFileset(ctx context.Context, filesystemName string, filesetName string) error
+	ListFilesets(ctx context.Context, filesystemName string) ([]resources.Volume, error)
+	ListFileset(ctx context.Context, filesystemName string, filesetName string) (resources.Volume, error)
+	IsFilesetLinked(ctx context.Context, filesystemName string, filesetName string) (bool, error)
 	//TODO modify quota from string to Capacity (see kubernetes)
-	ListFilesetQuota(filesystemName string, filesetName string) (string, error)
-	SetFilesetQuota(filesystemName string, filesetName string, quota string) error
-    CheckIfFSQuotaEnabled(filesystem string) error
+	ListFilesetQuota(ctx context.Context, filesystemName string, filesetName string) (string, error)
+	SetFilesetQuota(ctx context.Context, filesystemName string, filesetName string, quota string) error
+    CheckIfFSQuotaEnabled(ctx context.Context, filesystem string) error
+	//Snapshot operations
+	CreateSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error
+	DeleteSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error
+	//Copy operations
+	CopyFilesetSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string, targetPath string) error
+	CopyDirectory(ctx context.Context, filesystemName string, sourcePath string, targetPath string) error
+}
+
+// FilesystemCapacity is the capacity of a filesystem in bytes, QuotaSize is the sum of the block quotas of its filesets
+type FilesystemCapacity struct {
+	TotalSize int64
+	FreeSize  int64
+	QuotaSize int64
 }
 
 const (
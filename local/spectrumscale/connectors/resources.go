@@ -501,6 +501,21 @@ type Quota_v2 struct {
 	DefaultQuota   string `json:"defaultQuota,omitempty"`
 }
 
+type GetDisksResponse_v2 struct {
+	Disks  []Disk_v2 `json:"disks,omitempty"`
+	Status Status    `json:"status,omitempty"`
+	Paging Pages     `json:"paging,omitempty"`
+}
+
+type Disk_v2 struct {
+	Name            string `json:"name,omitempty"`
+	FileSystem      string `json:"fileSystem,omitempty"`
+	Type            string `json:"type,omitempty"`
+	StoragePool     string `json:"storagePool,omitempty"`
+	Size            int64  `json:"size,omitempty"`
+	AvailableBlocks int64  `json:"availableBlocks,omitempty"`
+}
+
 type SetQuotaRequest_v2 struct {
 	BlockGracePeriod string `json:"blockGracePeriod,omitempty"`
 	BlockHardLimit   string `json:"blockHardLimit,omitempty"`
@@ -521,6 +536,14 @@ type UnlinkFilesetRequest struct {
 	Force bool `json:"force,omitempty"`
 }
 
+type CreateSnapshotRequest struct {
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+type CopyRequest struct {
+	TargetPath string `json:"targetPath,omitempty"`
+}
+
 type CreateFilesetRequest struct {
 	FilesetName                  string `json:"filesetName,omitempty"`
 	Path                         string `json:"path,omitempty"`
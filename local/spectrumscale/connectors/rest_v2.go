@@ -17,6 +17,7 @@
 package connectors
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -29,9 +30,13 @@ import (
     "io/ioutil"
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
+	"github.com/IBM/ubiquity/utils/metrics"
 	"os"
 )
 
+// jobPollInterval is the interval of the queries of the state of an asynchronous job
+const jobPollInterval = 2000 * time.Millisecond
+
 type spectrumRestV2 struct {
 	logger     logs.Logger
 	httpClient *http.Client
@@ -92,13 +97,13 @@ func (s *spectrumRestV2) isRequestAccepted(response GenericResponse, url string)
 	return nil
 }
 
-func (s *spectrumRestV2) waitForJobCompletion(statusCode int, jobID uint64) error {
+func (s *spectrumRestV2) waitForJobCompletion(ctx context.Context, statusCode int, jobID uint64) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	if s.checkAsynchronousJob(statusCode) {
 		jobURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/jobs/%d?fields=:all:", jobID))
 		s.logger.Debug("Job URL: ", logs.Args{{"jobUrl", jobURL}})
-		err := s.AsyncJobCompletion(jobURL)
+		err := s.AsyncJobCompletion(ctx, jobURL)
 		if err != nil {
 			s.logger.Debug("Error", logs.Args{{"Error", err}})
 			return err
@@ -107,13 +112,13 @@ func (s *spectrumRestV2) waitForJobCompletion(statusCode int, jobID uint64) erro
 	return nil
 }
 
-func (s *spectrumRestV2) AsyncJobCompletion(jobURL string) error {
+func (s *spectrumRestV2) AsyncJobCompletion(ctx context.Context, jobURL string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	jobQueryResponse := GenericResponse{}
 	for {
 		s.logger.Debug("jobUrl ", logs.Args{{"JobUrl", jobURL}})
-		err := s.doHTTP(jobURL, "GET", &jobQueryResponse, nil)
+		err := s.doHTTP(ctx, jobURL, "GET", &jobQueryResponse, nil)
 		if err != nil {
 			return err
 		}
@@ -122,7 +127,12 @@ func (s *spectrumRestV2) AsyncJobCompletion(jobURL string) error {
 		}
 
 		if jobQueryResponse.Jobs[0].Status == "RUNNING" {
-			time.Sleep(2000 * time.Millisecond)
+			// the job keeps running on the storage, but the request stops waiting for it once its context is done
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("Job %v did not complete: %v", jobURL, ctx.Err())
+			case <-time.After(jobPollInterval):
+			}
 			continue
 		}
 		break
@@ -194,7 +204,7 @@ func NewspectrumRestV2WithClient(logger logs.Logger, restConfig resources.RestCo
 
 }
 
-func (s *spectrumRestV2) GetClusterId() (string, error) {
+func (s *spectrumRestV2) GetClusterId(ctx context.Context) (string, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	getClusterURL := utils.FormatURL(s.endpoint, "scalemgmt/v2/cluster")
@@ -202,7 +212,7 @@ func (s *spectrumRestV2) GetClusterId() (string, error) {
 
 	s.logger.Debug("", logs.Args{{"ClusterUrl", getClusterURL}})
 
-	err := s.doHTTP(getClusterURL, "GET", &getClusterResponse, nil)
+	err := s.doHTTP(ctx, getClusterURL, "GET", &getClusterResponse, nil)
 	if err != nil {
 		s.logger.Debug("error in executing remote call", logs.Args{{"Error", err}})
 		return "", fmt.Errorf("Unable to get cluster id. Please refer Ubiquity server logs for more details")
@@ -211,12 +221,12 @@ func (s *spectrumRestV2) GetClusterId() (string, error) {
 	return cid_str, nil
 }
 
-func (s *spectrumRestV2) IsFilesystemMounted(filesystemName string) (bool, error) {
+func (s *spectrumRestV2) IsFilesystemMounted(ctx context.Context, filesystemName string) (bool, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	ownerResp := OwnerResp_v2{}
 	ownerUrl := utils.FormatURL(s.endpoint,fmt.Sprintf("scalemgmt/v2/filesystems/%s/owner/%s", filesystemName, url.QueryEscape("/")))
-	err := s.doHTTP(ownerUrl, "GET", &ownerResp, nil)
+	err := s.doHTTP(ctx, ownerUrl, "GET", &ownerResp, nil)
     if err != nil {
 		s.logger.Debug("Filesystem not mounted", logs.Args{{"Filesystem", filesystemName}, {"Url", ownerUrl}})
 		return false, err
@@ -225,7 +235,7 @@ func (s *spectrumRestV2) IsFilesystemMounted(filesystemName string) (bool, error
 	return true, nil
 }
 
-func (s *spectrumRestV2) ListFilesystems() ([]string, error) {
+func (s *spectrumRestV2) ListFilesystems(ctx context.Context) ([]string, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	listFilesystemsURL := utils.FormatURL(s.endpoint, "scalemgmt/v2/filesystems")
@@ -233,7 +243,7 @@ func (s *spectrumRestV2) ListFilesystems() ([]string, error) {
 
 	s.logger.Debug("List Filesystem", logs.Args{{"ListFilesystemUrl", listFilesystemsURL}})
 
-	err := s.doHTTP(listFilesystemsURL, "GET", &getFilesystemResponse, nil)
+	err := s.doHTTP(ctx, listFilesystemsURL, "GET", &getFilesystemResponse, nil)
 	if err != nil {
 		s.logger.Debug("error in executing remote call", logs.Args{{"Error", err}})
 		return nil, fmt.Errorf("Unable to list filesystems. Please refer Ubiquity server logs for more details")
@@ -246,7 +256,7 @@ func (s *spectrumRestV2) ListFilesystems() ([]string, error) {
 	return filesystems, nil
 }
 
-func (s *spectrumRestV2) GetFilesystemMountpoint(filesystemName string) (string, error) {
+func (s *spectrumRestV2) GetFilesystemMountpoint(ctx context.Context, filesystemName string) (string, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	getFilesystemURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s", filesystemName))
@@ -254,7 +264,7 @@ func (s *spectrumRestV2) GetFilesystemMountpoint(filesystemName string) (string,
 
 	s.logger.Debug("Get Filesystem Mount ", logs.Args{{"getFilesystemURL", getFilesystemURL}})
 
-	err := s.doHTTP(getFilesystemURL, "GET", &getFilesystemResponse, nil)
+	err := s.doHTTP(ctx, getFilesystemURL, "GET", &getFilesystemResponse, nil)
 	if err != nil {
 		s.logger.Debug("error in executing remote call", logs.Args{{"Error", err}})
 		return "", fmt.Errorf("Unable to fetch mount point for %v. Please refer Ubiquity server logs for more details", filesystemName)
@@ -267,7 +277,47 @@ func (s *spectrumRestV2) GetFilesystemMountpoint(filesystemName string) (string,
 	}
 }
 
-func (s *spectrumRestV2) CreateFileset(filesystemName string, filesetName string, opts map[string]interface{}) error {
+// GetFilesystemCapacity sums the size and the free blocks of the data disks of the filesystem, and the block quotas of its filesets
+func (s *spectrumRestV2) GetFilesystemCapacity(ctx context.Context, filesystemName string) (FilesystemCapacity, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	capacity := FilesystemCapacity{}
+	getDisksURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/disks?fields=:all:", filesystemName))
+	getDisksResponse := GetDisksResponse_v2{}
+
+	s.logger.Debug("Get Filesystem Disks", logs.Args{{"getDisksURL", getDisksURL}})
+
+	err := s.doHTTP(ctx, getDisksURL, "GET", &getDisksResponse, nil)
+	if err != nil {
+		s.logger.Debug("error in executing remote call", logs.Args{{"Error", err}})
+		return capacity, fmt.Errorf("Unable to fetch capacity of %v. Please refer Ubiquity server logs for more details", filesystemName)
+	}
+	for _, disk := range getDisksResponse.Disks {
+		if disk.Type == "metadataOnly" || disk.Type == "descOnly" {
+			continue
+		}
+		capacity.TotalSize += disk.Size
+		capacity.FreeSize += disk.AvailableBlocks
+	}
+
+	listQuotaURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/quotas?filter=quotaType=FILESET", filesystemName))
+	listQuotaResponse := GetQuotaResponse_v2{}
+
+	s.logger.Debug("List Quota URL", logs.Args{{"listQuotaURL", listQuotaURL}})
+
+	err = s.doHTTP(ctx, listQuotaURL, "GET", &listQuotaResponse, nil)
+	if err != nil {
+		s.logger.Debug("error in executing remote call", logs.Args{{"Error", err}})
+		return capacity, fmt.Errorf("Unable to fetch quota information %v. Please refer Ubiquity server logs for more details", filesystemName)
+	}
+	for _, quota := range listQuotaResponse.Quotas {
+		// block quotas are in KiB
+		capacity.QuotaSize += int64(quota.BlockQuota) * 1024
+	}
+	return capacity, nil
+}
+
+func (s *spectrumRestV2) CreateFileset(ctx context.Context, filesystemName string, filesetName string, opts map[string]interface{}) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	filesetreq := CreateFilesetRequest{}
@@ -302,7 +352,7 @@ func (s *spectrumRestV2) CreateFileset(filesystemName string, filesetName string
 
 	s.logger.Debug("Create Fileset URL", logs.Args{{"createFilesetURL", createFilesetURL}})
 
-	err := s.doHTTP(createFilesetURL, "POST", &createFilesetResponse, filesetreq)
+	err := s.doHTTP(ctx, createFilesetURL, "POST", &createFilesetResponse, filesetreq)
 	if err != nil {
 		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
 		return fmt.Errorf("Unable to create fileset %v. Please refer Ubiquity server logs for more details", filesetName)
@@ -313,14 +363,14 @@ func (s *spectrumRestV2) CreateFileset(filesystemName string, filesetName string
 		return err
 	}
 
-	err = s.waitForJobCompletion(createFilesetResponse.Status.Code, createFilesetResponse.Jobs[0].JobID)
+	err = s.waitForJobCompletion(ctx, createFilesetResponse.Status.Code, createFilesetResponse.Jobs[0].JobID)
 	if err != nil {
 		return fmt.Errorf("Unable to create fileset %v:%v Please refer Ubiquity server logs for more details", filesetName, err)
 	}
 	return nil
 }
 
-func (s *spectrumRestV2) DeleteFileset(filesystemName string, filesetName string) error {
+func (s *spectrumRestV2) DeleteFileset(ctx context.Context, filesystemName string, filesetName string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	deleteFilesetURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s", filesystemName, filesetName))
@@ -328,7 +378,7 @@ func (s *spectrumRestV2) DeleteFileset(filesystemName string, filesetName string
 
 	s.logger.Debug("Delete Fileset ", logs.Args{{"deleteFilesetURL", deleteFilesetURL}})
 
-	err := s.doHTTP(deleteFilesetURL, "DELETE", &deleteFilesetResponse, nil)
+	err := s.doHTTP(ctx, deleteFilesetURL, "DELETE", &deleteFilesetResponse, nil)
 	if err != nil {
 		s.logger.Debug("Error in delete remote call")
 		return fmt.Errorf("Unable to delete fileset %v. Please refer Ubiquity server logs for more details", filesetName)
@@ -339,7 +389,7 @@ func (s *spectrumRestV2) DeleteFileset(filesystemName string, filesetName string
 		return err
 	}
 
-	err = s.waitForJobCompletion(deleteFilesetResponse.Status.Code, deleteFilesetResponse.Jobs[0].JobID)
+	err = s.waitForJobCompletion(ctx, deleteFilesetResponse.Status.Code, deleteFilesetResponse.Jobs[0].JobID)
 	if err != nil {
 		return fmt.Errorf("Unable to delete fileset %v:%v. Please refer Ubiquity server logs for more details", filesetName, err)
 	}
@@ -347,11 +397,11 @@ func (s *spectrumRestV2) DeleteFileset(filesystemName string, filesetName string
 	return nil
 }
 
-func (s *spectrumRestV2) LinkFileset(filesystemName string, filesetName string) error {
+func (s *spectrumRestV2) LinkFileset(ctx context.Context, filesystemName string, filesetName string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	linkReq := LinkFilesetRequest{}
-	fsMountpoint, err := s.GetFilesystemMountpoint(filesystemName)
+	fsMountpoint, err := s.GetFilesystemMountpoint(ctx, filesystemName)
 	if err != nil {
 		s.logger.Debug("error in linking fileset")
 		return err
@@ -363,7 +413,7 @@ func (s *spectrumRestV2) LinkFileset(filesystemName string, filesetName string)
 
 	s.logger.Debug("Link Fileset URL", logs.Args{{"linkFilesetURL",  linkFilesetURL}})
 
-	err = s.doHTTP(linkFilesetURL, "POST", &linkFilesetResponse, linkReq)
+	err = s.doHTTP(ctx, linkFilesetURL, "POST", &linkFilesetResponse, linkReq)
 	if err != nil {
 		s.logger.Debug("error in remote call",logs.Args{{"Error", err}})
 		return fmt.Errorf("Unable to link fileset %v. Please refer Ubiquity server logs for more details", filesetName)
@@ -374,14 +424,14 @@ func (s *spectrumRestV2) LinkFileset(filesystemName string, filesetName string)
 		return err
 	}
 
-	err = s.waitForJobCompletion(linkFilesetResponse.Status.Code, linkFilesetResponse.Jobs[0].JobID)
+	err = s.waitForJobCompletion(ctx, linkFilesetResponse.Status.Code, linkFilesetResponse.Jobs[0].JobID)
 	if err != nil {
 		return fmt.Errorf("Unable to link fileset %v:%v. Please refer Ubiquity server logs for more details", filesetName, err)
 	}
 	return nil
 }
 
-func (s *spectrumRestV2) UnlinkFileset(filesystemName string, filesetName string) error {
+func (s *spectrumRestV2) UnlinkFileset(ctx context.Context, filesystemName string, filesetName string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	unlinkFilesetURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/link?force=True", filesystemName, filesetName))
@@ -389,7 +439,7 @@ func (s *spectrumRestV2) UnlinkFileset(filesystemName string, filesetName string
 
 	s.logger.Debug("Unlink Fileset ", logs.Args{{"unlinkFilesetURL", unlinkFilesetURL}})
 
-	err := s.doHTTP(unlinkFilesetURL, "DELETE", &unlinkFilesetResponse, nil)
+	err := s.doHTTP(ctx, unlinkFilesetURL, "DELETE", &unlinkFilesetResponse, nil)
 
 	if err != nil {
 		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
@@ -401,7 +451,7 @@ func (s *spectrumRestV2) UnlinkFileset(filesystemName string, filesetName string
 		return err
 	}
 
-	err = s.waitForJobCompletion(unlinkFilesetResponse.Status.Code, unlinkFilesetResponse.Jobs[0].JobID)
+	err = s.waitForJobCompletion(ctx, unlinkFilesetResponse.Status.Code, unlinkFilesetResponse.Jobs[0].JobID)
 	if err != nil {
 		return fmt.Errorf("Unable to unlink fileset %v:%v. Please refer Ubiquity server logs for more details", filesetName, err)
 	}
@@ -409,7 +459,7 @@ func (s *spectrumRestV2) UnlinkFileset(filesystemName string, filesetName string
 	return nil
 }
 
-func (s *spectrumRestV2) ListFileset(filesystemName string, filesetName string) (resources.Volume, error) {
+func (s *spectrumRestV2) ListFileset(ctx context.Context, filesystemName string, filesetName string) (resources.Volume, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	getFilesetURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s", filesystemName, filesetName))
@@ -417,7 +467,7 @@ func (s *spectrumRestV2) ListFileset(filesystemName string, filesetName string)
 
 	s.logger.Debug("List Fileset URL", logs.Args{{"getFilesetURL", getFilesetURL}})
 
-	err := s.doHTTP(getFilesetURL, "GET", &getFilesetResponse, nil)
+	err := s.doHTTP(ctx, getFilesetURL, "GET", &getFilesetResponse, nil)
 	if err != nil {
 		s.logger.Debug("error in processing remote call", logs.Args{{"Error", err}})
 		return resources.Volume{}, fmt.Errorf("Unable to list fileset %v. Please refer Ubiquity server logs for more details", filesetName)
@@ -433,7 +483,7 @@ func (s *spectrumRestV2) ListFileset(filesystemName string, filesetName string)
 	return resources.Volume{Name: name, Mountpoint: mountpoint}, nil
 }
 
-func (s *spectrumRestV2) ListFilesets(filesystemName string) ([]resources.Volume, error) {
+func (s *spectrumRestV2) ListFilesets(ctx context.Context, filesystemName string) ([]resources.Volume, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	listFilesetURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets", filesystemName))
@@ -444,7 +494,7 @@ func (s *spectrumRestV2) ListFilesets(filesystemName string) ([]resources.Volume
 	var response []resources.Volume
 	var responseSize int
 	for {
-		err := s.doHTTP(listFilesetURL, "GET", &listFilesetResponse, nil)
+		err := s.doHTTP(ctx, listFilesetURL, "GET", &listFilesetResponse, nil)
 		if err != nil {
 			s.logger.Debug("error in processing remote call", logs.Args{{"Error", err}})
 			return nil, fmt.Errorf("Unable to list filesets for %v. Please refer Ubiquity server logs for more details", filesystemName)
@@ -465,10 +515,10 @@ func (s *spectrumRestV2) ListFilesets(filesystemName string) ([]resources.Volume
 	return response, nil
 }
 
-func (s *spectrumRestV2) IsFilesetLinked(filesystemName string, filesetName string) (bool, error) {
+func (s *spectrumRestV2) IsFilesetLinked(ctx context.Context, filesystemName string, filesetName string) (bool, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
-	fileset, err := s.ListFileset(filesystemName, filesetName)
+	fileset, err := s.ListFileset(ctx, filesystemName, filesetName)
 	if err != nil {
 		s.logger.Debug("error retrieving fileset data")
 		return false, err
@@ -481,7 +531,7 @@ func (s *spectrumRestV2) IsFilesetLinked(filesystemName string, filesetName stri
 	return true, nil
 }
 
-func (s *spectrumRestV2) SetFilesetQuota(filesystemName string, filesetName string, quota string) error {
+func (s *spectrumRestV2) SetFilesetQuota(ctx context.Context, filesystemName string, filesetName string, quota string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	setQuotaURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/quotas", filesystemName))
@@ -497,7 +547,7 @@ func (s *spectrumRestV2) SetFilesetQuota(filesystemName string, filesetName stri
 
 	setQuotaResponse := GenericResponse{}
 
-	err := s.doHTTP(setQuotaURL, "POST", &setQuotaResponse, quotaRequest)
+	err := s.doHTTP(ctx, setQuotaURL, "POST", &setQuotaResponse, quotaRequest)
 	if err != nil {
 		s.logger.Debug("error setting quota for fileset", logs.Args{{"Error", err}})
 		return fmt.Errorf("Unable to set quota for fileset %v. Please refer Ubiquity server logs for more details", filesetName)
@@ -508,14 +558,14 @@ func (s *spectrumRestV2) SetFilesetQuota(filesystemName string, filesetName stri
 		return err
 	}
 
-	err = s.waitForJobCompletion(setQuotaResponse.Status.Code, setQuotaResponse.Jobs[0].JobID)
+	err = s.waitForJobCompletion(ctx, setQuotaResponse.Status.Code, setQuotaResponse.Jobs[0].JobID)
 	if err != nil {
 		return fmt.Errorf("Unable to set quota for fileset %v:%v. Please refer Ubiquity server logs for more details", filesetName, err)
 	}
 	return nil
 }
 
-func (s *spectrumRestV2)  CheckIfFSQuotaEnabled(filesystemName string) error {
+func (s *spectrumRestV2)  CheckIfFSQuotaEnabled(ctx context.Context, filesystemName string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
     checkQuotaURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/quotas", filesystemName))
@@ -523,14 +573,14 @@ func (s *spectrumRestV2)  CheckIfFSQuotaEnabled(filesystemName string) error {
 
     s.logger.Debug("Check Quota URL", logs.Args{{"checkQuotaURL", checkQuotaURL}})
 
-    err := s.doHTTP(checkQuotaURL, "GET", &QuotaResponse, nil)
+    err := s.doHTTP(ctx, checkQuotaURL, "GET", &QuotaResponse, nil)
     if err != nil {
         return s.logger.ErrorRet(err, "Quota not enabled for Filesystem", logs.Args{{"Filesystem",filesystemName}})
     }
     return nil
 }
 
-func (s *spectrumRestV2) ListFilesetQuota(filesystemName string, filesetName string) (string, error) {
+func (s *spectrumRestV2) ListFilesetQuota(ctx context.Context, filesystemName string, filesetName string) (string, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
 	listQuotaURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/quotas?filter=objectName=%s", filesystemName, filesetName))
@@ -538,7 +588,7 @@ func (s *spectrumRestV2) ListFilesetQuota(filesystemName string, filesetName str
 
 	s.logger.Debug("List Quota URL", logs.Args{{"listQuotaURL", listQuotaURL}})
 
-	err := s.doHTTP(listQuotaURL, "GET", &listQuotaResponse, nil)
+	err := s.doHTTP(ctx, listQuotaURL, "GET", &listQuotaResponse, nil)
 	if err != nil {
 		s.logger.Debug("error in processing remote call", logs.Args{{"Error", err}})
 		return "", fmt.Errorf("Unable to fetch quota information %v. Please refer Ubiquity server logs for more details", filesystemName)
@@ -552,8 +602,126 @@ func (s *spectrumRestV2) ListFilesetQuota(filesystemName string, filesetName str
 	}
 }
 
-func (s *spectrumRestV2) doHTTP(endpoint string, method string, responseObject interface{}, param interface{}) error {
-	response, err := utils.HttpExecuteUserAuth(s.httpClient, method, endpoint, s.user, s.password, param)
+func (s *spectrumRestV2) CreateSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	snapshotReq := CreateSnapshotRequest{SnapshotName: snapshotName}
+	createSnapshotURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/snapshots", filesystemName, filesetName))
+	createSnapshotResponse := GenericResponse{}
+
+	s.logger.Debug("Create Snapshot URL", logs.Args{{"createSnapshotURL", createSnapshotURL}})
+
+	err := s.doHTTP(ctx, createSnapshotURL, "POST", &createSnapshotResponse, snapshotReq)
+	if err != nil {
+		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
+		return fmt.Errorf("Unable to create snapshot %v of fileset %v. Please refer Ubiquity server logs for more details", snapshotName, filesetName)
+	}
+
+	err = s.isRequestAccepted(createSnapshotResponse, createSnapshotURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.waitForJobCompletion(ctx, createSnapshotResponse.Status.Code, createSnapshotResponse.Jobs[0].JobID)
+	if err != nil {
+		return fmt.Errorf("Unable to create snapshot %v of fileset %v:%v. Please refer Ubiquity server logs for more details", snapshotName, filesetName, err)
+	}
+	return nil
+}
+
+func (s *spectrumRestV2) DeleteSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	deleteSnapshotURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/snapshots/%s", filesystemName, filesetName, snapshotName))
+	deleteSnapshotResponse := GenericResponse{}
+
+	s.logger.Debug("Delete Snapshot URL", logs.Args{{"deleteSnapshotURL", deleteSnapshotURL}})
+
+	err := s.doHTTP(ctx, deleteSnapshotURL, "DELETE", &deleteSnapshotResponse, nil)
+	if err != nil {
+		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
+		return fmt.Errorf("Unable to delete snapshot %v of fileset %v. Please refer Ubiquity server logs for more details", snapshotName, filesetName)
+	}
+
+	err = s.isRequestAccepted(deleteSnapshotResponse, deleteSnapshotURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.waitForJobCompletion(ctx, deleteSnapshotResponse.Status.Code, deleteSnapshotResponse.Jobs[0].JobID)
+	if err != nil {
+		return fmt.Errorf("Unable to delete snapshot %v of fileset %v:%v. Please refer Ubiquity server logs for more details", snapshotName, filesetName, err)
+	}
+	return nil
+}
+
+// CopyFilesetSnapshot copies the content of the fileset snapshot into targetPath
+func (s *spectrumRestV2) CopyFilesetSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string, targetPath string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	copyReq := CopyRequest{TargetPath: targetPath}
+	copySnapshotURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/snapshotCopy/%s", filesystemName, filesetName, snapshotName))
+	copySnapshotResponse := GenericResponse{}
+
+	s.logger.Debug("Copy Snapshot URL", logs.Args{{"copySnapshotURL", copySnapshotURL}, {"targetPath", targetPath}})
+
+	err := s.doHTTP(ctx, copySnapshotURL, "POST", &copySnapshotResponse, copyReq)
+	if err != nil {
+		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
+		return fmt.Errorf("Unable to copy snapshot %v of fileset %v to %v. Please refer Ubiquity server logs for more details", snapshotName, filesetName, targetPath)
+	}
+
+	err = s.isRequestAccepted(copySnapshotResponse, copySnapshotURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.waitForJobCompletion(ctx, copySnapshotResponse.Status.Code, copySnapshotResponse.Jobs[0].JobID)
+	if err != nil {
+		return fmt.Errorf("Unable to copy snapshot %v of fileset %v to %v:%v. Please refer Ubiquity server logs for more details", snapshotName, filesetName, targetPath, err)
+	}
+	return nil
+}
+
+// CopyDirectory copies the content of sourcePath into targetPath, both are absolute paths inside the filesystem
+func (s *spectrumRestV2) CopyDirectory(ctx context.Context, filesystemName string, sourcePath string, targetPath string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	fsMountpoint, err := s.GetFilesystemMountpoint(ctx, filesystemName)
+	if err != nil {
+		s.logger.Debug("error in copying directory")
+		return err
+	}
+
+	// the source path in the URL is relative to the filesystem mount point
+	relativeSourcePath := strings.TrimPrefix(strings.TrimPrefix(sourcePath, fsMountpoint), "/")
+	copyReq := CopyRequest{TargetPath: targetPath}
+	copyDirectoryURL := utils.FormatURL(s.endpoint, fmt.Sprintf("scalemgmt/v2/filesystems/%s/directoryCopy/%s", filesystemName, url.QueryEscape(relativeSourcePath)))
+	copyDirectoryResponse := GenericResponse{}
+
+	s.logger.Debug("Copy Directory URL", logs.Args{{"copyDirectoryURL", copyDirectoryURL}, {"targetPath", targetPath}})
+
+	err = s.doHTTP(ctx, copyDirectoryURL, "POST", &copyDirectoryResponse, copyReq)
+	if err != nil {
+		s.logger.Debug("error in remote call", logs.Args{{"Error", err}})
+		return fmt.Errorf("Unable to copy directory %v to %v. Please refer Ubiquity server logs for more details", sourcePath, targetPath)
+	}
+
+	err = s.isRequestAccepted(copyDirectoryResponse, copyDirectoryURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.waitForJobCompletion(ctx, copyDirectoryResponse.Status.Code, copyDirectoryResponse.Jobs[0].JobID)
+	if err != nil {
+		return fmt.Errorf("Unable to copy directory %v to %v:%v. Please refer Ubiquity server logs for more details", sourcePath, targetPath, err)
+	}
+	return nil
+}
+
+func (s *spectrumRestV2) doHTTP(ctx context.Context, endpoint string, method string, responseObject interface{}, param interface{}) (err error) {
+	defer func(start time.Time) { metrics.ObserveRestCall(resources.SpectrumScale, method, start, err) }(time.Now())
+	response, err := utils.HttpExecuteUserAuth(ctx, s.httpClient, method, endpoint, s.user, s.password, param)
 	if err != nil {
 		s.logger.Debug("Error in remote call", logs.Args{{"Method", method}, {"endpoint", endpoint}, {"Error", err}})
 
@@ -17,6 +17,7 @@
 package connectors_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"github.com/IBM/ubiquity/utils/logs"
@@ -28,6 +29,7 @@ import (
 	. "github.com/onsi/gomega"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 var _ = Describe("spectrumRestV2", func() {
@@ -73,7 +75,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			str, err := spectrumRestV2.GetClusterId()
+			str, err := spectrumRestV2.GetClusterId(context.Background())
 			Expect(str).To(Equal("12345"))
 		})
 
@@ -89,7 +91,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			str, err := spectrumRestV2.GetClusterId()
+			str, err := spectrumRestV2.GetClusterId(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(str).To(Equal(""))
 		})
@@ -112,7 +114,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			ismounted, err := spectrumRestV2.IsFilesystemMounted(filesystem)
+			ismounted, err := spectrumRestV2.IsFilesystemMounted(context.Background(), filesystem)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ismounted).To(Equal(true))
 		})
@@ -132,7 +134,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			arr_str, err := spectrumRestV2.ListFilesystems()
+			arr_str, err := spectrumRestV2.ListFilesystems(context.Background())
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(arr_str)).To(Equal(1))
@@ -152,7 +154,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			arr_str, err := spectrumRestV2.ListFilesystems()
+			arr_str, err := spectrumRestV2.ListFilesystems(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(len(arr_str)).To(Equal(0))
 		})
@@ -172,7 +174,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(filesystem)
+			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(context.Background(), filesystem)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(arr_str).To(Equal("fakemount"))
@@ -191,7 +193,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(filesystem)
+			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(context.Background(), filesystem)
 
 			Expect(err).To(HaveOccurred())
 			Expect(arr_str).To(Equal(""))
@@ -209,13 +211,59 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(filesystem)
+			arr_str, err := spectrumRestV2.GetFilesystemMountpoint(context.Background(), filesystem)
 
 			Expect(err).To(HaveOccurred())
 			Expect(arr_str).To(Equal(""))
 		})
 	})
 
+	Context(".GetFilesystemCapacity", func() {
+		var (
+			disksurl  string
+			quotasurl string
+		)
+		BeforeEach(func() {
+			disksurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/disks?fields=:all:"
+			quotasurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/quotas?filter=quotaType=FILESET"
+		})
+
+		It("Should pass by summing the data disks and the fileset quotas", func() {
+			getDisksResp := connectors.GetDisksResponse_v2{}
+			getDisksResp.Disks = []connectors.Disk_v2{
+				{Name: "disk1", Type: "dataAndMetadata", Size: 1000, AvailableBlocks: 600},
+				{Name: "disk2", Type: "dataOnly", Size: 500, AvailableBlocks: 100},
+				{Name: "disk3", Type: "metadataOnly", Size: 200, AvailableBlocks: 200},
+			}
+			getDisksResp.Status.Code = 200
+			marshalledDisks, err := json.Marshal(getDisksResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder("GET", disksurl, httpmock.NewStringResponder(200, string(marshalledDisks)))
+
+			getQuotaResp := connectors.GetQuotaResponse_v2{}
+			getQuotaResp.Quotas = []connectors.Quota_v2{{FilesetName: "fileset1", BlockQuota: 1}, {FilesetName: "fileset2", BlockQuota: 2}}
+			getQuotaResp.Status.Code = 200
+			marshalledQuotas, err := json.Marshal(getQuotaResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder("GET", quotasurl, httpmock.NewStringResponder(200, string(marshalledQuotas)))
+
+			capacity, err := spectrumRestV2.GetFilesystemCapacity(context.Background(), filesystem)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capacity).To(Equal(connectors.FilesystemCapacity{TotalSize: 1500, FreeSize: 700, QuotaSize: 3 * 1024}))
+		})
+
+		It("Should fail with http error on the disks", func() {
+			getDisksResp := connectors.GetDisksResponse_v2{}
+			getDisksResp.Status.Code = 500
+			marshalledDisks, err := json.Marshal(getDisksResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder("GET", disksurl, httpmock.NewStringResponder(500, string(marshalledDisks)))
+
+			_, err = spectrumRestV2.GetFilesystemCapacity(context.Background(), filesystem)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context(".CreateFileset", func() {
 		var (
 			createFilesetResp connectors.GenericResponse
@@ -247,7 +295,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CreateFileset(filesystem, fileset, opts)
+			err = spectrumRestV2.CreateFileset(context.Background(), filesystem, fileset, opts)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -271,7 +319,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CreateFileset(filesystem, fileset, opts)
+			err = spectrumRestV2.CreateFileset(context.Background(), filesystem, fileset, opts)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -292,7 +340,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CreateFileset(filesystem, fileset, opts)
+			err = spectrumRestV2.CreateFileset(context.Background(), filesystem, fileset, opts)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -313,7 +361,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CreateFileset(filesystem, fileset, opts)
+			err = spectrumRestV2.CreateFileset(context.Background(), filesystem, fileset, opts)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -334,10 +382,36 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CreateFileset(filesystem, fileset, opts)
+			err = spectrumRestV2.CreateFileset(context.Background(), filesystem, fileset, opts)
 			Expect(err).To(HaveOccurred())
 
 		})
+
+		It("Should stop waiting for a running job once the context is done", func() {
+			createFilesetResp.Status.Code = 202
+			createFilesetResp.Jobs[0].Status = "RUNNING"
+			marshalledResponse, err := json.Marshal(createFilesetResp)
+			Expect(err).ToNot(HaveOccurred())
+
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			err = spectrumRestV2.CreateFileset(ctx, filesystem, fileset, opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(context.DeadlineExceeded.Error()))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
 	})
 
 	Context(".DeleteFileset", func() {
@@ -374,7 +448,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.DeleteFileset(filesystem, fileset)
+			err = spectrumRestV2.DeleteFileset(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -395,7 +469,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.DeleteFileset(filesystem, fileset)
+			err = spectrumRestV2.DeleteFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -416,7 +490,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.DeleteFileset(filesystem, fileset)
+			err = spectrumRestV2.DeleteFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -437,7 +511,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.DeleteFileset(filesystem, fileset)
+			err = spectrumRestV2.DeleteFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 
 		})
@@ -490,7 +564,7 @@ var _ = Describe("spectrumRestV2", func() {
 				httpmock.NewStringResponder(200, string(marshalledResponse_filesys)),
 			)
 
-			err = spectrumRestV2.LinkFileset(filesystem, fileset)
+			err = spectrumRestV2.LinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -520,7 +594,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerFSurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse_filesys)),
 			)
-			err = spectrumRestV2.LinkFileset(filesystem, fileset)
+			err = spectrumRestV2.LinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -548,7 +622,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerFSurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse_filesys)),
 			)
-			err = spectrumRestV2.LinkFileset(filesystem, fileset)
+			err = spectrumRestV2.LinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -578,7 +652,7 @@ var _ = Describe("spectrumRestV2", func() {
 				httpmock.NewStringResponder(200, string(marshalledResponse_filesys)),
 			)
 
-			err = spectrumRestV2.LinkFileset(filesystem, fileset)
+			err = spectrumRestV2.LinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 
 		})
@@ -615,7 +689,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.UnlinkFileset(filesystem, fileset)
+			err = spectrumRestV2.UnlinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -636,7 +710,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.UnlinkFileset(filesystem, fileset)
+			err = spectrumRestV2.UnlinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -657,7 +731,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.UnlinkFileset(filesystem, fileset)
+			err = spectrumRestV2.UnlinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -678,7 +752,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.UnlinkFileset(filesystem, fileset)
+			err = spectrumRestV2.UnlinkFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 
 		})
@@ -709,7 +783,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			volumemeta, err := spectrumRestV2.ListFileset(filesystem, fileset)
+			volumemeta, err := spectrumRestV2.ListFileset(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(volumemeta.Name).To(Equal(fileset))
 			Expect(volumemeta.Mountpoint).To(Equal("fakemount"))
@@ -725,7 +799,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			volumemeta, err := spectrumRestV2.ListFileset(filesystem, fileset)
+			volumemeta, err := spectrumRestV2.ListFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 			Expect(volumemeta.Name).To(Equal(""))
 			Expect(volumemeta.Mountpoint).To(Equal(""))
@@ -741,7 +815,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			volumemeta, err := spectrumRestV2.ListFileset(filesystem, fileset)
+			volumemeta, err := spectrumRestV2.ListFileset(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 			Expect(volumemeta.Name).To(Equal(""))
 			Expect(volumemeta.Mountpoint).To(Equal(""))
@@ -769,7 +843,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerURLFilesets,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			volumemeta_arr, err := spectrumRestV2.ListFilesets(filesystem)
+			volumemeta_arr, err := spectrumRestV2.ListFilesets(context.Background(), filesystem)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(volumemeta_arr)).To(Equal(4))
 			for iter := 0; iter < len(volumemeta_arr); iter++ {
@@ -801,7 +875,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerURLFilesets,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			volumemeta_arr, err := spectrumRestV2.ListFilesets(filesystem)
+			volumemeta_arr, err := spectrumRestV2.ListFilesets(context.Background(), filesystem)
 			Expect(err).To(HaveOccurred())
 			Expect(len(volumemeta_arr)).To(Equal(0))
 		})
@@ -817,7 +891,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerURLFilesets,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			volumemeta_arr, err := spectrumRestV2.ListFilesets(filesystem)
+			volumemeta_arr, err := spectrumRestV2.ListFilesets(context.Background(), filesystem)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(volumemeta_arr)).To(Equal(0))
 		})
@@ -844,7 +918,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			islinked, err := spectrumRestV2.IsFilesetLinked(filesystem, fileset)
+			islinked, err := spectrumRestV2.IsFilesetLinked(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(islinked).To(Equal(true))
 
@@ -859,7 +933,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			islinked, err := spectrumRestV2.IsFilesetLinked(filesystem, fileset)
+			islinked, err := spectrumRestV2.IsFilesetLinked(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 			Expect(islinked).To(Equal(false))
 		})
@@ -874,7 +948,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			islinked, err := spectrumRestV2.IsFilesetLinked(filesystem, fileset)
+			islinked, err := spectrumRestV2.IsFilesetLinked(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(islinked).To(Equal(false))
 		})
@@ -889,7 +963,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			islinked, err := spectrumRestV2.IsFilesetLinked(filesystem, fileset)
+			islinked, err := spectrumRestV2.IsFilesetLinked(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(islinked).To(Equal(false))
 		})
@@ -927,7 +1001,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.SetFilesetQuota(filesystem, fileset, "10G")
+			err = spectrumRestV2.SetFilesetQuota(context.Background(), filesystem, fileset, "10G")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -948,7 +1022,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.SetFilesetQuota(filesystem, fileset, "10G")
+			err = spectrumRestV2.SetFilesetQuota(context.Background(), filesystem, fileset, "10G")
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -969,7 +1043,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.SetFilesetQuota(filesystem, fileset, "10G")
+			err = spectrumRestV2.SetFilesetQuota(context.Background(), filesystem, fileset, "10G")
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -990,7 +1064,7 @@ var _ = Describe("spectrumRestV2", func() {
 				joburl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.SetFilesetQuota(filesystem, fileset, "10G")
+			err = spectrumRestV2.SetFilesetQuota(context.Background(), filesystem, fileset, "10G")
 			Expect(err).To(HaveOccurred())
 
 		})
@@ -1015,7 +1089,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CheckIfFSQuotaEnabled(filesystem)
+			err = spectrumRestV2.CheckIfFSQuotaEnabled(context.Background(), filesystem)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1029,7 +1103,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(400, string(marshalledResponse)),
 			)
-			err = spectrumRestV2.CheckIfFSQuotaEnabled(filesystem)
+			err = spectrumRestV2.CheckIfFSQuotaEnabled(context.Background(), filesystem)
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -1055,7 +1129,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			quota, err := spectrumRestV2.ListFilesetQuota(filesystem, fileset)
+			quota, err := spectrumRestV2.ListFilesetQuota(context.Background(), filesystem, fileset)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(quota).To(Equal("1024K"))
 		})
@@ -1069,7 +1143,7 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(500, string(marshalledResponse)),
 			)
-			quota, err := spectrumRestV2.ListFilesetQuota(filesystem, fileset)
+			quota, err := spectrumRestV2.ListFilesetQuota(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 			Expect(quota).To(Equal(""))
 		})
@@ -1085,9 +1159,228 @@ var _ = Describe("spectrumRestV2", func() {
 				registerurl,
 				httpmock.NewStringResponder(200, string(marshalledResponse)),
 			)
-			quota, err := spectrumRestV2.ListFilesetQuota(filesystem, fileset)
+			quota, err := spectrumRestV2.ListFilesetQuota(context.Background(), filesystem, fileset)
 			Expect(err).To(HaveOccurred())
 			Expect(quota).To(Equal(""))
 		})
 	})
+	Context(".CreateSnapshot", func() {
+		var (
+			createSnapshotResp connectors.GenericResponse
+			registerurl        string
+			joburl             string
+		)
+		BeforeEach(func() {
+			createSnapshotResp = connectors.GenericResponse{}
+			createSnapshotResp.Jobs = make([]connectors.Job, 1)
+			createSnapshotResp.Jobs[0].JobID = 1234
+			registerurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/filesets/" + fileset + "/snapshots"
+			joburl = fakeurl + "/scalemgmt/v2/jobs/1234?fields=:all:"
+		})
+		It("Should pass while creating a snapshot", func() {
+			createSnapshotResp.Status.Code = 202
+			createSnapshotResp.Jobs[0].Status = "COMPLETED"
+			marshalledResponse, err := json.Marshal(createSnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CreateSnapshot(context.Background(), filesystem, fileset, "snap1")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should fail with http error", func() {
+			createSnapshotResp.Status.Code = 500
+			marshalledResponse, err := json.Marshal(createSnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(500, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CreateSnapshot(context.Background(), filesystem, fileset, "snap1")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should fail when the job fails", func() {
+			createSnapshotResp.Status.Code = 202
+			createSnapshotResp.Jobs[0].Status = "FAILED"
+			marshalledResponse, err := json.Marshal(createSnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CreateSnapshot(context.Background(), filesystem, fileset, "snap1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".DeleteSnapshot", func() {
+		var (
+			deleteSnapshotResp connectors.GenericResponse
+			registerurl        string
+			joburl             string
+		)
+		BeforeEach(func() {
+			deleteSnapshotResp = connectors.GenericResponse{}
+			deleteSnapshotResp.Jobs = make([]connectors.Job, 1)
+			deleteSnapshotResp.Jobs[0].JobID = 1234
+			registerurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/filesets/" + fileset + "/snapshots/snap1"
+			joburl = fakeurl + "/scalemgmt/v2/jobs/1234?fields=:all:"
+		})
+		It("Should pass while deleting a snapshot", func() {
+			deleteSnapshotResp.Status.Code = 202
+			deleteSnapshotResp.Jobs[0].Status = "COMPLETED"
+			marshalledResponse, err := json.Marshal(deleteSnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"DELETE",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.DeleteSnapshot(context.Background(), filesystem, fileset, "snap1")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should fail with http error", func() {
+			deleteSnapshotResp.Status.Code = 500
+			marshalledResponse, err := json.Marshal(deleteSnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"DELETE",
+				registerurl,
+				httpmock.NewStringResponder(500, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.DeleteSnapshot(context.Background(), filesystem, fileset, "snap1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".CopyFilesetSnapshot", func() {
+		var (
+			copySnapshotResp connectors.GenericResponse
+			registerurl      string
+			joburl           string
+		)
+		BeforeEach(func() {
+			copySnapshotResp = connectors.GenericResponse{}
+			copySnapshotResp.Jobs = make([]connectors.Job, 1)
+			copySnapshotResp.Jobs[0].JobID = 1234
+			registerurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/filesets/" + fileset + "/snapshotCopy/snap1"
+			joburl = fakeurl + "/scalemgmt/v2/jobs/1234?fields=:all:"
+		})
+		It("Should pass while copying a snapshot", func() {
+			copySnapshotResp.Status.Code = 202
+			copySnapshotResp.Jobs[0].Status = "COMPLETED"
+			marshalledResponse, err := json.Marshal(copySnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CopyFilesetSnapshot(context.Background(), filesystem, fileset, "snap1", "/fakemount/target")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should fail with http error", func() {
+			copySnapshotResp.Status.Code = 500
+			marshalledResponse, err := json.Marshal(copySnapshotResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(500, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CopyFilesetSnapshot(context.Background(), filesystem, fileset, "snap1", "/fakemount/target")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".CopyDirectory", func() {
+		var (
+			copyDirectoryResp connectors.GenericResponse
+			registerurl       string
+			joburl            string
+		)
+		BeforeEach(func() {
+			getfilesysResp := connectors.GetFilesystemResponse_v2{}
+			getfilesysResp.FileSystems = make([]connectors.FileSystem_v2, 1)
+			getfilesysResp.FileSystems[0].Mount.MountPoint = "/fakemount"
+			getfilesysResp.Status.Code = 200
+			marshalledResponse, err := json.Marshal(getfilesysResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"GET",
+				fakeurl+"/scalemgmt/v2/filesystems/"+filesystem,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			copyDirectoryResp = connectors.GenericResponse{}
+			copyDirectoryResp.Jobs = make([]connectors.Job, 1)
+			copyDirectoryResp.Jobs[0].JobID = 1234
+			registerurl = fakeurl + "/scalemgmt/v2/filesystems/" + filesystem + "/directoryCopy/" + fileset
+			joburl = fakeurl + "/scalemgmt/v2/jobs/1234?fields=:all:"
+		})
+		It("Should pass while copying a directory", func() {
+			copyDirectoryResp.Status.Code = 202
+			copyDirectoryResp.Jobs[0].Status = "COMPLETED"
+			marshalledResponse, err := json.Marshal(copyDirectoryResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CopyDirectory(context.Background(), filesystem, "/fakemount/"+fileset, "/fakemount/target")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should fail when the job fails", func() {
+			copyDirectoryResp.Status.Code = 202
+			copyDirectoryResp.Jobs[0].Status = "FAILED"
+			marshalledResponse, err := json.Marshal(copyDirectoryResp)
+			Expect(err).ToNot(HaveOccurred())
+			httpmock.RegisterResponder(
+				"POST",
+				registerurl,
+				httpmock.NewStringResponder(202, string(marshalledResponse)),
+			)
+			httpmock.RegisterResponder(
+				"GET",
+				joburl,
+				httpmock.NewStringResponder(200, string(marshalledResponse)),
+			)
+			err = spectrumRestV2.CopyDirectory(context.Background(), filesystem, "/fakemount/"+fileset, "/fakemount/target")
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
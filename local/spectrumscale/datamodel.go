@@ -18,6 +18,7 @@ package spectrumscale
 
 import (
 	"fmt"
+	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/IBM/ubiquity/model"
 	"github.com/IBM/ubiquity/resources"
@@ -26,13 +27,18 @@ import (
 
 //go:generate counterfeiter -o ../../fakes/fake_SpectrumDataModel.go . SpectrumDataModel
 type SpectrumDataModel interface {
-	CreateVolumeTable() error
 	DeleteVolume(name string) error
 	InsertFilesetVolume(fileset, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
 	InsertFilesetQuotaVolume(fileset, quota, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
 	GetVolume(name string) (SpectrumScaleVolume, bool, error)
-	ListVolumes() ([]resources.Volume, error)
+	ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error)
+	ListSpectrumScaleVolumes() ([]SpectrumScaleVolume, error)
 	UpdateVolumeMountpoint(name string, mountpoint string) error
+	UpdateVolumeQuota(name string, quota string) error
+	InsertSnapshot(volumeName string, name string, storageId string) error
+	GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error)
+	ListSnapshots(volumeName string) ([]resources.Snapshot, error)
+	DeleteSnapshot(volumeName string, name string) error
 }
 
 type spectrumDataModel struct {
@@ -74,14 +80,6 @@ func NewSpectrumDataModel(log logs.Logger, db *gorm.DB, backend string) Spectrum
 	return &spectrumDataModel{log: log, database: db, backend: backend}
 }
 
-func (d *spectrumDataModel) CreateVolumeTable() error {
-	defer d.log.Trace(logs.DEBUG)()
-	if err := d.database.AutoMigrate(&SpectrumScaleVolume{}).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 func (d *spectrumDataModel) DeleteVolume(name string) error {
 	defer d.log.Trace(logs.DEBUG)()
 	volume, exists, err := d.GetVolume(name)
@@ -104,30 +102,45 @@ func (d *spectrumDataModel) DeleteVolume(name string) error {
 
 func (d *spectrumDataModel) InsertFilesetVolume(fileset, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error {
 	defer d.log.Trace(logs.DEBUG)()
-	volume := SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend}, Type: Fileset, ClusterId: d.clusterId, FileSystem: filesystem,
+	volume := SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend, BackendType: resources.SpectrumScale}, Type: Fileset, ClusterId: d.clusterId, FileSystem: filesystem,
 		Fileset: fileset, IsPreexisting: isPreexisting}
 
 	addPermissionsForVolume(&volume, opts)
+	addOriginForVolume(&volume, opts)
+	addLabelsForVolume(&volume, opts)
 
 	return d.insertVolume(volume)
 }
 
 func (d *spectrumDataModel) InsertFilesetQuotaVolume(fileset, quota, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error {
 	defer d.log.Trace(logs.DEBUG)()
-	volume := SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend}, Type: FilesetWithQuota, ClusterId: d.clusterId, FileSystem: filesystem,
+	volume := SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend, BackendType: resources.SpectrumScale}, Type: FilesetWithQuota, ClusterId: d.clusterId, FileSystem: filesystem,
 		Fileset: fileset, Quota: quota, IsPreexisting: isPreexisting}
 
 	addPermissionsForVolume(&volume, opts)
+	addOriginForVolume(&volume, opts)
+	addLabelsForVolume(&volume, opts)
 
 	return d.insertVolume(volume)
 }
 
+// insertVolume inserts the volume, in place of its creating record if there is one
 func (d *spectrumDataModel) insertVolume(volume SpectrumScaleVolume) error {
 	defer d.log.Trace(logs.DEBUG)()
-	if err := d.database.Create(&volume).Error; err != nil {
+	// the creating record is claimed in the transaction of the insert, so it is never claimed without its fileset volume
+	tx := d.database.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := model.ClaimCreatingVolume(tx, &volume.Volume); err != nil {
+		tx.Rollback()
 		return err
 	}
-	return nil
+	if err := tx.Create(&volume).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
 
 func (d *spectrumDataModel) GetVolume(name string) (SpectrumScaleVolume, bool, error) {
@@ -150,20 +163,26 @@ func (d *spectrumDataModel) GetVolume(name string) (SpectrumScaleVolume, bool, e
 	return spectrumVolume, true, nil
 }
 
-func (d *spectrumDataModel) ListVolumes() ([]resources.Volume, error) {
+// ListVolumes returns the volumes of the backend that match the filter of the request, sorted and paged as requested
+func (d *spectrumDataModel) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
 	defer d.log.Trace(logs.DEBUG)()
-	var volumesInDb []SpectrumScaleVolume
-	if err := d.database.Preload("Volume").Find(&volumesInDb).Error; err != nil {
-		return nil, err
-	}
-	// hack: to be replaced by proper DB filtering (joins)
-	var volumes []resources.Volume
-	for _, volume := range volumesInDb {
-		if volume.Volume.Backend == d.backend {
-			volumes = append(volumes, volume.Volume)
-		}
+	return model.ListVolumes(d.database, d.backend, listVolumesRequest)
+}
+
+// ListSpectrumScaleVolumes returns all the volumes of the backend with their filesystems and filesets
+func (d *spectrumDataModel) ListSpectrumScaleVolumes() ([]SpectrumScaleVolume, error) {
+	defer d.log.Trace(logs.DEBUG)()
+
+	query := d.database.Select("spectrum_scale_volumes.*").Joins("JOIN volumes ON volumes.id = spectrum_scale_volumes.volume_id")
+	query, err := model.FilterVolumes(query, d.backend, resources.ListVolumesRequest{})
+	if err != nil {
+		return nil, d.log.ErrorRet(err, "model.FilterVolumes failed")
 	}
 
+	var volumes []SpectrumScaleVolume
+	if err := query.Preload("Volume").Find(&volumes).Error; err != nil {
+		return nil, d.log.ErrorRet(err, "failed")
+	}
 	return volumes, nil
 }
 
@@ -180,6 +199,65 @@ func (d *spectrumDataModel) UpdateVolumeMountpoint(name string, mountpoint strin
 	return nil
 }
 
+// UpdateVolumeQuota records a new quota for the volume, a fileset without quota becomes a fileset with quota
+func (d *spectrumDataModel) UpdateVolumeQuota(name string, quota string) error {
+	defer d.log.Trace(logs.DEBUG)()
+	volume, exists, err := d.GetVolume(name)
+	if err != nil {
+		return err
+	}
+	if exists == false {
+		return fmt.Errorf("Volume : %s not found", name)
+	}
+
+	if err = d.database.Model(&volume).Updates(map[string]interface{}{"quota": quota, "type": FilesetWithQuota}).Error; err != nil {
+		return fmt.Errorf("Error updating quota of volume %s to %s: %s", name, quota, err.Error())
+	}
+	return nil
+}
+
+func (d *spectrumDataModel) InsertSnapshot(volumeName string, name string, storageId string) error {
+	defer d.log.Trace(logs.DEBUG)()
+	snapshot := resources.Snapshot{Name: name, VolumeName: volumeName, Backend: d.backend, StorageId: storageId}
+	if err := d.database.Create(&snapshot).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *spectrumDataModel) GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error) {
+	defer d.log.Trace(logs.DEBUG)()
+	snapshot, err := model.GetSnapshot(d.database, volumeName, name, d.backend)
+	if err != nil {
+		if err.Error() == "record not found" {
+			return resources.Snapshot{}, false, nil
+		}
+		return resources.Snapshot{}, false, err
+	}
+	return snapshot, true, nil
+}
+
+func (d *spectrumDataModel) ListSnapshots(volumeName string) ([]resources.Snapshot, error) {
+	defer d.log.Trace(logs.DEBUG)()
+	return model.ListSnapshots(d.database, volumeName, d.backend)
+}
+
+func (d *spectrumDataModel) DeleteSnapshot(volumeName string, name string) error {
+	defer d.log.Trace(logs.DEBUG)()
+	snapshot, exists, err := d.GetSnapshot(volumeName, name)
+	if err != nil {
+		return err
+	}
+	if exists == false {
+		return &resources.SnapshotNotFoundError{VolName: volumeName, SnapName: name}
+	}
+
+	if err := model.DeleteSnapshot(d.database, &snapshot).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func addPermissionsForVolume(volume *SpectrumScaleVolume, opts map[string]interface{}) {
 
 	if len(opts) > 0 {
@@ -193,3 +271,20 @@ func addPermissionsForVolume(volume *SpectrumScaleVolume, opts map[string]interf
 		}
 	}
 }
+
+func addOriginForVolume(volume *SpectrumScaleVolume, opts map[string]interface{}) {
+
+	// the options are read as utils.GetCloneSource normalized them when CreateVolume validated them
+	if sourceVolume, sourceSnapshot, err := utils.GetCloneSource(resources.CreateVolumeRequest{Opts: opts}); err == nil && sourceVolume != "" {
+		volume.Volume.SourceVolume = sourceVolume
+		volume.Volume.SourceSnapshot = sourceSnapshot
+	}
+}
+
+// addLabelsForVolume records the labels option, it is validated by CreateVolume before the volume is inserted
+func addLabelsForVolume(volume *SpectrumScaleVolume, opts map[string]interface{}) {
+
+	if labels, err := utils.GetVolumeLabels(opts); err == nil {
+		volume.Volume.Labels = utils.EncodeLabels(labels)
+	}
+}
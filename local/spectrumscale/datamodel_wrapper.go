@@ -17,16 +17,24 @@ package spectrumscale
 
 import (
         "github.com/IBM/ubiquity/resources"
+        "github.com/IBM/ubiquity/utils"
         "github.com/IBM/ubiquity/utils/logs"
         "github.com/IBM/ubiquity/database"
 )
 
+//go:generate counterfeiter -o ../../fakes/fake_spectrum_data_model_wrapper.go . SpectrumDataModelWrapper
 type SpectrumDataModelWrapper interface {
 	DeleteVolume(name string) error
 	InsertFilesetVolume(fileset, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
 	InsertFilesetQuotaVolume(fileset, quota, volumeName string, filesystem string, isPreexisting bool, opts map[string]interface{}) error
 	GetVolume(name string) (SpectrumScaleVolume, bool, error)
-	ListVolumes() ([]resources.Volume, error)
+	ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error)
+	ListStoredVolumes() ([]SpectrumScaleVolume, error)
+	UpdateVolumeQuota(name string, quota string) error
+	InsertSnapshot(volumeName string, name string, storageId string) error
+	GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error)
+	ListSnapshots(volumeName string) ([]resources.Snapshot, error)
+	DeleteSnapshot(volumeName string, name string) error
 	UpdateDatabaseVolume(newVolume *SpectrumScaleVolume)
 	IsDbVolume(name string) bool
 	GetDbName() string 
@@ -39,8 +47,6 @@ type spectrumDataModelWrapper struct {
 }
 
 func NewSpectrumDataModelWrapper(backend string) SpectrumDataModelWrapper {
-	database.RegisterMigration(resources.Volume{})
-	database.RegisterMigration(&SpectrumScaleVolume{})
 	return &spectrumDataModelWrapper{logger: logs.GetLogger(), backend: backend}
 }
 
@@ -60,6 +66,9 @@ func (d *spectrumDataModelWrapper) IsDbVolume(name string) bool {
 func (d *spectrumDataModelWrapper) UpdateDatabaseVolume(newVolume *SpectrumScaleVolume) {
         defer d.logger.Trace(logs.DEBUG)()
         d.logger.Debug("", logs.Args{{"dbVolume", d.dbVolume}, {"newVolume", newVolume}})
+        if newVolume != nil && newVolume.Volume.Status == "" {
+                newVolume.Volume.Status = resources.VolumeStatusAvailable
+        }
         d.dbVolume = newVolume
 }
 
@@ -145,8 +154,9 @@ func (d *spectrumDataModelWrapper) InsertFilesetVolume(fileset string, volumeNam
 		if d.dbVolume != nil {
 			return d.logger.ErrorRet(&resources.VolAlreadyExistsError{volumeName}, "failed")
 		}
-		volume := &SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend}, Type: Fileset, FileSystem: filesystem, Fileset: fileset, IsPreexisting: isPreexisting}
+		volume := &SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend, BackendType: resources.SpectrumScale}, Type: Fileset, FileSystem: filesystem, Fileset: fileset, IsPreexisting: isPreexisting}
 		d.addPermissionsForVolume(volume, opts)
+		addLabelsForVolume(volume, opts)
 		d.UpdateDatabaseVolume(volume)
 	} else {
 		dbConnection := database.NewConnection()
@@ -171,8 +181,9 @@ func (d *spectrumDataModelWrapper) InsertFilesetQuotaVolume(fileset string, quot
 		if d.dbVolume != nil {
 			return d.logger.ErrorRet(&resources.VolAlreadyExistsError{volumeName}, "failed")
 		}
-		volume := &SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend}, Type: FilesetWithQuota, FileSystem: filesystem, Fileset: fileset, Quota: quota, IsPreexisting: isPreexisting}
+		volume := &SpectrumScaleVolume{Volume: resources.Volume{Name: volumeName, Backend: d.backend, BackendType: resources.SpectrumScale}, Type: FilesetWithQuota, FileSystem: filesystem, Fileset: fileset, Quota: quota, IsPreexisting: isPreexisting}
 		d.addPermissionsForVolume(volume, opts)
+		addLabelsForVolume(volume, opts)
 		d.UpdateDatabaseVolume(volume)
 	} else {
 		dbConnection := database.NewConnection()
@@ -189,7 +200,36 @@ func (d *spectrumDataModelWrapper) InsertFilesetQuotaVolume(fileset string, quot
 	return nil
 }
 
-func (d *spectrumDataModelWrapper) ListVolumes() ([]resources.Volume, error) {
+func (d *spectrumDataModelWrapper) UpdateVolumeQuota(volumeName string, quota string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	if database.IsDatabaseVolume(volumeName) {
+		// sanity
+		if d.dbVolume == nil {
+			return d.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: volumeName}, "failed")
+		}
+		// work with memory object
+		volume := *d.dbVolume
+		volume.Quota = quota
+		volume.Type = FilesetWithQuota
+		d.UpdateDatabaseVolume(&volume)
+	} else {
+		dbConnection := database.NewConnection()
+		if err = dbConnection.Open(); err != nil {
+			return d.logger.ErrorRet(err, "dbConnection.Open failed")
+		}
+
+		defer dbConnection.Close()
+		dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
+		if err = dataModel.UpdateVolumeQuota(volumeName, quota); err != nil {
+			return d.logger.ErrorRet(err, "dataModel.UpdateVolumeQuota failed")
+		}
+	}
+	return nil
+}
+
+func (d *spectrumDataModelWrapper) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
 	defer d.logger.Trace(logs.DEBUG)()
 	var err error
 	var volumes []resources.Volume
@@ -200,12 +240,118 @@ func (d *spectrumDataModelWrapper) ListVolumes() ([]resources.Volume, error) {
 		defer dbConnection.Close()
 		// list volumes
 		dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
-		if volumes, err = dataModel.ListVolumes(); err != nil {
+		if volumes, err = dataModel.ListVolumes(listVolumesRequest); err != nil {
 			return nil, d.logger.ErrorRet(err, "dataModel.ListVolumes failed")
 		}
 	}
-	if d.dbVolume != nil {
+	// the db volume is kept in memory, so it is filtered here
+	if d.dbVolume != nil && utils.VolumeMatchesListRequest(d.dbVolume.Volume, listVolumesRequest) {
 		volumes = append(volumes,(*d.dbVolume).Volume)
 	}
 	return volumes, nil
 }
+
+// ListStoredVolumes returns all the volumes of the backend in the Ubiquity DB, without the in-memory db volume.
+// Unlike ListVolumes it fails if the DB cannot be opened, since its callers compare the volumes with the storage.
+func (d *spectrumDataModelWrapper) ListStoredVolumes() ([]SpectrumScaleVolume, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return nil, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	volumes, err := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend).ListSpectrumScaleVolumes()
+	if err != nil {
+		return nil, d.logger.ErrorRet(err, "dataModel.ListSpectrumScaleVolumes failed")
+	}
+	return volumes, nil
+}
+
+func (d *spectrumDataModelWrapper) InsertSnapshot(volumeName string, name string, storageId string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	// the db volume is kept in memory and has no snapshots
+	if database.IsDatabaseVolume(volumeName) {
+		return d.logger.ErrorRet(&resources.SnapshotNotSupportedForVolumeError{VolName: volumeName}, "failed")
+	}
+
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
+	if err = dataModel.InsertSnapshot(volumeName, name, storageId); err != nil {
+		return d.logger.ErrorRet(err, "dataModel.InsertSnapshot failed")
+	}
+	return nil
+}
+
+func (d *spectrumDataModelWrapper) GetSnapshot(volumeName string, name string) (resources.Snapshot, bool, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	if database.IsDatabaseVolume(volumeName) {
+		return resources.Snapshot{}, false, nil
+	}
+
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return resources.Snapshot{}, false, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
+	snapshot, exists, err := dataModel.GetSnapshot(volumeName, name)
+	if err != nil {
+		return resources.Snapshot{}, false, d.logger.ErrorRet(err, "dataModel.GetSnapshot failed")
+	}
+	return snapshot, exists, nil
+}
+
+func (d *spectrumDataModelWrapper) ListSnapshots(volumeName string) ([]resources.Snapshot, error) {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+	var snapshots []resources.Snapshot
+
+	if database.IsDatabaseVolume(volumeName) {
+		return snapshots, nil
+	}
+
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return nil, d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
+	if snapshots, err = dataModel.ListSnapshots(volumeName); err != nil {
+		return nil, d.logger.ErrorRet(err, "dataModel.ListSnapshots failed")
+	}
+	return snapshots, nil
+}
+
+func (d *spectrumDataModelWrapper) DeleteSnapshot(volumeName string, name string) error {
+	defer d.logger.Trace(logs.DEBUG)()
+	var err error
+
+	if database.IsDatabaseVolume(volumeName) {
+		return d.logger.ErrorRet(&resources.SnapshotNotFoundError{VolName: volumeName, SnapName: name}, "failed")
+	}
+
+	dbConnection := database.NewConnection()
+	if err = dbConnection.Open(); err != nil {
+		return d.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	dataModel := NewSpectrumDataModel(d.logger, dbConnection.GetDb(), d.backend)
+	if err = dataModel.DeleteSnapshot(volumeName, name); err != nil {
+		return d.logger.ErrorRet(err, "dataModel.DeleteSnapshot failed")
+	}
+	return nil
+}
@@ -43,9 +43,6 @@ var _ = Describe("SpectrumDataModelWrapper test", func() {
 		dataModelWrapper = spectrumscale.NewSpectrumDataModelWrapper(backend)
 		opts = make(map[string]interface{})
 	})
-	AfterEach(func() {
-		database.UnregisterAllMigrations()
-	})
 
 	Context("Database cannot be accessed yet", func() {
         Context("InsertFilesetVolume", func() {
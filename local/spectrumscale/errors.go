@@ -91,3 +91,21 @@ func (e * SpectrumScaleFileSetNotLinkError) Error() string {
 
 
 
+
+type SpectrumScaleQuotaNotSpecifiedError struct {
+	VolName string
+}
+
+func (e *SpectrumScaleQuotaNotSpecifiedError) Error() string {
+	return fmt.Sprintf("Volume [%s] expansion failure due to the missing [%s] option", e.VolName, Quota)
+}
+
+type SpectrumScaleQuotaNotBiggerError struct {
+	VolName      string
+	Quota        string
+	CurrentQuota string
+}
+
+func (e *SpectrumScaleQuotaNotBiggerError) Error() string {
+	return fmt.Sprintf("Volume [%s] expansion failure. The requested quota [%s] must be bigger than the current quota [%s]", e.VolName, e.Quota, e.CurrentQuota)
+}
@@ -17,16 +17,19 @@
 package spectrumscale
 
 import (
+	"context"
     "github.com/IBM/ubiquity/utils/logs"
 	"github.com/IBM/ubiquity/utils"
 	"fmt"
 	"github.com/IBM/ubiquity/local/spectrumscale/connectors"
 	"sync"
 	"github.com/IBM/ubiquity/resources"
+	"time"
 )
 
 type spectrumLocalClient struct {
 	logger         logs.Logger
+	backend        string
 	connector      connectors.SpectrumScaleConnector
 	dataModel      SpectrumDataModelWrapper
 	executor       utils.Executor
@@ -39,6 +42,7 @@ type spectrumLocalClient struct {
 const (
 	Type            string = "type"
 	TypeFileset     string = "fileset"
+	rootFileset     string = "root"
 
 	FilesetID string = "fileset"
 	Directory string = "directory"
@@ -62,7 +66,7 @@ func NewSpectrumLocalClient(config resources.UbiquityServerConfig) (resources.St
 }
 
 func NewSpectrumLocalClientWithConnectors(logger logs.Logger, connector connectors.SpectrumScaleConnector, spectrumExecutor utils.Executor, config resources.SpectrumScaleConfig, datamodel SpectrumDataModelWrapper) (resources.StorageClient, error) {
-	return &spectrumLocalClient{logger: logger, connector: connector, dataModel: datamodel, executor: spectrumExecutor, config: config, activationLock: &sync.RWMutex{}}, nil
+	return &spectrumLocalClient{logger: logger, backend: resources.SpectrumScale, connector: connector, dataModel: datamodel, executor: spectrumExecutor, config: config, activationLock: &sync.RWMutex{}}, nil
 }
 
 func newSpectrumLocalClient(config resources.SpectrumScaleConfig, backend string) (*spectrumLocalClient, error) {
@@ -83,7 +87,7 @@ func newSpectrumLocalClient(config resources.SpectrumScaleConfig, backend string
 
 	dbName := datamodel.GetDbName()
 
-	SpectrumScaleLocalClient := &spectrumLocalClient{logger: logger, connector: client, dataModel: datamodel, config: config, executor: utils.NewExecutor(), activationLock: &sync.RWMutex{}}
+	SpectrumScaleLocalClient := &spectrumLocalClient{logger: logger, backend: backend, connector: client, dataModel: datamodel, config: config, executor: utils.NewExecutor(), activationLock: &sync.RWMutex{}}
 
 
     // Validate Spectrum Scale cluster
@@ -91,10 +95,12 @@ func newSpectrumLocalClient(config resources.SpectrumScaleConfig, backend string
         return &spectrumLocalClient{}, err
     }
 
-    volume, err := client.ListFileset(config.DefaultFilesystemName, dbName)
+    ctx, cancel := backgroundContext()
+    defer cancel()
+    volume, err := client.ListFileset(ctx, config.DefaultFilesystemName, dbName)
 	if err == nil {
 		logger.Debug("DB volume fileset present")
-        scaleDbVol := &SpectrumScaleVolume{Volume: resources.Volume{Name: volume.Name, Backend: backend}, Type: Fileset, FileSystem: config.DefaultFilesystemName, Fileset: dbName}
+        scaleDbVol := &SpectrumScaleVolume{Volume: resources.Volume{Name: volume.Name, Backend: backend, BackendType: resources.SpectrumScale}, Type: Fileset, FileSystem: config.DefaultFilesystemName, Fileset: dbName}
         datamodel.UpdateDatabaseVolume(scaleDbVol)
 	} else {
 		logger.Debug("DB Vol Fileset Not Found", logs.Args{{"Filesystem", config.DefaultFilesystemName}, {"Fileset", dbName}})
@@ -122,30 +128,45 @@ func validateSpectrumscaleConfig(logger logs.Logger, config resources.SpectrumSc
 
 func basicSpectrumscaleLocalClientValidation(spectrumScaleClient *spectrumLocalClient) error {
     defer spectrumScaleClient.logger.Trace(logs.DEBUG)()
+    ctx, cancel := backgroundContext()
+    defer cancel()
 
     // SpectrumScale Validation
-    // 1. get cluster id with provided configuration to confirm connectivity and credentials
+    // get cluster id with provided configuration to confirm connectivity and credentials, and check if default filesystem exist and mounted
     spectrumScaleClient.logger.Info("Verifying the Connectivity and Credential of Spectrum Scale Cluster by getting Cluster ID of Spectrum Scale Cluster")
-    clusterid, err := spectrumScaleClient.connector.GetClusterId()
-    if err != nil {
-        return spectrumScaleClient.logger.ErrorRet(&SpectrumScaleGetClusterIdError{ErrorMsg: SpectrumScaleGetClusterIdErrorStr}, "")
-    }
-    spectrumScaleClient.logger.Info("Cluster ID of SpectrumScale Cluster", logs.Args{{"Cluster ID", clusterid}})
-
-    // 2. Check if default filesystem exist and mounted
-    spectrumScaleClient.logger.Info("Check if filesystem exist and mounted", logs.Args{{"Filesystem", spectrumScaleClient.config.DefaultFilesystemName}})
-    isfsmounted, err := spectrumScaleClient.connector.IsFilesystemMounted(spectrumScaleClient.config.DefaultFilesystemName)
-    if err != nil {
-        return spectrumScaleClient.logger.ErrorRet(&SpectrumScaleFileSystemNotPresent{Filesystem: spectrumScaleClient.config.DefaultFilesystemName},"")
-    }
-    if ! isfsmounted {
-        return spectrumScaleClient.logger.ErrorRet(&SpectrumScaleFileSystemNotMounted{Filesystem: spectrumScaleClient.config.DefaultFilesystemName},"")
+    if err := spectrumScaleClient.checkConnectivity(ctx); err != nil {
+        return err
     }
     spectrumScaleClient.logger.Info("Spectrum Scale filesystem is mounted.", logs.Args{{"Filesystem", spectrumScaleClient.config.DefaultFilesystemName}})
 
     return nil
 }
 
+// checkConnectivity gets the cluster id and checks that the default filesystem is mounted, on startup and on every health check
+func (s *spectrumLocalClient) checkConnectivity(ctx context.Context) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	clusterid, err := s.connector.GetClusterId(ctx)
+	if err != nil {
+		return s.logger.ErrorRet(&SpectrumScaleGetClusterIdError{ErrorMsg: SpectrumScaleGetClusterIdErrorStr}, "", logs.Args{{"cause", err}})
+	}
+	s.logger.Debug("Cluster ID of SpectrumScale Cluster", logs.Args{{"Cluster ID", clusterid}})
+
+	isfsmounted, err := s.connector.IsFilesystemMounted(ctx, s.config.DefaultFilesystemName)
+	if err != nil {
+		return s.logger.ErrorRet(&SpectrumScaleFileSystemNotPresent{Filesystem: s.config.DefaultFilesystemName}, "", logs.Args{{"cause", err}})
+	}
+	if !isfsmounted {
+		return s.logger.ErrorRet(&SpectrumScaleFileSystemNotMounted{Filesystem: s.config.DefaultFilesystemName}, "")
+	}
+	return nil
+}
+
+// backgroundContext bounds the Spectrum Scale calls that no request bounds (e.g the startup validation)
+func backgroundContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), resources.DefaultOperationDeadline*time.Second)
+}
+
 func (s *spectrumLocalClient) Attach(attachRequest resources.AttachRequest) (volumeMountpoint string, err error) {
 	return "", nil
 }
@@ -171,8 +192,100 @@ func (s *spectrumLocalClient) Activate(activateRequest resources.ActivateRequest
 	return nil
 }
 
+// CheckHealth verifies that the Spectrum Scale REST API is reachable and that the default filesystem is mounted
+func (s *spectrumLocalClient) CheckHealth(ctx context.Context) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	return s.checkConnectivity(ctx)
+}
+
+// ListServices returns the filesystems of the cluster with their capacity, the provisioned capacity is the sum of the fileset quotas
+func (s *spectrumLocalClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := listServicesRequest.Context.GetCtx()
+
+	filesystems, err := s.connector.ListFilesystems(ctx)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "ListFilesystems failed")
+	}
+
+	services := make([]resources.StorageService, 0, len(filesystems))
+	for _, filesystem := range filesystems {
+		capacity, err := s.connector.GetFilesystemCapacity(ctx, filesystem)
+		if err != nil {
+			return nil, s.logger.ErrorRet(err, "GetFilesystemCapacity failed", logs.Args{{"filesystem", filesystem}})
+		}
+		services = append(services, resources.StorageService{
+			Name:                filesystem,
+			Backend:             s.backend,
+			TotalCapacity:       capacity.TotalSize,
+			UsedCapacity:        capacity.TotalSize - capacity.FreeSize,
+			FreeCapacity:        capacity.FreeSize,
+			ProvisionedCapacity: capacity.QuotaSize,
+		})
+	}
+	return services, nil
+}
+
+// Reconcile compares the volumes of the backend in the Ubiquity DB with the filesets of the filesystems they are on
+// (and of the default filesystem). The filesets are named after the volumes, so the unmanaged filesets may include
+// filesets that were not created by Ubiquity, they are only reported.
+func (s *spectrumLocalClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := reconcileRequest.Context.GetCtx()
+	report := resources.ReconcileReport{Backend: s.backend, DanglingVolumes: []resources.Orphan{}, UnmanagedStorage: []resources.Orphan{}}
+
+	volumes, err := s.dataModel.ListStoredVolumes()
+	if err != nil {
+		return report, s.logger.ErrorRet(err, "dataModel.ListStoredVolumes failed")
+	}
+
+	filesystems := []string{s.config.DefaultFilesystemName}
+	isListedFilesystem := map[string]bool{s.config.DefaultFilesystemName: true}
+	volumeFilesets := make(map[string]bool)
+	for _, volume := range volumes {
+		if !isListedFilesystem[volume.FileSystem] {
+			filesystems = append(filesystems, volume.FileSystem)
+			isListedFilesystem[volume.FileSystem] = true
+		}
+		volumeFilesets[filesetStorageId(volume.FileSystem, volume.Fileset)] = true
+	}
+
+	storageFilesets := make(map[string]bool)
+	for _, filesystem := range filesystems {
+		filesets, err := s.connector.ListFilesets(ctx, filesystem)
+		if err != nil {
+			return report, s.logger.ErrorRet(err, "ListFilesets failed", logs.Args{{"filesystem", filesystem}})
+		}
+		for _, fileset := range filesets {
+			storageId := filesetStorageId(filesystem, fileset.Name)
+			storageFilesets[storageId] = true
+			// the root fileset is not a volume, and the db volume is not in the DB
+			if fileset.Name == rootFileset || s.dataModel.IsDbVolume(fileset.Name) || volumeFilesets[storageId] {
+				continue
+			}
+			report.UnmanagedStorage = append(report.UnmanagedStorage, resources.Orphan{Name: fileset.Name, StorageId: storageId})
+		}
+	}
+	for _, volume := range volumes {
+		storageId := filesetStorageId(volume.FileSystem, volume.Fileset)
+		if !storageFilesets[storageId] {
+			report.DanglingVolumes = append(report.DanglingVolumes, resources.Orphan{Name: volume.Volume.Name, StorageId: storageId})
+		}
+	}
+
+	s.logger.Info("reconciled", logs.Args{{"backend", s.backend}, {"danglingVolumes", len(report.DanglingVolumes)}, {"unmanagedStorage", len(report.UnmanagedStorage)}})
+	return report, nil
+}
+
+// filesetStorageId identifies a fileset in the reconcile reports
+func filesetStorageId(filesystem string, fileset string) string {
+	return filesystem + "/" + fileset
+}
+
 func (s *spectrumLocalClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) (err error) {
     defer s.logger.Trace(logs.DEBUG)()
+    ctx := createVolumeRequest.Context.GetCtx()
 
 	_, volExists, err := s.dataModel.GetVolume(createVolumeRequest.Name)
 
@@ -186,8 +299,23 @@ func (s *spectrumLocalClient) CreateVolume(createVolumeRequest resources.CreateV
 
 	s.logger.Debug("Opts for create:", logs.Args{{"Opts", createVolumeRequest.Opts}})
 
+	sourceVolume, sourceSnapshot, err := utils.GetCloneSource(createVolumeRequest)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Error in clone source")
+	}
+	if sourceVolume != "" {
+		err = s.validateCloneSource(createVolumeRequest.Name, sourceVolume, sourceSnapshot)
+		if err != nil {
+			return s.logger.ErrorRet(err, "Error in validate clone source")
+		}
+	}
+
+	if _, err = utils.GetVolumeLabels(createVolumeRequest.Opts); err != nil {
+		return s.logger.ErrorRet(err, "Error in labels")
+	}
+
 	if len(createVolumeRequest.Opts) == 0 {
-        return s.createFilesetVolume(s.config.DefaultFilesystemName, createVolumeRequest.Name, createVolumeRequest.Opts)
+        return s.createFilesetVolume(ctx, s.config.DefaultFilesystemName, createVolumeRequest.Name, createVolumeRequest.Opts)
 	}
 	s.logger.Debug("Trying to determine type for request")
 	userSpecifiedType, err := determineTypeFromRequest(s.logger, createVolumeRequest.Opts)
@@ -201,26 +329,31 @@ func (s *spectrumLocalClient) CreateVolume(createVolumeRequest resources.CreateV
 	}
 
 
+	if isExistingVolume && sourceVolume != "" {
+		return s.logger.ErrorRet(fmt.Errorf("%s cannot be specified along with existing fileset", resources.OptionNameForSourceVolume), "")
+	}
+
 	if isExistingVolume && userSpecifiedType == TypeFileset {
 		quota, quotaSpecified := createVolumeRequest.Opts[Quota]
 		if quotaSpecified {
-			return s.updateDBWithExistingFilesetQuota(filesystem, createVolumeRequest.Name, existingFileset, quota.(string), createVolumeRequest.Opts)
+			return s.updateDBWithExistingFilesetQuota(ctx, filesystem, createVolumeRequest.Name, existingFileset, quota.(string), createVolumeRequest.Opts)
 		}
-		return s.updateDBWithExistingFileset(filesystem, createVolumeRequest.Name, existingFileset, createVolumeRequest.Opts)
+		return s.updateDBWithExistingFileset(ctx, filesystem, createVolumeRequest.Name, existingFileset, createVolumeRequest.Opts)
 	}
 
 	if userSpecifiedType == TypeFileset {
 		quota, quotaSpecified := createVolumeRequest.Opts[Quota]
         if quotaSpecified {
-            return s.createFilesetQuotaVolume(filesystem, createVolumeRequest.Name, quota.(string), createVolumeRequest.Opts)
+            return s.createFilesetQuotaVolume(ctx, filesystem, createVolumeRequest.Name, quota.(string), createVolumeRequest.Opts)
         }
-		return s.createFilesetVolume(filesystem, createVolumeRequest.Name, createVolumeRequest.Opts)
+		return s.createFilesetVolume(ctx, filesystem, createVolumeRequest.Name, createVolumeRequest.Opts)
 	}
 	return s.logger.ErrorRet(fmt.Errorf("Internal error"),"")
 }
 
 func (s *spectrumLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) (err error) {
     defer s.logger.Trace(logs.DEBUG)()
+    ctx := removeVolumeRequest.Context.GetCtx()
 
 	existingVolume, volExists, err := s.dataModel.GetVolume(removeVolumeRequest.Name)
 
@@ -232,14 +365,23 @@ func (s *spectrumLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveV
 		return &resources.VolumeNotFoundError{VolName: removeVolumeRequest.Name}
 	}
 
-	isFilesetLinked, err := s.connector.IsFilesetLinked(existingVolume.FileSystem, existingVolume.Fileset)
+	// a volume with snapshots cannot be removed, otherwise the snapshots are left behind
+	snapshots, err := s.dataModel.ListSnapshots(removeVolumeRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to list volume snapshots from Database", logs.Args{{"VolumeName", removeVolumeRequest.Name}})
+	}
+	if len(snapshots) > 0 {
+		return s.logger.ErrorRet(&resources.VolumeHasSnapshotsError{VolName: removeVolumeRequest.Name, Snapshots: len(snapshots)}, "")
+	}
+
+	isFilesetLinked, err := s.connector.IsFilesetLinked(ctx, existingVolume.FileSystem, existingVolume.Fileset)
 
 	if err != nil {
 		return s.logger.ErrorRet(err, "Unable to check if fileset is linked", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
 	}
 
     if isFilesetLinked && existingVolume.IsPreexisting == false {
-        err := s.connector.UnlinkFileset(existingVolume.FileSystem, existingVolume.Fileset)
+        err := s.connector.UnlinkFileset(ctx, existingVolume.FileSystem, existingVolume.Fileset)
 		if err != nil {
 			return s.logger.ErrorRet(err, "Failed to Unlink fileset", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
 		}
@@ -250,7 +392,7 @@ func (s *spectrumLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveV
 		return s.logger.ErrorRet(err, "failed to delete volume", logs.Args{{"VolumeName", removeVolumeRequest.Name}})
 	}
 	if s.config.ForceDelete == true && existingVolume.IsPreexisting == false {
-		err = s.connector.DeleteFileset(existingVolume.FileSystem, existingVolume.Fileset)
+		err = s.connector.DeleteFileset(ctx, existingVolume.FileSystem, existingVolume.Fileset)
 
 		if err != nil {
 			return s.logger.ErrorRet(err, "failed to delete fileset", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
@@ -260,6 +402,163 @@ func (s *spectrumLocalClient) RemoveVolume(removeVolumeRequest resources.RemoveV
 	return nil
 }
 
+// ExpandVolume raises the fileset quota of the volume to the quota given in the request options.
+// A fileset created without quota gets the new quota as its first limit.
+func (s *spectrumLocalClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := expandVolumeRequest.Context.GetCtx()
+
+	existingVolume, volExists, err := s.dataModel.GetVolume(expandVolumeRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", expandVolumeRequest.Name}})
+	}
+	if volExists == false {
+		return s.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: expandVolumeRequest.Name}, "")
+	}
+
+	quota, quotaSpecified := expandVolumeRequest.Opts[Quota]
+	if !quotaSpecified {
+		return s.logger.ErrorRet(&SpectrumScaleQuotaNotSpecifiedError{VolName: expandVolumeRequest.Name}, "")
+	}
+
+	err = s.checkIfFSMounted(ctx, existingVolume.FileSystem)
+	if err != nil {
+		return err
+	}
+
+	err = s.connector.CheckIfFSQuotaEnabled(ctx, existingVolume.FileSystem)
+	if err != nil {
+		return s.logger.ErrorRet(&SpectrumScaleQuotaNotEnabledError{Filesystem: existingVolume.FileSystem}, "")
+	}
+
+	quotaBytes, err := utils.ConvertToBytes(s.logger, quota.(string))
+	if err != nil {
+		return s.logger.ErrorRet(err, "utils.ConvertToBytes failed", logs.Args{{"Quota", quota}})
+	}
+
+	if existingVolume.Type == FilesetWithQuota {
+		currentQuota, err := s.connector.ListFilesetQuota(ctx, existingVolume.FileSystem, existingVolume.Fileset)
+		if err != nil {
+			return s.logger.ErrorRet(err, "ListFilesetQuota failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+		}
+		currentQuotaBytes, err := utils.ConvertToBytes(s.logger, currentQuota)
+		if err != nil {
+			return s.logger.ErrorRet(err, "utils.ConvertToBytes failed", logs.Args{{"filesetQuota", currentQuota}})
+		}
+		if quotaBytes <= currentQuotaBytes {
+			return s.logger.ErrorRet(&SpectrumScaleQuotaNotBiggerError{VolName: expandVolumeRequest.Name, Quota: quota.(string), CurrentQuota: currentQuota}, "")
+		}
+	}
+
+	err = s.connector.SetFilesetQuota(ctx, existingVolume.FileSystem, existingVolume.Fileset, quota.(string))
+	if err != nil {
+		return s.logger.ErrorRet(err, "SetFilesetQuota failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+	}
+
+	err = s.dataModel.UpdateVolumeQuota(expandVolumeRequest.Name, quota.(string))
+	if err != nil {
+		return s.logger.ErrorRet(err, "UpdateVolumeQuota failed", logs.Args{{"VolumeName", expandVolumeRequest.Name}})
+	}
+
+	s.logger.Debug("Expanded fileset volume", logs.Args{{"VolumeName", expandVolumeRequest.Name}, {"quota", quota}})
+	return nil
+}
+
+// CreateSnapshot takes a fileset snapshot of the volume, the snapshot keeps the name given in the request.
+func (s *spectrumLocalClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := createSnapshotRequest.Context.GetCtx()
+
+	existingVolume, volExists, err := s.dataModel.GetVolume(createSnapshotRequest.VolumeName)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", createSnapshotRequest.VolumeName}})
+	}
+	if volExists == false {
+		return s.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: createSnapshotRequest.VolumeName}, "")
+	}
+	// the fileset of the Ubiquity DB is rejected before it is snapshotted, so no fileset snapshot is left without its record
+	if existingVolume.Type == Lightweight || s.dataModel.IsDbVolume(createSnapshotRequest.VolumeName) {
+		return s.logger.ErrorRet(&resources.SnapshotNotSupportedForVolumeError{VolName: createSnapshotRequest.VolumeName}, "")
+	}
+
+	_, snapExists, err := s.dataModel.GetSnapshot(createSnapshotRequest.VolumeName, createSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get snapshot from Database", logs.Args{{"VolumeName", createSnapshotRequest.VolumeName}, {"SnapshotName", createSnapshotRequest.Name}})
+	}
+	if snapExists {
+		return s.logger.ErrorRet(&resources.SnapshotAlreadyExistsError{VolName: createSnapshotRequest.VolumeName, SnapName: createSnapshotRequest.Name}, "")
+	}
+
+	err = s.checkIfFSMounted(ctx, existingVolume.FileSystem)
+	if err != nil {
+		return err
+	}
+
+	err = s.connector.CreateSnapshot(ctx, existingVolume.FileSystem, existingVolume.Fileset, createSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "CreateSnapshot failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+	}
+
+	err = s.dataModel.InsertSnapshot(createSnapshotRequest.VolumeName, createSnapshotRequest.Name, createSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "InsertSnapshot failed", logs.Args{{"VolumeName", createSnapshotRequest.VolumeName}, {"SnapshotName", createSnapshotRequest.Name}})
+	}
+
+	s.logger.Debug("Created fileset snapshot", logs.Args{{"VolumeName", createSnapshotRequest.VolumeName}, {"SnapshotName", createSnapshotRequest.Name}})
+	return nil
+}
+
+func (s *spectrumLocalClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	_, volExists, err := s.dataModel.GetVolume(listSnapshotsRequest.VolumeName)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", listSnapshotsRequest.VolumeName}})
+	}
+	if volExists == false {
+		return nil, s.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: listSnapshotsRequest.VolumeName}, "")
+	}
+
+	snapshots, err := s.dataModel.ListSnapshots(listSnapshotsRequest.VolumeName)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "Unable to list snapshots from Database", logs.Args{{"VolumeName", listSnapshotsRequest.VolumeName}})
+	}
+	return snapshots, nil
+}
+
+func (s *spectrumLocalClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+	ctx := deleteSnapshotRequest.Context.GetCtx()
+
+	existingVolume, volExists, err := s.dataModel.GetVolume(deleteSnapshotRequest.VolumeName)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", deleteSnapshotRequest.VolumeName}})
+	}
+	if volExists == false {
+		return s.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: deleteSnapshotRequest.VolumeName}, "")
+	}
+
+	existingSnapshot, snapExists, err := s.dataModel.GetSnapshot(deleteSnapshotRequest.VolumeName, deleteSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get snapshot from Database", logs.Args{{"VolumeName", deleteSnapshotRequest.VolumeName}, {"SnapshotName", deleteSnapshotRequest.Name}})
+	}
+	if snapExists == false {
+		s.logger.Warning("Idempotent issue encountered: snapshot was not found in DB during delete request.", logs.Args{{"VolumeName", deleteSnapshotRequest.VolumeName}, {"SnapshotName", deleteSnapshotRequest.Name}})
+		return nil
+	}
+
+	err = s.connector.DeleteSnapshot(ctx, existingVolume.FileSystem, existingVolume.Fileset, existingSnapshot.StorageId)
+	if err != nil {
+		return s.logger.ErrorRet(err, "DeleteSnapshot failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+	}
+
+	err = s.dataModel.DeleteSnapshot(deleteSnapshotRequest.VolumeName, deleteSnapshotRequest.Name)
+	if err != nil {
+		return s.logger.ErrorRet(err, "failed to delete snapshot", logs.Args{{"VolumeName", deleteSnapshotRequest.VolumeName}, {"SnapshotName", deleteSnapshotRequest.Name}})
+	}
+	return nil
+}
+
 func (s *spectrumLocalClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (resources.Volume, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
@@ -271,11 +570,12 @@ func (s *spectrumLocalClient) GetVolume(getVolumeRequest resources.GetVolumeRequ
 		return resources.Volume{},&resources.VolumeNotFoundError{VolName: getVolumeRequest.Name}
 	}
 
-	return resources.Volume{Name: existingVolume.Volume.Name, Backend: existingVolume.Volume.Backend, Mountpoint: existingVolume.Volume.Mountpoint}, nil
+	return resources.Volume{Name: existingVolume.Volume.Name, Backend: existingVolume.Volume.Backend, BackendType: resources.SpectrumScale, Mountpoint: existingVolume.Volume.Mountpoint, Status: existingVolume.Volume.Status}, nil
 }
 
 func (s *spectrumLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (volumeConfigDetails map[string]interface{}, err error) {
     defer s.logger.Trace(logs.DEBUG)()
+    ctx := getVolumeConfigRequest.Context.GetCtx()
 
 	existingVolume, volExists, err := s.dataModel.GetVolume(getVolumeConfigRequest.Name)
 
@@ -285,12 +585,12 @@ func (s *spectrumLocalClient) GetVolumeConfig(getVolumeConfigRequest resources.G
 
 	if volExists {
 		volumeConfigDetails = make(map[string]interface{})
-		volumeMountpoint, err := s.getVolumeMountPoint(existingVolume)
+		volumeMountpoint, err := s.getVolumeMountPoint(ctx, existingVolume)
 		if err != nil {
 			return nil, s.logger.ErrorRet(err, "failed to get mountpoint for volume", logs.Args{{"VolumeName", getVolumeConfigRequest.Name}})
 		}
 
-		isFilesetLinked, err := s.connector.IsFilesetLinked(existingVolume.FileSystem, existingVolume.Fileset)
+		isFilesetLinked, err := s.connector.IsFilesetLinked(ctx, existingVolume.FileSystem, existingVolume.Fileset)
 		if err != nil {
 			return nil, s.logger.ErrorRet(err, "failed to check if fileset is linked", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
 		}
@@ -318,14 +618,14 @@ func (s *spectrumLocalClient) ListVolumes(listVolumesRequest resources.ListVolum
     defer s.logger.Trace(logs.DEBUG)()
 
 	var err error
-	volumesInDb, err := s.dataModel.ListVolumes()
+	volumesInDb, err := s.dataModel.ListVolumes(listVolumesRequest)
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "failed to list volumes")
 	}
 	return volumesInDb, nil
 }
 
-func (s *spectrumLocalClient) createFilesetVolume(filesystem, name string, opts map[string]interface{}) error {
+func (s *spectrumLocalClient) createFilesetVolume(ctx context.Context, filesystem, name string, opts map[string]interface{}) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	filesetName := generateFilesetName(name)
@@ -333,7 +633,7 @@ func (s *spectrumLocalClient) createFilesetVolume(filesystem, name string, opts
 	var dbVolerr error
 	var err error
 
-    err = s.checkIfFSMounted(filesystem)
+    err = s.checkIfFSMounted(ctx, filesystem)
     if err != nil {
         return err
     }
@@ -342,15 +642,24 @@ func (s *spectrumLocalClient) createFilesetVolume(filesystem, name string, opts
 	if isDbVolume {
         // Check if fileset is present
         s.logger.Debug("DB Volume, check if present",logs.Args{{"VolumeName",name},{"Filesystem",filesystem}})
-		_, dbVolerr = s.connector.ListFileset(filesystem, name)
+		_, dbVolerr = s.connector.ListFileset(ctx, filesystem, name)
 	}
 
 	if !isDbVolume || dbVolerr != nil {
         s.logger.Debug("Create fileset: DB volume is not present OR it is normal volume creation", logs.Args{{"VolumeName", filesetName}, {"Filesystem", filesystem}})
-		err := s.connector.CreateFileset(filesystem, filesetName, opts)
+		err := s.connector.CreateFileset(ctx, filesystem, filesetName, opts)
 		if  err != nil {
 			return s.logger.ErrorRet(err, "Error creating fileset", logs.Args{{"Filesystem", filesystem}, {"Fileset", filesetName}})
 		}
+
+		err = s.copyCloneSource(ctx, filesystem, filesetName, opts)
+		if err != nil {
+			deleteErr := s.connector.DeleteFileset(ctx, filesystem, filesetName)
+			if deleteErr != nil {
+				return s.logger.ErrorRet(deleteErr, "Error copying clone source (rollback error on delete fileset", logs.Args{{"filesetName", filesetName}})
+			}
+			return err
+		}
 	}
 
 	err = s.dataModel.InsertFilesetVolume(filesetName, name, filesystem, false, opts)
@@ -362,43 +671,52 @@ func (s *spectrumLocalClient) createFilesetVolume(filesystem, name string, opts
 	return nil
 }
 
-func (s *spectrumLocalClient) createFilesetQuotaVolume(filesystem, name, quota string, opts map[string]interface{}) error {
+func (s *spectrumLocalClient) createFilesetQuotaVolume(ctx context.Context, filesystem, name, quota string, opts map[string]interface{}) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	filesetName := generateFilesetName(name)
     var dbVolerr error
 	var err error
 
-    err = s.checkIfFSMounted(filesystem)
+    err = s.checkIfFSMounted(ctx, filesystem)
     if err != nil {
         return err
     }
 
-    err = s.connector.CheckIfFSQuotaEnabled(filesystem)
+    err = s.connector.CheckIfFSQuotaEnabled(ctx, filesystem)
     if err != nil {
         return s.logger.ErrorRet(&SpectrumScaleQuotaNotEnabledError{Filesystem: filesystem}, "")
     }
     if s.dataModel.IsDbVolume(name) {
     //Check if fileset is present
     s.logger.Debug("DB Volume, check if present",logs.Args{{"VolumeName",name},{"Filesystem",filesystem}})
-        _, dbVolerr = s.connector.ListFileset(filesystem, name)
+        _, dbVolerr = s.connector.ListFileset(ctx, filesystem, name)
     }
 
     if !s.dataModel.IsDbVolume(name) || dbVolerr != nil {
         s.logger.Debug("Create fileset: DB volume is not present OR it is normal volume creation", logs.Args{{"VolumeName", filesetName}, {"Filesystem", filesystem}})
-        err := s.connector.CreateFileset(filesystem, filesetName, opts)
+        err := s.connector.CreateFileset(ctx, filesystem, filesetName, opts)
         if  err != nil {
             return s.logger.ErrorRet(err, "Error creating fileset", logs.Args{{"Filesystem", filesystem}, {"Fileset", filesetName}})
         }
 
-        err = s.connector.SetFilesetQuota(filesystem, filesetName, quota)
+        err = s.connector.SetFilesetQuota(ctx, filesystem, filesetName, quota)
 		if err != nil {
-			deleteErr := s.connector.DeleteFileset(filesystem, filesetName)
+			deleteErr := s.connector.DeleteFileset(ctx, filesystem, filesetName)
 			if deleteErr != nil {
 				return s.logger.ErrorRet(deleteErr, "Error setting quota (rollback error on delete fileset", logs.Args{{"filesetName", filesetName}})
 			}
 			return err
 		}
+
+		err = s.copyCloneSource(ctx, filesystem, filesetName, opts)
+		if err != nil {
+			deleteErr := s.connector.DeleteFileset(ctx, filesystem, filesetName)
+			if deleteErr != nil {
+				return s.logger.ErrorRet(deleteErr, "Error copying clone source (rollback error on delete fileset", logs.Args{{"filesetName", filesetName}})
+			}
+			return err
+		}
 	}
 
 	err = s.dataModel.InsertFilesetQuotaVolume(filesetName, quota, name, filesystem, false, opts)
@@ -411,6 +729,113 @@ func (s *spectrumLocalClient) createFilesetQuotaVolume(filesystem, name, quota s
 	return nil
 }
 
+// validateCloneSource checks that the volume can be created as a clone of the source volume, or of its snapshot
+func (s *spectrumLocalClient) validateCloneSource(name, sourceVolume, sourceSnapshot string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	if s.dataModel.IsDbVolume(name) {
+		return &resources.CloneNotSupportedForVolumeError{VolName: name}
+	}
+	if s.dataModel.IsDbVolume(sourceVolume) {
+		return &resources.CloneNotSupportedForVolumeError{VolName: sourceVolume}
+	}
+
+	existingVolume, volExists, err := s.dataModel.GetVolume(sourceVolume)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", sourceVolume}})
+	}
+	if volExists == false {
+		return &resources.VolumeNotFoundError{VolName: sourceVolume}
+	}
+	if existingVolume.Type == Lightweight {
+		return &resources.CloneNotSupportedForVolumeError{VolName: sourceVolume}
+	}
+
+	if sourceSnapshot != "" {
+		_, snapExists, err := s.dataModel.GetSnapshot(sourceVolume, sourceSnapshot)
+		if err != nil {
+			return s.logger.ErrorRet(err, "Unable to get snapshot from Database", logs.Args{{"VolumeName", sourceVolume}, {"SnapshotName", sourceSnapshot}})
+		}
+		if snapExists == false {
+			return &resources.SnapshotNotFoundError{VolName: sourceVolume, SnapName: sourceSnapshot}
+		}
+	}
+	return nil
+}
+
+// copyCloneSource links the new fileset and copies into it the content of the source volume (or of its snapshot) given in opts.
+// Nothing is done if the volume is not a clone, the fileset is left unlinked if the copy fails.
+func (s *spectrumLocalClient) copyCloneSource(ctx context.Context, filesystem, filesetName string, opts map[string]interface{}) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	// the clone source was validated by CreateVolume, the options are read as it normalized them
+	sourceVolume, sourceSnapshot, _ := utils.GetCloneSource(resources.CreateVolumeRequest{Opts: opts})
+	if sourceVolume == "" {
+		return nil
+	}
+
+	existingVolume, _, err := s.dataModel.GetVolume(sourceVolume)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get volume from Database", logs.Args{{"VolumeName", sourceVolume}})
+	}
+
+	err = s.connector.LinkFileset(ctx, filesystem, filesetName)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Error linking fileset", logs.Args{{"Filesystem", filesystem}, {"Fileset", filesetName}})
+	}
+
+	err = s.copyToFileset(ctx, existingVolume, sourceSnapshot, filesystem, filesetName)
+	if err != nil {
+		unlinkErr := s.connector.UnlinkFileset(ctx, filesystem, filesetName)
+		if unlinkErr != nil {
+			return s.logger.ErrorRet(unlinkErr, "Error copying clone source (rollback error on unlink fileset", logs.Args{{"filesetName", filesetName}})
+		}
+		return err
+	}
+
+	s.logger.Debug("Copied clone source into fileset", logs.Args{{"filesetName", filesetName}, {"sourceVolume", sourceVolume}, {"sourceSnapshot", sourceSnapshot}})
+	return nil
+}
+
+func (s *spectrumLocalClient) copyToFileset(ctx context.Context, existingVolume SpectrumScaleVolume, sourceSnapshot, filesystem, filesetName string) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	targetVolume, err := s.connector.ListFileset(ctx, filesystem, filesetName)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get fileset mountpoint", logs.Args{{"Filesystem", filesystem}, {"Fileset", filesetName}})
+	}
+
+	if sourceSnapshot != "" {
+		snapshot, _, err := s.dataModel.GetSnapshot(existingVolume.Volume.Name, sourceSnapshot)
+		if err != nil {
+			return s.logger.ErrorRet(err, "Unable to get snapshot from Database", logs.Args{{"VolumeName", existingVolume.Volume.Name}, {"SnapshotName", sourceSnapshot}})
+		}
+		err = s.connector.CopyFilesetSnapshot(ctx, existingVolume.FileSystem, existingVolume.Fileset, snapshot.StorageId, targetVolume.Mountpoint)
+		if err != nil {
+			return s.logger.ErrorRet(err, "CopyFilesetSnapshot failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+		}
+		return nil
+	}
+
+	isFilesetLinked, err := s.connector.IsFilesetLinked(ctx, existingVolume.FileSystem, existingVolume.Fileset)
+	if err != nil {
+		return s.logger.ErrorRet(&SpectrumScaleFileSetLinkError{Filesystem: existingVolume.FileSystem, Fileset: existingVolume.Fileset}, "")
+	}
+	if !isFilesetLinked {
+		return s.logger.ErrorRet(&SpectrumScaleFileSetNotLinkError{Filesystem: existingVolume.FileSystem, Fileset: existingVolume.Fileset}, "")
+	}
+
+	sourcePath, err := s.getVolumeMountPoint(ctx, existingVolume)
+	if err != nil {
+		return s.logger.ErrorRet(err, "Unable to get fileset mountpoint", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+	}
+	err = s.connector.CopyDirectory(ctx, existingVolume.FileSystem, sourcePath, targetVolume.Mountpoint)
+	if err != nil {
+		return s.logger.ErrorRet(err, "CopyDirectory failed", logs.Args{{"Filesystem", existingVolume.FileSystem}, {"Fileset", existingVolume.Fileset}})
+	}
+	return nil
+}
+
 func generateFilesetName(name string) string {
 	//TODO: placeholder for now
 	return name
@@ -419,12 +844,12 @@ func generateFilesetName(name string) string {
 
 //TODO move updates to DB file
 
-func (s *spectrumLocalClient) updateDBWithExistingFileset(filesystem, name, userSpecifiedFileset string, opts map[string]interface{}) error {
+func (s *spectrumLocalClient) updateDBWithExistingFileset(ctx context.Context, filesystem, name, userSpecifiedFileset string, opts map[string]interface{}) error {
     defer s.logger.Trace(logs.DEBUG)()
 
 	s.logger.Debug("User specified fileset", logs.Args{{"userSpecifiedFileset", userSpecifiedFileset}})
 
-	_, err := s.connector.ListFileset(filesystem, userSpecifiedFileset)
+	_, err := s.connector.ListFileset(ctx, filesystem, userSpecifiedFileset)
 	if err != nil {
 		return s.logger.ErrorRet(err, "Fileset does not exist", logs.Args{{"filesystem", filesystem}, {"Fileset", userSpecifiedFileset}})
 	}
@@ -452,10 +877,10 @@ func (s *spectrumLocalClient) checkIfVolumeExistsInDB(name, userSpecifiedFileset
 	return nil
 }
 
-func (s *spectrumLocalClient) updateDBWithExistingFilesetQuota(filesystem, name, userSpecifiedFileset, quota string, opts map[string]interface{}) error {
+func (s *spectrumLocalClient) updateDBWithExistingFilesetQuota(ctx context.Context, filesystem, name, userSpecifiedFileset, quota string, opts map[string]interface{}) error {
     defer s.logger.Trace(logs.DEBUG)()
 
-	filesetQuota, err := s.connector.ListFilesetQuota(filesystem, userSpecifiedFileset)
+	filesetQuota, err := s.connector.ListFilesetQuota(ctx, filesystem, userSpecifiedFileset)
 
 	if err != nil {
 		return s.logger.ErrorRet(err, "ListFilesetQuota failed",logs.Args{{"filesystem", filesystem}, {"Fileset", userSpecifiedFileset}})
@@ -463,7 +888,7 @@ func (s *spectrumLocalClient) updateDBWithExistingFilesetQuota(filesystem, name,
 
 // We need to make sure fileset is linked for existing fileset case, else we will fail while mounting.
 
-    isFilesetLinked, err := s.connector.IsFilesetLinked(filesystem, userSpecifiedFileset)
+    isFilesetLinked, err := s.connector.IsFilesetLinked(ctx, filesystem, userSpecifiedFileset)
 
     if err != nil {
         return s.logger.ErrorRet(&SpectrumScaleFileSetLinkError{Filesystem: filesystem, Fileset: userSpecifiedFileset}, "")
@@ -513,10 +938,10 @@ func determineTypeFromRequest(logger logs.Logger, opts map[string]interface{}) (
 	return userSpecifiedType.(string), nil
 }
 
-func (s *spectrumLocalClient) checkIfFSMounted(filesystem string) error {
+func (s *spectrumLocalClient) checkIfFSMounted(ctx context.Context, filesystem string) error {
     defer s.logger.Trace(logs.DEBUG)()
 
-    isfsmounted, err := s.connector.IsFilesystemMounted(filesystem)
+    isfsmounted, err := s.connector.IsFilesystemMounted(ctx, filesystem)
     if err != nil {
         return s.logger.ErrorRet(&SpectrumScaleFileSystemMountError{Filesystem: filesystem},"")
     }
@@ -562,10 +987,10 @@ func (s *spectrumLocalClient) validateAndParseParams(logger logs.Logger, opts ma
 		}
 }
 
-func (s *spectrumLocalClient) getVolumeMountPoint(volume SpectrumScaleVolume) (string, error) {
+func (s *spectrumLocalClient) getVolumeMountPoint(ctx context.Context, volume SpectrumScaleVolume) (string, error) {
     defer s.logger.Trace(logs.DEBUG)()
 
-    vol, err := s.connector.ListFileset(volume.FileSystem, volume.Fileset)
+    vol, err := s.connector.ListFileset(ctx, volume.FileSystem, volume.Fileset)
 	if err != nil {
 		return "", err
 	}
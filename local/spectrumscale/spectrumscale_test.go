@@ -17,11 +17,13 @@
 package spectrumscale_test
 
 import (
+	"context"
 	"fmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/IBM/ubiquity/local/spectrumscale"
+	"github.com/IBM/ubiquity/local/spectrumscale/connectors"
 
 	"github.com/IBM/ubiquity/fakes"
 	"github.com/IBM/ubiquity/resources"
@@ -98,6 +100,16 @@ var _ = Describe("local-client", func() {
 			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(1))
 		})
 
+		It("should fail when the labels are invalid", func() {
+			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{}, false, nil)
+			createVolumeRequest.Opts = map[string]interface{}{resources.OptionNameForLabels: "app"}
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.InvalidLabelsError)
+			Expect(ok).To(BeTrue())
+			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(0))
+		})
+
 		It("should fail since we unable to fetch filesystem mounted status", func() {
 			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{}, false, nil)
 			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(false, fmt.Errorf("Failed to fetch filesystem"))
@@ -340,6 +352,213 @@ var _ = Describe("local-client", func() {
 
 	})
 
+	Context(".CheckHealth", func() {
+		It("should fail when the cluster id cannot be fetched", func() {
+			fakeSpectrumScaleConnector.GetClusterIdReturns("", fmt.Errorf("error in get cluster id"))
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.IsFilesystemMountedCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the default filesystem is not mounted", func() {
+			fakeSpectrumScaleConnector.GetClusterIdReturns("fake-cluster", nil)
+			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(false, nil)
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*spectrumscale.SpectrumScaleFileSystemNotMounted)
+			Expect(ok).To(Equal(true))
+		})
+
+		It("should succeed when the cluster is reachable and the filesystem is mounted", func() {
+			fakeSpectrumScaleConnector.GetClusterIdReturns("fake-cluster", nil)
+			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(true, nil)
+			err = client.(resources.HealthChecker).CheckHealth(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context(".ListServices", func() {
+		It("should return the filesystems with their capacity", func() {
+			fakeSpectrumScaleConnector.ListFilesystemsReturns([]string{"gold", "silver"}, nil)
+			fakeSpectrumScaleConnector.GetFilesystemCapacityStub = func(ctx context.Context, filesystem string) (connectors.FilesystemCapacity, error) {
+				if filesystem == "gold" {
+					return connectors.FilesystemCapacity{TotalSize: 1000, FreeSize: 600, QuotaSize: 800}, nil
+				}
+				return connectors.FilesystemCapacity{TotalSize: 500, FreeSize: 500}, nil
+			}
+			services, err := client.ListServices(resources.ListServicesRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(services).To(Equal([]resources.StorageService{
+				{Name: "gold", Backend: resources.SpectrumScale, TotalCapacity: 1000, UsedCapacity: 400, FreeCapacity: 600, ProvisionedCapacity: 800},
+				{Name: "silver", Backend: resources.SpectrumScale, TotalCapacity: 500, UsedCapacity: 0, FreeCapacity: 500},
+			}))
+		})
+
+		It("should fail when the filesystems cannot be listed", func() {
+			fakeSpectrumScaleConnector.ListFilesystemsReturns(nil, fmt.Errorf("error in list filesystems"))
+			_, err = client.ListServices(resources.ListServicesRequest{})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.GetFilesystemCapacityCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the capacity of a filesystem cannot be fetched", func() {
+			fakeSpectrumScaleConnector.ListFilesystemsReturns([]string{"gold"}, nil)
+			fakeSpectrumScaleConnector.GetFilesystemCapacityReturns(connectors.FilesystemCapacity{}, fmt.Errorf("error in get capacity"))
+			_, err = client.ListServices(resources.ListServicesRequest{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".Reconcile", func() {
+		BeforeEach(func() {
+			fakeConfig.DefaultFilesystemName = "gold"
+			client, err = spectrumscale.NewSpectrumLocalClientWithConnectors(logger, fakeSpectrumScaleConnector, fakeExec, fakeConfig, fakeSpectrumDataModel)
+			Expect(err).ToNot(HaveOccurred())
+			fakeSpectrumDataModel.IsDbVolumeStub = func(name string) bool { return name == "ibm-ubiquity-db" }
+		})
+
+		It("should report the dangling volumes and the unmanaged filesets of the filesystems in use", func() {
+			fakeSpectrumDataModel.ListStoredVolumesReturns([]spectrumscale.SpectrumScaleVolume{
+				{Volume: resources.Volume{Name: "vol1"}, FileSystem: "gold", Fileset: "vol1"},
+				{Volume: resources.Volume{Name: "vol2"}, FileSystem: "silver", Fileset: "vol2"},
+			}, nil)
+			fakeSpectrumScaleConnector.ListFilesetsStub = func(ctx context.Context, filesystem string) ([]resources.Volume, error) {
+				if filesystem == "gold" {
+					return []resources.Volume{{Name: "root"}, {Name: "ibm-ubiquity-db"}, {Name: "vol1"}, {Name: "leaked"}}, nil
+				}
+				return []resources.Volume{{Name: "root"}}, nil
+			}
+			report, err := client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.ListFilesetsCallCount()).To(Equal(2))
+			Expect(report).To(Equal(resources.ReconcileReport{
+				Backend:          resources.SpectrumScale,
+				DanglingVolumes:  []resources.Orphan{{Name: "vol2", StorageId: "silver/vol2"}},
+				UnmanagedStorage: []resources.Orphan{{Name: "leaked", StorageId: "gold/leaked"}},
+			}))
+		})
+
+		It("should fail when the filesets cannot be listed", func() {
+			fakeSpectrumScaleConnector.ListFilesetsReturns(nil, fmt.Errorf("error in list filesets"))
+			_, err = client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail when the volumes in the DB cannot be listed", func() {
+			fakeSpectrumDataModel.ListStoredVolumesReturns(nil, fmt.Errorf("error in list volumes"))
+			_, err = client.Reconcile(resources.ReconcileRequest{})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.ListFilesetsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context(".CreateVolume clone", func() {
+		var (
+			opts         map[string]interface{}
+			sourceVolume spectrumscale.SpectrumScaleVolume
+		)
+		BeforeEach(func() {
+			opts = make(map[string]interface{})
+			opts[resources.OptionNameForSourceVolume] = "source-volume"
+			createVolumeRequest = resources.CreateVolumeRequest{Name: "fake-fileset", Opts: opts}
+			sourceVolume = spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "source-volume"}, Type: spectrumscale.Fileset, FileSystem: "fake-filesystem", Fileset: "source-fileset"}
+			fakeSpectrumDataModel.GetVolumeStub = func(name string) (spectrumscale.SpectrumScaleVolume, bool, error) {
+				if name == "source-volume" {
+					return sourceVolume, true, nil
+				}
+				return spectrumscale.SpectrumScaleVolume{}, false, nil
+			}
+			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(true, nil)
+			fakeSpectrumScaleConnector.IsFilesetLinkedReturns(true, nil)
+			fakeSpectrumScaleConnector.ListFilesetStub = func(ctx context.Context, filesystem, fileset string) (resources.Volume, error) {
+				return resources.Volume{Name: fileset, Mountpoint: "/gpfs/" + fileset}, nil
+			}
+		})
+
+		It("should fail when the source volume does not exist", func() {
+			opts[resources.OptionNameForSourceVolume] = "missing-volume"
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("[missing-volume] " + resources.VolumeNotFoundErrorMsg))
+			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the source snapshot does not exist", func() {
+			opts[resources.OptionNameForSourceSnapshot] = "source-snapshot"
+			fakeSpectrumDataModel.GetSnapshotReturns(resources.Snapshot{}, false, nil)
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.SnapshotNotFoundError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the source volume is a lightweight volume", func() {
+			sourceVolume.Type = spectrumscale.Lightweight
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.CloneNotSupportedForVolumeError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(0))
+		})
+
+		It("should copy the source fileset into the new fileset", func() {
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.CreateFilesetCallCount()).To(Equal(1))
+			_, filesystem, fileset := fakeSpectrumScaleConnector.LinkFilesetArgsForCall(0)
+			Expect(filesystem).To(Equal(fakeConfig.DefaultFilesystemName))
+			Expect(fileset).To(Equal("fake-fileset"))
+			Expect(fakeSpectrumScaleConnector.CopyDirectoryCallCount()).To(Equal(1))
+			_, filesystem, sourcePath, targetPath := fakeSpectrumScaleConnector.CopyDirectoryArgsForCall(0)
+			Expect(filesystem).To(Equal("fake-filesystem"))
+			Expect(sourcePath).To(Equal("/gpfs/source-fileset"))
+			Expect(targetPath).To(Equal("/gpfs/fake-fileset"))
+			Expect(fakeSpectrumDataModel.InsertFilesetVolumeCallCount()).To(Equal(1))
+			_, _, _, _, insertOpts := fakeSpectrumDataModel.InsertFilesetVolumeArgsForCall(0)
+			Expect(insertOpts[resources.OptionNameForSourceVolume]).To(Equal("source-volume"))
+		})
+
+		It("should clone a source volume that is not given as a string", func() {
+			opts[resources.OptionNameForSourceVolume] = 42
+			sourceVolume.Volume.Name = "42"
+			fakeSpectrumDataModel.GetVolumeStub = func(name string) (spectrumscale.SpectrumScaleVolume, bool, error) {
+				if name == "42" {
+					return sourceVolume, true, nil
+				}
+				return spectrumscale.SpectrumScaleVolume{}, false, nil
+			}
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.CopyDirectoryCallCount()).To(Equal(1))
+		})
+
+		It("should copy the source snapshot into the new fileset", func() {
+			opts[resources.OptionNameForSourceSnapshot] = "source-snapshot"
+			fakeSpectrumDataModel.GetSnapshotReturns(resources.Snapshot{Name: "source-snapshot", StorageId: "source-snapshot"}, true, nil)
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.CopyDirectoryCallCount()).To(Equal(0))
+			Expect(fakeSpectrumScaleConnector.CopyFilesetSnapshotCallCount()).To(Equal(1))
+			_, filesystem, fileset, snapshot, targetPath := fakeSpectrumScaleConnector.CopyFilesetSnapshotArgsForCall(0)
+			Expect(filesystem).To(Equal("fake-filesystem"))
+			Expect(fileset).To(Equal("source-fileset"))
+			Expect(snapshot).To(Equal("source-snapshot"))
+			Expect(targetPath).To(Equal("/gpfs/fake-fileset"))
+			Expect(fakeSpectrumDataModel.InsertFilesetVolumeCallCount()).To(Equal(1))
+		})
+
+		It("should delete the new fileset when the copy fails", func() {
+			fakeSpectrumScaleConnector.CopyDirectoryReturns(fmt.Errorf("error copying directory"))
+			err = client.CreateVolume(createVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("error copying directory"))
+			Expect(fakeSpectrumScaleConnector.UnlinkFilesetCallCount()).To(Equal(1))
+			Expect(fakeSpectrumScaleConnector.DeleteFilesetCallCount()).To(Equal(1))
+			Expect(fakeSpectrumDataModel.InsertFilesetVolumeCallCount()).To(Equal(0))
+		})
+	})
+
 	Context(".RemoveVolume", func() {
 		BeforeEach(func() {
 			removeVolumeRequest = resources.RemoveVolumeRequest{Name: "fake-volume"}
@@ -361,6 +580,16 @@ var _ = Describe("local-client", func() {
 			Expect(fakeSpectrumDataModel.GetVolumeCallCount()).To(Equal(1))
 		})
 
+		It("should fail to remove a volume that has snapshots", func() {
+			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}}, true, nil)
+			fakeSpectrumDataModel.ListSnapshotsReturns([]resources.Snapshot{{Name: "fake-snapshot"}}, nil)
+			err = client.RemoveVolume(removeVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.VolumeHasSnapshotsError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumDataModel.DeleteVolumeCallCount()).To(Equal(0))
+		})
+
 		It("should fail when the dbClient fails to get the volume", func() {
 			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{}, false, fmt.Errorf("error getting volume"))
 			err = client.RemoveVolume(removeVolumeRequest)
@@ -490,7 +719,194 @@ var _ = Describe("local-client", func() {
 			Expect(len(volumes)).To(Equal(2))
 			Expect(fakeSpectrumDataModel.ListVolumesCallCount()).To(Equal(1))
 		})
+		It("should pass the filter of the request to the data model", func() {
+			listVolumesRequest.NamePrefix = "fake"
+			listVolumesRequest.Labels = map[string]string{"app": "db"}
+			listVolumesRequest.SortBy = resources.ListVolumesSortByCreated
+			listVolumesRequest.Limit = 10
+			_, err := client.ListVolumes(listVolumesRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumDataModel.ListVolumesArgsForCall(0)).To(Equal(listVolumesRequest))
+		})
+
+	})
+
+	Context(".ExpandVolume", func() {
+		var (
+			expandVolumeRequest resources.ExpandVolumeRequest
+		)
+		BeforeEach(func() {
+			expandVolumeRequest = resources.ExpandVolumeRequest{Name: "fake-volume", Opts: map[string]interface{}{"quota": "2G"}}
+			volume := spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}, Type: spectrumscale.FilesetWithQuota, FileSystem: "fake-filesystem", Fileset: "fake-fileset", Quota: "1G"}
+			fakeSpectrumDataModel.GetVolumeReturns(volume, true, nil)
+			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(true, nil)
+			fakeSpectrumScaleConnector.ListFilesetQuotaReturns("1G", nil)
+		})
+
+		It("should fail when the volume does not exist", func() {
+			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{}, false, nil)
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("[fake-volume] " + resources.VolumeNotFoundErrorMsg))
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(0))
+		})
+
+		It("should fail when quota is not specified", func() {
+			expandVolumeRequest.Opts = map[string]interface{}{}
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*spectrumscale.SpectrumScaleQuotaNotSpecifiedError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(0))
+		})
+
+		It("should fail when quota is not enabled on the filesystem", func() {
+			fakeSpectrumScaleConnector.CheckIfFSQuotaEnabledReturns(fmt.Errorf("quota not enabled"))
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*spectrumscale.SpectrumScaleQuotaNotEnabledError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the new quota is not bigger than the current quota", func() {
+			expandVolumeRequest.Opts = map[string]interface{}{"quota": "1G"}
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*spectrumscale.SpectrumScaleQuotaNotBiggerError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(0))
+		})
+
+		It("should fail when SetFilesetQuota fails", func() {
+			fakeSpectrumScaleConnector.SetFilesetQuotaReturns(fmt.Errorf("error setting quota"))
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("error setting quota"))
+			Expect(fakeSpectrumDataModel.UpdateVolumeQuotaCallCount()).To(Equal(0))
+		})
+
+		It("should succeed and update the quota in the database", func() {
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(1))
+			_, filesystem, fileset, quota := fakeSpectrumScaleConnector.SetFilesetQuotaArgsForCall(0)
+			Expect(filesystem).To(Equal("fake-filesystem"))
+			Expect(fileset).To(Equal("fake-fileset"))
+			Expect(quota).To(Equal("2G"))
+			Expect(fakeSpectrumDataModel.UpdateVolumeQuotaCallCount()).To(Equal(1))
+			name, quota := fakeSpectrumDataModel.UpdateVolumeQuotaArgsForCall(0)
+			Expect(name).To(Equal("fake-volume"))
+			Expect(quota).To(Equal("2G"))
+		})
+
+		It("should succeed to set a first quota on a fileset without quota", func() {
+			volume := spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}, Type: spectrumscale.Fileset, FileSystem: "fake-filesystem", Fileset: "fake-fileset"}
+			fakeSpectrumDataModel.GetVolumeReturns(volume, true, nil)
+			err = client.ExpandVolume(expandVolumeRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.ListFilesetQuotaCallCount()).To(Equal(0))
+			Expect(fakeSpectrumScaleConnector.SetFilesetQuotaCallCount()).To(Equal(1))
+		})
+	})
+
+	Context(".CreateSnapshot", func() {
+		var (
+			createSnapshotRequest resources.CreateSnapshotRequest
+		)
+		BeforeEach(func() {
+			createSnapshotRequest = resources.CreateSnapshotRequest{VolumeName: "fake-volume", Name: "fake-snapshot"}
+			volume := spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}, Type: spectrumscale.Fileset, FileSystem: "fake-filesystem", Fileset: "fake-fileset"}
+			fakeSpectrumDataModel.GetVolumeReturns(volume, true, nil)
+			fakeSpectrumScaleConnector.IsFilesystemMountedReturns(true, nil)
+		})
+
+		It("should fail when the volume does not exist", func() {
+			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{}, false, nil)
+			err = client.CreateSnapshot(createSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("[fake-volume] " + resources.VolumeNotFoundErrorMsg))
+			Expect(fakeSpectrumScaleConnector.CreateSnapshotCallCount()).To(Equal(0))
+		})
 
+		It("should fail when the snapshot already exists", func() {
+			fakeSpectrumDataModel.GetSnapshotReturns(resources.Snapshot{Name: "fake-snapshot"}, true, nil)
+			err = client.CreateSnapshot(createSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*resources.SnapshotAlreadyExistsError)
+			Expect(ok).To(Equal(true))
+			Expect(fakeSpectrumScaleConnector.CreateSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("should fail for the Ubiquity DB volume before creating the fileset snapshot", func() {
+			fakeSpectrumDataModel.IsDbVolumeReturns(true)
+			err = client.CreateSnapshot(createSnapshotRequest)
+			Expect(err).To(BeAssignableToTypeOf(&resources.SnapshotNotSupportedForVolumeError{}))
+			Expect(fakeSpectrumScaleConnector.CreateSnapshotCallCount()).To(Equal(0))
+			Expect(fakeSpectrumDataModel.InsertSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the connector fails to create the snapshot", func() {
+			fakeSpectrumScaleConnector.CreateSnapshotReturns(fmt.Errorf("error creating snapshot"))
+			err = client.CreateSnapshot(createSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("error creating snapshot"))
+			Expect(fakeSpectrumDataModel.InsertSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("should succeed to create the fileset snapshot", func() {
+			err = client.CreateSnapshot(createSnapshotRequest)
+			Expect(err).ToNot(HaveOccurred())
+			_, filesystem, fileset, snapshot := fakeSpectrumScaleConnector.CreateSnapshotArgsForCall(0)
+			Expect(filesystem).To(Equal("fake-filesystem"))
+			Expect(fileset).To(Equal("fake-fileset"))
+			Expect(snapshot).To(Equal("fake-snapshot"))
+			Expect(fakeSpectrumDataModel.InsertSnapshotCallCount()).To(Equal(1))
+		})
+	})
+
+	Context(".DeleteSnapshot", func() {
+		var (
+			deleteSnapshotRequest resources.DeleteSnapshotRequest
+		)
+		BeforeEach(func() {
+			deleteSnapshotRequest = resources.DeleteSnapshotRequest{VolumeName: "fake-volume", Name: "fake-snapshot"}
+			volume := spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}, Type: spectrumscale.Fileset, FileSystem: "fake-filesystem", Fileset: "fake-fileset"}
+			fakeSpectrumDataModel.GetVolumeReturns(volume, true, nil)
+			fakeSpectrumDataModel.GetSnapshotReturns(resources.Snapshot{Name: "fake-snapshot", StorageId: "fake-snapshot"}, true, nil)
+		})
+
+		It("should succeed when the snapshot does not exist (idempotent)", func() {
+			fakeSpectrumDataModel.GetSnapshotReturns(resources.Snapshot{}, false, nil)
+			err = client.DeleteSnapshot(deleteSnapshotRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.DeleteSnapshotCallCount()).To(Equal(0))
+			Expect(fakeSpectrumDataModel.DeleteSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("should fail when the connector fails to delete the snapshot", func() {
+			fakeSpectrumScaleConnector.DeleteSnapshotReturns(fmt.Errorf("error deleting snapshot"))
+			err = client.DeleteSnapshot(deleteSnapshotRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeSpectrumDataModel.DeleteSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("should succeed to delete the fileset snapshot", func() {
+			err = client.DeleteSnapshot(deleteSnapshotRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSpectrumScaleConnector.DeleteSnapshotCallCount()).To(Equal(1))
+			Expect(fakeSpectrumDataModel.DeleteSnapshotCallCount()).To(Equal(1))
+		})
+	})
+
+	Context(".ListSnapshots", func() {
+		It("should return the snapshots of the volume", func() {
+			fakeSpectrumDataModel.GetVolumeReturns(spectrumscale.SpectrumScaleVolume{Volume: resources.Volume{Name: "fake-volume"}}, true, nil)
+			fakeSpectrumDataModel.ListSnapshotsReturns([]resources.Snapshot{{Name: "fake-snapshot"}}, nil)
+			snapshots, err := client.ListSnapshots(resources.ListSnapshotsRequest{VolumeName: "fake-volume"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(snapshots)).To(Equal(1))
+		})
 	})
 
 	Context("GetVolume", func() {
@@ -20,23 +20,28 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	//"path"
 
-	"time"
-
 	"github.com/IBM/ubiquity/database"
 	"github.com/IBM/ubiquity/local"
+	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/IBM/ubiquity/web_server"
 )
 
-const (
-	HeartbeatInterval = 5 //seconds
-)
-
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	config, err := utils.LoadConfig()
 	if err != nil {
 		panic(fmt.Errorf("Failed to load config %s", err.Error()))
@@ -63,63 +68,71 @@ func main() {
 		panic(err.Error())
 	}
 
-	//check if lock exists -- peer ubiquity server(s)
-	heartbeat := utils.NewHeartbeat(ubiquityConfigPath)
+	defer database.InitializeFromConfig(config.Database)()
 
-	logger.Info("Checking for heartbeat....")
-	probeHeartbeatUntilFree(heartbeat)
+	//check if lock exists -- peer ubiquity server(s)
+	heartbeat := newHeartbeat(config.Heartbeat, ubiquityConfigPath)
 
-	err = heartbeat.Create()
+	logger.Info("Checking for heartbeat....", logs.Args{{"mode", config.Heartbeat.Mode}})
+	err = utils.AcquireHeartbeat(heartbeat, config.Heartbeat)
 	if err != nil {
 		panic("failed to initialize heartbeat")
 	}
 	logger.Info("Heartbeat acquired")
-	go keepAlive(heartbeat)
-
-	defer database.Initialize()()
+	if fencedHeartbeat, ok := heartbeat.(utils.FencedHeartbeat); ok {
+		// the DB rejects the writes of this server once a peer takes the lease over
+		database.FenceWrites(database.HeartbeatLeaseName, fencedHeartbeat.FencingToken())
+	}
+	// the background jobs and the server stop once the server is signalled to exit
+	stop := make(chan struct{})
+	go stopOnSignal(stop)
+	go keepAlive(heartbeat, config.Heartbeat, stop)
+
+	// only the server that holds the heartbeat migrates, so a standby peer does not change the schema under the active one.
+	// The DB may not be reachable yet (e.g its volume is provisioned by this server), then the first connection migrates it
+	if err = database.Migrate(); err != nil {
+		if _, ok := err.(*database.MigrationFailedError); ok {
+			panic(fmt.Errorf("Failed to migrate the DB schema %s", err.Error()))
+		}
+		logger.Warning("The DB cannot be reached, its schema will be migrated on the first connection", logs.Args{{"err", err}})
+	}
 
-	clients, err := local.GetLocalClients(logger, config)
+	clients, err := local.GetLocalClients(logger, config, stop)
 	if err != nil {
 		panic(err)
 	}
+	// only the server that holds the heartbeat recovers, so a standby peer does not race the active one
+	local.RecoverOperations(logger, clients)
 
-	server, err := web_server.NewStorageApiServer(clients, config)
+	server, err := web_server.NewStorageApiServer(clients, config, heartbeat)
 	if err != nil {
 		log.Fatal(fmt.Sprintf("Error creating Storage API server [%s]...", err.Error()))
 	}
 
-	log.Fatal(server.Start())
+	if err = server.Start(stop); err != nil {
+		log.Fatal(err)
+	}
+	logger.Info("Storage API server stopped")
 }
 
-func keepAlive(heartbeat utils.Heartbeat) {
-	for {
-		err := heartbeat.Update()
-		if err != nil {
-			panic("Failed updating heartbeat...aborting")
-		}
-		time.Sleep(HeartbeatInterval * time.Second)
-	}
+// stopOnSignal closes stop once the server gets an interrupt or a termination signal
+func stopOnSignal(stop chan struct{}) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	close(stop)
 }
-func probeHeartbeatUntilFree(heartbeat utils.Heartbeat) {
-	exists, err := heartbeat.Exists()
-	if err != nil {
-		panic("failed to initialize heartbeat")
-	}
-	if !exists {
-		fmt.Println("Probing...1.1")
-		return
+
+func newHeartbeat(config resources.HeartbeatConfig, ubiquityConfigPath string) utils.Heartbeat {
+	if config.Mode == resources.HeartbeatModeDatabase {
+		return database.NewLeaseHeartbeat(database.HeartbeatLeaseName, "", config)
 	}
-	for {
-		// check timestamp
-		currentTime := time.Now()
-		lastUpdateTimestamp, err := heartbeat.GetLastUpdateTimestamp()
-		if err != nil {
-			panic("Unable to determine state of heartbeat...aborting")
-		}
+	return utils.NewHeartbeat(ubiquityConfigPath)
+}
 
-		if currentTime.Sub(lastUpdateTimestamp).Seconds() > HeartbeatInterval {
-			break
-		}
-		time.Sleep(HeartbeatInterval * time.Second)
+// keepAlive renews the heartbeat, the server stops once the heartbeat is lost since a standby peer may be serving already
+func keepAlive(heartbeat utils.Heartbeat, config resources.HeartbeatConfig, stop <-chan struct{}) {
+	if err := utils.KeepHeartbeatAlive(heartbeat, config, stop); err != nil {
+		log.Fatal(fmt.Sprintf("Heartbeat lost [%s], aborting...", err.Error()))
 	}
 }
@@ -0,0 +1,76 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+)
+
+const (
+	exportMetadataCommand  = "export-metadata"
+	restoreMetadataCommand = "restore-metadata"
+)
+
+// runCommand runs a maintenance command instead of the server, on the Ubiquity DB set in the environment.
+// export-metadata writes the volume metadata of the DB to the file, restore-metadata restores the metadata of the file into an empty DB.
+func runCommand(args []string) error {
+	if len(args) != 2 || (args[0] != exportMetadataCommand && args[0] != restoreMetadataCommand) {
+		return fmt.Errorf("Usage: ubiquity %s|%s <file>", exportMetadataCommand, restoreMetadataCommand)
+	}
+	command, path := args[0], args[1]
+
+	config, err := utils.LoadConfig()
+	if err != nil {
+		return err
+	}
+	defer utils.InitUbiquityServerLogger()()
+	defer database.InitializeFromConfig(config.Database)()
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	if command == exportMetadataCommand {
+		metadata, err := database.ExportMetadata(dbConnection.GetDb())
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(metadata, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, data, 0600)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	metadata := resources.Metadata{}
+	if err = json.Unmarshal(data, &metadata); err != nil {
+		return err
+	}
+	return database.RestoreMetadata(dbConnection.GetDb(), metadata)
+}
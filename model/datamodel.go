@@ -18,16 +18,27 @@ package model
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
 	"github.com/jinzhu/gorm"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func GetVolume(db *gorm.DB, name string, backend string) (resources.Volume, error) {
 	var volume resources.Volume
 	err := db.Where("name = ? AND backend = ?", name, fmt.Sprintf("%s", backend)).First(&volume).Error
 	return volume, err
 }
+
+// GetVolumeByName returns the volume of any backend by its name, the names are unique across the backends
+func GetVolumeByName(db *gorm.DB, name string) (resources.Volume, error) {
+	var volume resources.Volume
+	err := db.Where("name = ? ", name).First(&volume).Error
+	return volume, err
+}
 func GetBackendForVolume(db *gorm.DB, name string) (string, error) {
 	var volume resources.Volume
 	err := db.Where("name = ? ", name).First(&volume).Error
@@ -48,7 +59,135 @@ func DeleteVolume(db *gorm.DB, volume *resources.Volume) *gorm.DB {
 	return db.Delete(volume)
 }
 
+// InsertCreatingVolume records the volume in the creating status, before its backend creates it
+func InsertCreatingVolume(db *gorm.DB, volume *resources.Volume) error {
+	volume.Status = resources.VolumeStatusCreating
+	return db.Create(volume).Error
+}
+
+// DeleteCreatingVolume deletes the volume record if it is still creating, a volume that its backend inserted is kept
+func DeleteCreatingVolume(db *gorm.DB, volume *resources.Volume) error {
+	return db.Where("status = ?", resources.VolumeStatusCreating).Delete(volume).Error
+}
+
+// ClaimCreatingVolume prepares the volume that a backend inserts to replace its creating record, if there is one.
+// The volume is available once it is inserted.
+func ClaimCreatingVolume(db *gorm.DB, volume *resources.Volume) error {
+	var creatingVolumes []resources.Volume
+	if err := db.Where("name = ? AND backend = ? AND status = ?", volume.Name, volume.Backend, resources.VolumeStatusCreating).Find(&creatingVolumes).Error; err != nil {
+		return err
+	}
+	if len(creatingVolumes) > 0 {
+		volume.Model = creatingVolumes[0].Model
+	}
+	volume.Status = resources.VolumeStatusAvailable
+	return nil
+}
+
+// UpdateVolumeStatus moves the volume to the status, if the status of the volume allows it and was not changed since the volume was read
+func UpdateVolumeStatus(db *gorm.DB, volume *resources.Volume, status string) error {
+	if !resources.IsVolumeStatusTransitionValid(volume.Status, status) {
+		return &resources.InvalidVolumeStatusError{VolName: volume.Name, Status: volume.Status, To: status}
+	}
+	result := db.Model(&resources.Volume{}).Where("id = ? AND status = ?", volume.ID, volume.Status).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &resources.InvalidVolumeStatusError{VolName: volume.Name, Status: volume.Status, To: status}
+	}
+	volume.Status = status
+	return nil
+}
+
 func UpdateVolumeMountpoint(db *gorm.DB, volume *resources.Volume, mountpoint string) error {
 	err := db.Model(volume).Update("mountpoint", mountpoint).Error
 	return err
 }
+
+func UpdateVolumeAttachedHost(db *gorm.DB, volume *resources.Volume, host string) error {
+	err := db.Model(volume).Update("attached_host", host).Error
+	return err
+}
+
+// ListVolumes returns the volumes of the backend that match the filter of the request, sorted and paged as requested
+func ListVolumes(db *gorm.DB, backend string, listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
+	var volumes []resources.Volume
+	query, err := FilterVolumes(db.Model(&resources.Volume{}), backend, listVolumesRequest)
+	if err != nil {
+		return nil, err
+	}
+	// a creating volume is listed once its backend created it
+	err = query.Where("volumes.status <> ?", resources.VolumeStatusCreating).Find(&volumes).Error
+	return volumes, err
+}
+
+// FilterVolumes adds the filter, sort, cursor and limit of the request on the volumes table to the query.
+// The query must select from the volumes table or join it (e.g a backend specific volume table joined with its volume).
+func FilterVolumes(query *gorm.DB, backend string, listVolumesRequest resources.ListVolumesRequest) (*gorm.DB, error) {
+	volumeCursor, err := utils.DecodeVolumeCursor(listVolumesRequest.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// soft deleted volumes are filtered by gorm only when volumes is the main table of the query
+	query = query.Where("volumes.deleted_at IS NULL AND volumes.backend = ?", backend)
+	if listVolumesRequest.NamePrefix != "" {
+		query = query.Where(`volumes.name LIKE ? ESCAPE '\'`, likeEscaper.Replace(listVolumesRequest.NamePrefix)+"%")
+	}
+	for key, value := range listVolumesRequest.Labels {
+		query = query.Where(`volumes.labels LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(utils.EncodeLabel(key, value))+"%")
+	}
+	if listVolumesRequest.Host != "" {
+		query = query.Where("volumes.attached_host = ?", listVolumesRequest.Host)
+	}
+
+	comparator, order := ">", "asc"
+	if listVolumesRequest.SortOrder == resources.ListVolumesSortDesc {
+		comparator, order = "<", "desc"
+	}
+	name := volumeNameColumn(query)
+	if listVolumesRequest.SortBy == resources.ListVolumesSortByCreated {
+		if volumeCursor != nil {
+			query = query.Where(fmt.Sprintf("volumes.created_at %s ? OR (volumes.created_at = ? AND %s %s ?)", comparator, name, comparator),
+				volumeCursor.CreatedAt, volumeCursor.CreatedAt, volumeCursor.Name)
+		}
+		query = query.Order(fmt.Sprintf("volumes.created_at %s, %s %s", order, name, order))
+	} else {
+		if volumeCursor != nil {
+			query = query.Where(fmt.Sprintf("%s %s ?", name, comparator), volumeCursor.Name)
+		}
+		query = query.Order(fmt.Sprintf("%s %s", name, order))
+	}
+
+	if listVolumesRequest.Limit > 0 {
+		query = query.Limit(listVolumesRequest.Limit)
+	}
+	return query, nil
+}
+
+// volumeNameColumn returns the volume name column compared by byte order, as utils.PageVolumes merges the pages of the
+// backends, so a cursor does not skip or repeat volumes under a DB collation (e.g Postgres en_US ignores case and punctuation)
+func volumeNameColumn(query *gorm.DB) string {
+	if query.Dialect().GetName() == "postgres" {
+		return `volumes.name COLLATE "C"`
+	}
+	// the default BINARY collation of sqlite compares the bytes
+	return "volumes.name"
+}
+
+func GetSnapshot(db *gorm.DB, volumeName string, name string, backend string) (resources.Snapshot, error) {
+	var snapshot resources.Snapshot
+	err := db.Where("volume_name = ? AND name = ? AND backend = ?", volumeName, name, backend).First(&snapshot).Error
+	return snapshot, err
+}
+
+func ListSnapshots(db *gorm.DB, volumeName string, backend string) ([]resources.Snapshot, error) {
+	var snapshots []resources.Snapshot
+	err := db.Where("volume_name = ? AND backend = ?", volumeName, backend).Find(&snapshots).Error
+	return snapshots, err
+}
+
+func DeleteSnapshot(db *gorm.DB, snapshot *resources.Snapshot) *gorm.DB {
+	return db.Delete(snapshot)
+}
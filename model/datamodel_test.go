@@ -0,0 +1,186 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/model"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const testComponent = "model-test"
+
+type testBackendVolume struct {
+	ID       uint
+	Volume   resources.Volume
+	VolumeID uint
+	WWN      string
+}
+
+var _ = Describe("Volume status", func() {
+	var (
+		dir          string
+		cleanup      func()
+		dbConnection database.Connection
+		db           *gorm.DB
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ubiquity-model")
+		Expect(err).ToNot(HaveOccurred())
+		database.RegisterMigrations(testComponent, database.Migration{Version: 1, Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&testBackendVolume{}).Error
+		}})
+		cleanup = database.InitSqlite(filepath.Join(dir, "ubiquity.db"))
+		dbConnection = database.NewConnection()
+		Expect(dbConnection.Open()).To(Succeed())
+		db = dbConnection.GetDb()
+	})
+	AfterEach(func() {
+		dbConnection.Close()
+		cleanup()
+		database.UnregisterMigrations(testComponent)
+		os.RemoveAll(dir)
+	})
+
+	Context(".ClaimCreatingVolume", func() {
+		It("should insert the backend volume in place of its creating record", func() {
+			creatingVolume := resources.Volume{Name: "vol1", Backend: "backend1"}
+			Expect(model.InsertCreatingVolume(db, &creatingVolume)).To(Succeed())
+			Expect(creatingVolume.Status).To(Equal(resources.VolumeStatusCreating))
+
+			backendVolume := testBackendVolume{Volume: resources.Volume{Name: "vol1", Backend: "backend1", BackendType: "test"}, WWN: "wwn1"}
+			Expect(model.ClaimCreatingVolume(db, &backendVolume.Volume)).To(Succeed())
+			Expect(db.Create(&backendVolume).Error).ToNot(HaveOccurred())
+
+			var volumes []resources.Volume
+			Expect(db.Find(&volumes).Error).ToNot(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].ID).To(Equal(creatingVolume.ID))
+			Expect(volumes[0].Status).To(Equal(resources.VolumeStatusAvailable))
+			Expect(volumes[0].BackendType).To(Equal("test"))
+			Expect(backendVolume.VolumeID).To(Equal(creatingVolume.ID))
+		})
+
+		It("should insert an available volume if there is no creating record", func() {
+			backendVolume := testBackendVolume{Volume: resources.Volume{Name: "vol1", Backend: "backend1"}}
+			Expect(model.ClaimCreatingVolume(db, &backendVolume.Volume)).To(Succeed())
+			Expect(backendVolume.Volume.ID).To(BeZero())
+			Expect(backendVolume.Volume.Status).To(Equal(resources.VolumeStatusAvailable))
+		})
+	})
+
+	Context(".DeleteCreatingVolume", func() {
+		It("should keep a volume that its backend inserted", func() {
+			volume := resources.Volume{Name: "vol1", Backend: "backend1"}
+			Expect(model.InsertCreatingVolume(db, &volume)).To(Succeed())
+			Expect(db.Model(&volume).Update("status", resources.VolumeStatusAvailable).Error).ToNot(HaveOccurred())
+			volume.Status = resources.VolumeStatusCreating
+			Expect(model.DeleteCreatingVolume(db, &volume)).To(Succeed())
+			exists, err := model.VolumeExists(db, "vol1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+
+		It("should delete a creating volume", func() {
+			volume := resources.Volume{Name: "vol1", Backend: "backend1"}
+			Expect(model.InsertCreatingVolume(db, &volume)).To(Succeed())
+			Expect(model.DeleteCreatingVolume(db, &volume)).To(Succeed())
+			_, err := model.VolumeExists(db, "vol1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".UpdateVolumeStatus", func() {
+		var volume resources.Volume
+		BeforeEach(func() {
+			volume = resources.Volume{Name: "vol1", Backend: "backend1", Status: resources.VolumeStatusAvailable}
+			Expect(db.Create(&volume).Error).ToNot(HaveOccurred())
+		})
+
+		It("should move the volume to a valid status", func() {
+			Expect(model.UpdateVolumeStatus(db, &volume, resources.VolumeStatusAttached)).To(Succeed())
+			Expect(volume.Status).To(Equal(resources.VolumeStatusAttached))
+			stored, err := model.GetVolumeByName(db, "vol1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stored.Status).To(Equal(resources.VolumeStatusAttached))
+		})
+
+		It("should fail if the transition is not valid", func() {
+			err := model.UpdateVolumeStatus(db, &volume, resources.VolumeStatusFailed)
+			Expect(err).To(BeAssignableToTypeOf(&resources.InvalidVolumeStatusError{}))
+			Expect(volume.Status).To(Equal(resources.VolumeStatusAvailable))
+		})
+
+		It("should fail if the status was changed since the volume was read", func() {
+			stale := volume
+			Expect(model.UpdateVolumeStatus(db, &volume, resources.VolumeStatusDeleting)).To(Succeed())
+			err := model.UpdateVolumeStatus(db, &stale, resources.VolumeStatusAttached)
+			Expect(err).To(BeAssignableToTypeOf(&resources.InvalidVolumeStatusError{}))
+		})
+	})
+
+	Context(".ListVolumes", func() {
+		It("should not list a creating volume", func() {
+			Expect(model.InsertCreatingVolume(db, &resources.Volume{Name: "vol1", Backend: "backend1"})).To(Succeed())
+			Expect(db.Create(&resources.Volume{Name: "vol2", Backend: "backend1", Status: resources.VolumeStatusAvailable}).Error).ToNot(HaveOccurred())
+			volumes, err := model.ListVolumes(db, "backend1", resources.ListVolumesRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Name).To(Equal("vol2"))
+		})
+		It("should page mixed case names of several backends in the order of their merge", func() {
+			names := map[string][]string{"backend1": {"b", "A-2", "a", "_c"}, "backend2": {"B", "a_1", "A", "c"}}
+			for backend, backendNames := range names {
+				for _, name := range backendNames {
+					Expect(db.Create(&resources.Volume{Name: name, Backend: backend, Status: resources.VolumeStatusAvailable}).Error).ToNot(HaveOccurred())
+				}
+			}
+
+			// page the way the storage API handler merges the backends
+			var listed []string
+			listVolumesRequest := resources.ListVolumesRequest{Limit: 3}
+			for {
+				backendRequest := listVolumesRequest
+				backendRequest.Limit = listVolumesRequest.Limit + 1
+				var volumes []resources.Volume
+				for backend := range names {
+					backendVolumes, err := model.ListVolumes(db, backend, backendRequest)
+					Expect(err).ToNot(HaveOccurred())
+					volumes = append(volumes, backendVolumes...)
+				}
+				page, nextCursor := utils.PageVolumes(volumes, listVolumesRequest)
+				for _, volume := range page {
+					listed = append(listed, volume.Name)
+				}
+				if nextCursor == "" {
+					break
+				}
+				listVolumesRequest.Cursor = nextCursor
+			}
+			Expect(listed).To(Equal([]string{"A", "A-2", "B", "_c", "a", "a_1", "b", "c"}))
+		})
+	})
+})
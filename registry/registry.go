@@ -0,0 +1,123 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry holds the storage backends known to Ubiquity.
+// Every backend package registers, usually from its init function, the factory of its StorageClient (on the server side)
+// and the factory of its Mounter (on the plugin side), so new backends are added without changing the server or the plugins.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+// StorageClientFactory creates the storage client of a backend instance from the server configuration,
+// name is the name of the instance, recorded as the backend of its volumes
+type StorageClientFactory func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error)
+
+// MounterFactory creates the mounter of a backend on the plugin side
+type MounterFactory func(pluginConfig resources.UbiquityPluginConfig, requestContext resources.RequestContext) (resources.Mounter, error)
+
+// Backend describes how the server creates the storage client of a backend.
+// ConfigSection is the prefix of the environment variables of the backend settings, they are loaded into
+// UbiquityServerConfig.BackendsConfig[Name] (the built-in backends have dedicated config structs and leave it empty).
+// IsConfigured reports whether the backend is set in the configuration, only configured backends are created.
+// ValidateConfig (optional) checks the backend settings before its storage client is created.
+// Instances (optional) returns the configuration of every named instance of the backend by instance name, the storage
+// client of an instance is created (after ValidateConfig) from its configuration, in addition to the default instance named after the backend.
+// VolumeTable (optional) is a pointer to the row of the backend specific volume table (e.g &scbe.ScbeVolume{}), the rows are part of
+// the metadata export. The row must have the ID field and the VolumeID field of its resources.Volume row.
+type Backend struct {
+	Name             string
+	ConfigSection    string
+	IsConfigured     func(config resources.UbiquityServerConfig) bool
+	ValidateConfig   func(config resources.UbiquityServerConfig) error
+	Instances        func(config resources.UbiquityServerConfig) map[string]resources.UbiquityServerConfig
+	NewStorageClient StorageClientFactory
+	VolumeTable      interface{}
+}
+
+var (
+	lock     sync.RWMutex
+	backends = make(map[string]Backend)
+	mounters = make(map[string]MounterFactory)
+)
+
+// RegisterBackend adds the backend to the registry, a backend registered with the same name is replaced
+func RegisterBackend(backend Backend) {
+	if backend.Name == "" || backend.IsConfigured == nil || backend.NewStorageClient == nil {
+		panic(fmt.Sprintf("registry: backend %#v must have a name, IsConfigured and NewStorageClient", backend))
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	backends[backend.Name] = backend
+}
+
+// UnregisterBackend removes the backend from the registry
+func UnregisterBackend(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(backends, name)
+}
+
+// GetBackend returns the registered backend with the given name
+func GetBackend(name string) (Backend, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	backend, ok := backends[name]
+	return backend, ok
+}
+
+// GetBackends returns the registered backends sorted by name
+func GetBackends() []Backend {
+	lock.RLock()
+	defer lock.RUnlock()
+	var list []Backend
+	for _, backend := range backends {
+		list = append(list, backend)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// RegisterMounter adds the mounter factory of the backend to the registry, a factory registered for the same backend is replaced
+func RegisterMounter(backendName string, factory MounterFactory) {
+	if backendName == "" || factory == nil {
+		panic("registry: mounter must have a backend name and a factory")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	mounters[backendName] = factory
+}
+
+// UnregisterMounter removes the mounter factory of the backend from the registry
+func UnregisterMounter(backendName string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(mounters, backendName)
+}
+
+// GetMounterFactory returns the mounter factory registered for the backend
+func GetMounterFactory(backendName string) (MounterFactory, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	factory, ok := mounters[backendName]
+	return factory, ok
+}
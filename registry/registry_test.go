@@ -0,0 +1,107 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry_test
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/registry"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+var _ = Describe("Registry", func() {
+	var backend registry.Backend
+
+	BeforeEach(func() {
+		backend = registry.Backend{
+			Name:         "fake-backend",
+			IsConfigured: func(config resources.UbiquityServerConfig) bool { return true },
+			NewStorageClient: func(logger logs.Logger, name string, config resources.UbiquityServerConfig) (resources.StorageClient, error) {
+				return new(fakes.FakeStorageClient), nil
+			},
+		}
+	})
+
+	AfterEach(func() {
+		registry.UnregisterBackend("fake-backend")
+		registry.UnregisterBackend("another-fake-backend")
+		registry.UnregisterMounter("fake-backend")
+	})
+
+	Context(".RegisterBackend", func() {
+		It("should return the registered backend", func() {
+			registry.RegisterBackend(backend)
+			registered, ok := registry.GetBackend("fake-backend")
+			Expect(ok).To(BeTrue())
+			Expect(registered.Name).To(Equal("fake-backend"))
+		})
+		It("should replace a backend registered with the same name", func() {
+			registry.RegisterBackend(backend)
+			backend.ConfigSection = "FAKE"
+			registry.RegisterBackend(backend)
+			registered, _ := registry.GetBackend("fake-backend")
+			Expect(registered.ConfigSection).To(Equal("FAKE"))
+		})
+		It("should panic if the backend has no storage client factory", func() {
+			backend.NewStorageClient = nil
+			Expect(func() { registry.RegisterBackend(backend) }).To(Panic())
+		})
+		It("should not return an unregistered backend", func() {
+			registry.RegisterBackend(backend)
+			registry.UnregisterBackend("fake-backend")
+			_, ok := registry.GetBackend("fake-backend")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context(".GetBackends", func() {
+		It("should return the backends sorted by name", func() {
+			registry.RegisterBackend(backend)
+			backend.Name = "another-fake-backend"
+			registry.RegisterBackend(backend)
+			var names []string
+			for _, registered := range registry.GetBackends() {
+				names = append(names, registered.Name)
+			}
+			Expect(sort.StringsAreSorted(names)).To(BeTrue())
+			Expect(names).To(ContainElement("another-fake-backend"))
+			Expect(names).To(ContainElement("fake-backend"))
+		})
+	})
+
+	Context(".RegisterMounter", func() {
+		It("should return the registered mounter factory", func() {
+			registry.RegisterMounter("fake-backend", func(resources.UbiquityPluginConfig, resources.RequestContext) (resources.Mounter, error) {
+				return new(fakes.FakeMounter), nil
+			})
+			factory, ok := registry.GetMounterFactory("fake-backend")
+			Expect(ok).To(BeTrue())
+			mounter, err := factory(resources.UbiquityPluginConfig{}, resources.RequestContext{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mounter).ToNot(BeNil())
+		})
+		It("should not return a factory for an unknown backend", func() {
+			_, ok := registry.GetMounterFactory("fake-backend")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -21,6 +21,7 @@
 package remote
 
 import (
+	"fmt"
 	"net/http"
 	"reflect"
 	"time"
@@ -207,6 +208,141 @@ func (s *remoteClient) Detach(detachRequest resources.DetachRequest) error {
 	return nil
 }
 
+func (s *remoteClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	expandRemoteURL := utils.FormatURL(s.storageApiURL, "volumes", expandVolumeRequest.Name, "expand")
+	expandVolumeRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "PUT", expandRemoteURL, expandVolumeRequest, expandVolumeRequest.Context)
+	if err != nil {
+		return s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	return nil
+}
+
+func (s *remoteClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	createSnapshotRemoteURL := utils.FormatURL(s.storageApiURL, "volumes", createSnapshotRequest.VolumeName, "snapshots")
+	createSnapshotRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "POST", createSnapshotRemoteURL, createSnapshotRequest, createSnapshotRequest.Context)
+	if err != nil {
+		return s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	return nil
+}
+
+func (s *remoteClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	listSnapshotsRemoteURL := utils.FormatURL(s.storageApiURL, "volumes", listSnapshotsRequest.VolumeName, "snapshots")
+	listSnapshotsRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "GET", listSnapshotsRemoteURL, listSnapshotsRequest, listSnapshotsRequest.Context)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	listSnapshotsResponse := resources.ListSnapshotsResponse{}
+	err = utils.UnmarshalResponse(response, &listSnapshotsResponse)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "utils.UnmarshalResponse failed", logs.Args{{"response", response}})
+	}
+
+	return listSnapshotsResponse.Snapshots, nil
+}
+
+func (s *remoteClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	deleteSnapshotRemoteURL := utils.FormatURL(s.storageApiURL, "volumes", deleteSnapshotRequest.VolumeName, "snapshots", deleteSnapshotRequest.Name)
+	deleteSnapshotRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "DELETE", deleteSnapshotRemoteURL, deleteSnapshotRequest, deleteSnapshotRequest.Context)
+	if err != nil {
+		return s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	return nil
+}
+
+func (s *remoteClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	listServicesRemoteURL := utils.FormatURL(s.storageApiURL, "backends", listServicesRequest.Backend, "services")
+	listServicesRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "GET", listServicesRemoteURL, listServicesRequest, listServicesRequest.Context)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	listServicesResponse := resources.ListServicesResponse{}
+	err = utils.UnmarshalResponse(response, &listServicesResponse)
+	if err != nil {
+		return nil, s.logger.ErrorRet(err, "utils.UnmarshalResponse failed", logs.Args{{"response", response}})
+	}
+
+	return listServicesResponse.Services, nil
+}
+
+func (s *remoteClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	defer s.logger.Trace(logs.DEBUG)()
+
+	reconcileRemoteURL := utils.FormatURL(s.storageApiURL, "backends", reconcileRequest.Backend, "orphans")
+	reconcileRequest.CredentialInfo = s.config.CredentialInfo
+	response, err := utils.HttpExecute(s.httpClient, "GET", reconcileRemoteURL, reconcileRequest, reconcileRequest.Context)
+	if err != nil {
+		return resources.ReconcileReport{}, s.logger.ErrorRet(err, "utils.HttpExecute failed")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return resources.ReconcileReport{}, s.logger.ErrorRet(utils.ExtractErrorResponse(response), "failed", logs.Args{{"response", response}})
+	}
+
+	reconcileResponse := resources.ReconcileResponse{}
+	err = utils.UnmarshalResponse(response, &reconcileResponse)
+	if err != nil {
+		return resources.ReconcileReport{}, s.logger.ErrorRet(err, "utils.UnmarshalResponse failed", logs.Args{{"response", response}})
+	}
+	if len(reconcileResponse.Reports) != 1 {
+		return resources.ReconcileReport{}, s.logger.ErrorRet(fmt.Errorf("expected a single report, got %d", len(reconcileResponse.Reports)), "failed")
+	}
+
+	return reconcileResponse.Reports[0], nil
+}
+
 func (s *remoteClient) ListVolumes(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
 	defer s.logger.Trace(logs.DEBUG)()
 
@@ -228,6 +364,9 @@ func (s *remoteClient) ListVolumes(listVolumesRequest resources.ListVolumesReque
 	if err != nil {
 		return nil, s.logger.ErrorRet(err, "utils.UnmarshalResponse failed", logs.Args{{"response", response}})
 	}
+	if len(listResponse.BackendErrors) > 0 {
+		s.logger.Warning("Listed the volumes of some of the backends only", logs.Args{{"backendErrors", listResponse.BackendErrors}})
+	}
 
 	return listResponse.Volumes, nil
 
@@ -3,6 +3,7 @@ package mounter
 import (
 	"log"
 
+	"github.com/IBM/ubiquity/registry"
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils/logs"
 )
@@ -26,11 +27,9 @@ func (m *mounterFactory) GetMounterPerBackend(backend string, legacyLogger *log.
 	m.logger = logs.GetLogger()
 	defer m.logger.Trace(logs.DEBUG)()
 
-	if backend == resources.SpectrumScale {
-		return NewSpectrumScaleMounter(), nil
-	} else if backend == resources.SCBE {
-		return NewScbeMounter(), nil
-	} else {
+	newMounter, ok := registry.GetMounterFactory(backend)
+	if !ok {
 		return nil, &NoMounterForVolumeError{backend}
 	}
+	return newMounter(pluginConfig, requestContext)
 }
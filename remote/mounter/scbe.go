@@ -22,11 +22,18 @@ import (
 
 	"github.com/IBM/ubiquity/remote/mounter/block_device_mounter_utils"
 	"github.com/IBM/ubiquity/remote/mounter/block_device_utils"
+	"github.com/IBM/ubiquity/registry"
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
 )
 
+func init() {
+	registry.RegisterMounter(resources.SCBE, func(resources.UbiquityPluginConfig, resources.RequestContext) (resources.Mounter, error) {
+		return NewScbeMounter(), nil
+	})
+}
+
 type scbeMounter struct {
 	logger                  logs.Logger
 	blockDeviceMounterUtils block_device_mounter_utils.BlockDeviceMounterUtils
@@ -18,10 +18,17 @@ package mounter
 
 import (
 	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/registry"
 	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
 )
 
+func init() {
+	registry.RegisterMounter(resources.SpectrumScale, func(resources.UbiquityPluginConfig, resources.RequestContext) (resources.Mounter, error) {
+		return NewSpectrumScaleMounter(), nil
+	})
+}
+
 type spectrumScaleMounter struct {
 	logger   logs.Logger
 	executor utils.Executor
@@ -0,0 +1,251 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resources
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Error codes of the storage API error responses, the codes are stable and can be used by the clients to branch on the error kind
+const (
+	ErrorCodeInternal                         = "InternalError"
+	ErrorCodeInvalidRequest                   = "InvalidRequest"
+	ErrorCodeBackendNotFound                  = "BackendNotFound"
+	ErrorCodeVolumeNotFound                   = "VolumeNotFound"
+	ErrorCodeVolumeAlreadyExists              = "VolumeAlreadyExists"
+	ErrorCodeVolumeHasSnapshots               = "VolumeHasSnapshots"
+	ErrorCodeVolumeAttached                   = "VolumeAttached"
+	ErrorCodeVolumeAlreadyAttached            = "VolumeAlreadyAttached"
+	ErrorCodeSnapshotNotFound                 = "SnapshotNotFound"
+	ErrorCodeSnapshotAlreadyExists            = "SnapshotAlreadyExists"
+	ErrorCodeSnapshotNotSupported             = "SnapshotNotSupported"
+	ErrorCodeCloneNotSupported                = "CloneNotSupported"
+	ErrorCodeCloneAcrossBackends              = "CloneAcrossBackends"
+	ErrorCodeCloneSourceSnapshotWithoutVolume = "CloneSourceSnapshotWithoutVolume"
+	ErrorCodeInvalidLabels                    = "InvalidLabels"
+	ErrorCodeFsTypeNotSupported               = "FsTypeNotSupported"
+	ErrorCodeStorageBadHttpStatus             = "StorageBadHttpStatus"
+	ErrorCodeLockTimeout                      = "LockTimeout"
+	ErrorCodeMetadataConflict                 = "MetadataConflict"
+	ErrorCodeInvalidVolumeStatus              = "InvalidVolumeStatus"
+	ErrorCodeDeadlineExceeded                 = "DeadlineExceeded"
+	ErrorCodeBackendUnavailable               = "BackendUnavailable"
+)
+
+// CodedError is implemented by the errors that are returned by the storage API with a stable code, an HTTP status and details
+type CodedError interface {
+	error
+	ErrorCode() string
+	HttpStatus() int
+	ErrorDetails() map[string]string
+}
+
+// StorageError is a coded error that has no dedicated type on the client side (e.g a backend specific error)
+type StorageError struct {
+	Code    string
+	Message string
+	Status  int
+	Details map[string]string
+}
+
+func (e *StorageError) Error() string {
+	return e.Message
+}
+
+func (e *StorageError) ErrorCode() string               { return e.Code }
+func (e *StorageError) HttpStatus() int                 { return e.Status }
+func (e *StorageError) ErrorDetails() map[string]string { return e.Details }
+
+// invalidRequestError error for all the interfaces if the request cannot be parsed
+type InvalidRequestError struct {
+	Reason string
+}
+
+func (e *InvalidRequestError) Error() string {
+	return fmt.Sprintf("Invalid request: %s", e.Reason)
+}
+
+func (e *InvalidRequestError) ErrorCode() string               { return ErrorCodeInvalidRequest }
+func (e *InvalidRequestError) HttpStatus() int                 { return http.StatusBadRequest }
+func (e *InvalidRequestError) ErrorDetails() map[string]string { return nil }
+
+// backendNotFoundError error for all the interfaces if the requested backend (or the backend of the volume) is not configured
+type BackendNotFoundError struct {
+	Backend string
+}
+
+func (e *BackendNotFoundError) Error() string {
+	return fmt.Sprintf("Backend [%s] not found.", e.Backend)
+}
+
+func (e *BackendNotFoundError) ErrorCode() string { return ErrorCodeBackendNotFound }
+func (e *BackendNotFoundError) HttpStatus() int   { return http.StatusNotFound }
+func (e *BackendNotFoundError) ErrorDetails() map[string]string {
+	return map[string]string{"backend": e.Backend}
+}
+
+func (e *BackendUnavailableError) ErrorCode() string { return ErrorCodeBackendUnavailable }
+func (e *BackendUnavailableError) HttpStatus() int   { return http.StatusServiceUnavailable }
+func (e *BackendUnavailableError) ErrorDetails() map[string]string {
+	return map[string]string{"backend": e.Backend, "reason": e.Reason}
+}
+
+func (e *VolumeNotFoundError) ErrorCode() string { return ErrorCodeVolumeNotFound }
+func (e *VolumeNotFoundError) HttpStatus() int   { return http.StatusNotFound }
+func (e *VolumeNotFoundError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName}
+}
+
+func (e *VolAlreadyExistsError) ErrorCode() string { return ErrorCodeVolumeAlreadyExists }
+func (e *VolAlreadyExistsError) HttpStatus() int   { return http.StatusConflict }
+func (e *VolAlreadyExistsError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName}
+}
+
+func (e *SnapshotNotFoundError) ErrorCode() string { return ErrorCodeSnapshotNotFound }
+func (e *SnapshotNotFoundError) HttpStatus() int   { return http.StatusNotFound }
+func (e *SnapshotNotFoundError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "snapshot": e.SnapName}
+}
+
+func (e *SnapshotAlreadyExistsError) ErrorCode() string { return ErrorCodeSnapshotAlreadyExists }
+func (e *SnapshotAlreadyExistsError) HttpStatus() int   { return http.StatusConflict }
+func (e *SnapshotAlreadyExistsError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "snapshot": e.SnapName}
+}
+
+func (e *VolumeHasSnapshotsError) ErrorCode() string { return ErrorCodeVolumeHasSnapshots }
+func (e *VolumeHasSnapshotsError) HttpStatus() int   { return http.StatusConflict }
+func (e *VolumeHasSnapshotsError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "snapshots": strconv.Itoa(e.Snapshots)}
+}
+
+func (e *InvalidVolumeStatusError) ErrorCode() string { return ErrorCodeInvalidVolumeStatus }
+func (e *InvalidVolumeStatusError) HttpStatus() int   { return http.StatusConflict }
+func (e *InvalidVolumeStatusError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "status": e.Status, "to": e.To}
+}
+
+func (e *DeadlineExceededError) ErrorCode() string { return ErrorCodeDeadlineExceeded }
+func (e *DeadlineExceededError) HttpStatus() int   { return http.StatusGatewayTimeout }
+func (e *DeadlineExceededError) ErrorDetails() map[string]string {
+	return map[string]string{"backend": e.Backend, "operation": e.Operation, "deadline": e.Deadline.String()}
+}
+
+func (e *SnapshotNotSupportedForVolumeError) ErrorCode() string { return ErrorCodeSnapshotNotSupported }
+func (e *SnapshotNotSupportedForVolumeError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *SnapshotNotSupportedForVolumeError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName}
+}
+
+func (e *CloneSourceSnapshotWithoutVolumeError) ErrorCode() string {
+	return ErrorCodeCloneSourceSnapshotWithoutVolume
+}
+func (e *CloneSourceSnapshotWithoutVolumeError) HttpStatus() int { return http.StatusBadRequest }
+func (e *CloneSourceSnapshotWithoutVolumeError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "snapshot": e.SnapName}
+}
+
+func (e *CloneAcrossBackendsError) ErrorCode() string { return ErrorCodeCloneAcrossBackends }
+func (e *CloneAcrossBackendsError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *CloneAcrossBackendsError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName, "backend": e.Backend, "source-volume": e.SourceVolName, "source-backend": e.SourceBackend}
+}
+
+func (e *CloneNotSupportedForVolumeError) ErrorCode() string { return ErrorCodeCloneNotSupported }
+func (e *CloneNotSupportedForVolumeError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *CloneNotSupportedForVolumeError) ErrorDetails() map[string]string {
+	return map[string]string{"volume": e.VolName}
+}
+
+func (e *InvalidLabelsError) ErrorCode() string               { return ErrorCodeInvalidLabels }
+func (e *InvalidLabelsError) HttpStatus() int                 { return http.StatusBadRequest }
+func (e *InvalidLabelsError) ErrorDetails() map[string]string { return nil }
+
+func (e *InvalidListVolumesRequestError) ErrorCode() string { return ErrorCodeInvalidRequest }
+func (e *InvalidListVolumesRequestError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *InvalidListVolumesRequestError) ErrorDetails() map[string]string {
+	return map[string]string{"param": e.Param}
+}
+
+func (e *MetadataVersionNotSupportedError) ErrorCode() string { return ErrorCodeInvalidRequest }
+func (e *MetadataVersionNotSupportedError) HttpStatus() int   { return http.StatusBadRequest }
+func (e *MetadataVersionNotSupportedError) ErrorDetails() map[string]string {
+	return map[string]string{"version": strconv.Itoa(e.Version)}
+}
+
+func (e *MetadataConflictError) ErrorCode() string               { return ErrorCodeMetadataConflict }
+func (e *MetadataConflictError) HttpStatus() int                 { return http.StatusConflict }
+func (e *MetadataConflictError) ErrorDetails() map[string]string { return nil }
+
+// NewErrorResponse returns the HTTP status and the response of the error, errors that are not coded are internal errors
+func NewErrorResponse(err error) (int, GenericResponse) {
+	codedError, ok := err.(CodedError)
+	if !ok {
+		return http.StatusInternalServerError, GenericResponse{Err: err.Error(), Code: ErrorCodeInternal}
+	}
+	return codedError.HttpStatus(), GenericResponse{Err: err.Error(), Code: codedError.ErrorCode(), Details: codedError.ErrorDetails()}
+}
+
+// NewBackendError returns the entry of the error of the backend in the response of a request that queries several backends
+func NewBackendError(backend string, err error) BackendError {
+	_, response := NewErrorResponse(err)
+	return BackendError{Backend: backend, Code: response.Code, Err: response.Err}
+}
+
+// NewErrorFromResponse rebuilds the error of an error response, as its dedicated type if there is one and as a StorageError otherwise.
+// A response without code (of a server that does not send codes) is rebuilt as a plain error.
+func NewErrorFromResponse(status int, response GenericResponse) error {
+	details := response.Details
+	switch response.Code {
+	case "":
+		return fmt.Errorf("%s", response.Err)
+	case ErrorCodeBackendNotFound:
+		return &BackendNotFoundError{Backend: details["backend"]}
+	case ErrorCodeBackendUnavailable:
+		return &BackendUnavailableError{Backend: details["backend"], Reason: details["reason"]}
+	case ErrorCodeVolumeNotFound:
+		return &VolumeNotFoundError{VolName: details["volume"]}
+	case ErrorCodeVolumeAlreadyExists:
+		return &VolAlreadyExistsError{VolName: details["volume"]}
+	case ErrorCodeSnapshotNotFound:
+		return &SnapshotNotFoundError{VolName: details["volume"], SnapName: details["snapshot"]}
+	case ErrorCodeSnapshotAlreadyExists:
+		return &SnapshotAlreadyExistsError{VolName: details["volume"], SnapName: details["snapshot"]}
+	case ErrorCodeVolumeHasSnapshots:
+		if snapshots, err := strconv.Atoi(details["snapshots"]); err == nil {
+			return &VolumeHasSnapshotsError{VolName: details["volume"], Snapshots: snapshots}
+		}
+	case ErrorCodeInvalidVolumeStatus:
+		return &InvalidVolumeStatusError{VolName: details["volume"], Status: details["status"], To: details["to"]}
+	case ErrorCodeDeadlineExceeded:
+		deadline, _ := time.ParseDuration(details["deadline"])
+		return &DeadlineExceededError{Backend: details["backend"], Operation: details["operation"], Deadline: deadline}
+	case ErrorCodeSnapshotNotSupported:
+		return &SnapshotNotSupportedForVolumeError{VolName: details["volume"]}
+	case ErrorCodeCloneSourceSnapshotWithoutVolume:
+		return &CloneSourceSnapshotWithoutVolumeError{VolName: details["volume"], SnapName: details["snapshot"]}
+	case ErrorCodeCloneAcrossBackends:
+		return &CloneAcrossBackendsError{VolName: details["volume"], Backend: details["backend"], SourceVolName: details["source-volume"], SourceBackend: details["source-backend"]}
+	case ErrorCodeCloneNotSupported:
+		return &CloneNotSupportedForVolumeError{VolName: details["volume"]}
+	}
+	return &StorageError{Code: response.Code, Message: response.Err, Status: status, Details: details}
+}
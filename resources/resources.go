@@ -17,7 +17,10 @@
 package resources
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
@@ -39,6 +42,137 @@ type UbiquityServerConfig struct {
 	BrokerConfig        BrokerConfig
 	DefaultBackend      string
 	LogLevel            string
+	BackendsConfig      map[string]map[string]string // the settings of the backends without a dedicated config struct, by backend name
+
+	// The named instances of the backends (e.g separate storage for prod and dev), by instance name.
+	// They are served in addition to the default instance of every backend, which is named after the backend type.
+	ScbeInstances          map[string]ScbeConfig
+	SpectrumScaleInstances map[string]SpectrumScaleConfig
+
+	Database  DatabaseConfig
+	Heartbeat HeartbeatConfig
+	Locker    LockerConfig
+
+	// ReconcileInterval is the interval of the job that reports the orphans of the backends, the job is disabled if zero
+	ReconcileInterval time.Duration
+
+	// Deadlines bounds the backend operations of the requests
+	Deadlines DeadlinesConfig
+
+	// BackendInit retries the backends that fail to initialize on startup
+	BackendInit BackendInitConfig
+
+	// HealthMonitor probes the backends periodically, the monitor is disabled if its interval is zero
+	HealthMonitor HealthMonitorConfig
+
+	// FanOut configures the requests that query all the backends (ListVolumes and Activate)
+	FanOut FanOutConfig
+}
+
+const (
+	HeartbeatModeFile     = "file"     // the heartbeat is the mtime of a lock file in the shared config directory
+	HeartbeatModeDatabase = "database" // the heartbeat is a lease in the Ubiquity DB, with a fencing token (the DB must be reachable on startup)
+)
+
+// HeartbeatConfig configures the election of the active Ubiquity server among its standby peers.
+// The active server renews its heartbeat every Interval, a peer takes over a heartbeat that was not renewed for LeaseDuration.
+type HeartbeatConfig struct {
+	Mode          string
+	Interval      time.Duration
+	LeaseDuration time.Duration
+}
+
+const (
+	LockerModeMemory   = "memory"   // the locks are held in the memory of the server, they only serialize the requests of a single server
+	LockerModeDatabase = "database" // the locks are advisory locks in the Ubiquity DB, they serialize the requests of all the active servers
+)
+
+// LockerConfig configures the locks that serialize the requests on the same volume or host.
+// A lock that is not acquired within Timeout fails the request instead of blocking it forever.
+type LockerConfig struct {
+	Mode    string
+	Timeout time.Duration
+}
+
+// BackendInitConfig configures the retries of the backends that fail to initialize on startup, the server starts
+// without them and retries them in the background, the interval doubles after every failed retry up to MaxRetryInterval.
+type BackendInitConfig struct {
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+}
+
+const (
+	DefaultBackendInitRetryInterval    = 5   // in seconds
+	DefaultBackendInitMaxRetryInterval = 300 // in seconds
+)
+
+// HealthMonitorConfig configures the periodic health probes of the backends. A backend whose last FailureThreshold probes
+// failed is down and its circuit is open: its requests fail fast until a probe succeeds. A probe is bounded by Timeout.
+type HealthMonitorConfig struct {
+	Interval         time.Duration
+	Timeout          time.Duration
+	FailureThreshold int
+}
+
+const (
+	DefaultHealthMonitorInterval         = 30 // in seconds
+	DefaultHealthMonitorTimeout          = 10 // in seconds
+	DefaultHealthMonitorFailureThreshold = 3
+)
+
+// FanOutConfig configures the requests that query the backends concurrently. With AllowPartialResults, a request that
+// fails on some of the backends succeeds with the results of the others and reports the failed backends in BackendErrors.
+// The backends that did not answer within Timeout are reported as failed, a zero Timeout waits for all the backends.
+type FanOutConfig struct {
+	AllowPartialResults bool
+	Timeout             time.Duration
+}
+
+const DefaultFanOutTimeout = 25 // in seconds
+
+// DefaultOperationDeadline bounds the backend operations of a request without a configured deadline,
+// and the backend operations that no request bounds (e.g the startup of a backend), in seconds
+const DefaultOperationDeadline = 600
+
+// DeadlinesConfig bounds the time a backend may spend on the operation of a request, so a hung storage system
+// does not pin the request (and the volume lock it holds) forever. The operations are named after the StorageClient methods.
+type DeadlinesConfig struct {
+	Default    time.Duration
+	Operations map[string]time.Duration // the deadlines of the operations that do not use the default deadline
+}
+
+// Deadline returns the deadline of the operation, zero means the operation is not bounded
+func (c DeadlinesConfig) Deadline(operation string) time.Duration {
+	if deadline, ok := c.Operations[operation]; ok {
+		return deadline
+	}
+	return c.Default
+}
+
+const (
+	DatabaseTypePostgres = "postgres" // the Ubiquity DB is a Postgres server, set by the UBIQUITY_DB_* environment (e.g UBIQUITY_DB_PSQL_HOST)
+	DatabaseTypeSqlite   = "sqlite"   // the Ubiquity DB is a SQLite file of the server, for a single server without a Postgres deployment
+)
+
+const DefaultSqlitePath = "/var/lib/ubiquity/ubiquity.db"
+
+// DatabaseConfig configures the Ubiquity DB.
+// A SQLite DB serves a single server, so it cannot back the database heartbeat or the database locker.
+type DatabaseConfig struct {
+	Type       string
+	SqlitePath string
+	Pool       DatabasePoolConfig
+}
+
+// DatabasePoolConfig configures the connection pool that is shared by all the requests of the server.
+// The zero value keeps the database/sql defaults, checks the pool on every use and does not retry.
+type DatabasePoolConfig struct {
+	MaxOpenConns        int           // 0 is unlimited, the locks of the database locker hold connections of their own, outside of this limit
+	MaxIdleConns        int           // 0 is the database/sql default
+	ConnMaxLifetime     time.Duration // 0 is unlimited
+	HealthCheckInterval time.Duration // the pool is pinged when it was not checked for this long
+	OpenRetries         int           // the retries of a failed connect or ping before the request fails
+	RetryInterval       time.Duration // the first retry wait, doubled for every retry
 }
 
 // TODO we should consider to move dedicated backend structs to the backend resource file instead of this one.
@@ -76,6 +210,9 @@ const DefaultForScbeConfigParamDefaultVolumeSize = "1"    // if customer don't m
 const DefaultForScbeConfigParamDefaultFilesystem = "ext4" // if customer don't mention fstype, then the default is ext4
 const PathToMountUbiquityBlockDevices = "/ubiquity/%s"    // %s is the WWN of the volume # TODO this should be moved to docker plugin side
 const OptionNameForVolumeFsType = "fstype"                // the option name of the fstype and also the key in the volumeConfig
+const OptionNameForSourceVolume = "source-volume"         // the option name of the volume to clone the new volume from
+const OptionNameForSourceSnapshot = "source-snapshot"     // the option name of the snapshot (of the source-volume) to clone the new volume from
+const OptionNameForLabels = "labels"                      // the option name of the volume labels (a map or a "key=value,key=value" string)
 const ScbeKeyVolAttachToHost = "attach-to"                // the key in map for volume to host attachments
 const ScbeKeyVolAttachLunNumToHost = "LunNumber"          // the key in map for volume lun number to host attachments
 const ScbeDefaultPort = 8440                              // the default port for SCBE management
@@ -88,6 +225,7 @@ const DefaultScbeSslMode = SslModeVerifyFull
 const DefaultPluginsSslMode = SslModeVerifyFull
 const SpectrumscaleDefaultPort = 443 // the default port for SPECTRUM SCALE management
 const SpectrumScaleParamPrefix = "SPECTRUMSCALE_"
+const BackendInstancesParamSuffix = "INSTANCES" // e.g SCBE_INSTANCES lists the names of the named SCBE instances
 const KeySpectrumScaleSslMode = SpectrumScaleParamPrefix + "SSL_MODE"
 const DefaultSpectrumScaleSslMode = SslModeVerifyFull
 
@@ -154,6 +292,12 @@ type StorageClient interface {
 	GetVolumeConfig(getVolumeConfigRequest GetVolumeConfigRequest) (map[string]interface{}, error)
 	Attach(attachRequest AttachRequest) (string, error)
 	Detach(detachRequest DetachRequest) error
+	ExpandVolume(expandVolumeRequest ExpandVolumeRequest) error
+	CreateSnapshot(createSnapshotRequest CreateSnapshotRequest) error
+	ListSnapshots(listSnapshotsRequest ListSnapshotsRequest) ([]Snapshot, error)
+	DeleteSnapshot(deleteSnapshotRequest DeleteSnapshotRequest) error
+	ListServices(listServicesRequest ListServicesRequest) ([]StorageService, error)
+	Reconcile(reconcileRequest ReconcileRequest) (ReconcileReport, error)
 }
 
 // volumeNotFoundError error for Attach, Detach, GetVolume, GetVolumeConfig, RemoveVolume interfaces if volume not found in Ubiquity DB
@@ -176,6 +320,231 @@ func (e *VolAlreadyExistsError) Error() string {
 	return fmt.Sprintf("Volume [%s] already exists.", e.VolName)
 }
 
+// snapshotNotFoundError error for DeleteSnapshot interface if the snapshot not found in Ubiquity DB
+const SnapshotNotFoundErrorMsg = "snapshot was not found in Ubiqutiy database."
+
+type SnapshotNotFoundError struct {
+	VolName  string
+	SnapName string
+}
+
+func (e *SnapshotNotFoundError) Error() string {
+	return fmt.Sprintf("[%s@%s] "+SnapshotNotFoundErrorMsg, e.VolName, e.SnapName)
+}
+
+// snapshotAlreadyExistsError error for CreateSnapshot interface if the snapshot is already exist in the Ubiquity DB
+type SnapshotAlreadyExistsError struct {
+	VolName  string
+	SnapName string
+}
+
+func (e *SnapshotAlreadyExistsError) Error() string {
+	return fmt.Sprintf("Snapshot [%s] of volume [%s] already exists.", e.SnapName, e.VolName)
+}
+
+// volumeHasSnapshotsError error for RemoveVolume interface if the volume still has snapshots
+type VolumeHasSnapshotsError struct {
+	VolName   string
+	Snapshots int
+}
+
+func (e *VolumeHasSnapshotsError) Error() string {
+	return fmt.Sprintf("Volume [%s] has [%d] snapshots. Delete the snapshots before removing the volume.", e.VolName, e.Snapshots)
+}
+
+// deadlineExceededError error for all the interfaces if the backend did not complete the operation within its deadline
+type DeadlineExceededError struct {
+	Backend   string
+	Operation string
+	Deadline  time.Duration
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("Backend [%s] did not complete [%s] within the deadline [%s].", e.Backend, e.Operation, e.Deadline)
+}
+
+// invalidVolumeStatusError error for the volume interfaces if the volume status does not allow the operation (e.g attaching a volume that is being removed)
+type InvalidVolumeStatusError struct {
+	VolName string
+	Status  string
+	To      string
+}
+
+func (e *InvalidVolumeStatusError) Error() string {
+	return fmt.Sprintf("Volume [%s] in status [%s] cannot move to status [%s].", e.VolName, e.Status, e.To)
+}
+
+// snapshotNotSupportedForVolumeError error for snapshot interfaces if the volume cannot have snapshots (e.g the Ubiquity DB volume)
+type SnapshotNotSupportedForVolumeError struct {
+	VolName string
+}
+
+func (e *SnapshotNotSupportedForVolumeError) Error() string {
+	return fmt.Sprintf("Snapshots are not supported for volume [%s].", e.VolName)
+}
+
+// cloneSourceSnapshotWithoutVolumeError error for Create interface if source-snapshot is given without its source-volume
+type CloneSourceSnapshotWithoutVolumeError struct {
+	VolName  string
+	SnapName string
+}
+
+func (e *CloneSourceSnapshotWithoutVolumeError) Error() string {
+	return fmt.Sprintf("Cannot create volume [%s] from snapshot [%s], the option [%s] is missing.", e.VolName, e.SnapName, OptionNameForSourceVolume)
+}
+
+// cloneAcrossBackendsError error for Create interface if the source volume belongs to another backend
+type CloneAcrossBackendsError struct {
+	VolName       string
+	Backend       string
+	SourceVolName string
+	SourceBackend string
+}
+
+func (e *CloneAcrossBackendsError) Error() string {
+	return fmt.Sprintf("Cannot create volume [%s] on backend [%s] from volume [%s] of backend [%s].", e.VolName, e.Backend, e.SourceVolName, e.SourceBackend)
+}
+
+// cloneNotSupportedForVolumeError error for Create interface if the volume cannot be cloned or be a clone (e.g the Ubiquity DB volume)
+type CloneNotSupportedForVolumeError struct {
+	VolName string
+}
+
+func (e *CloneNotSupportedForVolumeError) Error() string {
+	return fmt.Sprintf("Clones are not supported for volume [%s].", e.VolName)
+}
+
+// invalidLabelsError error for Create interface if the labels option cannot be parsed
+type InvalidLabelsError struct {
+	Labels interface{}
+	Reason string
+}
+
+func (e *InvalidLabelsError) Error() string {
+	return fmt.Sprintf("Invalid volume labels [%v]: %s.", e.Labels, e.Reason)
+}
+
+// invalidListVolumesRequestError error for List interface if the filter, sort or pagination parameters are invalid
+type InvalidListVolumesRequestError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidListVolumesRequestError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for list volumes parameter [%s].", e.Value, e.Param)
+}
+
+// metadataVersionNotSupportedError error for the metadata restore if the document has an unknown version
+type MetadataVersionNotSupportedError struct {
+	Version int
+}
+
+func (e *MetadataVersionNotSupportedError) Error() string {
+	return fmt.Sprintf("Metadata version [%d] is not supported, the supported versions are [1-%d].", e.Version, MetadataVersion)
+}
+
+// metadataConflictError error for the metadata restore if the document is inconsistent or the database is not empty
+type MetadataConflictError struct {
+	Reason string
+}
+
+func (e *MetadataConflictError) Error() string {
+	return fmt.Sprintf("Cannot restore the metadata: %s.", e.Reason)
+}
+
+// invalidHeartbeatConfigError error for the config if the heartbeat mode or durations are invalid
+type InvalidHeartbeatConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidHeartbeatConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for heartbeat parameter [%s].", e.Value, e.Param)
+}
+
+// invalidLockerConfigError error for the config if the locker mode or timeout are invalid
+type InvalidLockerConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidLockerConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for locker parameter [%s].", e.Value, e.Param)
+}
+
+// invalidDeadlineConfigError error for the config if an operation deadline is invalid
+type InvalidDeadlineConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidDeadlineConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for deadline parameter [%s].", e.Value, e.Param)
+}
+
+// invalidDatabaseConfigError error for the config if the DB type or pool limits are invalid, or the DB does not support the heartbeat or locker mode
+type InvalidDatabaseConfigError struct {
+	Param  string
+	Value  interface{}
+	Reason string
+}
+
+func (e *InvalidDatabaseConfigError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("Invalid value [%v] for database parameter [%s]: %s.", e.Value, e.Param, e.Reason)
+	}
+	return fmt.Sprintf("Invalid value [%v] for database parameter [%s].", e.Value, e.Param)
+}
+
+// invalidReconcileConfigError error for the config if the reconcile interval is invalid
+type InvalidReconcileConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidReconcileConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for reconcile parameter [%s].", e.Value, e.Param)
+}
+
+// invalidBackendInitConfigError error for the config if a backend initialization retry interval is invalid
+type InvalidBackendInitConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidBackendInitConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for backend initialization parameter [%s].", e.Value, e.Param)
+}
+
+// invalidHealthMonitorConfigError error for the config if a health monitor parameter is invalid
+type InvalidHealthMonitorConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidHealthMonitorConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for health monitor parameter [%s].", e.Value, e.Param)
+}
+
+// invalidFanOutConfigError error for the config if a fan-out parameter is invalid
+type InvalidFanOutConfigError struct {
+	Param string
+	Value interface{}
+}
+
+func (e *InvalidFanOutConfigError) Error() string {
+	return fmt.Sprintf("Invalid value [%v] for fan-out parameter [%s].", e.Value, e.Param)
+}
+
+// duplicateBackendInstanceError error for the config if a backend instance name is already used by another backend or instance
+type DuplicateBackendInstanceError struct {
+	Name string
+}
+
+func (e *DuplicateBackendInstanceError) Error() string {
+	return fmt.Sprintf("Backend instance name [%s] is already used by another backend.", e.Name)
+}
+
 type BackendInitializationError struct {
 	BackendName string
 	Err         error
@@ -185,6 +554,16 @@ func (e *BackendInitializationError) Error() string {
 	return fmt.Sprintf("Error while initializing %s client:[%s]", e.BackendName, e.Err.Error())
 }
 
+// backendUnavailableError error for all the interfaces if the backend failed to initialize and its retries did not succeed yet
+type BackendUnavailableError struct {
+	Backend string
+	Reason  string
+}
+
+func (e *BackendUnavailableError) Error() string {
+	return fmt.Sprintf("Backend [%s] is unavailable: %s", e.Backend, e.Reason)
+}
+
 const ClientInitializationErrorStr = "Check backend configuration - SpectrumScale ManagementIP or SpectrumConnect managmentIP is mandatory in the ubiqutiy-configmap."
 
 //go:generate counterfeiter -o ../fakes/fake_mounter.go . Mounter
@@ -220,11 +599,28 @@ type RemoveVolumeRequest struct {
 	Context        RequestContext
 }
 
+const (
+	ListVolumesSortByName    = "name"
+	ListVolumesSortByCreated = "created"
+	ListVolumesSortAsc       = "asc"
+	ListVolumesSortDesc      = "desc"
+)
+
+// ListVolumesRequest lists the volumes of the given backends (all backends if empty).
+// NamePrefix, Labels (all must match) and Host (the host the volume is attached to) filter the volumes.
+// The volumes are sorted by SortBy (name or created, default name) in SortOrder (asc or desc, default asc).
+// When Limit is set at most Limit volumes are returned, and ListResponse.NextCursor is the Cursor of the next page.
 type ListVolumesRequest struct {
 	CredentialInfo CredentialInfo
-	//TODO add filter
-	Backends []string
-	Context  RequestContext
+	Backends       []string
+	NamePrefix     string
+	Labels         map[string]string
+	Host           string
+	SortBy         string
+	SortOrder      string
+	Limit          int
+	Cursor         string
+	Context        RequestContext
 }
 
 type AttachRequest struct {
@@ -240,6 +636,29 @@ type DetachRequest struct {
 	Host           string
 	Context        RequestContext
 }
+type ExpandVolumeRequest struct {
+	CredentialInfo CredentialInfo
+	Name           string
+	Opts           map[string]interface{} // the new size, using the same option names as CreateVolumeRequest (e.g size, quota)
+	Context        RequestContext
+}
+type CreateSnapshotRequest struct {
+	CredentialInfo CredentialInfo
+	VolumeName     string
+	Name           string
+	Context        RequestContext
+}
+type ListSnapshotsRequest struct {
+	CredentialInfo CredentialInfo
+	VolumeName     string
+	Context        RequestContext
+}
+type DeleteSnapshotRequest struct {
+	CredentialInfo CredentialInfo
+	VolumeName     string
+	Name           string
+	Context        RequestContext
+}
 type GetVolumeRequest struct {
 	CredentialInfo CredentialInfo
 	Name           string
@@ -251,12 +670,49 @@ type GetVolumeConfigRequest struct {
 	Context        RequestContext
 }
 type ActivateResponse struct {
-	Implements []string
-	Err        string
+	Implements    []string
+	Err           string
+	BackendErrors []BackendError `json:",omitempty"`
 }
 
+// BackendError is the error of one backend in the response of a request that queries several backends,
+// Code is the code of the error (see CodedError)
+type BackendError struct {
+	Backend string
+	Code    string
+	Err     string
+}
+
+// GenericResponse is the response of the storage API calls that return no data, and the response of the failed calls.
+// Code and Details describe the error for the clients to branch on (see CodedError).
 type GenericResponse struct {
-	Err string
+	Err     string
+	Code    string            `json:",omitempty"`
+	Details map[string]string `json:",omitempty"`
+}
+
+// HealthChecker is implemented by the backends that can check the connectivity to their storage system,
+// the check stops once ctx is done
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// OperationRecoverer is implemented by the backends that journal their operations, to resume or roll back
+// the operations that a restart interrupted
+type OperationRecoverer interface {
+	RecoverOperations() error
+}
+
+// HealthCheck is the result of one readiness check (the database, a backend or the heartbeat)
+type HealthCheck struct {
+	Name    string
+	Healthy bool
+	Err     string
+}
+
+type ReadinessResponse struct {
+	Ready  bool
+	Checks []HealthCheck
 }
 
 type MountRequest struct {
@@ -307,11 +763,193 @@ type DockerGetResponse struct {
 	Err    string
 }
 
+// Volume is the common record of a volume in the Ubiquity DB.
+// SourceVolume and SourceSnapshot record the origin of a volume that was created as a clone, they are empty otherwise.
+// Labels holds the volume labels encoded as ",key=value," (sorted by key) so they can be filtered in the DB, AttachedHost is the host the volume is attached to (if tracked by the backend).
+// Status is the lifecycle status of the volume, one of the VolumeStatus* constants.
 type Volume struct {
+	gorm.Model
+	Name           string
+	Backend        string // the name of the backend instance of the volume
+	BackendType    string // the type of the backend instance (e.g scbe), the plugins choose the mounter of the volume by it
+	Mountpoint     string
+	SourceVolume   string
+	SourceSnapshot string
+	Labels         string
+	AttachedHost   string
+	Status         string
+}
+
+const (
+	VolumeStatusCreating  = "creating"  // the volume is recorded before it is created by its backend, a volume that stays creating was interrupted
+	VolumeStatusAvailable = "available" // the volume is created and not attached
+	VolumeStatusAttached  = "attached"  // the volume is attached to a host
+	VolumeStatusDeleting  = "deleting"  // the volume is being removed by its backend
+	VolumeStatusFailed    = "failed"    // the removal of the volume failed on the storage, the volume can only be removed again
+)
+
+// volumeStatusTransitions are the statuses that a volume can move to from each status, a volume can always stay in its status
+var volumeStatusTransitions = map[string][]string{
+	VolumeStatusCreating:  {VolumeStatusAvailable, VolumeStatusDeleting},
+	VolumeStatusAvailable: {VolumeStatusAttached, VolumeStatusDeleting},
+	VolumeStatusAttached:  {VolumeStatusAvailable},
+	VolumeStatusDeleting:  {VolumeStatusAvailable, VolumeStatusFailed},
+	VolumeStatusFailed:    {VolumeStatusDeleting},
+}
+
+// IsVolumeStatusTransitionValid returns true if a volume in the status from can move to the status to
+func IsVolumeStatusTransitionValid(from string, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, status := range volumeStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot is a point-in-time copy of a volume.
+// StorageId is the identifier of the copy on the storage system (e.g the fileset snapshot name or the snapshot WWN)
+type Snapshot struct {
 	gorm.Model
 	Name       string
+	VolumeName string
 	Backend    string
-	Mountpoint string
+	StorageId  string
+}
+
+type ListSnapshotsResponse struct {
+	Snapshots []Snapshot
+	Err       string
+}
+
+// ListServicesRequest lists the storage services of the backend with their capacity
+type ListServicesRequest struct {
+	CredentialInfo CredentialInfo
+	Backend        string
+	Context        RequestContext
+}
+
+// StorageService is a storage service that volumes are provisioned on (an SCBE service or a Spectrum Scale filesystem).
+// The capacities are in bytes, ProvisionedCapacity is the capacity promised to volumes (e.g the fileset quotas) if the backend reports it.
+type StorageService struct {
+	Name                string
+	Backend             string
+	TotalCapacity       int64
+	UsedCapacity        int64
+	FreeCapacity        int64
+	ProvisionedCapacity int64 `json:",omitempty"`
+}
+
+// BackendCapacity is the capacity of a backend, the sum of the capacities of its storage services
+type BackendCapacity struct {
+	Name                string
+	Services            int
+	TotalCapacity       int64
+	UsedCapacity        int64
+	FreeCapacity        int64
+	ProvisionedCapacity int64 `json:",omitempty"`
+}
+
+const (
+	BackendStateUnknown = "unknown" // the backend was not probed yet
+	BackendStateUp      = "up"
+	BackendStateDown    = "down"
+)
+
+// BackendHealth is the state of a backend as seen by the health monitor, LastProbe is zero until the first probe
+type BackendHealth struct {
+	Name                string
+	State               string
+	CircuitOpen         bool
+	ConsecutiveFailures int
+	LastProbe           time.Time
+	Err                 string `json:",omitempty"`
+}
+
+type BackendsHealthResponse struct {
+	Backends []BackendHealth
+	Err      string
+}
+
+type ListServicesResponse struct {
+	Services []StorageService
+	Err      string
+}
+
+// ListBackendsResponse is the capacity of the backends, BackendErrors are the backends that failed to list their
+// services (e.g a backend that is down or not initialized yet) when the server allows partial results, they are not in Backends
+type ListBackendsResponse struct {
+	Backends      []BackendCapacity
+	Err           string
+	BackendErrors []BackendError `json:",omitempty"`
+}
+
+// LockInfo is a lock that is held by a request, Waiters is the number of requests that wait for the same lock
+type LockInfo struct {
+	Namespace   string
+	Name        string
+	Mode        string
+	RequestId   string
+	HeldSince   time.Time
+	HeldSeconds float64
+	Waiters     int
+}
+
+type ListLocksResponse struct {
+	Locks []LockInfo
+	Err   string
+}
+
+// ReconcileRequest compares the volumes of the backend in the Ubiquity DB with the storage of the backend
+type ReconcileRequest struct {
+	CredentialInfo CredentialInfo
+	Backend        string
+	Context        RequestContext
+}
+
+// Orphan is a volume in the Ubiquity DB or a storage object of a backend that has no counterpart on the other side,
+// StorageId identifies the storage object (e.g the WWN of an SCBE volume or the filesystem/fileset of a Spectrum Scale volume)
+type Orphan struct {
+	Name      string
+	StorageId string
+}
+
+// ReconcileReport lists the volumes in the Ubiquity DB whose storage is gone (DanglingVolumes),
+// and the storage objects of the backend that have no volume in the Ubiquity DB (UnmanagedStorage).
+// Err is the reason the backend could not be reconciled, if it failed.
+type ReconcileReport struct {
+	Backend          string
+	DanglingVolumes  []Orphan
+	UnmanagedStorage []Orphan
+	Err              string `json:",omitempty"`
+}
+
+type ReconcileResponse struct {
+	Reports []ReconcileReport
+	Err     string
+}
+
+// MetadataVersion is the version of the metadata documents that are exported, documents up to this version can be restored
+const MetadataVersion = 1
+
+// Metadata is the export of the volume metadata kept in the Ubiquity DB, it can be restored into an empty DB.
+// BackendVolumes holds the rows of the backend specific volume tables by backend type, each row references its Volumes row by VolumeID.
+// The DB volume is not part of the metadata, its backend keeps it in memory and finds it on the storage system when activated.
+type Metadata struct {
+	Version        int
+	ExportedAt     time.Time
+	Volumes        []Volume
+	BackendVolumes map[string]json.RawMessage
+	Snapshots      []Snapshot
+}
+
+type RestoreMetadataResponse struct {
+	Volumes   int
+	Snapshots int
+	Err       string
 }
 
 type GetConfigResponse struct {
@@ -319,9 +957,13 @@ type GetConfigResponse struct {
 	Err          string
 }
 
+// ListResponse is the page of the volumes of the backends, BackendErrors are the backends that failed (the page does not
+// have their volumes) when the server allows partial results. A page of partial results has no NextCursor.
 type ListResponse struct {
-	Volumes []Volume
-	Err     string
+	Volumes       []Volume
+	NextCursor    string
+	Err           string
+	BackendErrors []BackendError `json:",omitempty"`
 }
 
 type FlexVolumeResponse struct {
@@ -354,6 +996,18 @@ type FlexVolumeDetachRequest struct {
 type RequestContext struct {
 	Id         string
 	ActionName string
+
+	// Ctx bounds the request on the server (the client disconnected or the deadline of the operation passed),
+	// it is not sent by the clients
+	Ctx context.Context `json:"-"`
+}
+
+// GetCtx returns the context that bounds the request, or the background context if the request is not bounded
+func (c RequestContext) GetCtx() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
 }
 
 type VolumeMountProperties struct {
@@ -18,6 +18,10 @@ package utils
 
 import (
 	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
 )
 
 type NoENVKeyError struct {
@@ -45,3 +49,43 @@ type CommandNotFoundError struct {
 func (e *CommandNotFoundError) Error() string {
 	return fmt.Sprintf("command [%v] is not found [%v]", e.Cmd, e.Err)
 }
+
+type HeartbeatHeldError struct {
+	Holder string
+}
+
+func (e *HeartbeatHeldError) Error() string {
+	return fmt.Sprintf("heartbeat is held by another server [%v]", e.Holder)
+}
+
+type HeartbeatLostError struct {
+	Holder string
+	Token  int64
+}
+
+func (e *HeartbeatLostError) Error() string {
+	return fmt.Sprintf("heartbeat of [%v] with fencing token [%v] was taken over by another server", e.Holder, e.Token)
+}
+
+type StaleFencingTokenError struct {
+	Lease string
+	Token int64
+}
+
+func (e *StaleFencingTokenError) Error() string {
+	return fmt.Sprintf("write with fencing token [%v] was rejected, lease [%v] was taken over by another server", e.Token, e.Lease)
+}
+
+type LockTimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *LockTimeoutError) Error() string {
+	return fmt.Sprintf("lock [%v] was not acquired within [%v]", e.Name, e.Timeout)
+}
+
+// the lock is held by another request on the same resource, which the caller may retry later
+func (e *LockTimeoutError) ErrorCode() string               { return resources.ErrorCodeLockTimeout }
+func (e *LockTimeoutError) HttpStatus() int                 { return http.StatusConflict }
+func (e *LockTimeoutError) ErrorDetails() map[string]string { return map[string]string{"lock": e.Name} }
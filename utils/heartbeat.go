@@ -22,10 +22,21 @@ import (
 	"path"
 	"time"
 
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
 	"github.com/djherbis/times"
 )
 
+const (
+	HeartbeatInterval       = 5 //seconds
+	HeartbeatLeaseIntervals = 3 // the default lease duration, in heartbeat intervals
+)
+
 //go:generate counterfeiter -o ../fakes/fake_heartbeat.go . Heartbeat
+
+// Heartbeat elects the active server among its standby peers.
+// Create takes the heartbeat (it may fail with HeartbeatHeldError if a peer holds it), and Update renews it
+// (it may fail with HeartbeatLostError if a peer took it over, then the server must stop serving).
 type Heartbeat interface {
 	Exists() (bool, error)
 	Create() error
@@ -33,6 +44,13 @@ type Heartbeat interface {
 	GetLastUpdateTimestamp() (time.Time, error)
 }
 
+// FencedHeartbeat is a heartbeat that hands out a fencing token, the token increases every time the heartbeat changes hands
+// so a stale active server can be told apart from the current one (see database.FenceWrites, which rejects its writes).
+type FencedHeartbeat interface {
+	Heartbeat
+	FencingToken() int64
+}
+
 type heartbeat struct {
 	filePath string
 }
@@ -83,3 +101,73 @@ func (l *heartbeat) GetLastUpdateTimestamp() (time.Time, error) {
 	}
 	return fi.ChangeTime(), nil
 }
+
+// AcquireHeartbeat blocks until the heartbeat is free (missing, or not renewed for the lease duration) and takes it.
+// A heartbeat that cannot be probed or taken (e.g the DB of a database heartbeat is not reachable yet) is retried
+// with an exponential backoff, up to the lease duration between retries.
+func AcquireHeartbeat(heartbeat Heartbeat, config resources.HeartbeatConfig) error {
+	logger := logs.GetLogger()
+	retryInterval := config.Interval
+	for {
+		free, err := isHeartbeatFree(heartbeat, config.LeaseDuration)
+		if err == nil && free {
+			err = heartbeat.Create()
+			if err == nil {
+				return nil
+			}
+			if _, held := err.(*HeartbeatHeldError); held {
+				logger.Info("heartbeat was taken by another server, waiting", logs.Args{{"err", err}})
+				err = nil
+			}
+		}
+		if err == nil {
+			retryInterval = config.Interval
+			time.Sleep(config.Interval)
+			continue
+		}
+		logger.Warning("failed to acquire heartbeat, retrying", logs.Args{{"err", err}, {"wait", retryInterval}})
+		time.Sleep(retryInterval)
+		if retryInterval *= 2; retryInterval > config.LeaseDuration {
+			retryInterval = config.LeaseDuration
+		}
+	}
+}
+
+func isHeartbeatFree(heartbeat Heartbeat, leaseDuration time.Duration) (bool, error) {
+	exists, err := heartbeat.Exists()
+	if err != nil || !exists {
+		return !exists, err
+	}
+	lastUpdateTimestamp, err := heartbeat.GetLastUpdateTimestamp()
+	if err != nil {
+		return false, err
+	}
+	return time.Since(lastUpdateTimestamp) > leaseDuration, nil
+}
+
+// KeepHeartbeatAlive renews the heartbeat every interval until stop is closed.
+// A failed renewal is retried, it returns an error once the heartbeat is lost to another server or could not be renewed
+// for the lease duration, since a peer may take it over from then on.
+func KeepHeartbeatAlive(heartbeat Heartbeat, config resources.HeartbeatConfig, stop <-chan struct{}) error {
+	logger := logs.GetLogger()
+	lastRenewal := time.Now()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(config.Interval):
+		}
+		err := heartbeat.Update()
+		if err == nil {
+			lastRenewal = time.Now()
+			continue
+		}
+		if _, lost := err.(*HeartbeatLostError); lost {
+			return logger.ErrorRet(err, "heartbeat lost")
+		}
+		if time.Since(lastRenewal) >= config.LeaseDuration {
+			return logger.ErrorRet(err, "failed to renew heartbeat within the lease duration", logs.Args{{"leaseDuration", config.LeaseDuration}})
+		}
+		logger.Warning("failed to renew heartbeat, retrying", logs.Args{{"err", err}})
+	}
+}
@@ -0,0 +1,115 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+)
+
+var _ = Describe("Heartbeat", func() {
+	var (
+		fakeHeartbeat *fakes.FakeHeartbeat
+		config        resources.HeartbeatConfig
+	)
+
+	BeforeEach(func() {
+		fakeHeartbeat = new(fakes.FakeHeartbeat)
+		config = resources.HeartbeatConfig{Interval: time.Millisecond, LeaseDuration: 10 * time.Millisecond}
+	})
+
+	Context(".AcquireHeartbeat", func() {
+		It("should create the heartbeat if it does not exist", func() {
+			fakeHeartbeat.ExistsReturns(false, nil)
+			err := utils.AcquireHeartbeat(fakeHeartbeat, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeHeartbeat.CreateCallCount()).To(Equal(1))
+		})
+
+		It("should wait until the heartbeat is stale before creating it", func() {
+			fakeHeartbeat.ExistsReturns(true, nil)
+			// renewed in the future, so the heartbeat is fresh however slow the test runs
+			fakeHeartbeat.GetLastUpdateTimestampReturnsOnCall(0, time.Now().Add(time.Hour), nil)
+			fakeHeartbeat.GetLastUpdateTimestampReturnsOnCall(1, time.Now().Add(time.Hour), nil)
+			fakeHeartbeat.GetLastUpdateTimestampReturns(time.Now().Add(-time.Second), nil)
+			err := utils.AcquireHeartbeat(fakeHeartbeat, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeHeartbeat.GetLastUpdateTimestampCallCount()).To(Equal(3))
+			Expect(fakeHeartbeat.CreateCallCount()).To(Equal(1))
+		})
+
+		It("should retry if another server took the heartbeat first", func() {
+			fakeHeartbeat.ExistsReturns(false, nil)
+			fakeHeartbeat.CreateReturnsOnCall(0, &utils.HeartbeatHeldError{Holder: "peer"})
+			fakeHeartbeat.CreateReturnsOnCall(1, nil)
+			err := utils.AcquireHeartbeat(fakeHeartbeat, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeHeartbeat.CreateCallCount()).To(Equal(2))
+		})
+
+		It("should retry until the heartbeat can be probed", func() {
+			fakeHeartbeat.ExistsReturnsOnCall(0, false, errors.New("fake error"))
+			fakeHeartbeat.ExistsReturnsOnCall(1, false, errors.New("fake error"))
+			fakeHeartbeat.ExistsReturns(false, nil)
+			err := utils.AcquireHeartbeat(fakeHeartbeat, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeHeartbeat.ExistsCallCount()).To(Equal(3))
+			Expect(fakeHeartbeat.CreateCallCount()).To(Equal(1))
+		})
+
+		It("should retry until the heartbeat can be created", func() {
+			fakeHeartbeat.ExistsReturns(false, nil)
+			fakeHeartbeat.CreateReturnsOnCall(0, errors.New("fake error"))
+			fakeHeartbeat.CreateReturnsOnCall(1, nil)
+			err := utils.AcquireHeartbeat(fakeHeartbeat, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeHeartbeat.CreateCallCount()).To(Equal(2))
+		})
+	})
+
+	Context(".KeepHeartbeatAlive", func() {
+		It("should renew the heartbeat until stopped", func() {
+			stop := make(chan struct{})
+			done := make(chan error)
+			go func() { done <- utils.KeepHeartbeatAlive(fakeHeartbeat, config, stop) }()
+			Eventually(fakeHeartbeat.UpdateCallCount).Should(BeNumerically(">", 2))
+			close(stop)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("should fail once the heartbeat is taken over", func() {
+			fakeHeartbeat.UpdateReturns(&utils.HeartbeatLostError{Holder: "me", Token: 1})
+			err := utils.KeepHeartbeatAlive(fakeHeartbeat, config, nil)
+			Expect(err).To(BeAssignableToTypeOf(&utils.HeartbeatLostError{}))
+			Expect(fakeHeartbeat.UpdateCallCount()).To(Equal(1))
+		})
+
+		It("should retry a failed renewal until the lease duration passed", func() {
+			fakeHeartbeat.UpdateReturns(errors.New("fake error"))
+			err := utils.KeepHeartbeatAlive(fakeHeartbeat, config, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeHeartbeat.UpdateCallCount()).To(BeNumerically(">", 1))
+		})
+	})
+})
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -36,7 +37,7 @@ func ExtractErrorResponse(response *http.Response) error {
 	if err != nil {
 		return logs.GetLogger().ErrorRet(err, "json.Unmarshal failed")
 	}
-	return fmt.Errorf(errorResponse.Err)
+	return resources.NewErrorFromResponse(response.StatusCode, errorResponse)
 }
 
 func UnmarshalResponse(r *http.Response, object interface{}) error {
@@ -65,7 +66,7 @@ func FormatURL(url string, entries ...string) string {
 	return fmt.Sprintf("%s%s", base, suffix)
 }
 
-func HttpExecuteUserAuth(httpClient *http.Client, requestType string, requestURL string, user string, password string, rawPayload interface{}) (*http.Response, error) {
+func HttpExecuteUserAuth(ctx context.Context, httpClient *http.Client, requestType string, requestURL string, user string, password string, rawPayload interface{}) (*http.Response, error) {
 	logger := logs.GetLogger()
 	payload, err := json.MarshalIndent(rawPayload, "", " ")
 	if err != nil {
@@ -82,6 +83,7 @@ func HttpExecuteUserAuth(httpClient *http.Client, requestType string, requestURL
 		err = fmt.Errorf("Error in creating request %#v", err)
 		return nil, logger.ErrorRet(err, "failed")
 	}
+	request = request.WithContext(ctx)
 
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("Accept", "application/json")
@@ -121,6 +123,12 @@ func WriteResponse(w http.ResponseWriter, code int, object interface{}) {
 	fmt.Fprintf(w, string(data))
 }
 
+// WriteErrorResponse writes the error with the HTTP status and the code of its type (see resources.CodedError)
+func WriteErrorResponse(w http.ResponseWriter, err error) {
+	code, errorResponse := resources.NewErrorResponse(err)
+	WriteResponse(w, code, &errorResponse)
+}
+
 func Unmarshal(r *http.Request, object interface{}) error {
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -0,0 +1,76 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("utils - http error responses", func() {
+	roundTrip := func(err error) (int, error) {
+		recorder := httptest.NewRecorder()
+		utils.WriteErrorResponse(recorder, err)
+		response := recorder.Result()
+		return response.StatusCode, utils.ExtractErrorResponse(response)
+	}
+
+	It("should rebuild a typed error with its status", func() {
+		status, err := roundTrip(&resources.VolumeNotFoundError{VolName: "vol1"})
+		Expect(status).To(Equal(http.StatusNotFound))
+		volumeNotFoundError, ok := err.(*resources.VolumeNotFoundError)
+		Expect(ok).To(BeTrue())
+		Expect(volumeNotFoundError.VolName).To(Equal("vol1"))
+	})
+	It("should rebuild an error with snapshots count", func() {
+		status, err := roundTrip(&resources.VolumeHasSnapshotsError{VolName: "vol1", Snapshots: 2})
+		Expect(status).To(Equal(http.StatusConflict))
+		Expect(err).To(Equal(&resources.VolumeHasSnapshotsError{VolName: "vol1", Snapshots: 2}))
+	})
+	It("should rebuild an error without a dedicated type as a StorageError", func() {
+		original := &resources.InvalidLabelsError{Labels: "app", Reason: "bad format"}
+		status, err := roundTrip(original)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		storageError, ok := err.(*resources.StorageError)
+		Expect(ok).To(BeTrue())
+		Expect(storageError.ErrorCode()).To(Equal(resources.ErrorCodeInvalidLabels))
+		Expect(storageError.HttpStatus()).To(Equal(http.StatusBadRequest))
+		Expect(storageError.Error()).To(Equal(original.Error()))
+	})
+	It("should return an error that is not coded as an internal error", func() {
+		status, err := roundTrip(fmt.Errorf("some failure"))
+		Expect(status).To(Equal(http.StatusInternalServerError))
+		storageError, ok := err.(*resources.StorageError)
+		Expect(ok).To(BeTrue())
+		Expect(storageError.ErrorCode()).To(Equal(resources.ErrorCodeInternal))
+		Expect(storageError.Error()).To(Equal("some failure"))
+	})
+	It("should return a plain error if the response has no code", func() {
+		recorder := httptest.NewRecorder()
+		utils.WriteResponse(recorder, http.StatusConflict, &resources.GenericResponse{Err: "some failure"})
+		err := utils.ExtractErrorResponse(recorder.Result())
+		Expect(err).To(MatchError("some failure"))
+		_, ok := err.(resources.CodedError)
+		Expect(ok).To(BeFalse())
+	})
+})
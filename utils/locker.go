@@ -17,119 +17,232 @@
 package utils
 
 import (
-	"fmt"
-	"github.com/IBM/ubiquity/utils/logs"
+	"context"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
 )
 
 //go:generate counterfeiter -o ../fakes/fake_locker.go . Locker
 type Locker interface {
-	WriteLock(name string)
+	// WriteLock and ReadLock fail with LockTimeoutError if the lock is not acquired within the lock timeout
+	WriteLock(name string) error
 	WriteUnlock(name string)
-	ReadLock(name string)
+	ReadLock(name string) error
 	ReadUnlock(name string)
+
+	// TryWriteLock and TryReadLock fail with LockTimeoutError if the lock is not acquired within the lock timeout
+	// or before the context is done (e.g the request is cancelled by its client)
+	TryWriteLock(ctx context.Context, name string) error
+	TryReadLock(ctx context.Context, name string) error
+
+	// HeldLocks returns the locks that are held through the locker, the oldest first
+	HeldLocks() []resources.LockInfo
 }
 
 func NewLocker() Locker {
-	return &locker{locks: make(map[string]*sync.RWMutex), accessLock: &sync.Mutex{}, statsLock: &sync.Mutex{}, cleanupLock: &sync.Mutex{}, stats: make(map[string]time.Time), logger: logs.GetLogger()}
+	return NewLockerWithTimeout(DefaultLockTimeout * time.Second)
+}
+
+// NewLockerWithTimeout returns an in-memory locker that gives up waiting for a lock after the timeout (the default timeout if not positive)
+func NewLockerWithTimeout(timeout time.Duration) Locker {
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout * time.Second
+	}
+	return &locker{locks: make(map[string]*lockEntry), accessLock: &sync.Mutex{}, logger: logs.GetLogger(), timeout: timeout}
 }
 
 const (
-	STALE_LOCK_TIMEOUT = 600 //in seconds
+	DefaultLockTimeout = 300 //in seconds
 )
 
 type locker struct {
-	accessLock  *sync.Mutex
-	locks       map[string]*sync.RWMutex
-	statsLock   *sync.Mutex
-	stats       map[string]time.Time
-	cleanupLock *sync.Mutex
-	logger      logs.Logger
+	accessLock *sync.Mutex
+	locks      map[string]*lockEntry
+	logger     logs.Logger
+	timeout    time.Duration
 }
 
-func (l *locker) WriteLock(name string) {
-	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+// lockEntry is a named lock, it is removed once no goroutine holds it or waits for it
+type lockEntry struct {
+	lock    *sync.RWMutex
+	refs    int // the goroutines that hold or wait for the lock
+	holders []LockHolder
+}
 
-	defer l.updateStats(name)
-	l.accessLock.Lock()
-	if lock, exists := l.locks[name]; exists {
-		l.accessLock.Unlock()
-		lock.Lock()
-		return
-	}
+// LockHolder is a request that holds a lock in the given mode since the given time
+type LockHolder struct {
+	Mode      string
+	RequestId string
+	Since     time.Time
+}
 
-	lock := &sync.RWMutex{}
-	lock.Lock()
-	l.locks[name] = lock
-	l.accessLock.Unlock()
+func (l *locker) WriteLock(name string) error {
+	return l.TryWriteLock(context.Background(), name)
 }
+
 func (l *locker) WriteUnlock(name string) {
 	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
-	defer l.updateStats(name)
+
+	l.unlock(name, metrics.LockModeWrite)
+}
+
+func (l *locker) ReadLock(name string) error {
+	return l.TryReadLock(context.Background(), name)
+}
+
+func (l *locker) ReadUnlock(name string) {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	l.unlock(name, metrics.LockModeRead)
+}
+
+func (l *locker) TryWriteLock(ctx context.Context, name string) error {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return l.lock(ctx, name, metrics.LockModeWrite)
+}
+
+func (l *locker) TryReadLock(ctx context.Context, name string) error {
+	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return l.lock(ctx, name, metrics.LockModeRead)
+}
+
+func (l *locker) HeldLocks() []resources.LockInfo {
+	defer l.logger.Trace(logs.DEBUG)()
+
 	l.accessLock.Lock()
 	defer l.accessLock.Unlock()
-	if lock, exists := l.locks[name]; exists {
-		lock.Unlock()
-		return
+	locks := make([]resources.LockInfo, 0)
+	for name, entry := range l.locks {
+		for _, holder := range entry.holders {
+			locks = append(locks, NewLockInfo(name, holder, entry.refs-len(entry.holders)))
+		}
 	}
+	SortLockInfos(locks)
+	return locks
+}
 
+// lock waits for the lock until the context is done, a lock that is acquired only after that is released right away
+func (l *locker) lock(ctx context.Context, name string, mode string) error {
+	start := time.Now()
+	defer metrics.ObserveLockWait(mode, start)
+	requestId := logs.GetRequestId()
+
+	entry := l.ref(name)
+	lock, unlock := entry.lock.Lock, entry.lock.Unlock
+	if mode == metrics.LockModeRead {
+		lock, unlock = entry.lock.RLock, entry.lock.RUnlock
+	}
+	acquired := make(chan struct{})
+	go func() {
+		lock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		l.accessLock.Lock()
+		entry.holders = append(entry.holders, LockHolder{Mode: mode, RequestId: requestId, Since: time.Now()})
+		l.accessLock.Unlock()
+		return nil
+	case <-ctx.Done():
+		go func() {
+			<-acquired
+			unlock()
+			l.unref(name, entry)
+		}()
+		metrics.ObserveLockTimeout(mode)
+		return l.logger.ErrorRet(&LockTimeoutError{Name: name, Timeout: time.Since(start)}, "failed")
+	}
 }
-func (l *locker) ReadLock(name string) {
-	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
-	defer l.updateStats(name)
+
+// unlock releases the lock held in the mode by the current request (or else by the oldest request)
+func (l *locker) unlock(name string, mode string) {
 	l.accessLock.Lock()
-	if lock, exists := l.locks[name]; exists {
+	entry, exists := l.locks[name]
+	if !exists || !entry.removeHolder(mode, logs.GetRequestId()) {
 		l.accessLock.Unlock()
-		lock.RLock()
+		l.logger.Warning("lock is not held", logs.Args{{"lockName", name}, {"mode", mode}})
 		return
 	}
-
-	lock := &sync.RWMutex{}
-	lock.RLock()
-	l.locks[name] = lock
 	l.accessLock.Unlock()
+
+	if mode == metrics.LockModeRead {
+		entry.lock.RUnlock()
+	} else {
+		entry.lock.Unlock()
+	}
+	l.unref(name, entry)
 }
-func (l *locker) ReadUnlock(name string) {
-	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
-	defer l.updateStats(name)
+
+// ref returns the lock entry of the name, and counts the caller as a goroutine that holds or waits for it
+func (l *locker) ref(name string) *lockEntry {
 	l.accessLock.Lock()
 	defer l.accessLock.Unlock()
-	if lock, exists := l.locks[name]; exists {
-		lock.RUnlock()
-		return
+	entry, exists := l.locks[name]
+	if !exists {
+		entry = &lockEntry{lock: &sync.RWMutex{}}
+		l.locks[name] = entry
 	}
+	entry.refs++
+	return entry
 }
-func (l *locker) updateStats(name string) {
-	defer l.logger.Trace(logs.DEBUG, logs.Args{{"lockName", name}})()
 
-	l.statsLock.Lock()
-	defer l.statsLock.Unlock()
-	defer l.cleanup()
-	if stat, exists := l.stats[name]; exists {
-		stat = time.Now()
-		l.stats[name] = stat
-		return
+// unref removes the lock entry of the name once the last goroutine that held or waited for it is done
+func (l *locker) unref(name string, entry *lockEntry) {
+	l.accessLock.Lock()
+	defer l.accessLock.Unlock()
+	entry.refs--
+	if entry.refs == 0 {
+		delete(l.locks, name)
 	}
-	stat := time.Now()
-	l.stats[name] = stat
 }
-func (l *locker) cleanup() {
-	defer l.logger.Trace(logs.DEBUG)()
 
-	l.cleanupLock.Lock()
-	defer l.cleanupLock.Unlock()
-	currentTime := time.Now()
-	var statsToDelete []string
-	for name, stat := range l.stats {
-		if currentTime.Sub(stat).Seconds() > STALE_LOCK_TIMEOUT {
-			msg := fmt.Sprint("Removing stalelock '%s' as it has exceeded configured timeout ('%d seconds')\n", name, STALE_LOCK_TIMEOUT)
-			l.logger.Debug(msg)
-			delete(l.locks, name)
-			statsToDelete = append(statsToDelete, name)
+// removeHolder removes the holder of the mode with the request id, or else the oldest holder of the mode
+func (e *lockEntry) removeHolder(mode string, requestId string) bool {
+	index := -1
+	for i, holder := range e.holders {
+		if holder.Mode != mode {
+			continue
+		}
+		if holder.RequestId == requestId {
+			index = i
+			break
 		}
+		if index == -1 {
+			index = i
+		}
+	}
+	if index == -1 {
+		return false
 	}
-	for _, name := range statsToDelete {
-		delete(l.stats, name)
+	e.holders = append(e.holders[:index], e.holders[index+1:]...)
+	return true
+}
+
+// NewLockInfo returns the info of the lock of the name held by the holder
+func NewLockInfo(name string, holder LockHolder, waiters int) resources.LockInfo {
+	return resources.LockInfo{
+		Name:        name,
+		Mode:        holder.Mode,
+		RequestId:   holder.RequestId,
+		HeldSince:   holder.Since,
+		HeldSeconds: time.Since(holder.Since).Seconds(),
+		Waiters:     waiters,
 	}
 }
+
+// SortLockInfos sorts the locks by the time they were acquired, the oldest first
+func SortLockInfos(locks []resources.LockInfo) {
+	sort.Slice(locks, func(i, j int) bool { return locks[i].HeldSince.Before(locks[j].HeldSince) })
+}
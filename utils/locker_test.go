@@ -17,13 +17,18 @@
 package utils_test
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
 	"reflect"
+	"time"
 
 	"fmt"
 
+	"github.com/IBM/ubiquity/resources"
 	"github.com/IBM/ubiquity/utils"
+	"github.com/IBM/ubiquity/utils/logs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -92,6 +97,90 @@ var _ = Describe("utils - locker", func() {
 
 		})
 	})
+	Context(".WriteLock with timeout", func() {
+		BeforeEach(func() {
+			locker = utils.NewLockerWithTimeout(50 * time.Millisecond)
+		})
+
+		It("should fail if the lock is not released within the timeout", func() {
+			Expect(locker.WriteLock("timeoutTest")).To(Succeed())
+			lockErr := locker.WriteLock("timeoutTest")
+			Expect(lockErr).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			Expect(lockErr.(*utils.LockTimeoutError).HttpStatus()).To(Equal(http.StatusConflict))
+			Expect(locker.ReadLock("timeoutTest")).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+
+			// the waits that timed out do not keep the lock once it is released
+			locker.WriteUnlock("timeoutTest")
+			Eventually(func() error {
+				err := locker.WriteLock("timeoutTest")
+				if err == nil {
+					locker.WriteUnlock("timeoutTest")
+				}
+				return err
+			}, time.Second).Should(Succeed())
+		})
+
+		It("should share the lock between readers", func() {
+			Expect(locker.ReadLock("timeoutTest")).To(Succeed())
+			Expect(locker.ReadLock("timeoutTest")).To(Succeed())
+			Expect(locker.WriteLock("timeoutTest")).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			locker.ReadUnlock("timeoutTest")
+			locker.ReadUnlock("timeoutTest")
+		})
+	})
+	Context(".TryWriteLock", func() {
+		It("should fail once the context is cancelled", func() {
+			Expect(locker.WriteLock("tryTest")).To(Succeed())
+			ctx, cancel := context.WithCancel(context.Background())
+			result := make(chan error)
+			go func() { result <- locker.TryWriteLock(ctx, "tryTest") }()
+			cancel()
+			Expect(<-result).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			locker.WriteUnlock("tryTest")
+
+			Expect(locker.TryReadLock(context.Background(), "tryTest")).To(Succeed())
+			locker.ReadUnlock("tryTest")
+		})
+		It("should fail at the lock timeout if the context is not done before", func() {
+			locker = utils.NewLockerWithTimeout(50 * time.Millisecond)
+			Expect(locker.WriteLock("tryTest")).To(Succeed())
+			Expect(locker.TryWriteLock(context.Background(), "tryTest")).To(BeAssignableToTypeOf(&utils.LockTimeoutError{}))
+			locker.WriteUnlock("tryTest")
+		})
+	})
+	Context(".HeldLocks", func() {
+		It("should list the held locks with their request ids and waiters until they are released", func() {
+			go_id := logs.GetGoID()
+			logs.GoIdToRequestIdMap.Store(go_id, resources.RequestContext{Id: "request1"})
+			defer logs.GetDeleteFromMapFunc(go_id)()
+
+			Expect(locker.ReadLock("heldTest")).To(Succeed())
+			Expect(locker.WriteLock("otherTest")).To(Succeed())
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			Expect(locker.TryWriteLock(ctx, "heldTest")).To(HaveOccurred())
+
+			locks := locker.HeldLocks()
+			Expect(locks).To(HaveLen(2))
+			Expect(locks[0].Name).To(Equal("heldTest"))
+			Expect(locks[0].Mode).To(Equal("read"))
+			Expect(locks[0].RequestId).To(Equal("request1"))
+			Expect(locks[0].HeldSeconds).To(BeNumerically(">", 0))
+			Expect(locks[0].Waiters).To(Equal(1)) // the timed out writer until it gets and releases the lock
+			Expect(locks[1].Name).To(Equal("otherTest"))
+			Expect(locks[1].Mode).To(Equal("write"))
+
+			locker.ReadUnlock("heldTest")
+			locker.WriteUnlock("otherTest")
+			Eventually(locker.HeldLocks).Should(BeEmpty())
+		})
+
+		It("should ignore the unlock of a lock that is not held", func() {
+			locker.WriteUnlock("notHeldTest")
+			locker.ReadUnlock("notHeldTest")
+			Expect(locker.HeldLocks()).To(BeEmpty())
+		})
+	})
 })
 
 //func readLockTest(locker utils.Locker, c chan int, sharedResource *[]string, letter string) {
@@ -73,6 +73,15 @@ func GetGoID() uint64 {
 
 var GoIdToRequestIdMap = new(sync.Map)
 
+// GetRequestId returns the id of the request that is served by the current goroutine, or NA if there is none
+func GetRequestId() string {
+	context, exists := GoIdToRequestIdMap.Load(GetGoID())
+	if !exists || context.(resources.RequestContext).Id == "" {
+		return "NA"
+	}
+	return context.(resources.RequestContext).Id
+}
+
 func (l *goLoggingLogger) getContextStringFromGoid() string {
 	go_id := GetGoID()
 	context, exists := GoIdToRequestIdMap.Load(go_id)
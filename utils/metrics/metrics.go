@@ -0,0 +1,196 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics holds the Prometheus metrics of the Ubiquity server and the helpers to record them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ubiquity"
+
+const (
+	LockModeRead  = "read"
+	LockModeWrite = "write"
+
+	OrphanKindDangling  = "dangling"
+	OrphanKindUnmanaged = "unmanaged"
+)
+
+var (
+	// storage API routes
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of storage API requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+	RequestErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_request_errors_total",
+		Help:      "Number of storage API requests that returned an error status code, by route and method.",
+	}, []string{"route", "method"})
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the storage API requests by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// backends (StorageClient operations)
+	BackendRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "backend_requests_total",
+		Help:      "Number of backend operations by backend and operation.",
+	}, []string{"backend", "operation"})
+	BackendRequestErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "backend_request_errors_total",
+		Help:      "Number of failed backend operations by backend and operation.",
+	}, []string{"backend", "operation"})
+	BackendRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "backend_request_duration_seconds",
+		Help:      "Latency of the backend operations by backend and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "operation"})
+
+	// utils.Locker
+	LockWaitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "locker_wait_duration_seconds",
+		Help:      "Time spent waiting to acquire a volume lock, by lock mode.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"mode"})
+	LockTimeoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "locker_timeouts_total",
+		Help:      "Number of volume locks that were not acquired within the lock timeout, by lock mode.",
+	}, []string{"mode"})
+
+	// database
+	DatabaseOpenFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "database_open_failures_total",
+		Help:      "Number of failures to open a connection to the Ubiquity database.",
+	})
+
+	// web_server.Reconciler
+	Orphans = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "orphans",
+		Help:      "Number of orphans found by the last reconciliation of a backend, by backend and kind (dangling volumes in the DB, unmanaged storage).",
+	}, []string{"backend", "kind"})
+
+	// web_server.HealthMonitor
+	BackendUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "backend_up",
+		Help:      "Whether the last health probes of a backend succeeded (1) or opened its circuit (0), by backend.",
+	}, []string{"backend"})
+
+	// REST clients of the storage systems (SCBE, Spectrum Scale)
+	RestCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rest_call_duration_seconds",
+		Help:      "Latency of the REST calls to the storage systems by client and HTTP method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"client", "method"})
+	RestCallErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rest_call_errors_total",
+		Help:      "Number of REST calls to the storage systems that failed or returned an unexpected status code, by client and HTTP method.",
+	}, []string{"client", "method"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		RequestsTotal,
+		RequestErrorsTotal,
+		RequestDuration,
+		BackendRequestsTotal,
+		BackendRequestErrorsTotal,
+		BackendRequestDuration,
+		LockWaitDuration,
+		LockTimeoutsTotal,
+		DatabaseOpenFailuresTotal,
+		Orphans,
+		BackendUp,
+		RestCallDuration,
+		RestCallErrorsTotal,
+	)
+}
+
+// Handler returns the http handler that exposes the registered metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest records a storage API request that started at start and returned the status code
+func ObserveRequest(route string, method string, code int, start time.Time) {
+	RequestsTotal.WithLabelValues(route, method, strconv.Itoa(code)).Inc()
+	if code >= http.StatusBadRequest {
+		RequestErrorsTotal.WithLabelValues(route, method).Inc()
+	}
+	RequestDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveBackendRequest records a backend operation that started at start and returned err
+func ObserveBackendRequest(backend string, operation string, start time.Time, err error) {
+	BackendRequestsTotal.WithLabelValues(backend, operation).Inc()
+	if err != nil {
+		BackendRequestErrorsTotal.WithLabelValues(backend, operation).Inc()
+	}
+	BackendRequestDuration.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveLockWait records the time spent from start until a lock of the given mode was acquired
+func ObserveLockWait(mode string, start time.Time) {
+	LockWaitDuration.WithLabelValues(mode).Observe(time.Since(start).Seconds())
+}
+
+// ObserveLockTimeout records a lock of the given mode that was not acquired within the lock timeout
+func ObserveLockTimeout(mode string) {
+	LockTimeoutsTotal.WithLabelValues(mode).Inc()
+}
+
+// SetOrphans records the number of orphans found by the last reconciliation of the backend
+func SetOrphans(backend string, dangling int, unmanaged int) {
+	Orphans.WithLabelValues(backend, OrphanKindDangling).Set(float64(dangling))
+	Orphans.WithLabelValues(backend, OrphanKindUnmanaged).Set(float64(unmanaged))
+}
+
+// SetBackendUp records the state of the backend found by its last health probes
+func SetBackendUp(backend string, up bool) {
+	if up {
+		BackendUp.WithLabelValues(backend).Set(1)
+	} else {
+		BackendUp.WithLabelValues(backend).Set(0)
+	}
+}
+
+// ObserveRestCall records a REST call of the client that started at start and returned err
+func ObserveRestCall(client string, method string, start time.Time, err error) {
+	if err != nil {
+		RestCallErrorsTotal.WithLabelValues(client, method).Inc()
+	}
+	RestCallDuration.WithLabelValues(client, method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/ubiquity/utils/metrics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("metrics", func() {
+	Context(".ObserveRequest", func() {
+		It("should count the request and its error status code", func() {
+			before := testutil.ToFloat64(metrics.RequestErrorsTotal.WithLabelValues("/fake", "GET"))
+			metrics.ObserveRequest("/fake", "GET", http.StatusOK, time.Now())
+			metrics.ObserveRequest("/fake", "GET", http.StatusNotFound, time.Now())
+			Expect(testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("/fake", "GET", "200"))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("/fake", "GET", "404"))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(metrics.RequestErrorsTotal.WithLabelValues("/fake", "GET"))).To(Equal(before + 1))
+		})
+	})
+	Context(".ObserveBackendRequest", func() {
+		It("should count the operation errors per backend", func() {
+			metrics.ObserveBackendRequest("fake-backend", "CreateVolume", time.Now(), nil)
+			metrics.ObserveBackendRequest("fake-backend", "CreateVolume", time.Now(), errors.New("fake error"))
+			Expect(testutil.ToFloat64(metrics.BackendRequestsTotal.WithLabelValues("fake-backend", "CreateVolume"))).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(metrics.BackendRequestErrorsTotal.WithLabelValues("fake-backend", "CreateVolume"))).To(Equal(float64(1)))
+		})
+	})
+	Context(".ObserveRestCall", func() {
+		It("should count only the failed calls as errors", func() {
+			metrics.ObserveRestCall("fake-client", "POST", time.Now(), nil)
+			metrics.ObserveRestCall("fake-client", "POST", time.Now(), errors.New("fake error"))
+			Expect(testutil.ToFloat64(metrics.RestCallErrorsTotal.WithLabelValues("fake-client", "POST"))).To(Equal(float64(1)))
+		})
+	})
+	Context(".SetBackendUp", func() {
+		It("should record the state of the backend", func() {
+			metrics.SetBackendUp("fake-backend", true)
+			Expect(testutil.ToFloat64(metrics.BackendUp.WithLabelValues("fake-backend"))).To(Equal(float64(1)))
+			metrics.SetBackendUp("fake-backend", false)
+			Expect(testutil.ToFloat64(metrics.BackendUp.WithLabelValues("fake-backend"))).To(Equal(float64(0)))
+		})
+	})
+	Context(".Handler", func() {
+		It("should expose the ubiquity metrics", func() {
+			metrics.DatabaseOpenFailuresTotal.Inc()
+			metrics.ObserveLockWait(metrics.LockModeWrite, time.Now())
+			server := httptest.NewServer(metrics.Handler())
+			defer server.Close()
+			response, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring("ubiquity_database_open_failures_total 1"))
+			Expect(string(body)).To(ContainSubstring(`ubiquity_locker_wait_duration_seconds_count{mode="write"} 1`))
+		})
+	})
+})
@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"github.com/IBM/ubiquity/registry"
 	"github.com/IBM/ubiquity/resources"
 	"path"
 	"github.com/IBM/ubiquity/utils/logs"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func ReadAndUnmarshal(object interface{}, dir string, fileName string) error {
@@ -264,9 +266,410 @@ func LoadConfig() (resources.UbiquityServerConfig, error) {
 	scbeConfig.ConnectionInfo = scbeConnectionInfo
 	config.ScbeConfig = scbeConfig
 
+	config.BackendsConfig = loadBackendsConfig()
+
+	usedNames := map[string]bool{resources.SCBE: true, resources.SpectrumScale: true}
+	for _, backend := range registry.GetBackends() {
+		usedNames[backend.Name] = true
+	}
+	scbeInstanceNames, err := loadBackendInstanceNames("SCBE_", usedNames)
+	if err != nil {
+		return config, err
+	}
+	config.ScbeInstances = make(map[string]resources.ScbeConfig)
+	for _, name := range scbeInstanceNames {
+		config.ScbeInstances[name] = loadScbeInstanceConfig(BackendInstanceParamPrefix(name), scbeConfig)
+	}
+	sscInstanceNames, err := loadBackendInstanceNames(resources.SpectrumScaleParamPrefix, usedNames)
+	if err != nil {
+		return config, err
+	}
+	config.SpectrumScaleInstances = make(map[string]resources.SpectrumScaleConfig)
+	for _, name := range sscInstanceNames {
+		config.SpectrumScaleInstances[name] = loadSpectrumScaleInstanceConfig(BackendInstanceParamPrefix(name), sscConfig)
+	}
+
+	if config.Heartbeat, err = loadHeartbeatConfig(); err != nil {
+		return config, err
+	}
+	if config.Locker, err = loadLockerConfig(); err != nil {
+		return config, err
+	}
+	if config.Database, err = loadDatabaseConfig(config.Heartbeat, config.Locker); err != nil {
+		return config, err
+	}
+	if value := os.Getenv("RECONCILE_INTERVAL"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return config, &resources.InvalidReconcileConfigError{Param: "RECONCILE_INTERVAL", Value: value}
+		}
+		config.ReconcileInterval = time.Duration(interval) * time.Second
+	}
+	if config.Deadlines, err = loadDeadlinesConfig(); err != nil {
+		return config, err
+	}
+	if config.BackendInit, err = loadBackendInitConfig(); err != nil {
+		return config, err
+	}
+	if config.HealthMonitor, err = loadHealthMonitorConfig(); err != nil {
+		return config, err
+	}
+	if config.FanOut, err = loadFanOutConfig(); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
 
+// loadBackendInitConfig loads the retry intervals of the backends that fail to initialize (in seconds)
+func loadBackendInitConfig() (resources.BackendInitConfig, error) {
+	backendInitConfig := resources.BackendInitConfig{
+		RetryInterval:    resources.DefaultBackendInitRetryInterval * time.Second,
+		MaxRetryInterval: resources.DefaultBackendInitMaxRetryInterval * time.Second,
+	}
+	if value := os.Getenv("BACKEND_INIT_RETRY_INTERVAL"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || interval == 0 {
+			return backendInitConfig, &resources.InvalidBackendInitConfigError{Param: "BACKEND_INIT_RETRY_INTERVAL", Value: value}
+		}
+		backendInitConfig.RetryInterval = time.Duration(interval) * time.Second
+	}
+	if value := os.Getenv("BACKEND_INIT_MAX_RETRY_INTERVAL"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || time.Duration(interval)*time.Second < backendInitConfig.RetryInterval {
+			return backendInitConfig, &resources.InvalidBackendInitConfigError{Param: "BACKEND_INIT_MAX_RETRY_INTERVAL", Value: value}
+		}
+		backendInitConfig.MaxRetryInterval = time.Duration(interval) * time.Second
+	} else if backendInitConfig.MaxRetryInterval < backendInitConfig.RetryInterval {
+		backendInitConfig.MaxRetryInterval = backendInitConfig.RetryInterval
+	}
+	return backendInitConfig, nil
+}
+
+// loadHealthMonitorConfig loads the interval and the timeout of the backend health probes (in seconds), and the number
+// of failed probes that opens the circuit of a backend. A zero interval disables the monitor.
+func loadHealthMonitorConfig() (resources.HealthMonitorConfig, error) {
+	healthMonitorConfig := resources.HealthMonitorConfig{
+		Interval:         resources.DefaultHealthMonitorInterval * time.Second,
+		Timeout:          resources.DefaultHealthMonitorTimeout * time.Second,
+		FailureThreshold: resources.DefaultHealthMonitorFailureThreshold,
+	}
+	if value := os.Getenv("HEALTH_MONITOR_INTERVAL"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return healthMonitorConfig, &resources.InvalidHealthMonitorConfigError{Param: "HEALTH_MONITOR_INTERVAL", Value: value}
+		}
+		healthMonitorConfig.Interval = time.Duration(interval) * time.Second
+	}
+	if value := os.Getenv("HEALTH_MONITOR_TIMEOUT"); value != "" {
+		timeout, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || timeout == 0 {
+			return healthMonitorConfig, &resources.InvalidHealthMonitorConfigError{Param: "HEALTH_MONITOR_TIMEOUT", Value: value}
+		}
+		healthMonitorConfig.Timeout = time.Duration(timeout) * time.Second
+	}
+	if value := os.Getenv("HEALTH_MONITOR_FAILURE_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseUint(value, 10, 31)
+		if err != nil || threshold == 0 {
+			return healthMonitorConfig, &resources.InvalidHealthMonitorConfigError{Param: "HEALTH_MONITOR_FAILURE_THRESHOLD", Value: value}
+		}
+		healthMonitorConfig.FailureThreshold = int(threshold)
+	}
+	return healthMonitorConfig, nil
+}
+
+// loadFanOutConfig loads whether partial results are allowed (by default) and the fan-out timeout (in seconds), zero waits for all the backends
+func loadFanOutConfig() (resources.FanOutConfig, error) {
+	fanOutConfig := resources.FanOutConfig{AllowPartialResults: true, Timeout: resources.DefaultFanOutTimeout * time.Second}
+	if value := os.Getenv("FANOUT_ALLOW_PARTIAL_RESULTS"); value != "" {
+		allowPartialResults, err := strconv.ParseBool(value)
+		if err != nil {
+			return fanOutConfig, &resources.InvalidFanOutConfigError{Param: "FANOUT_ALLOW_PARTIAL_RESULTS", Value: value}
+		}
+		fanOutConfig.AllowPartialResults = allowPartialResults
+	}
+	if value := os.Getenv("FANOUT_TIMEOUT"); value != "" {
+		timeout, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fanOutConfig, &resources.InvalidFanOutConfigError{Param: "FANOUT_TIMEOUT", Value: value}
+		}
+		fanOutConfig.Timeout = time.Duration(timeout) * time.Second
+	}
+	return fanOutConfig, nil
+}
+
+// deadlineOperationParams are the parameters of the operations that can have their own deadline, by operation
+var deadlineOperationParams = map[string]string{
+	"Activate":        "OPERATION_DEADLINE_ACTIVATE",
+	"CreateVolume":    "OPERATION_DEADLINE_CREATE_VOLUME",
+	"RemoveVolume":    "OPERATION_DEADLINE_REMOVE_VOLUME",
+	"ListVolumes":     "OPERATION_DEADLINE_LIST_VOLUMES",
+	"GetVolume":       "OPERATION_DEADLINE_GET_VOLUME",
+	"GetVolumeConfig": "OPERATION_DEADLINE_GET_VOLUME_CONFIG",
+	"Attach":          "OPERATION_DEADLINE_ATTACH",
+	"Detach":          "OPERATION_DEADLINE_DETACH",
+	"ExpandVolume":    "OPERATION_DEADLINE_EXPAND_VOLUME",
+	"CreateSnapshot":  "OPERATION_DEADLINE_CREATE_SNAPSHOT",
+	"ListSnapshots":   "OPERATION_DEADLINE_LIST_SNAPSHOTS",
+	"DeleteSnapshot":  "OPERATION_DEADLINE_DELETE_SNAPSHOT",
+	"ListServices":    "OPERATION_DEADLINE_LIST_SERVICES",
+	"Reconcile":       "OPERATION_DEADLINE_RECONCILE",
+}
+
+// loadDeadlinesConfig loads the default deadline of the backend operations and the deadlines of single operations (in seconds), zero disables a deadline
+func loadDeadlinesConfig() (resources.DeadlinesConfig, error) {
+	deadlinesConfig := resources.DeadlinesConfig{Operations: make(map[string]time.Duration)}
+	deadline, err := loadDeadlineParam("OPERATION_DEADLINE", resources.DefaultOperationDeadline)
+	if err != nil {
+		return deadlinesConfig, err
+	}
+	deadlinesConfig.Default = deadline
+	for operation, param := range deadlineOperationParams {
+		if os.Getenv(param) == "" {
+			continue
+		}
+		if deadlinesConfig.Operations[operation], err = loadDeadlineParam(param, 0); err != nil {
+			return deadlinesConfig, err
+		}
+	}
+	return deadlinesConfig, nil
+}
+
+func loadDeadlineParam(param string, defaultValue uint64) (time.Duration, error) {
+	value := os.Getenv(param)
+	if value == "" {
+		return time.Duration(defaultValue) * time.Second, nil
+	}
+	deadline, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, &resources.InvalidDeadlineConfigError{Param: param, Value: value}
+	}
+	return time.Duration(deadline) * time.Second, nil
+}
+
+// loadHeartbeatConfig loads the heartbeat mode and durations (in seconds), the lease duration defaults to a few intervals
+func loadHeartbeatConfig() (resources.HeartbeatConfig, error) {
+	heartbeatConfig := resources.HeartbeatConfig{
+		Mode:     GetEnv("HEARTBEAT_MODE", resources.HeartbeatModeFile),
+		Interval: HeartbeatInterval * time.Second,
+	}
+	if heartbeatConfig.Mode != resources.HeartbeatModeFile && heartbeatConfig.Mode != resources.HeartbeatModeDatabase {
+		return heartbeatConfig, &resources.InvalidHeartbeatConfigError{Param: "HEARTBEAT_MODE", Value: heartbeatConfig.Mode}
+	}
+	if value := os.Getenv("HEARTBEAT_INTERVAL"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || interval == 0 {
+			return heartbeatConfig, &resources.InvalidHeartbeatConfigError{Param: "HEARTBEAT_INTERVAL", Value: value}
+		}
+		heartbeatConfig.Interval = time.Duration(interval) * time.Second
+	}
+	heartbeatConfig.LeaseDuration = HeartbeatLeaseIntervals * heartbeatConfig.Interval
+	if value := os.Getenv("HEARTBEAT_LEASE_DURATION"); value != "" {
+		leaseDuration, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || time.Duration(leaseDuration)*time.Second <= heartbeatConfig.Interval {
+			return heartbeatConfig, &resources.InvalidHeartbeatConfigError{Param: "HEARTBEAT_LEASE_DURATION", Value: value}
+		}
+		heartbeatConfig.LeaseDuration = time.Duration(leaseDuration) * time.Second
+	}
+	return heartbeatConfig, nil
+}
+
+// loadLockerConfig loads the locker mode and the lock acquisition timeout (in seconds)
+func loadLockerConfig() (resources.LockerConfig, error) {
+	lockerConfig := resources.LockerConfig{
+		Mode:    GetEnv("LOCKER_MODE", resources.LockerModeMemory),
+		Timeout: DefaultLockTimeout * time.Second,
+	}
+	if lockerConfig.Mode != resources.LockerModeMemory && lockerConfig.Mode != resources.LockerModeDatabase {
+		return lockerConfig, &resources.InvalidLockerConfigError{Param: "LOCKER_MODE", Value: lockerConfig.Mode}
+	}
+	if value := os.Getenv("LOCK_TIMEOUT"); value != "" {
+		timeout, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || timeout == 0 {
+			return lockerConfig, &resources.InvalidLockerConfigError{Param: "LOCK_TIMEOUT", Value: value}
+		}
+		lockerConfig.Timeout = time.Duration(timeout) * time.Second
+	}
+	return lockerConfig, nil
+}
+
+// loadDatabaseConfig loads the DB type, a SQLite DB is local to the server so the heartbeat and the locker cannot use it
+func loadDatabaseConfig(heartbeatConfig resources.HeartbeatConfig, lockerConfig resources.LockerConfig) (resources.DatabaseConfig, error) {
+	databaseConfig := resources.DatabaseConfig{
+		Type:       GetEnv("UBIQUITY_DB_TYPE", resources.DatabaseTypePostgres),
+		SqlitePath: GetEnv("UBIQUITY_DB_SQLITE_PATH", resources.DefaultSqlitePath),
+	}
+	if databaseConfig.Type != resources.DatabaseTypePostgres && databaseConfig.Type != resources.DatabaseTypeSqlite {
+		return databaseConfig, &resources.InvalidDatabaseConfigError{Param: "UBIQUITY_DB_TYPE", Value: databaseConfig.Type}
+	}
+	pool, err := loadDatabasePoolConfig()
+	if err != nil {
+		return databaseConfig, err
+	}
+	databaseConfig.Pool = pool
+	if databaseConfig.Type == resources.DatabaseTypeSqlite {
+		if heartbeatConfig.Mode == resources.HeartbeatModeDatabase {
+			return databaseConfig, &resources.InvalidDatabaseConfigError{Param: "UBIQUITY_DB_TYPE", Value: databaseConfig.Type, Reason: "HEARTBEAT_MODE database requires a postgres DB"}
+		}
+		if lockerConfig.Mode == resources.LockerModeDatabase {
+			return databaseConfig, &resources.InvalidDatabaseConfigError{Param: "UBIQUITY_DB_TYPE", Value: databaseConfig.Type, Reason: "LOCKER_MODE database requires a postgres DB"}
+		}
+	}
+	return databaseConfig, nil
+}
+
+const (
+	DefaultDbMaxOpenConns        = 20
+	DefaultDbMaxIdleConns        = 5
+	DefaultDbConnMaxLifetime     = 600 //in seconds
+	DefaultDbHealthCheckInterval = 30  //in seconds
+	DefaultDbOpenRetries         = 3
+	DefaultDbRetryInterval       = 1 //in seconds
+)
+
+// loadDatabasePoolConfig loads the limits of the DB connection pool, and its health check and retry intervals (in seconds)
+func loadDatabasePoolConfig() (resources.DatabasePoolConfig, error) {
+	poolConfig := resources.DatabasePoolConfig{}
+	var err error
+	if poolConfig.MaxOpenConns, err = loadDatabasePoolParam("UBIQUITY_DB_MAX_OPEN_CONNS", DefaultDbMaxOpenConns, 1); err != nil {
+		return poolConfig, err
+	}
+	if poolConfig.MaxIdleConns, err = loadDatabasePoolParam("UBIQUITY_DB_MAX_IDLE_CONNS", DefaultDbMaxIdleConns, 0); err != nil {
+		return poolConfig, err
+	}
+	if poolConfig.MaxIdleConns > poolConfig.MaxOpenConns {
+		return poolConfig, &resources.InvalidDatabaseConfigError{Param: "UBIQUITY_DB_MAX_IDLE_CONNS", Value: strconv.Itoa(poolConfig.MaxIdleConns), Reason: "more than UBIQUITY_DB_MAX_OPEN_CONNS"}
+	}
+	lifetime, err := loadDatabasePoolParam("UBIQUITY_DB_CONN_MAX_LIFETIME", DefaultDbConnMaxLifetime, 0)
+	if err != nil {
+		return poolConfig, err
+	}
+	poolConfig.ConnMaxLifetime = time.Duration(lifetime) * time.Second
+	healthCheckInterval, err := loadDatabasePoolParam("UBIQUITY_DB_HEALTH_CHECK_INTERVAL", DefaultDbHealthCheckInterval, 0)
+	if err != nil {
+		return poolConfig, err
+	}
+	poolConfig.HealthCheckInterval = time.Duration(healthCheckInterval) * time.Second
+	if poolConfig.OpenRetries, err = loadDatabasePoolParam("UBIQUITY_DB_OPEN_RETRIES", DefaultDbOpenRetries, 0); err != nil {
+		return poolConfig, err
+	}
+	retryInterval, err := loadDatabasePoolParam("UBIQUITY_DB_RETRY_INTERVAL", DefaultDbRetryInterval, 1)
+	if err != nil {
+		return poolConfig, err
+	}
+	poolConfig.RetryInterval = time.Duration(retryInterval) * time.Second
+	return poolConfig, nil
+}
+
+func loadDatabasePoolParam(name string, defaultValue int, min int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 31)
+	if err != nil || int(parsed) < min {
+		return 0, &resources.InvalidDatabaseConfigError{Param: name, Value: value}
+	}
+	return int(parsed), nil
+}
+
+// BackendInstanceParamPrefix returns the prefix of the environment variables of a named backend instance,
+// e.g the management IP of the instance scbe-prod is SCBE_PROD_MANAGEMENT_IP
+func BackendInstanceParamPrefix(name string) string {
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return strings.ToUpper(prefix) + "_"
+}
+
+// loadBackendInstanceNames returns the instance names listed (comma separated) in the <paramPrefix>INSTANCES environment variable,
+// a name that is already used by a backend or another instance is an error
+func loadBackendInstanceNames(paramPrefix string, usedNames map[string]bool) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(os.Getenv(paramPrefix+resources.BackendInstancesParamSuffix), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if usedNames[name] {
+			return nil, &resources.DuplicateBackendInstanceError{Name: name}
+		}
+		usedNames[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// loadScbeInstanceConfig loads the config of a named SCBE instance.
+// The connection settings are mandatory per instance, the other settings default to the ones of the default instance.
+func loadScbeInstanceConfig(prefix string, defaults resources.ScbeConfig) resources.ScbeConfig {
+	scbeConfig := defaults
+	scbeConfig.ConnectionInfo = resources.ConnectionInfo{}
+	scbeConfig.ConnectionInfo.ManagementIP = os.Getenv(prefix + "MANAGEMENT_IP")
+	scbeConfig.ConnectionInfo.Port = resources.ScbeDefaultPort
+	if port, err := strconv.ParseInt(os.Getenv(prefix+"MANAGEMENT_PORT"), 0, 32); err == nil {
+		scbeConfig.ConnectionInfo.Port = int(port)
+	}
+	scbeConfig.ConnectionInfo.CredentialInfo.UserName = os.Getenv(prefix + "USERNAME")
+	scbeConfig.ConnectionInfo.CredentialInfo.Password = os.Getenv(prefix + "PASSWORD")
+	setFromEnv(&scbeConfig.DefaultService, prefix+"DEFAULT_SERVICE")
+	setFromEnv(&scbeConfig.DefaultVolumeSize, prefix+"DEFAULT_VOLUME_SIZE")
+	setFromEnv(&scbeConfig.UbiquityInstanceName, prefix+"UBIQUITY_INSTANCE_NAME")
+	setFromEnv(&scbeConfig.DefaultFilesystemType, prefix+"DEFAULT_FSTYPE")
+	return scbeConfig
+}
+
+// loadSpectrumScaleInstanceConfig loads the config of a named Spectrum Scale instance.
+// The connection settings are mandatory per instance, the other settings default to the ones of the default instance.
+func loadSpectrumScaleInstanceConfig(prefix string, defaults resources.SpectrumScaleConfig) resources.SpectrumScaleConfig {
+	sscConfig := defaults
+	sscConfig.RestConfig = resources.RestConfig{}
+	sscConfig.RestConfig.ManagementIP = os.Getenv(prefix + "MANAGEMENT_IP")
+	sscConfig.RestConfig.Port = resources.SpectrumscaleDefaultPort
+	if port, err := strconv.ParseInt(os.Getenv(prefix+"MANAGEMENT_PORT"), 0, 32); err == nil {
+		sscConfig.RestConfig.Port = int(port)
+	}
+	sscConfig.RestConfig.User = os.Getenv(prefix + "REST_USER")
+	sscConfig.RestConfig.Password = os.Getenv(prefix + "REST_PASSWORD")
+	setFromEnv(&sscConfig.DefaultFilesystemName, prefix+"DEFAULT_FILESYSTEM_NAME")
+	setFromEnv(&sscConfig.NfsServerAddr, prefix+"NFS_SERVER_ADDRESS")
+	if forceDelete, err := strconv.ParseBool(os.Getenv(prefix + "FORCE_DELETE")); err == nil {
+		sscConfig.ForceDelete = forceDelete
+	}
+	return sscConfig
+}
+
+// setFromEnv sets the value to the environment variable, if the variable is set
+func setFromEnv(value *string, key string) {
+	if envValue := os.Getenv(key); envValue != "" {
+		*value = envValue
+	}
+}
+
+// loadBackendsConfig loads the config section of every registered backend that has one,
+// the section is made of the environment variables prefixed by its name (e.g MYBACKEND_URL is the URL setting of section MYBACKEND)
+func loadBackendsConfig() map[string]map[string]string {
+	backendsConfig := make(map[string]map[string]string)
+	for _, backend := range registry.GetBackends() {
+		if backend.ConfigSection == "" {
+			continue
+		}
+		prefix := backend.ConfigSection + "_"
+		section := make(map[string]string)
+		for _, env := range os.Environ() {
+			keyValue := strings.SplitN(env, "=", 2)
+			if len(keyValue) == 2 && strings.HasPrefix(keyValue[0], prefix) {
+				section[strings.TrimPrefix(keyValue[0], prefix)] = keyValue[1]
+			}
+		}
+		backendsConfig[backend.Name] = section
+	}
+	return backendsConfig
+}
+
 func GetEnv(envName string, defaultValue string) string {
 	envValue := os.Getenv(envName)
 	if envValue == "" {
@@ -274,3 +677,19 @@ func GetEnv(envName string, defaultValue string) string {
 	}
 	return envValue
 }
+
+// GetCloneSource returns the source volume and source snapshot options of a create volume request.
+// Both are empty if the new volume is not a clone, the source snapshot is empty for a clone of a volume.
+func GetCloneSource(createVolumeRequest resources.CreateVolumeRequest) (string, string, error) {
+	var sourceVolume, sourceSnapshot string
+	if value, ok := createVolumeRequest.Opts[resources.OptionNameForSourceVolume]; ok && value != nil {
+		sourceVolume = fmt.Sprintf("%v", value)
+	}
+	if value, ok := createVolumeRequest.Opts[resources.OptionNameForSourceSnapshot]; ok && value != nil {
+		sourceSnapshot = fmt.Sprintf("%v", value)
+	}
+	if sourceSnapshot != "" && sourceVolume == "" {
+		return "", "", &resources.CloneSourceSnapshotWithoutVolumeError{VolName: createVolumeRequest.Name, SnapName: sourceSnapshot}
+	}
+	return sourceVolume, sourceSnapshot, nil
+}
@@ -0,0 +1,240 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+)
+
+var _ = Describe("Utils", func() {
+	Context(".LoadConfig", func() {
+		var env map[string]string
+
+		BeforeEach(func() {
+			env = map[string]string{
+				"PORT":                      "9999",
+				"SCBE_MANAGEMENT_IP":        "1.1.1.1",
+				"SCBE_USERNAME":             "user",
+				"SCBE_DEFAULT_SERVICE":      "gold",
+				"DEFAULT_VOLUME_SIZE":       "5",
+				"SCBE_INSTANCES":            "scbe-prod, scbe-dev",
+				"SCBE_PROD_MANAGEMENT_IP":   "2.2.2.2",
+				"SCBE_PROD_USERNAME":        "prod-user",
+				"SCBE_PROD_DEFAULT_SERVICE": "platinum",
+				"SCBE_DEV_MANAGEMENT_IP":    "3.3.3.3",
+				"SCBE_DEV_MANAGEMENT_PORT":  "8441",
+			}
+		})
+
+		loadConfig := func() (resources.UbiquityServerConfig, error) {
+			for key, value := range env {
+				os.Setenv(key, value)
+			}
+			return utils.LoadConfig()
+		}
+
+		AfterEach(func() {
+			for key := range env {
+				os.Unsetenv(key)
+			}
+		})
+
+		It("should load the named instances of a backend", func() {
+			config, err := loadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.ScbeConfig.ConnectionInfo.ManagementIP).To(Equal("1.1.1.1"))
+			Expect(config.ScbeInstances).To(HaveLen(2))
+
+			prod := config.ScbeInstances["scbe-prod"]
+			Expect(prod.ConnectionInfo.ManagementIP).To(Equal("2.2.2.2"))
+			Expect(prod.ConnectionInfo.Port).To(Equal(resources.ScbeDefaultPort))
+			Expect(prod.ConnectionInfo.CredentialInfo.UserName).To(Equal("prod-user"))
+			Expect(prod.DefaultService).To(Equal("platinum"))
+			Expect(prod.DefaultVolumeSize).To(Equal("5"))
+
+			dev := config.ScbeInstances["scbe-dev"]
+			Expect(dev.ConnectionInfo.ManagementIP).To(Equal("3.3.3.3"))
+			Expect(dev.ConnectionInfo.Port).To(Equal(8441))
+			Expect(dev.ConnectionInfo.CredentialInfo.UserName).To(BeEmpty())
+			Expect(dev.DefaultService).To(Equal("gold"))
+		})
+
+		It("should load no named instances if none is listed", func() {
+			env["SCBE_INSTANCES"] = ""
+			config, err := loadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.ScbeInstances).To(BeEmpty())
+			Expect(config.SpectrumScaleInstances).To(BeEmpty())
+		})
+
+		It("should fail if an instance name is already used", func() {
+			env["SPECTRUMSCALE_INSTANCES"] = "scbe-dev"
+			_, err := loadConfig()
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&resources.DuplicateBackendInstanceError{}))
+		})
+
+		It("should fail if an instance is named after a backend type", func() {
+			env["SCBE_INSTANCES"] = resources.SpectrumScale
+			_, err := loadConfig()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context(".LoadConfig settings", func() {
+		var env map[string]string
+
+		loadConfig := func(settings map[string]string) (resources.UbiquityServerConfig, error) {
+			env = map[string]string{"PORT": "9999"}
+			for key, value := range settings {
+				env[key] = value
+			}
+			for key, value := range env {
+				os.Setenv(key, value)
+			}
+			return utils.LoadConfig()
+		}
+
+		AfterEach(func() {
+			for key := range env {
+				os.Unsetenv(key)
+			}
+		})
+
+		heartbeat := func(config resources.UbiquityServerConfig) interface{} { return config.Heartbeat }
+		locker := func(config resources.UbiquityServerConfig) interface{} { return config.Locker }
+		reconcileInterval := func(config resources.UbiquityServerConfig) interface{} { return config.ReconcileInterval }
+		deadlines := func(config resources.UbiquityServerConfig) interface{} {
+			return []time.Duration{config.Deadlines.Deadline("CreateVolume"), config.Deadlines.Deadline("ListVolumes"), config.Deadlines.Deadline("RemoveVolume")}
+		}
+		backendInit := func(config resources.UbiquityServerConfig) interface{} { return config.BackendInit }
+		healthMonitor := func(config resources.UbiquityServerConfig) interface{} { return config.HealthMonitor }
+		fanOut := func(config resources.UbiquityServerConfig) interface{} { return config.FanOut }
+		database := func(config resources.UbiquityServerConfig) interface{} {
+			return []string{config.Database.Type, config.Database.SqlitePath}
+		}
+		databasePool := func(config resources.UbiquityServerConfig) interface{} { return config.Database.Pool }
+
+		DescribeTable("should load the setting, or its default if it is not set",
+			func(settings map[string]string, setting func(resources.UbiquityServerConfig) interface{}, expected interface{}) {
+				config, err := loadConfig(settings)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(setting(config)).To(Equal(expected))
+			},
+			Entry("the file heartbeat by default", nil, heartbeat, resources.HeartbeatConfig{
+				Mode:          resources.HeartbeatModeFile,
+				Interval:      utils.HeartbeatInterval * time.Second,
+				LeaseDuration: utils.HeartbeatLeaseIntervals * utils.HeartbeatInterval * time.Second,
+			}),
+			Entry("the database heartbeat durations",
+				map[string]string{"HEARTBEAT_MODE": resources.HeartbeatModeDatabase, "HEARTBEAT_INTERVAL": "2", "HEARTBEAT_LEASE_DURATION": "30"},
+				heartbeat, resources.HeartbeatConfig{Mode: resources.HeartbeatModeDatabase, Interval: 2 * time.Second, LeaseDuration: 30 * time.Second}),
+			Entry("the memory locker by default", nil, locker,
+				resources.LockerConfig{Mode: resources.LockerModeMemory, Timeout: utils.DefaultLockTimeout * time.Second}),
+			Entry("the database locker timeout", map[string]string{"LOCKER_MODE": resources.LockerModeDatabase, "LOCK_TIMEOUT": "30"},
+				locker, resources.LockerConfig{Mode: resources.LockerModeDatabase, Timeout: 30 * time.Second}),
+			Entry("no reconcile interval by default", nil, reconcileInterval, time.Duration(0)),
+			Entry("the reconcile interval in seconds", map[string]string{"RECONCILE_INTERVAL": "3600"}, reconcileInterval, time.Hour),
+			Entry("the default deadline of every operation", nil, deadlines, []time.Duration{
+				resources.DefaultOperationDeadline * time.Second, resources.DefaultOperationDeadline * time.Second, resources.DefaultOperationDeadline * time.Second,
+			}),
+			Entry("the deadlines of single operations in seconds",
+				map[string]string{"OPERATION_DEADLINE": "60", "OPERATION_DEADLINE_CREATE_VOLUME": "300", "OPERATION_DEADLINE_LIST_VOLUMES": "0"},
+				deadlines, []time.Duration{5 * time.Minute, 0, time.Minute}),
+			Entry("the default backend init retry intervals", nil, backendInit, resources.BackendInitConfig{
+				RetryInterval:    resources.DefaultBackendInitRetryInterval * time.Second,
+				MaxRetryInterval: resources.DefaultBackendInitMaxRetryInterval * time.Second,
+			}),
+			Entry("the backend init retry intervals in seconds",
+				map[string]string{"BACKEND_INIT_RETRY_INTERVAL": "10", "BACKEND_INIT_MAX_RETRY_INTERVAL": "60"},
+				backendInit, resources.BackendInitConfig{RetryInterval: 10 * time.Second, MaxRetryInterval: time.Minute}),
+			Entry("the default health probes", nil, healthMonitor, resources.HealthMonitorConfig{
+				Interval:         resources.DefaultHealthMonitorInterval * time.Second,
+				Timeout:          resources.DefaultHealthMonitorTimeout * time.Second,
+				FailureThreshold: resources.DefaultHealthMonitorFailureThreshold,
+			}),
+			Entry("the health probes, disabled with a zero interval",
+				map[string]string{"HEALTH_MONITOR_INTERVAL": "0", "HEALTH_MONITOR_TIMEOUT": "5", "HEALTH_MONITOR_FAILURE_THRESHOLD": "1"},
+				healthMonitor, resources.HealthMonitorConfig{Interval: 0, Timeout: 5 * time.Second, FailureThreshold: 1}),
+			Entry("partial fan-out results by default", nil, fanOut,
+				resources.FanOutConfig{AllowPartialResults: true, Timeout: resources.DefaultFanOutTimeout * time.Second}),
+			Entry("the fan-out policy and timeout in seconds", map[string]string{"FANOUT_ALLOW_PARTIAL_RESULTS": "false", "FANOUT_TIMEOUT": "0"},
+				fanOut, resources.FanOutConfig{AllowPartialResults: false, Timeout: 0}),
+			Entry("the postgres database by default", nil, database, []string{resources.DatabaseTypePostgres, resources.DefaultSqlitePath}),
+			Entry("the sqlite path", map[string]string{"UBIQUITY_DB_TYPE": resources.DatabaseTypeSqlite, "UBIQUITY_DB_SQLITE_PATH": "/tmp/ubiquity.db"},
+				database, []string{resources.DatabaseTypeSqlite, "/tmp/ubiquity.db"}),
+			Entry("the default database connection pool", nil, databasePool, resources.DatabasePoolConfig{
+				MaxOpenConns:        utils.DefaultDbMaxOpenConns,
+				MaxIdleConns:        utils.DefaultDbMaxIdleConns,
+				ConnMaxLifetime:     utils.DefaultDbConnMaxLifetime * time.Second,
+				HealthCheckInterval: utils.DefaultDbHealthCheckInterval * time.Second,
+				OpenRetries:         utils.DefaultDbOpenRetries,
+				RetryInterval:       utils.DefaultDbRetryInterval * time.Second,
+			}),
+			Entry("the database connection pool limits", map[string]string{"UBIQUITY_DB_MAX_OPEN_CONNS": "50", "UBIQUITY_DB_MAX_IDLE_CONNS": "10"},
+				databasePool, resources.DatabasePoolConfig{
+					MaxOpenConns:        50,
+					MaxIdleConns:        10,
+					ConnMaxLifetime:     utils.DefaultDbConnMaxLifetime * time.Second,
+					HealthCheckInterval: utils.DefaultDbHealthCheckInterval * time.Second,
+					OpenRetries:         utils.DefaultDbOpenRetries,
+					RetryInterval:       utils.DefaultDbRetryInterval * time.Second,
+				}),
+		)
+
+		DescribeTable("should fail if the setting is not valid",
+			func(settings map[string]string, expectedErr error) {
+				_, err := loadConfig(settings)
+				Expect(err).To(BeAssignableToTypeOf(expectedErr))
+			},
+			Entry("an unknown heartbeat mode", map[string]string{"HEARTBEAT_MODE": "bad"}, &resources.InvalidHeartbeatConfigError{}),
+			Entry("a heartbeat lease that is not longer than the interval",
+				map[string]string{"HEARTBEAT_INTERVAL": "10", "HEARTBEAT_LEASE_DURATION": "10"}, &resources.InvalidHeartbeatConfigError{}),
+			Entry("an unknown locker mode", map[string]string{"LOCKER_MODE": "bad"}, &resources.InvalidLockerConfigError{}),
+			Entry("a zero lock timeout", map[string]string{"LOCK_TIMEOUT": "0"}, &resources.InvalidLockerConfigError{}),
+			Entry("a reconcile interval that is not a number", map[string]string{"RECONCILE_INTERVAL": "hourly"}, &resources.InvalidReconcileConfigError{}),
+			Entry("a deadline that is not a number", map[string]string{"OPERATION_DEADLINE_CREATE_VOLUME": "soon"}, &resources.InvalidDeadlineConfigError{}),
+			Entry("a backend init max retry interval that is less than the retry interval",
+				map[string]string{"BACKEND_INIT_RETRY_INTERVAL": "10", "BACKEND_INIT_MAX_RETRY_INTERVAL": "5"}, &resources.InvalidBackendInitConfigError{}),
+			Entry("a zero health failure threshold", map[string]string{"HEALTH_MONITOR_FAILURE_THRESHOLD": "0"}, &resources.InvalidHealthMonitorConfigError{}),
+			Entry("a fan-out policy that is not a boolean", map[string]string{"FANOUT_ALLOW_PARTIAL_RESULTS": "sometimes"}, &resources.InvalidFanOutConfigError{}),
+			Entry("an unknown database type", map[string]string{"UBIQUITY_DB_TYPE": "mysql"}, &resources.InvalidDatabaseConfigError{}),
+			Entry("zero max open DB connections", map[string]string{"UBIQUITY_DB_MAX_OPEN_CONNS": "0"}, &resources.InvalidDatabaseConfigError{}),
+			Entry("more max idle DB connections than max open connections",
+				map[string]string{"UBIQUITY_DB_MAX_OPEN_CONNS": "5", "UBIQUITY_DB_MAX_IDLE_CONNS": "6"}, &resources.InvalidDatabaseConfigError{}),
+			Entry("a DB retry interval that is not a number", map[string]string{"UBIQUITY_DB_RETRY_INTERVAL": "1s"}, &resources.InvalidDatabaseConfigError{}),
+			Entry("sqlite with the database heartbeat",
+				map[string]string{"UBIQUITY_DB_TYPE": resources.DatabaseTypeSqlite, "HEARTBEAT_MODE": resources.HeartbeatModeDatabase}, &resources.InvalidDatabaseConfigError{}),
+			Entry("sqlite with the database locker",
+				map[string]string{"UBIQUITY_DB_TYPE": resources.DatabaseTypeSqlite, "LOCKER_MODE": resources.LockerModeDatabase}, &resources.InvalidDatabaseConfigError{}),
+		)
+	})
+
+	Context(".BackendInstanceParamPrefix", func() {
+		It("should return the upper case name with underscores", func() {
+			Expect(utils.BackendInstanceParamPrefix("scbe-prod.1")).To(Equal("SCBE_PROD_1_"))
+		})
+	})
+})
@@ -0,0 +1,209 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+)
+
+const labelsSeparator = ","
+const labelKeyValueSeparator = "="
+
+// VolumeCursor is the position in a sorted volume list after which the next page starts
+type VolumeCursor struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// GetVolumeLabels returns the labels given in the create options, either as a map or as a "key=value,key=value" string
+func GetVolumeLabels(opts map[string]interface{}) (map[string]string, error) {
+	value, ok := opts[resources.OptionNameForLabels]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, labelValue := range typedValue {
+			labels[key] = fmt.Sprintf("%v", labelValue)
+		}
+	case map[string]string:
+		for key, labelValue := range typedValue {
+			labels[key] = labelValue
+		}
+	case string:
+		for _, pair := range strings.Split(typedValue, labelsSeparator) {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			keyValue := strings.SplitN(pair, labelKeyValueSeparator, 2)
+			if len(keyValue) != 2 {
+				return nil, &resources.InvalidLabelsError{Labels: value, Reason: fmt.Sprintf("label [%s] is not in key=value format", pair)}
+			}
+			labels[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	default:
+		return nil, &resources.InvalidLabelsError{Labels: value, Reason: "expected a map or a key=value list"}
+	}
+
+	if err := validateLabels(labels); err != nil {
+		return nil, &resources.InvalidLabelsError{Labels: value, Reason: err.Error()}
+	}
+	return labels, nil
+}
+
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if key == "" {
+			return fmt.Errorf("label key cannot be empty")
+		}
+		if strings.ContainsAny(key, labelsSeparator+labelKeyValueSeparator) || strings.Contains(value, labelsSeparator) {
+			return fmt.Errorf("label [%s] cannot contain [%s] or [%s] in its key or [%s] in its value", key, labelsSeparator, labelKeyValueSeparator, labelsSeparator)
+		}
+	}
+	return nil
+}
+
+// EncodeLabel returns the form of a single label as stored in Volume.Labels
+func EncodeLabel(key string, value string) string {
+	return labelsSeparator + key + labelKeyValueSeparator + value + labelsSeparator
+}
+
+// EncodeLabels returns the labels in the form stored in Volume.Labels (",key=value,key=value," sorted by key)
+func EncodeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encoded := labelsSeparator
+	for _, key := range keys {
+		encoded += key + labelKeyValueSeparator + labels[key] + labelsSeparator
+	}
+	return encoded
+}
+
+// ValidateListVolumesRequest checks the filter, sort and pagination parameters of the request
+func ValidateListVolumesRequest(listVolumesRequest resources.ListVolumesRequest) error {
+	switch listVolumesRequest.SortBy {
+	case "", resources.ListVolumesSortByName, resources.ListVolumesSortByCreated:
+	default:
+		return &resources.InvalidListVolumesRequestError{Param: "SortBy", Value: listVolumesRequest.SortBy}
+	}
+	switch listVolumesRequest.SortOrder {
+	case "", resources.ListVolumesSortAsc, resources.ListVolumesSortDesc:
+	default:
+		return &resources.InvalidListVolumesRequestError{Param: "SortOrder", Value: listVolumesRequest.SortOrder}
+	}
+	if listVolumesRequest.Limit < 0 {
+		return &resources.InvalidListVolumesRequestError{Param: "Limit", Value: listVolumesRequest.Limit}
+	}
+	if _, err := DecodeVolumeCursor(listVolumesRequest.Cursor); err != nil {
+		return &resources.InvalidListVolumesRequestError{Param: "Cursor", Value: listVolumesRequest.Cursor}
+	}
+	if err := validateLabels(listVolumesRequest.Labels); err != nil {
+		return &resources.InvalidListVolumesRequestError{Param: "Labels", Value: listVolumesRequest.Labels}
+	}
+	return nil
+}
+
+// EncodeVolumeCursor returns the opaque cursor of the page that starts after the given volume
+func EncodeVolumeCursor(volume resources.Volume) string {
+	bytes, _ := json.Marshal(VolumeCursor{Name: volume.Name, CreatedAt: volume.CreatedAt})
+	return base64.URLEncoding.EncodeToString(bytes)
+}
+
+// DecodeVolumeCursor returns the position encoded in the cursor, nil if the cursor is empty
+func DecodeVolumeCursor(cursor string) (*VolumeCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	bytes, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	volumeCursor := &VolumeCursor{}
+	if err = json.Unmarshal(bytes, volumeCursor); err != nil {
+		return nil, err
+	}
+	return volumeCursor, nil
+}
+
+// VolumeMatchesListRequest checks a volume that is not kept in the DB (e.g the Ubiquity DB volume) against the filter and cursor of the request
+func VolumeMatchesListRequest(volume resources.Volume, listVolumesRequest resources.ListVolumesRequest) bool {
+	if !strings.HasPrefix(volume.Name, listVolumesRequest.NamePrefix) {
+		return false
+	}
+	for key, value := range listVolumesRequest.Labels {
+		if !strings.Contains(volume.Labels, EncodeLabel(key, value)) {
+			return false
+		}
+	}
+	if listVolumesRequest.Host != "" && volume.AttachedHost != listVolumesRequest.Host {
+		return false
+	}
+	volumeCursor, err := DecodeVolumeCursor(listVolumesRequest.Cursor)
+	if err != nil {
+		return false
+	}
+	if volumeCursor != nil {
+		cursorVolume := resources.Volume{Name: volumeCursor.Name}
+		cursorVolume.CreatedAt = volumeCursor.CreatedAt
+		return volumeLess(cursorVolume, volume, listVolumesRequest)
+	}
+	return true
+}
+
+// SortVolumes sorts the volumes by the SortBy and SortOrder of the request, the volume name breaks ties
+func SortVolumes(volumes []resources.Volume, listVolumesRequest resources.ListVolumesRequest) {
+	sort.SliceStable(volumes, func(i, j int) bool {
+		return volumeLess(volumes[i], volumes[j], listVolumesRequest)
+	})
+}
+
+// PageVolumes sorts the volumes and cuts them to the Limit of the request, it returns the page and the cursor of the next page (empty on the last page)
+func PageVolumes(volumes []resources.Volume, listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, string) {
+	SortVolumes(volumes, listVolumesRequest)
+	if listVolumesRequest.Limit == 0 || len(volumes) <= listVolumesRequest.Limit {
+		return volumes, ""
+	}
+	page := volumes[:listVolumesRequest.Limit]
+	return page, EncodeVolumeCursor(page[len(page)-1])
+}
+
+// volumeLess reports whether volume a is listed before volume b, the names compare by byte order as in model.FilterVolumes
+func volumeLess(a resources.Volume, b resources.Volume, listVolumesRequest resources.ListVolumesRequest) bool {
+	if listVolumesRequest.SortOrder == resources.ListVolumesSortDesc {
+		a, b = b, a
+	}
+	if listVolumesRequest.SortBy == resources.ListVolumesSortByCreated && !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,153 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("utils - volumes", func() {
+	var (
+		now     time.Time
+		volumes []resources.Volume
+	)
+	newVolume := func(name string, createdAt time.Time) resources.Volume {
+		volume := resources.Volume{Name: name}
+		volume.CreatedAt = createdAt
+		return volume
+	}
+	names := func(volumes []resources.Volume) []string {
+		var names []string
+		for _, volume := range volumes {
+			names = append(names, volume.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		volumes = []resources.Volume{
+			newVolume("b", now.Add(time.Minute)),
+			newVolume("c", now),
+			newVolume("a", now.Add(2*time.Minute)),
+		}
+	})
+
+	Context(".GetVolumeLabels", func() {
+		It("should return nil if no labels are given", func() {
+			labels, err := utils.GetVolumeLabels(map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels).To(BeNil())
+		})
+		It("should parse a key=value list", func() {
+			labels, err := utils.GetVolumeLabels(map[string]interface{}{resources.OptionNameForLabels: "app=db, tier=gold"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels).To(Equal(map[string]string{"app": "db", "tier": "gold"}))
+		})
+		It("should parse a map", func() {
+			labels, err := utils.GetVolumeLabels(map[string]interface{}{resources.OptionNameForLabels: map[string]interface{}{"app": "db"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels).To(Equal(map[string]string{"app": "db"}))
+		})
+		It("should fail if a label is not in key=value format", func() {
+			_, err := utils.GetVolumeLabels(map[string]interface{}{resources.OptionNameForLabels: "app"})
+			_, ok := err.(*resources.InvalidLabelsError)
+			Expect(ok).To(BeTrue())
+		})
+		It("should fail if a label contains a separator", func() {
+			_, err := utils.GetVolumeLabels(map[string]interface{}{resources.OptionNameForLabels: map[string]interface{}{"app": "a,b"}})
+			_, ok := err.(*resources.InvalidLabelsError)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context(".EncodeLabels", func() {
+		It("should encode the labels sorted by key", func() {
+			Expect(utils.EncodeLabels(map[string]string{"tier": "gold", "app": "db"})).To(Equal(",app=db,tier=gold,"))
+			Expect(utils.EncodeLabels(nil)).To(Equal(""))
+		})
+	})
+
+	Context(".ValidateListVolumesRequest", func() {
+		It("should accept an empty request", func() {
+			Expect(utils.ValidateListVolumesRequest(resources.ListVolumesRequest{})).To(Succeed())
+		})
+		It("should reject invalid parameters", func() {
+			for _, request := range []resources.ListVolumesRequest{
+				{SortBy: "size"},
+				{SortOrder: "up"},
+				{Limit: -1},
+				{Cursor: "not-a-cursor"},
+			} {
+				err := utils.ValidateListVolumesRequest(request)
+				_, ok := err.(*resources.InvalidListVolumesRequestError)
+				Expect(ok).To(BeTrue())
+			}
+		})
+	})
+
+	Context(".PageVolumes", func() {
+		It("should sort by name by default", func() {
+			page, cursor := utils.PageVolumes(volumes, resources.ListVolumesRequest{})
+			Expect(names(page)).To(Equal([]string{"a", "b", "c"}))
+			Expect(cursor).To(Equal(""))
+		})
+		It("should sort by creation time in descending order", func() {
+			page, _ := utils.PageVolumes(volumes, resources.ListVolumesRequest{SortBy: resources.ListVolumesSortByCreated, SortOrder: resources.ListVolumesSortDesc})
+			Expect(names(page)).To(Equal([]string{"a", "b", "c"}))
+			page, _ = utils.PageVolumes(volumes, resources.ListVolumesRequest{SortBy: resources.ListVolumesSortByCreated})
+			Expect(names(page)).To(Equal([]string{"c", "b", "a"}))
+		})
+		It("should return the cursor of the next page", func() {
+			request := resources.ListVolumesRequest{Limit: 2}
+			page, cursor := utils.PageVolumes(volumes, request)
+			Expect(names(page)).To(Equal([]string{"a", "b"}))
+			Expect(cursor).NotTo(Equal(""))
+
+			volumeCursor, err := utils.DecodeVolumeCursor(cursor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumeCursor.Name).To(Equal("b"))
+		})
+	})
+
+	Context(".VolumeMatchesListRequest", func() {
+		var volume resources.Volume
+		BeforeEach(func() {
+			volume = newVolume("vol1", now)
+			volume.Labels = utils.EncodeLabels(map[string]string{"app": "db"})
+			volume.AttachedHost = "host1"
+		})
+		It("should match the filters", func() {
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{})).To(BeTrue())
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{NamePrefix: "vol", Labels: map[string]string{"app": "db"}, Host: "host1"})).To(BeTrue())
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{NamePrefix: "other"})).To(BeFalse())
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{Labels: map[string]string{"app": "web"}})).To(BeFalse())
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{Host: "host2"})).To(BeFalse())
+		})
+		It("should match only volumes after the cursor", func() {
+			before := utils.EncodeVolumeCursor(newVolume("vol0", now))
+			after := utils.EncodeVolumeCursor(newVolume("vol2", now))
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{Cursor: before})).To(BeTrue())
+			Expect(utils.VolumeMatchesListRequest(volume, resources.ListVolumesRequest{Cursor: after})).To(BeFalse())
+		})
+	})
+})
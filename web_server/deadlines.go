@@ -0,0 +1,137 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server
+
+import (
+	"context"
+
+	"github.com/IBM/ubiquity/resources"
+)
+
+// deadlineStorageClient bounds the operations of a backend with their configured deadline, on top of the context of the request
+type deadlineStorageClient struct {
+	backend   string
+	client    resources.StorageClient
+	deadlines resources.DeadlinesConfig
+}
+
+func newDeadlineStorageClient(backend string, client resources.StorageClient, deadlines resources.DeadlinesConfig) resources.StorageClient {
+	return &deadlineStorageClient{backend: backend, client: client, deadlines: deadlines}
+}
+
+// withDeadline bounds the context of the request with the deadline of the operation, the returned function releases it
+func (c *deadlineStorageClient) withDeadline(requestContext *resources.RequestContext, operation string) context.CancelFunc {
+	deadline := c.deadlines.Deadline(operation)
+	if deadline <= 0 {
+		return func() {}
+	}
+	ctx, cancel := context.WithTimeout(requestContext.GetCtx(), deadline)
+	requestContext.Ctx = ctx
+	return cancel
+}
+
+// deadlineError replaces the error of an operation that ran out of time, each backend reports it in its own words
+func (c *deadlineStorageClient) deadlineError(requestContext resources.RequestContext, operation string, err error) error {
+	if err != nil && requestContext.GetCtx().Err() == context.DeadlineExceeded {
+		return &resources.DeadlineExceededError{Backend: c.backend, Operation: operation, Deadline: c.deadlines.Deadline(operation)}
+	}
+	return err
+}
+
+func (c *deadlineStorageClient) Activate(activateRequest resources.ActivateRequest) error {
+	defer c.withDeadline(&activateRequest.Context, "Activate")()
+	err := c.client.Activate(activateRequest)
+	return c.deadlineError(activateRequest.Context, "Activate", err)
+}
+
+func (c *deadlineStorageClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) error {
+	defer c.withDeadline(&createVolumeRequest.Context, "CreateVolume")()
+	err := c.client.CreateVolume(createVolumeRequest)
+	return c.deadlineError(createVolumeRequest.Context, "CreateVolume", err)
+}
+
+func (c *deadlineStorageClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) error {
+	defer c.withDeadline(&removeVolumeRequest.Context, "RemoveVolume")()
+	err := c.client.RemoveVolume(removeVolumeRequest)
+	return c.deadlineError(removeVolumeRequest.Context, "RemoveVolume", err)
+}
+
+func (c *deadlineStorageClient) ListVolumes(listVolumeRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
+	defer c.withDeadline(&listVolumeRequest.Context, "ListVolumes")()
+	volumes, err := c.client.ListVolumes(listVolumeRequest)
+	return volumes, c.deadlineError(listVolumeRequest.Context, "ListVolumes", err)
+}
+
+func (c *deadlineStorageClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (resources.Volume, error) {
+	defer c.withDeadline(&getVolumeRequest.Context, "GetVolume")()
+	volume, err := c.client.GetVolume(getVolumeRequest)
+	return volume, c.deadlineError(getVolumeRequest.Context, "GetVolume", err)
+}
+
+func (c *deadlineStorageClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (map[string]interface{}, error) {
+	defer c.withDeadline(&getVolumeConfigRequest.Context, "GetVolumeConfig")()
+	config, err := c.client.GetVolumeConfig(getVolumeConfigRequest)
+	return config, c.deadlineError(getVolumeConfigRequest.Context, "GetVolumeConfig", err)
+}
+
+func (c *deadlineStorageClient) Attach(attachRequest resources.AttachRequest) (string, error) {
+	defer c.withDeadline(&attachRequest.Context, "Attach")()
+	mountpoint, err := c.client.Attach(attachRequest)
+	return mountpoint, c.deadlineError(attachRequest.Context, "Attach", err)
+}
+
+func (c *deadlineStorageClient) Detach(detachRequest resources.DetachRequest) error {
+	defer c.withDeadline(&detachRequest.Context, "Detach")()
+	err := c.client.Detach(detachRequest)
+	return c.deadlineError(detachRequest.Context, "Detach", err)
+}
+
+func (c *deadlineStorageClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	defer c.withDeadline(&expandVolumeRequest.Context, "ExpandVolume")()
+	err := c.client.ExpandVolume(expandVolumeRequest)
+	return c.deadlineError(expandVolumeRequest.Context, "ExpandVolume", err)
+}
+
+func (c *deadlineStorageClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	defer c.withDeadline(&createSnapshotRequest.Context, "CreateSnapshot")()
+	err := c.client.CreateSnapshot(createSnapshotRequest)
+	return c.deadlineError(createSnapshotRequest.Context, "CreateSnapshot", err)
+}
+
+func (c *deadlineStorageClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	defer c.withDeadline(&listSnapshotsRequest.Context, "ListSnapshots")()
+	snapshots, err := c.client.ListSnapshots(listSnapshotsRequest)
+	return snapshots, c.deadlineError(listSnapshotsRequest.Context, "ListSnapshots", err)
+}
+
+func (c *deadlineStorageClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	defer c.withDeadline(&deleteSnapshotRequest.Context, "DeleteSnapshot")()
+	err := c.client.DeleteSnapshot(deleteSnapshotRequest)
+	return c.deadlineError(deleteSnapshotRequest.Context, "DeleteSnapshot", err)
+}
+
+func (c *deadlineStorageClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	defer c.withDeadline(&listServicesRequest.Context, "ListServices")()
+	services, err := c.client.ListServices(listServicesRequest)
+	return services, c.deadlineError(listServicesRequest.Context, "ListServices", err)
+}
+
+func (c *deadlineStorageClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	defer c.withDeadline(&reconcileRequest.Context, "Reconcile")()
+	report, err := c.client.Reconcile(reconcileRequest)
+	return report, c.deadlineError(reconcileRequest.Context, "Reconcile", err)
+}
@@ -0,0 +1,90 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/web_server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deadlines", func() {
+	var (
+		backend *fakes.FakeStorageClient
+		config  resources.UbiquityServerConfig
+	)
+	BeforeEach(func() {
+		backend = new(fakes.FakeStorageClient)
+		config = resources.UbiquityServerConfig{
+			FanOut:    resources.FanOutConfig{AllowPartialResults: true},
+			Deadlines: resources.DeadlinesConfig{Default: time.Hour, Operations: map[string]time.Duration{"ListServices": 10 * time.Millisecond}},
+		}
+	})
+
+	listBackends := func() (int, resources.GenericResponse) {
+		handler := web_server.NewStorageApiHandler(map[string]resources.StorageClient{"backend1": backend}, config)
+		recorder := httptest.NewRecorder()
+		handler.ListBackends()(recorder, httptest.NewRequest("GET", "/ubiquity_storage/backends", nil))
+		genericResponse := resources.GenericResponse{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &genericResponse)).To(Succeed())
+		return recorder.Code, genericResponse
+	}
+
+	It("should fail an operation that does not end by its deadline with a gateway timeout", func() {
+		backend.ListServicesStub = func(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+			ctx := listServicesRequest.Context.GetCtx()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		code, genericResponse := listBackends()
+		Expect(code).To(Equal(http.StatusGatewayTimeout))
+		Expect(genericResponse.Code).To(Equal(resources.ErrorCodeDeadlineExceeded))
+		Expect(genericResponse.Details).To(HaveKeyWithValue("backend", "backend1"))
+		Expect(genericResponse.Details).To(HaveKeyWithValue("operation", "ListServices"))
+		Expect(genericResponse.Details).To(HaveKeyWithValue("deadline", "10ms"))
+	})
+
+	It("should keep the error of an operation that failed before its deadline", func() {
+		backend.ListServicesReturns(nil, errors.New("fake error"))
+
+		code, genericResponse := listBackends()
+		Expect(code).To(Equal(http.StatusInternalServerError))
+		Expect(genericResponse.Err).To(Equal("fake error"))
+	})
+
+	It("should not bound an operation whose deadline is zero", func() {
+		config.Deadlines.Operations["ListServices"] = 0
+		var hasDeadline bool
+		backend.ListServicesStub = func(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+			_, hasDeadline = listServicesRequest.Context.GetCtx().Deadline()
+			return nil, errors.New("fake error")
+		}
+
+		listBackends()
+		Expect(backend.ListServicesCallCount()).To(Equal(1))
+		Expect(hasDeadline).To(BeFalse())
+	})
+})
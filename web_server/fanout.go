@@ -0,0 +1,111 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+// fanOutResult is the result of the operation of one backend in a fan-out
+type fanOutResult struct {
+	backend string
+	value   interface{}
+	err     error
+}
+
+// fanOut runs the operation of every backend concurrently and returns their results sorted by backend name, a backend
+// that is named more than once is called once. The call gets the context of the request bounded by the fan-out timeout,
+// the backends that did not answer within the timeout are canceled and reported with a DeadlineExceededError, so one
+// slow backend does not hold the response of the others.
+func (h *StorageApiHandler) fanOut(ctx context.Context, backendNames []string, operation string, call func(ctx context.Context, name string, backend resources.StorageClient) (interface{}, error)) []fanOutResult {
+	defer h.logger.Trace(logs.DEBUG)()
+
+	// the results are collected by backend name, so they are awaited once per distinct backend
+	backendNames = uniqueBackendNames(backendNames)
+
+	timeout := h.config.FanOut.Timeout
+	fanOutCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		fanOutCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	// buffered so the calls that answer after the timeout do not block
+	resultsChan := make(chan fanOutResult, len(backendNames))
+	for _, name := range backendNames {
+		go func(name string, backend resources.StorageClient) {
+			value, err := call(fanOutCtx, name, backend)
+			resultsChan <- fanOutResult{backend: name, value: value, err: err}
+		}(name, h.backends[name])
+	}
+
+	results := make(map[string]fanOutResult)
+	for len(results) < len(backendNames) {
+		select {
+		case result := <-resultsChan:
+			results[result.backend] = result
+		case <-fanOutCtx.Done():
+			for _, name := range backendNames {
+				if _, ok := results[name]; ok {
+					continue
+				}
+				var err error = &resources.DeadlineExceededError{Backend: name, Operation: operation, Deadline: timeout}
+				if ctx.Err() != nil {
+					// the request itself is done (e.g the client disconnected)
+					err = ctx.Err()
+				}
+				results[name] = fanOutResult{backend: name, err: err}
+			}
+		}
+	}
+
+	sorted := make([]fanOutResult, 0, len(backendNames))
+	for _, result := range results {
+		if result.err != nil {
+			h.logger.Error("backend failed", logs.Args{{"backend", result.backend}, {"operation", operation}, {"err", result.err}})
+		}
+		sorted = append(sorted, result)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].backend < sorted[j].backend })
+	return sorted
+}
+
+func uniqueBackendNames(backendNames []string) []string {
+	unique := make([]string, 0, len(backendNames))
+	seen := make(map[string]bool)
+	for _, name := range backendNames {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// isFanOutFailed reports whether a fan-out with the backend errors fails the request, a request succeeds with
+// partial results only if they are allowed and at least one backend succeeded. backends is the number of results
+// of the fan-out.
+func (h *StorageApiHandler) isFanOutFailed(backends int, backendErrors []resources.BackendError) bool {
+	if len(backendErrors) == 0 {
+		return false
+	}
+	return !h.config.FanOut.AllowPartialResults || len(backendErrors) == backends
+}
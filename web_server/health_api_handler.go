@@ -0,0 +1,129 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IBM/ubiquity/database"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils"
+	"github.com/IBM/ubiquity/utils/logs"
+)
+
+const (
+	healthCheckDatabase  = "database"
+	healthCheckHeartbeat = "heartbeat"
+	healthCheckBackend   = "backend/%s"
+)
+
+type HealthApiHandler struct {
+	logger          logs.Logger
+	backends        map[string]resources.StorageClient
+	heartbeat       utils.Heartbeat
+	heartbeatMaxAge time.Duration // the heartbeat is stale once its lease expired
+}
+
+func NewHealthApiHandler(backends map[string]resources.StorageClient, heartbeat utils.Heartbeat, heartbeatConfig resources.HeartbeatConfig) *HealthApiHandler {
+	heartbeatMaxAge := heartbeatConfig.LeaseDuration
+	if heartbeatMaxAge == 0 {
+		heartbeatMaxAge = utils.HeartbeatLeaseIntervals * utils.HeartbeatInterval * time.Second
+	}
+	return &HealthApiHandler{logger: logs.GetLogger(), backends: backends, heartbeat: heartbeat, heartbeatMaxAge: heartbeatMaxAge}
+}
+
+// Healthz reports that the server is alive, it does not check any dependency
+func (h *HealthApiHandler) Healthz() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		utils.WriteResponse(w, http.StatusOK, &resources.GenericResponse{})
+	}
+}
+
+// Readyz reports whether the server can serve requests: the database is reachable,
+// every backend can reach its storage system and the heartbeat is fresh.
+func (h *HealthApiHandler) Readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer h.logger.Trace(logs.DEBUG)()
+
+		response := resources.ReadinessResponse{Ready: true}
+		response.Checks = append(response.Checks, newHealthCheck(healthCheckDatabase, h.checkDatabase()))
+
+		names := make([]string, 0, len(h.backends))
+		for name := range h.backends {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			response.Checks = append(response.Checks, newHealthCheck(fmt.Sprintf(healthCheckBackend, name), h.checkBackend(req.Context(), h.backends[name])))
+		}
+
+		if h.heartbeat != nil {
+			response.Checks = append(response.Checks, newHealthCheck(healthCheckHeartbeat, h.checkHeartbeat()))
+		}
+
+		for _, check := range response.Checks {
+			if !check.Healthy {
+				h.logger.Warning("readiness check failed", logs.Args{{"check", check.Name}, {"err", check.Err}})
+				response.Ready = false
+			}
+		}
+		if !response.Ready {
+			utils.WriteResponse(w, http.StatusServiceUnavailable, response)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, response)
+	}
+}
+
+func newHealthCheck(name string, err error) resources.HealthCheck {
+	if err != nil {
+		return resources.HealthCheck{Name: name, Healthy: false, Err: err.Error()}
+	}
+	return resources.HealthCheck{Name: name, Healthy: true}
+}
+
+func (h *HealthApiHandler) checkDatabase() error {
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return err
+	}
+	return dbConnection.Close()
+}
+
+func (h *HealthApiHandler) checkBackend(ctx context.Context, backend resources.StorageClient) error {
+	healthChecker, ok := backend.(resources.HealthChecker)
+	if !ok {
+		// nothing to check for this backend
+		return nil
+	}
+	return healthChecker.CheckHealth(ctx)
+}
+
+func (h *HealthApiHandler) checkHeartbeat() error {
+	lastUpdateTimestamp, err := h.heartbeat.GetLastUpdateTimestamp()
+	if err != nil {
+		return err
+	}
+	if age := time.Since(lastUpdateTimestamp); age > h.heartbeatMaxAge {
+		return fmt.Errorf("heartbeat was last updated %s ago", age.Truncate(time.Second))
+	}
+	return nil
+}
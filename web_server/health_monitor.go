@@ -0,0 +1,263 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
+)
+
+// HealthMonitor probes the backends periodically (see resources.HealthChecker) and keeps their state. A backend whose
+// last FailureThreshold probes failed is down and its circuit is open: its requests fail fast with a BackendUnavailableError
+// instead of waiting for the storage system to time out. The circuit is closed by the next probe that succeeds.
+// A backend that cannot be probed is always up.
+type HealthMonitor struct {
+	logger   logs.Logger
+	backends map[string]resources.StorageClient
+	config   resources.HealthMonitorConfig
+	lock     sync.RWMutex
+	states   map[string]*resources.BackendHealth
+}
+
+func NewHealthMonitor(backends map[string]resources.StorageClient, config resources.HealthMonitorConfig) *HealthMonitor {
+	states := make(map[string]*resources.BackendHealth)
+	for name := range backends {
+		states[name] = &resources.BackendHealth{Name: name, State: resources.BackendStateUnknown}
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = resources.DefaultHealthMonitorFailureThreshold
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = resources.DefaultHealthMonitorTimeout * time.Second
+	}
+	return &HealthMonitor{logger: logs.GetLogger(), backends: backends, config: config, states: states}
+}
+
+// Probe probes all the backends concurrently, so one hung backend does not delay the state of the others
+func (m *HealthMonitor) Probe() {
+	defer m.logger.Trace(logs.DEBUG)()
+
+	var wg sync.WaitGroup
+	for name, backend := range m.backends {
+		wg.Add(1)
+		go func(name string, backend resources.StorageClient) {
+			defer wg.Done()
+			m.update(name, m.probe(backend))
+		}(name, backend)
+	}
+	wg.Wait()
+}
+
+func (m *HealthMonitor) probe(backend resources.StorageClient) error {
+	healthChecker, ok := backend.(resources.HealthChecker)
+	if !ok {
+		// nothing to probe for this backend
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.config.Timeout)
+	defer cancel()
+	return healthChecker.CheckHealth(ctx)
+}
+
+// update records the result of a probe of the backend, the state changes are logged
+func (m *HealthMonitor) update(name string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state := m.states[name]
+	state.LastProbe = time.Now()
+	if err == nil {
+		if state.State != resources.BackendStateUp {
+			m.logger.Info("backend is up", logs.Args{{"backend", name}, {"previousState", state.State}})
+		}
+		state.State, state.CircuitOpen, state.ConsecutiveFailures, state.Err = resources.BackendStateUp, false, 0, ""
+		metrics.SetBackendUp(name, true)
+		return
+	}
+
+	state.ConsecutiveFailures++
+	state.Err = err.Error()
+	if state.ConsecutiveFailures < m.config.FailureThreshold {
+		m.logger.Warning("backend health probe failed", logs.Args{{"backend", name}, {"consecutiveFailures", state.ConsecutiveFailures}, {"err", err}})
+		return
+	}
+	if !state.CircuitOpen {
+		m.logger.Error("backend is down, its requests fail until a health probe succeeds", logs.Args{{"backend", name}, {"consecutiveFailures", state.ConsecutiveFailures}, {"err", err}})
+	}
+	state.State, state.CircuitOpen = resources.BackendStateDown, true
+	metrics.SetBackendUp(name, false)
+}
+
+// Allow returns a BackendUnavailableError if the circuit of the backend is open
+func (m *HealthMonitor) Allow(name string) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	state, ok := m.states[name]
+	if !ok || !state.CircuitOpen {
+		return nil
+	}
+	return &resources.BackendUnavailableError{
+		Backend: name,
+		Reason:  fmt.Sprintf("%d consecutive health probes failed, the last one with: %s", state.ConsecutiveFailures, state.Err),
+	}
+}
+
+// Health returns the state of the backends, sorted by name
+func (m *HealthMonitor) Health() []resources.BackendHealth {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	health := make([]resources.BackendHealth, 0, len(m.states))
+	for _, state := range m.states {
+		health = append(health, *state)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Name < health[j].Name })
+	return health
+}
+
+// Run probes the backends now and then every interval until stop is closed
+func (m *HealthMonitor) Run(stop <-chan struct{}) {
+	defer m.logger.Trace(logs.DEBUG)()
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		m.Probe()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// circuitStorageClient fails the operations of a backend fast while the health monitor has its circuit open
+type circuitStorageClient struct {
+	backend string
+	client  resources.StorageClient
+	monitor *HealthMonitor
+}
+
+func newCircuitStorageClient(backend string, client resources.StorageClient, monitor *HealthMonitor) resources.StorageClient {
+	return &circuitStorageClient{backend: backend, client: client, monitor: monitor}
+}
+
+func (c *circuitStorageClient) Activate(activateRequest resources.ActivateRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.Activate(activateRequest)
+}
+
+func (c *circuitStorageClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.CreateVolume(createVolumeRequest)
+}
+
+func (c *circuitStorageClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.RemoveVolume(removeVolumeRequest)
+}
+
+func (c *circuitStorageClient) ListVolumes(listVolumeRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return nil, err
+	}
+	return c.client.ListVolumes(listVolumeRequest)
+}
+
+func (c *circuitStorageClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (resources.Volume, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return resources.Volume{}, err
+	}
+	return c.client.GetVolume(getVolumeRequest)
+}
+
+func (c *circuitStorageClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (map[string]interface{}, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return nil, err
+	}
+	return c.client.GetVolumeConfig(getVolumeConfigRequest)
+}
+
+func (c *circuitStorageClient) Attach(attachRequest resources.AttachRequest) (string, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return "", err
+	}
+	return c.client.Attach(attachRequest)
+}
+
+func (c *circuitStorageClient) Detach(detachRequest resources.DetachRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.Detach(detachRequest)
+}
+
+func (c *circuitStorageClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.ExpandVolume(expandVolumeRequest)
+}
+
+func (c *circuitStorageClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.CreateSnapshot(createSnapshotRequest)
+}
+
+func (c *circuitStorageClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) ([]resources.Snapshot, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return nil, err
+	}
+	return c.client.ListSnapshots(listSnapshotsRequest)
+}
+
+func (c *circuitStorageClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) error {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return err
+	}
+	return c.client.DeleteSnapshot(deleteSnapshotRequest)
+}
+
+func (c *circuitStorageClient) ListServices(listServicesRequest resources.ListServicesRequest) ([]resources.StorageService, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return nil, err
+	}
+	return c.client.ListServices(listServicesRequest)
+}
+
+func (c *circuitStorageClient) Reconcile(reconcileRequest resources.ReconcileRequest) (resources.ReconcileReport, error) {
+	if err := c.monitor.Allow(c.backend); err != nil {
+		return resources.ReconcileReport{}, err
+	}
+	return c.client.Reconcile(reconcileRequest)
+}
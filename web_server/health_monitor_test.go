@@ -0,0 +1,203 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/metrics"
+	"github.com/IBM/ubiquity/web_server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// probedBackend is a backend whose health probes fail with the last error set by setHealth
+type probedBackend struct {
+	*fakes.FakeStorageClient
+	health atomic.Value
+	probes int32
+}
+
+func newProbedBackend() *probedBackend {
+	backend := &probedBackend{FakeStorageClient: new(fakes.FakeStorageClient)}
+	backend.setHealth(nil)
+	return backend
+}
+
+type probeResult struct{ err error }
+
+func (b *probedBackend) setHealth(err error) { b.health.Store(probeResult{err}) }
+
+func (b *probedBackend) CheckHealth(ctx context.Context) error {
+	atomic.AddInt32(&b.probes, 1)
+	return b.health.Load().(probeResult).err
+}
+
+var _ = Describe("HealthMonitor", func() {
+	var (
+		backend1 *probedBackend
+		backend2 *fakes.FakeStorageClient
+		monitor  *web_server.HealthMonitor
+	)
+
+	BeforeEach(func() {
+		backend1 = newProbedBackend()
+		backend2 = new(fakes.FakeStorageClient)
+		monitor = web_server.NewHealthMonitor(
+			map[string]resources.StorageClient{"health-backend1": backend1, "health-backend2": backend2},
+			resources.HealthMonitorConfig{FailureThreshold: 2},
+		)
+	})
+
+	probeFailures := func(failures int) {
+		backend1.setHealth(errors.New("connection refused"))
+		for i := 0; i < failures; i++ {
+			monitor.Probe()
+		}
+	}
+
+	Context(".Probe", func() {
+		It("should report the backends as unknown until they are probed", func() {
+			health := monitor.Health()
+			Expect(health).To(HaveLen(2))
+			Expect(health[0].Name).To(Equal("health-backend1"))
+			Expect(health[0].State).To(Equal(resources.BackendStateUnknown))
+			Expect(health[1].Name).To(Equal("health-backend2"))
+			Expect(health[1].State).To(Equal(resources.BackendStateUnknown))
+		})
+		It("should report a backend that cannot be probed as up", func() {
+			monitor.Probe()
+			health := monitor.Health()
+			Expect(health[1].State).To(Equal(resources.BackendStateUp))
+			Expect(health[1].CircuitOpen).To(BeFalse())
+			Expect(monitor.Allow("health-backend2")).To(Succeed())
+		})
+		It("should keep the circuit closed below the failure threshold", func() {
+			probeFailures(1)
+			health := monitor.Health()
+			Expect(health[0].ConsecutiveFailures).To(Equal(1))
+			Expect(health[0].Err).To(Equal("connection refused"))
+			Expect(health[0].CircuitOpen).To(BeFalse())
+			Expect(monitor.Allow("health-backend1")).To(Succeed())
+		})
+		It("should open the circuit once the failure threshold is reached", func() {
+			probeFailures(2)
+			health := monitor.Health()
+			Expect(health[0].State).To(Equal(resources.BackendStateDown))
+			Expect(health[0].CircuitOpen).To(BeTrue())
+			Expect(health[0].ConsecutiveFailures).To(Equal(2))
+			Expect(testutil.ToFloat64(metrics.BackendUp.WithLabelValues("health-backend1"))).To(Equal(float64(0)))
+
+			err := monitor.Allow("health-backend1")
+			Expect(err).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+			Expect(err.(*resources.BackendUnavailableError).Backend).To(Equal("health-backend1"))
+			Expect(err.Error()).To(ContainSubstring("connection refused"))
+			Expect(monitor.Allow("health-backend2")).To(Succeed())
+		})
+		It("should reset the failures of a backend when a probe succeeds below the threshold", func() {
+			probeFailures(1)
+			backend1.setHealth(nil)
+			monitor.Probe()
+			probeFailures(1)
+			Expect(monitor.Health()[0].CircuitOpen).To(BeFalse())
+			Expect(monitor.Health()[0].ConsecutiveFailures).To(Equal(1))
+		})
+		It("should close the circuit when a probe succeeds after the backend was down", func() {
+			probeFailures(3)
+			Expect(monitor.Allow("health-backend1")).ToNot(Succeed())
+
+			backend1.setHealth(nil)
+			monitor.Probe()
+			health := monitor.Health()
+			Expect(health[0].State).To(Equal(resources.BackendStateUp))
+			Expect(health[0].CircuitOpen).To(BeFalse())
+			Expect(health[0].ConsecutiveFailures).To(Equal(0))
+			Expect(health[0].Err).To(BeEmpty())
+			Expect(testutil.ToFloat64(metrics.BackendUp.WithLabelValues("health-backend1"))).To(Equal(float64(1)))
+			Expect(monitor.Allow("health-backend1")).To(Succeed())
+		})
+		It("should allow the requests of an unknown backend", func() {
+			Expect(monitor.Allow("no-such-backend")).To(Succeed())
+		})
+	})
+
+	Context(".Run", func() {
+		It("should probe the backends every interval until stopped", func() {
+			monitor = web_server.NewHealthMonitor(
+				map[string]resources.StorageClient{"health-backend1": backend1},
+				resources.HealthMonitorConfig{Interval: time.Millisecond},
+			)
+			stop := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				monitor.Run(stop)
+			}()
+
+			Eventually(func() int32 { return atomic.LoadInt32(&backend1.probes) }).Should(BeNumerically(">=", 3))
+			close(stop)
+			Eventually(done).Should(BeClosed())
+			probes := atomic.LoadInt32(&backend1.probes)
+			Consistently(func() int32 { return atomic.LoadInt32(&backend1.probes) }, 20*time.Millisecond).Should(Equal(probes))
+		})
+	})
+
+	Context("circuitStorageClient", func() {
+		var client resources.StorageClient
+
+		BeforeEach(func() {
+			client = web_server.NewCircuitStorageClient("health-backend1", backend1, monitor)
+		})
+
+		It("should pass the requests to the backend while the circuit is closed", func() {
+			backend1.ListVolumesReturns([]resources.Volume{{Name: "volume1"}}, nil)
+			volumes, err := client.ListVolumes(resources.ListVolumesRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(Equal([]resources.Volume{{Name: "volume1"}}))
+			Expect(client.CreateVolume(resources.CreateVolumeRequest{Name: "volume2"})).To(Succeed())
+			Expect(backend1.CreateVolumeCallCount()).To(Equal(1))
+		})
+		It("should fail the requests fast without calling the backend while the circuit is open", func() {
+			probeFailures(2)
+
+			_, err := client.ListVolumes(resources.ListVolumesRequest{})
+			Expect(err).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+			err = client.CreateVolume(resources.CreateVolumeRequest{Name: "volume2"})
+			Expect(err).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+			_, err = client.Attach(resources.AttachRequest{Name: "volume2", Host: "host1"})
+			Expect(err).To(BeAssignableToTypeOf(&resources.BackendUnavailableError{}))
+			Expect(backend1.ListVolumesCallCount()).To(Equal(0))
+			Expect(backend1.CreateVolumeCallCount()).To(Equal(0))
+			Expect(backend1.AttachCallCount()).To(Equal(0))
+		})
+		It("should pass the requests to the backend again once the circuit is closed", func() {
+			probeFailures(2)
+			Expect(client.RemoveVolume(resources.RemoveVolumeRequest{Name: "volume1"})).ToNot(Succeed())
+
+			backend1.setHealth(nil)
+			monitor.Probe()
+			Expect(client.RemoveVolume(resources.RemoveVolumeRequest{Name: "volume1"})).To(Succeed())
+			Expect(backend1.RemoveVolumeCallCount()).To(Equal(1))
+		})
+	})
+})
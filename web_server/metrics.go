@@ -0,0 +1,134 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/metrics"
+	"github.com/gorilla/mux"
+)
+
+// statusRecorder keeps the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// instrumentRoute records the count, the errors and the latency of the requests served by handler
+func instrumentRoute(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		handler(recorder, req)
+
+		route := req.URL.Path
+		if current := mux.CurrentRoute(req); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		metrics.ObserveRequest(route, req.Method, recorder.code, start)
+	}
+}
+
+// instrumentedStorageClient records the count, the errors and the latency of the operations of a backend
+type instrumentedStorageClient struct {
+	backend string
+	client  resources.StorageClient
+}
+
+func newInstrumentedStorageClient(backend string, client resources.StorageClient) resources.StorageClient {
+	return &instrumentedStorageClient{backend: backend, client: client}
+}
+
+func (c *instrumentedStorageClient) Activate(activateRequest resources.ActivateRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "Activate", start, err) }(time.Now())
+	return c.client.Activate(activateRequest)
+}
+
+func (c *instrumentedStorageClient) CreateVolume(createVolumeRequest resources.CreateVolumeRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "CreateVolume", start, err) }(time.Now())
+	return c.client.CreateVolume(createVolumeRequest)
+}
+
+func (c *instrumentedStorageClient) RemoveVolume(removeVolumeRequest resources.RemoveVolumeRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "RemoveVolume", start, err) }(time.Now())
+	return c.client.RemoveVolume(removeVolumeRequest)
+}
+
+func (c *instrumentedStorageClient) ListVolumes(listVolumeRequest resources.ListVolumesRequest) (volumes []resources.Volume, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "ListVolumes", start, err) }(time.Now())
+	return c.client.ListVolumes(listVolumeRequest)
+}
+
+func (c *instrumentedStorageClient) GetVolume(getVolumeRequest resources.GetVolumeRequest) (volume resources.Volume, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "GetVolume", start, err) }(time.Now())
+	return c.client.GetVolume(getVolumeRequest)
+}
+
+func (c *instrumentedStorageClient) GetVolumeConfig(getVolumeConfigRequest resources.GetVolumeConfigRequest) (config map[string]interface{}, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "GetVolumeConfig", start, err) }(time.Now())
+	return c.client.GetVolumeConfig(getVolumeConfigRequest)
+}
+
+func (c *instrumentedStorageClient) Attach(attachRequest resources.AttachRequest) (mountpoint string, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "Attach", start, err) }(time.Now())
+	return c.client.Attach(attachRequest)
+}
+
+func (c *instrumentedStorageClient) Detach(detachRequest resources.DetachRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "Detach", start, err) }(time.Now())
+	return c.client.Detach(detachRequest)
+}
+
+func (c *instrumentedStorageClient) ExpandVolume(expandVolumeRequest resources.ExpandVolumeRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "ExpandVolume", start, err) }(time.Now())
+	return c.client.ExpandVolume(expandVolumeRequest)
+}
+
+func (c *instrumentedStorageClient) CreateSnapshot(createSnapshotRequest resources.CreateSnapshotRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "CreateSnapshot", start, err) }(time.Now())
+	return c.client.CreateSnapshot(createSnapshotRequest)
+}
+
+func (c *instrumentedStorageClient) ListSnapshots(listSnapshotsRequest resources.ListSnapshotsRequest) (snapshots []resources.Snapshot, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "ListSnapshots", start, err) }(time.Now())
+	return c.client.ListSnapshots(listSnapshotsRequest)
+}
+
+func (c *instrumentedStorageClient) DeleteSnapshot(deleteSnapshotRequest resources.DeleteSnapshotRequest) (err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "DeleteSnapshot", start, err) }(time.Now())
+	return c.client.DeleteSnapshot(deleteSnapshotRequest)
+}
+
+func (c *instrumentedStorageClient) ListServices(listServicesRequest resources.ListServicesRequest) (services []resources.StorageService, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "ListServices", start, err) }(time.Now())
+	return c.client.ListServices(listServicesRequest)
+}
+
+func (c *instrumentedStorageClient) Reconcile(reconcileRequest resources.ReconcileRequest) (report resources.ReconcileReport, err error) {
+	defer func(start time.Time) { metrics.ObserveBackendRequest(c.backend, "Reconcile", start, err) }(time.Now())
+	return c.client.Reconcile(reconcileRequest)
+}
@@ -0,0 +1,85 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package web_server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
+)
+
+// Reconciler reports the orphans of the backends, the volumes in the Ubiquity DB whose storage is gone
+// and the storage without volumes (e.g after a partial failure of CreateVolume or RemoveVolume). It does not fix them.
+type Reconciler struct {
+	logger   logs.Logger
+	backends map[string]resources.StorageClient
+}
+
+func NewReconciler(backends map[string]resources.StorageClient) *Reconciler {
+	return &Reconciler{logger: logs.GetLogger(), backends: backends}
+}
+
+// Reconcile reconciles all the backends, sorted by name. A backend that fails is reported with its error,
+// so one unreachable backend does not hide the orphans of the others.
+func (r *Reconciler) Reconcile(reconcileRequest resources.ReconcileRequest) []resources.ReconcileReport {
+	defer r.logger.Trace(logs.DEBUG)()
+
+	var backendNames []string
+	for name := range r.backends {
+		backendNames = append(backendNames, name)
+	}
+	sort.Strings(backendNames)
+
+	reports := make([]resources.ReconcileReport, 0, len(backendNames))
+	for _, name := range backendNames {
+		backendRequest := reconcileRequest
+		backendRequest.Backend = name
+		report, err := r.backends[name].Reconcile(backendRequest)
+		if err != nil {
+			r.logger.Error("Error reconciling backend", logs.Args{{"backend", name}, {"err", err}})
+			report = resources.ReconcileReport{Backend: name, Err: err.Error()}
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// Run reconciles the backends every interval until stop is closed, the orphans are logged and exported as metrics
+func (r *Reconciler) Run(interval time.Duration, stop <-chan struct{}) {
+	defer r.logger.Trace(logs.DEBUG)()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for _, report := range r.Reconcile(resources.ReconcileRequest{}) {
+				if report.Err != "" {
+					continue
+				}
+				metrics.SetOrphans(report.Backend, len(report.DanglingVolumes), len(report.UnmanagedStorage))
+				if len(report.DanglingVolumes) > 0 || len(report.UnmanagedStorage) > 0 {
+					r.logger.Warning("backend has orphans", logs.Args{{"backend", report.Backend}, {"danglingVolumes", report.DanglingVolumes}, {"unmanagedStorage", report.UnmanagedStorage}})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/utils/metrics"
+	"github.com/IBM/ubiquity/web_server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		backend1   *fakes.FakeStorageClient
+		backend2   *fakes.FakeStorageClient
+		reconciler *web_server.Reconciler
+		dangling   resources.Orphan
+		unmanaged  resources.Orphan
+	)
+
+	BeforeEach(func() {
+		backend1 = new(fakes.FakeStorageClient)
+		backend2 = new(fakes.FakeStorageClient)
+		reconciler = web_server.NewReconciler(map[string]resources.StorageClient{"reconcile-backend2": backend2, "reconcile-backend1": backend1})
+		dangling = resources.Orphan{Name: "vol1", StorageId: "wwn1"}
+		unmanaged = resources.Orphan{Name: "lun2", StorageId: "wwn2"}
+	})
+
+	Context(".Reconcile", func() {
+		It("should report the orphans of every backend, sorted by backend name", func() {
+			backend1.ReconcileReturns(resources.ReconcileReport{Backend: "reconcile-backend1", DanglingVolumes: []resources.Orphan{dangling}}, nil)
+			backend2.ReconcileReturns(resources.ReconcileReport{Backend: "reconcile-backend2", UnmanagedStorage: []resources.Orphan{unmanaged}}, nil)
+
+			reports := reconciler.Reconcile(resources.ReconcileRequest{})
+			Expect(reports).To(Equal([]resources.ReconcileReport{
+				{Backend: "reconcile-backend1", DanglingVolumes: []resources.Orphan{dangling}},
+				{Backend: "reconcile-backend2", UnmanagedStorage: []resources.Orphan{unmanaged}},
+			}))
+			Expect(backend1.ReconcileArgsForCall(0).Backend).To(Equal("reconcile-backend1"))
+			Expect(backend2.ReconcileArgsForCall(0).Backend).To(Equal("reconcile-backend2"))
+		})
+
+		It("should report the error of a failed backend without hiding the orphans of the others", func() {
+			backend1.ReconcileReturns(resources.ReconcileReport{}, errors.New("fake error"))
+			backend2.ReconcileReturns(resources.ReconcileReport{Backend: "reconcile-backend2", UnmanagedStorage: []resources.Orphan{unmanaged}}, nil)
+
+			reports := reconciler.Reconcile(resources.ReconcileRequest{})
+			Expect(reports).To(Equal([]resources.ReconcileReport{
+				{Backend: "reconcile-backend1", Err: "fake error"},
+				{Backend: "reconcile-backend2", UnmanagedStorage: []resources.Orphan{unmanaged}},
+			}))
+		})
+	})
+
+	Context(".Run", func() {
+		It("should export the orphans of the backends every interval until stopped", func() {
+			backend1.ReconcileReturns(resources.ReconcileReport{Backend: "reconcile-backend1", DanglingVolumes: []resources.Orphan{dangling}}, nil)
+			backend2.ReconcileReturns(resources.ReconcileReport{}, errors.New("fake error"))
+
+			stop := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				reconciler.Run(time.Millisecond, stop)
+				close(done)
+			}()
+			Eventually(backend1.ReconcileCallCount).Should(BeNumerically(">=", 2))
+			close(stop)
+			Eventually(done).Should(BeClosed())
+
+			Expect(testutil.ToFloat64(metrics.Orphans.WithLabelValues("reconcile-backend1", metrics.OrphanKindDangling))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(metrics.Orphans.WithLabelValues("reconcile-backend1", metrics.OrphanKindUnmanaged))).To(Equal(float64(0)))
+
+			calls := backend1.ReconcileCallCount()
+			time.Sleep(10 * time.Millisecond)
+			Expect(backend1.ReconcileCallCount()).To(Equal(calls))
+		})
+	})
+})
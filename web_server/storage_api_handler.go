@@ -17,6 +17,7 @@
 package web_server
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/IBM/ubiquity/database"
@@ -28,44 +29,76 @@ import (
 )
 
 type StorageApiHandler struct {
-	logger   logs.Logger
-	backends map[string]resources.StorageClient
-	config   resources.UbiquityServerConfig
-	locker   utils.Locker
+	logger        logs.Logger
+	backends      map[string]resources.StorageClient
+	config        resources.UbiquityServerConfig
+	locker        utils.Locker
+	reconciler    *Reconciler
+	healthMonitor *HealthMonitor
 }
 
+// volumeLockNamespace scopes the names of the volume locks, which are shared by all the servers with a database locker
+const volumeLockNamespace = "volume"
+
 func NewStorageApiHandler(backends map[string]resources.StorageClient, config resources.UbiquityServerConfig) *StorageApiHandler {
-	return &StorageApiHandler{logger: logs.GetLogger(), backends: backends, config: config, locker: utils.NewLocker()}
+	healthMonitor := NewHealthMonitor(backends, config.HealthMonitor)
+	instrumentedBackends := make(map[string]resources.StorageClient)
+	for name, backend := range backends {
+		instrumentedBackends[name] = newInstrumentedStorageClient(name, newCircuitStorageClient(name, newDeadlineStorageClient(name, backend, config.Deadlines), healthMonitor))
+	}
+	return &StorageApiHandler{
+		logger:        logs.GetLogger(),
+		backends:      instrumentedBackends,
+		config:        config,
+		locker:        database.NewLocker(volumeLockNamespace, config.Locker),
+		reconciler:    NewReconciler(instrumentedBackends),
+		healthMonitor: healthMonitor,
+	}
 }
 
 func (h *StorageApiHandler) Activate() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		activateRequest := resources.ActivateRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &activateRequest)
+		activateRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, activateRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 
 		defer h.logger.Trace(logs.DEBUG)()
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
-		var errors string
 		h.logger.Info("Activating backends")
-		errors = ""
-		for name, backend := range h.backends {
-			err := backend.Activate(activateRequest)
-			if err != nil {
-				h.logger.Error("Error activating", logs.Args{{"name", name}, {"err", err}})
-				errors = fmt.Sprintf("%s,%s", errors, name)
+		backendNames := make([]string, 0, len(h.backends))
+		for name := range h.backends {
+			backendNames = append(backendNames, name)
+		}
+		results := h.fanOut(activateRequest.Context.GetCtx(), backendNames, "Activate", func(ctx context.Context, name string, backend resources.StorageClient) (interface{}, error) {
+			backendRequest := activateRequest
+			backendRequest.Context.Ctx = ctx
+			return nil, backend.Activate(backendRequest)
+		})
+
+		var backendErrors []resources.BackendError
+		for _, result := range results {
+			if result.err != nil {
+				backendErrors = append(backendErrors, resources.NewBackendError(result.backend, result.err))
 			}
 		}
-		if errors != "" {
-			utils.WriteResponse(w, http.StatusInternalServerError, &resources.GenericResponse{Err: errors})
+		if h.isFanOutFailed(len(results), backendErrors) {
+			// the failed backends are listed in Err as before, and with their errors in Details
+			errors := ""
+			details := make(map[string]string)
+			for _, backendError := range backendErrors {
+				errors = fmt.Sprintf("%s,%s", errors, backendError.Backend)
+				details[backendError.Backend] = backendError.Err
+			}
+			utils.WriteResponse(w, http.StatusInternalServerError, &resources.GenericResponse{Err: errors, Code: resources.ErrorCodeInternal, Details: details})
 			return
 		}
-		utils.WriteResponse(w, http.StatusOK, nil)
+		utils.WriteResponse(w, http.StatusOK, &resources.ActivateResponse{BackendErrors: backendErrors})
 	}
 }
 
@@ -73,6 +106,7 @@ func (h *StorageApiHandler) CreateVolume() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		createVolumeRequest := resources.CreateVolumeRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &createVolumeRequest)
+		createVolumeRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, createVolumeRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
@@ -80,7 +114,7 @@ func (h *StorageApiHandler) CreateVolume() http.HandlerFunc {
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
@@ -90,23 +124,72 @@ func (h *StorageApiHandler) CreateVolume() http.HandlerFunc {
 		backend, ok := h.backends[createVolumeRequest.Backend]
 		if !ok {
 			h.logger.Error("error-backend-not-found", logs.Args{{"backend", createVolumeRequest.Backend}})
-			utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: "backend-not-found"})
+			utils.WriteErrorResponse(w, &resources.BackendNotFoundError{Backend: createVolumeRequest.Backend})
 			return
 		}
 
-		h.locker.ReadLock(createVolumeRequest.Name) // will block if another caller is already in process of creating volume with same name
+		// will block if another caller is already in process of creating volume with same name
+		if err = h.locker.TryReadLock(req.Context(), createVolumeRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		if exists := h.getVolumeExists(createVolumeRequest.Name); exists == true {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: fmt.Sprintf("Volume `%s` already exists", createVolumeRequest.Name)})
+			utils.WriteErrorResponse(w, &resources.VolAlreadyExistsError{VolName: createVolumeRequest.Name})
 			h.locker.ReadUnlock(createVolumeRequest.Name)
 			return
 		}
 		h.locker.ReadUnlock(createVolumeRequest.Name)
 
-		h.locker.WriteLock(createVolumeRequest.Name) // will ensure no other caller can create volume with same name concurrently
+		sourceVolume, _, err := utils.GetCloneSource(createVolumeRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if sourceVolume != "" {
+			// a clone is created by the backend of its source volume
+			sourceBackend := h.getBackendName(sourceVolume)
+			if sourceBackend == "" {
+				err = &resources.VolumeNotFoundError{VolName: sourceVolume}
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+			if sourceBackend != createVolumeRequest.Backend {
+				err = &resources.CloneAcrossBackendsError{
+					VolName:       createVolumeRequest.Name,
+					Backend:       createVolumeRequest.Backend,
+					SourceVolName: sourceVolume,
+					SourceBackend: sourceBackend,
+				}
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+		}
+
+		// will ensure no other caller can create volume with same name concurrently
+		if err = h.locker.TryWriteLock(req.Context(), createVolumeRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(createVolumeRequest.Name)
+		if sourceVolume != "" {
+			// will ensure the source volume is not removed while it is being cloned
+			if err = h.locker.TryReadLock(req.Context(), sourceVolume); err != nil {
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+			defer h.locker.ReadUnlock(sourceVolume)
+		}
+		volume, recorded, err := h.insertCreatingVolume(createVolumeRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		err = backend.CreateVolume(createVolumeRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			if recorded {
+				h.deleteCreatingVolume(&volume)
+			}
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 		utils.WriteResponse(w, http.StatusOK, nil)
@@ -117,13 +200,14 @@ func (h *StorageApiHandler) RemoveVolume() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		removeVolumeRequest := resources.RemoveVolumeRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &removeVolumeRequest)
+		removeVolumeRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, removeVolumeRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
@@ -136,16 +220,54 @@ func (h *StorageApiHandler) RemoveVolume() http.HandlerFunc {
 					return
 				default:
 					h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", removeVolumeRequest.Name}})
-					utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: err.Error()})
+					utils.WriteErrorResponse(w, err)
 					return
 			}
 		}
 
-		h.locker.WriteLock(removeVolumeRequest.Name)
+		if err = h.locker.TryWriteLock(req.Context(), removeVolumeRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(removeVolumeRequest.Name)
+		volume, recorded, err := h.getVolumeRecord(removeVolumeRequest.Name)
+		if err != nil {
+			if _, ok := err.(*resources.VolumeNotFoundError); ok {
+				h.logger.Warning("Idempotent issue encountered : volume does not exist in remove command.", logs.Args{{"volume", removeVolumeRequest.Name}})
+				utils.WriteResponse(w, http.StatusOK, nil)
+				return
+			}
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if recorded && volume.Status == resources.VolumeStatusCreating {
+			// the create was interrupted before the backend recorded the volume, so only the record is removed
+			h.logger.Warning("removing the record of an interrupted create, the storage volume (if any) is reported by the reconciler", logs.Args{{"volume", volume.Name}})
+			if err = h.deleteCreatingVolume(&volume); err != nil {
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+			utils.WriteResponse(w, http.StatusOK, nil)
+			return
+		}
+		previousStatus := volume.Status
+		if recorded {
+			if err = h.setVolumeStatus(&volume, resources.VolumeStatusDeleting); err != nil {
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+		}
 		err = backend.RemoveVolume(removeVolumeRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			if recorded {
+				// a rejected removal (e.g the volume has snapshots) did not change the volume
+				status := resources.VolumeStatusFailed
+				if codedError, ok := err.(resources.CodedError); ok && codedError.HttpStatus() < http.StatusInternalServerError {
+					status = previousStatus
+				}
+				h.setVolumeStatus(&volume, status)
+			}
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 		utils.WriteResponse(w, http.StatusOK, nil)
@@ -156,30 +278,49 @@ func (h *StorageApiHandler) AttachVolume() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		attachRequest := resources.AttachRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &attachRequest)
+		attachRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, attachRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
 		backend, err := h.getBackend(attachRequest.Name)
 		if err != nil {
 			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", attachRequest.Name}})
-			utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
-		h.locker.WriteLock(attachRequest.Name)
+		if err = h.locker.TryWriteLock(req.Context(), attachRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(attachRequest.Name)
+		volume, recorded, err := h.getVolumeRecord(attachRequest.Name)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if recorded && !resources.IsVolumeStatusTransitionValid(volume.Status, resources.VolumeStatusAttached) {
+			utils.WriteErrorResponse(w, &resources.InvalidVolumeStatusError{VolName: volume.Name, Status: volume.Status, To: resources.VolumeStatusAttached})
+			return
+		}
 		mountpoint, err := backend.Attach(attachRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
+		if recorded {
+			if err = h.setVolumeStatus(&volume, resources.VolumeStatusAttached); err != nil {
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+		}
 		attachResponse := resources.MountResponse{Mountpoint: mountpoint}
 
 		utils.WriteResponse(w, http.StatusOK, attachResponse)
@@ -190,27 +331,183 @@ func (h *StorageApiHandler) DetachVolume() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		detachRequest := resources.DetachRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &detachRequest)
+		detachRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, detachRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
 		backend, err := h.getBackend(detachRequest.Name)
 		if err != nil {
 			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", detachRequest.Name}})
-			utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
-		h.locker.WriteLock(detachRequest.Name)
+		if err = h.locker.TryWriteLock(req.Context(), detachRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(detachRequest.Name)
+		volume, recorded, err := h.getVolumeRecord(detachRequest.Name)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if recorded && !resources.IsVolumeStatusTransitionValid(volume.Status, resources.VolumeStatusAvailable) {
+			utils.WriteErrorResponse(w, &resources.InvalidVolumeStatusError{VolName: volume.Name, Status: volume.Status, To: resources.VolumeStatusAvailable})
+			return
+		}
 		err = backend.Detach(detachRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if recorded {
+			if err = h.setVolumeStatus(&volume, resources.VolumeStatusAvailable); err != nil {
+				utils.WriteErrorResponse(w, err)
+				return
+			}
+		}
+		utils.WriteResponse(w, http.StatusOK, nil)
+	}
+}
+
+func (h *StorageApiHandler) ExpandVolume() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		expandVolumeRequest := resources.ExpandVolumeRequest{}
+		err := utils.UnmarshalDataFromRequest(req, &expandVolumeRequest)
+		expandVolumeRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, expandVolumeRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		backend, err := h.getBackend(expandVolumeRequest.Name)
+		if err != nil {
+			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", expandVolumeRequest.Name}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+
+		if err = h.locker.TryWriteLock(req.Context(), expandVolumeRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		defer h.locker.WriteUnlock(expandVolumeRequest.Name)
+		err = backend.ExpandVolume(expandVolumeRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, nil)
+	}
+}
+
+func (h *StorageApiHandler) CreateSnapshot() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		createSnapshotRequest := resources.CreateSnapshotRequest{}
+		err := utils.UnmarshalDataFromRequest(req, &createSnapshotRequest)
+		createSnapshotRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, createSnapshotRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		backend, err := h.getBackend(createSnapshotRequest.VolumeName)
+		if err != nil {
+			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", createSnapshotRequest.VolumeName}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+
+		if err = h.locker.TryWriteLock(req.Context(), createSnapshotRequest.VolumeName); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		defer h.locker.WriteUnlock(createSnapshotRequest.VolumeName)
+		err = backend.CreateSnapshot(createSnapshotRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, nil)
+	}
+}
+
+func (h *StorageApiHandler) ListSnapshots() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		listSnapshotsRequest := resources.ListSnapshotsRequest{}
+		err := utils.UnmarshalDataFromRequest(req, &listSnapshotsRequest)
+		listSnapshotsRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, listSnapshotsRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		backend, err := h.getBackend(listSnapshotsRequest.VolumeName)
+		if err != nil {
+			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", listSnapshotsRequest.VolumeName}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+
+		snapshots, err := backend.ListSnapshots(listSnapshotsRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		listSnapshotsResponse := resources.ListSnapshotsResponse{Snapshots: snapshots}
+		h.logger.Debug("", logs.Args{{"listSnapshotsResponse", listSnapshotsResponse}})
+		utils.WriteResponse(w, http.StatusOK, listSnapshotsResponse)
+	}
+}
+
+func (h *StorageApiHandler) DeleteSnapshot() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		deleteSnapshotRequest := resources.DeleteSnapshotRequest{}
+		err := utils.UnmarshalDataFromRequest(req, &deleteSnapshotRequest)
+		deleteSnapshotRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, deleteSnapshotRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		backend, err := h.getBackend(deleteSnapshotRequest.VolumeName)
+		if err != nil {
+			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", deleteSnapshotRequest.VolumeName}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+
+		if err = h.locker.TryWriteLock(req.Context(), deleteSnapshotRequest.VolumeName); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		defer h.locker.WriteUnlock(deleteSnapshotRequest.VolumeName)
+		err = backend.DeleteSnapshot(deleteSnapshotRequest)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 		utils.WriteResponse(w, http.StatusOK, nil)
@@ -221,29 +518,33 @@ func (h *StorageApiHandler) GetVolumeConfig() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		getVolumeConfigRequest := resources.GetVolumeConfigRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &getVolumeConfigRequest)
+		getVolumeConfigRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, getVolumeConfigRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
 		backend, err := h.getBackend(getVolumeConfigRequest.Name)
 		if err != nil {
 			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", getVolumeConfigRequest.Name}})
-			utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
-		h.locker.WriteLock(getVolumeConfigRequest.Name)
+		if err = h.locker.TryWriteLock(req.Context(), getVolumeConfigRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(getVolumeConfigRequest.Name)
 
 		config, err := backend.GetVolumeConfig(getVolumeConfigRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GetConfigResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
@@ -257,29 +558,44 @@ func (h *StorageApiHandler) GetVolume() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		getVolumeRequest := resources.GetVolumeRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &getVolumeRequest)
+		getVolumeRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, getVolumeRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
 		backend, err := h.getBackend(getVolumeRequest.Name)
 		if err != nil {
 			h.logger.Error("error-backend-not-found-for-volume", logs.Args{{"name", getVolumeRequest.Name}})
-			utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
-		h.locker.WriteLock(getVolumeRequest.Name)
+		if err = h.locker.TryWriteLock(req.Context(), getVolumeRequest.Name); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 		defer h.locker.WriteUnlock(getVolumeRequest.Name)
 
+		// the backend does not know a volume before it creates it
+		volume, recorded, err := h.getVolumeRecord(getVolumeRequest.Name)
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		if recorded && volume.Status == resources.VolumeStatusCreating {
+			utils.WriteResponse(w, http.StatusOK, resources.GetResponse{Volume: volume})
+			return
+		}
+
 		volumeInfo, err := backend.GetVolume(getVolumeRequest)
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GetResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, err)
 			return
 		}
 
@@ -293,58 +609,251 @@ func (h *StorageApiHandler) ListVolumes() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		listVolumesRequest := resources.ListVolumesRequest{}
 		err := utils.UnmarshalDataFromRequest(req, &listVolumesRequest)
+		listVolumesRequest.Context.Ctx = req.Context()
 		go_id := logs.GetGoID()
 		logs.GoIdToRequestIdMap.Store(go_id, listVolumesRequest.Context)
 		defer logs.GetDeleteFromMapFunc(go_id)
 		defer h.logger.Trace(logs.DEBUG)()
 
 		if err != nil {
-			utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
 			return
 		}
 
-		var volumes []resources.Volume
-		if len(listVolumesRequest.Backends) != 0 {
+		if err = utils.ValidateListVolumesRequest(listVolumesRequest); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
 
-			for _, b := range listVolumesRequest.Backends {
-				backend, ok := h.backends[b]
-				if !ok {
-					h.logger.Error("error-backend-not-found", logs.Args{{"backend", b}})
-					utils.WriteResponse(w, http.StatusNotFound, &resources.GenericResponse{Err: "backend-not-found"})
-					return
-				}
-				volumesForBackend, err := backend.ListVolumes(listVolumesRequest)
-				if err != nil {
-					h.logger.Error("Error listing volume", logs.Args{{"err", err}})
-					utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
-					return
-				}
-				volumes = append(volumes, volumesForBackend...)
+		backendNames := listVolumesRequest.Backends
+		if len(backendNames) == 0 {
+			for name := range h.backends {
+				backendNames = append(backendNames, name)
 			}
-			listResponse := resources.ListResponse{Volumes: volumes}
-			h.logger.Debug("", logs.Args{{"listResponse", listResponse}})
-			utils.WriteResponse(w, http.StatusOK, listResponse)
-			return
+		}
 
+		// every backend returns its first Limit+1 volumes after the cursor, so the merged page tells whether there is a next page
+		backendRequest := listVolumesRequest
+		if listVolumesRequest.Limit > 0 {
+			backendRequest.Limit = listVolumesRequest.Limit + 1
 		}
 
-		for _, backend := range h.backends {
-			volumesForBackend, err := backend.ListVolumes(listVolumesRequest)
-			if err != nil {
-				h.logger.Error("Error listing volume", logs.Args{{"err", err}})
-				utils.WriteResponse(w, 409, &resources.GenericResponse{Err: err.Error()})
+		for _, b := range backendNames {
+			if _, ok := h.backends[b]; !ok {
+				h.logger.Error("error-backend-not-found", logs.Args{{"backend", b}})
+				utils.WriteErrorResponse(w, &resources.BackendNotFoundError{Backend: b})
 				return
 			}
-			volumes = append(volumes, volumesForBackend...)
+		}
+		results := h.fanOut(listVolumesRequest.Context.GetCtx(), backendNames, "ListVolumes", func(ctx context.Context, name string, backend resources.StorageClient) (interface{}, error) {
+			backendRequest := backendRequest
+			backendRequest.Context.Ctx = ctx
+			return backend.ListVolumes(backendRequest)
+		})
 
+		var volumes []resources.Volume
+		var backendErrors []resources.BackendError
+		var firstErr error
+		for _, result := range results {
+			if result.err != nil {
+				backendErrors = append(backendErrors, resources.NewBackendError(result.backend, result.err))
+				if firstErr == nil {
+					firstErr = result.err
+				}
+				continue
+			}
+			volumes = append(volumes, result.value.([]resources.Volume)...)
+		}
+		if h.isFanOutFailed(len(results), backendErrors) {
+			utils.WriteErrorResponse(w, firstErr)
+			return
 		}
 
-		listResponse := resources.ListResponse{Volumes: volumes}
+		// the page of partial results has only the volumes of the backends that succeeded, and no next cursor since
+		// the next pages would skip the volumes of the failed backends that sort before it
+		volumes, nextCursor := utils.PageVolumes(volumes, listVolumesRequest)
+		if len(backendErrors) > 0 {
+			nextCursor = ""
+		}
+		listResponse := resources.ListResponse{Volumes: volumes, NextCursor: nextCursor, BackendErrors: backendErrors}
 		h.logger.Debug("", logs.Args{{"listResponse", listResponse}})
 		utils.WriteResponse(w, http.StatusOK, listResponse)
 	}
 }
 
+// ListBackends returns the capacity of every backend, summed over its storage services
+func (h *StorageApiHandler) ListBackends() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		listServicesRequest := resources.ListServicesRequest{}
+		var err error
+		if req.ContentLength != 0 {
+			err = utils.UnmarshalDataFromRequest(req, &listServicesRequest)
+		}
+		listServicesRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, listServicesRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		backendNames := make([]string, 0, len(h.backends))
+		for name := range h.backends {
+			backendNames = append(backendNames, name)
+		}
+		results := h.fanOut(listServicesRequest.Context.GetCtx(), backendNames, "ListServices", func(ctx context.Context, name string, backend resources.StorageClient) (interface{}, error) {
+			backendRequest := listServicesRequest
+			backendRequest.Backend = name
+			backendRequest.Context.Ctx = ctx
+			return backend.ListServices(backendRequest)
+		})
+
+		// a failed backend is reported with its error and does not fail the listing of the others, if partial results are allowed
+		backends := make([]resources.BackendCapacity, 0, len(backendNames))
+		var backendErrors []resources.BackendError
+		var firstErr error
+		for _, result := range results {
+			if result.err != nil {
+				backendErrors = append(backendErrors, resources.NewBackendError(result.backend, result.err))
+				if firstErr == nil {
+					firstErr = result.err
+				}
+				continue
+			}
+			services := result.value.([]resources.StorageService)
+			backend := resources.BackendCapacity{Name: result.backend, Services: len(services)}
+			for _, service := range services {
+				backend.TotalCapacity += service.TotalCapacity
+				backend.UsedCapacity += service.UsedCapacity
+				backend.FreeCapacity += service.FreeCapacity
+				backend.ProvisionedCapacity += service.ProvisionedCapacity
+			}
+			backends = append(backends, backend)
+		}
+		if h.isFanOutFailed(len(results), backendErrors) {
+			utils.WriteErrorResponse(w, firstErr)
+			return
+		}
+
+		listBackendsResponse := resources.ListBackendsResponse{Backends: backends, BackendErrors: backendErrors}
+		h.logger.Debug("", logs.Args{{"listBackendsResponse", listBackendsResponse}})
+		utils.WriteResponse(w, http.StatusOK, listBackendsResponse)
+	}
+}
+
+// BackendsHealth returns the state of the backends as seen by the health monitor
+func (h *StorageApiHandler) BackendsHealth() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer h.logger.Trace(logs.DEBUG)()
+
+		backendsHealthResponse := resources.BackendsHealthResponse{Backends: h.healthMonitor.Health()}
+		h.logger.Debug("", logs.Args{{"backendsHealthResponse", backendsHealthResponse}})
+		utils.WriteResponse(w, http.StatusOK, backendsHealthResponse)
+	}
+}
+
+// ListServices returns the storage services of the backend with their capacity
+func (h *StorageApiHandler) ListServices() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		listServicesRequest := resources.ListServicesRequest{}
+		var err error
+		if req.ContentLength != 0 {
+			err = utils.UnmarshalDataFromRequest(req, &listServicesRequest)
+		}
+		listServicesRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, listServicesRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		listServicesRequest.Backend = utils.ExtractVarsFromRequest(req, "backend")
+		backend, ok := h.backends[listServicesRequest.Backend]
+		if !ok {
+			h.logger.Error("error-backend-not-found", logs.Args{{"backend", listServicesRequest.Backend}})
+			utils.WriteErrorResponse(w, &resources.BackendNotFoundError{Backend: listServicesRequest.Backend})
+			return
+		}
+
+		services, err := backend.ListServices(listServicesRequest)
+		if err != nil {
+			h.logger.Error("Error listing services", logs.Args{{"backend", listServicesRequest.Backend}, {"err", err}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		listServicesResponse := resources.ListServicesResponse{Services: services}
+		h.logger.Debug("", logs.Args{{"listServicesResponse", listServicesResponse}})
+		utils.WriteResponse(w, http.StatusOK, listServicesResponse)
+	}
+}
+
+// Reconcile reports the orphans of all the backends, a backend that fails is reported with its error
+func (h *StorageApiHandler) Reconcile() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		reconcileRequest := resources.ReconcileRequest{}
+		var err error
+		if req.ContentLength != 0 {
+			err = utils.UnmarshalDataFromRequest(req, &reconcileRequest)
+		}
+		reconcileRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, reconcileRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		reconcileResponse := resources.ReconcileResponse{Reports: h.reconciler.Reconcile(reconcileRequest)}
+		h.logger.Debug("", logs.Args{{"reconcileResponse", reconcileResponse}})
+		utils.WriteResponse(w, http.StatusOK, reconcileResponse)
+	}
+}
+
+// ReconcileBackend reports the orphans of the backend
+func (h *StorageApiHandler) ReconcileBackend() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		reconcileRequest := resources.ReconcileRequest{}
+		var err error
+		if req.ContentLength != 0 {
+			err = utils.UnmarshalDataFromRequest(req, &reconcileRequest)
+		}
+		reconcileRequest.Context.Ctx = req.Context()
+		go_id := logs.GetGoID()
+		logs.GoIdToRequestIdMap.Store(go_id, reconcileRequest.Context)
+		defer logs.GetDeleteFromMapFunc(go_id)
+		defer h.logger.Trace(logs.DEBUG)()
+		if err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		reconcileRequest.Backend = utils.ExtractVarsFromRequest(req, "backend")
+		backend, ok := h.backends[reconcileRequest.Backend]
+		if !ok {
+			h.logger.Error("error-backend-not-found", logs.Args{{"backend", reconcileRequest.Backend}})
+			utils.WriteErrorResponse(w, &resources.BackendNotFoundError{Backend: reconcileRequest.Backend})
+			return
+		}
+
+		report, err := backend.Reconcile(reconcileRequest)
+		if err != nil {
+			h.logger.Error("Error reconciling backend", logs.Args{{"backend", reconcileRequest.Backend}, {"err", err}})
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		reconcileResponse := resources.ReconcileResponse{Reports: []resources.ReconcileReport{report}}
+		h.logger.Debug("", logs.Args{{"reconcileResponse", reconcileResponse}})
+		utils.WriteResponse(w, http.StatusOK, reconcileResponse)
+	}
+}
+
 func (h *StorageApiHandler) getBackend(name string) (resources.StorageClient, error) {
 	defer h.logger.Trace(logs.DEBUG)()
 	var backendName string
@@ -358,7 +867,7 @@ func (h *StorageApiHandler) getBackend(name string) (resources.StorageClient, er
 	// fetch client by name
 	backend, exists := h.backends[backendName]
 	if !exists {
-		err := fmt.Errorf("cannot find backend %s", backend)
+		err := &resources.BackendNotFoundError{Backend: backendName}
 		return nil, h.logger.ErrorRet(err, "failed")
 	}
 	return backend, nil
@@ -414,3 +923,135 @@ func (h *StorageApiHandler) getVolumeExists(volumeName string) bool {
 	h.logger.Debug("VolumeExists", logs.Args{{"volumeName", volumeName}, {"exists", exists}})
 	return exists
 }
+
+// getVolumeRecord returns the DB record of the volume with its status.
+// The Ubiquity DB volume is not recorded in the DB, so it is not recorded and its status is not tracked by the handler.
+func (h *StorageApiHandler) getVolumeRecord(name string) (resources.Volume, bool, error) {
+	defer h.logger.Trace(logs.DEBUG)()
+
+	if database.IsDatabaseVolume(name) {
+		return resources.Volume{}, false, nil
+	}
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return resources.Volume{}, false, h.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	volume, err := model.GetVolumeByName(dbConnection.GetDb(), name)
+	if err != nil {
+		return resources.Volume{}, false, h.logger.ErrorRet(&resources.VolumeNotFoundError{VolName: name}, "model.GetVolumeByName failed", logs.Args{{"error", err}})
+	}
+	return volume, true, nil
+}
+
+// setVolumeStatus moves the volume to the status, if its status allows it
+func (h *StorageApiHandler) setVolumeStatus(volume *resources.Volume, status string) error {
+	defer h.logger.Trace(logs.DEBUG)()
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return h.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	previousStatus := volume.Status
+	if err := model.UpdateVolumeStatus(dbConnection.GetDb(), volume, status); err != nil {
+		return h.logger.ErrorRet(err, "model.UpdateVolumeStatus failed", logs.Args{{"volume", volume.Name}, {"status", status}})
+	}
+	h.logger.Info("volume status updated", logs.Args{{"volume", volume.Name}, {"from", previousStatus}, {"to", status}})
+	return nil
+}
+
+// insertCreatingVolume records the volume as creating until its backend records it, so an interrupted create can be told from a created volume
+func (h *StorageApiHandler) insertCreatingVolume(createVolumeRequest resources.CreateVolumeRequest) (resources.Volume, bool, error) {
+	defer h.logger.Trace(logs.DEBUG)()
+
+	if database.IsDatabaseVolume(createVolumeRequest.Name) {
+		return resources.Volume{}, false, nil
+	}
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return resources.Volume{}, false, h.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	volume := resources.Volume{Name: createVolumeRequest.Name, Backend: createVolumeRequest.Backend}
+	if err := model.InsertCreatingVolume(dbConnection.GetDb(), &volume); err != nil {
+		return resources.Volume{}, false, h.logger.ErrorRet(err, "model.InsertCreatingVolume failed", logs.Args{{"volume", volume.Name}})
+	}
+	return volume, true, nil
+}
+
+// deleteCreatingVolume deletes the record of a volume that its backend did not create
+func (h *StorageApiHandler) deleteCreatingVolume(volume *resources.Volume) error {
+	defer h.logger.Trace(logs.DEBUG)()
+
+	dbConnection := database.NewConnection()
+	if err := dbConnection.Open(); err != nil {
+		return h.logger.ErrorRet(err, "dbConnection.Open failed")
+	}
+	defer dbConnection.Close()
+
+	if err := model.DeleteCreatingVolume(dbConnection.GetDb(), volume); err != nil {
+		return h.logger.ErrorRet(err, "model.DeleteCreatingVolume failed", logs.Args{{"volume", volume.Name}})
+	}
+	return nil
+}
+
+// ListLocks lists the volume and host locks held by the requests of this server, the oldest first,
+// so a request that blocks other requests (e.g behind a hung storage call) can be found
+func (h *StorageApiHandler) ListLocks() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer h.logger.Trace(logs.DEBUG)()
+
+		utils.WriteResponse(w, http.StatusOK, resources.ListLocksResponse{Locks: database.GetHeldLocks()})
+	}
+}
+
+// ExportMetadata returns the volume metadata of the Ubiquity DB as a versioned document, which RestoreMetadata restores
+func (h *StorageApiHandler) ExportMetadata() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer h.logger.Trace(logs.DEBUG)()
+
+		dbConnection := database.NewConnection()
+		if err := dbConnection.Open(); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		defer dbConnection.Close()
+
+		metadata, err := database.ExportMetadata(dbConnection.GetDb())
+		if err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, metadata)
+	}
+}
+
+// RestoreMetadata restores an exported metadata document into the Ubiquity DB, which must be empty
+func (h *StorageApiHandler) RestoreMetadata() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer h.logger.Trace(logs.DEBUG)()
+
+		metadata := resources.Metadata{}
+		if err := utils.UnmarshalDataFromRequest(req, &metadata); err != nil {
+			utils.WriteErrorResponse(w, &resources.InvalidRequestError{Reason: err.Error()})
+			return
+		}
+
+		dbConnection := database.NewConnection()
+		if err := dbConnection.Open(); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		defer dbConnection.Close()
+
+		if err := database.RestoreMetadata(dbConnection.GetDb(), metadata); err != nil {
+			utils.WriteErrorResponse(w, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, resources.RestoreMetadataResponse{Volumes: len(metadata.Volumes), Snapshots: len(metadata.Snapshots)})
+	}
+}
@@ -0,0 +1,208 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/web_server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StorageApiHandler", func() {
+	var (
+		backend1 *fakes.FakeStorageClient
+		backend2 *fakes.FakeStorageClient
+		handler  *web_server.StorageApiHandler
+	)
+	BeforeEach(func() {
+		backend1 = new(fakes.FakeStorageClient)
+		backend2 = new(fakes.FakeStorageClient)
+		handler = web_server.NewStorageApiHandler(
+			map[string]resources.StorageClient{"backend1": backend1, "backend2": backend2},
+			resources.UbiquityServerConfig{FanOut: resources.FanOutConfig{AllowPartialResults: true}})
+	})
+
+	Context(".ListBackends", func() {
+		It("should list the backends that succeeded and report the failed backend with its error", func() {
+			backend1.ListServicesReturns([]resources.StorageService{{Name: "service1", TotalCapacity: 10, UsedCapacity: 4, FreeCapacity: 6}}, nil)
+			backend2.ListServicesReturns(nil, &resources.BackendUnavailableError{Backend: "backend2", Reason: "not initialized"})
+
+			recorder := httptest.NewRecorder()
+			handler.ListBackends()(recorder, httptest.NewRequest("GET", "/ubiquity_storage/backends", nil))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			listBackendsResponse := resources.ListBackendsResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &listBackendsResponse)).To(Succeed())
+			Expect(listBackendsResponse.Backends).To(Equal([]resources.BackendCapacity{{Name: "backend1", Services: 1, TotalCapacity: 10, UsedCapacity: 4, FreeCapacity: 6}}))
+			Expect(listBackendsResponse.BackendErrors).To(HaveLen(1))
+			Expect(listBackendsResponse.BackendErrors[0].Backend).To(Equal("backend2"))
+			Expect(listBackendsResponse.BackendErrors[0].Code).To(Equal(resources.ErrorCodeBackendUnavailable))
+		})
+		It("should fail if all the backends failed", func() {
+			backend1.ListServicesReturns(nil, errors.New("error1"))
+			backend2.ListServicesReturns(nil, errors.New("error2"))
+
+			recorder := httptest.NewRecorder()
+			handler.ListBackends()(recorder, httptest.NewRequest("GET", "/ubiquity_storage/backends", nil))
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			genericResponse := resources.GenericResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &genericResponse)).To(Succeed())
+			Expect(genericResponse.Err).To(Equal("error1"))
+		})
+		It("should fail if a backend failed and partial results are not allowed", func() {
+			handler = web_server.NewStorageApiHandler(
+				map[string]resources.StorageClient{"backend1": backend1, "backend2": backend2},
+				resources.UbiquityServerConfig{FanOut: resources.FanOutConfig{AllowPartialResults: false}})
+			backend2.ListServicesReturns(nil, &resources.BackendUnavailableError{Backend: "backend2", Reason: "not initialized"})
+
+			recorder := httptest.NewRecorder()
+			handler.ListBackends()(recorder, httptest.NewRequest("GET", "/ubiquity_storage/backends", nil))
+			Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
+	Context(".ListVolumes", func() {
+		listVolumes := func(listVolumesRequest resources.ListVolumesRequest) (int, resources.ListResponse) {
+			body, err := json.Marshal(listVolumesRequest)
+			Expect(err).ToNot(HaveOccurred())
+			recorder := httptest.NewRecorder()
+			handler.ListVolumes()(recorder, httptest.NewRequest("GET", "/ubiquity_storage/volumes", bytes.NewReader(body)))
+			listResponse := resources.ListResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &listResponse)).To(Succeed())
+			return recorder.Code, listResponse
+		}
+
+		It("should page the volumes of all the backends", func() {
+			backend1.ListVolumesReturns([]resources.Volume{{Name: "vol1"}, {Name: "vol3"}}, nil)
+			backend2.ListVolumesReturns([]resources.Volume{{Name: "vol2"}}, nil)
+
+			code, listResponse := listVolumes(resources.ListVolumesRequest{Limit: 2})
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(listResponse.Volumes).To(HaveLen(2))
+			Expect(listResponse.Volumes[0].Name).To(Equal("vol1"))
+			Expect(listResponse.Volumes[1].Name).To(Equal("vol2"))
+			Expect(listResponse.NextCursor).ToNot(BeEmpty())
+			Expect(listResponse.BackendErrors).To(BeEmpty())
+		})
+		It("should return a page of partial results without a next cursor", func() {
+			backend1.ListVolumesReturns([]resources.Volume{{Name: "vol1"}, {Name: "vol3"}, {Name: "vol5"}}, nil)
+			backend2.ListVolumesReturns(nil, errors.New("error2"))
+
+			code, listResponse := listVolumes(resources.ListVolumesRequest{Limit: 2})
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(listResponse.Volumes).To(HaveLen(2))
+			Expect(listResponse.NextCursor).To(BeEmpty())
+			Expect(listResponse.BackendErrors).To(Equal([]resources.BackendError{{Backend: "backend2", Code: resources.ErrorCodeInternal, Err: "error2"}}))
+		})
+		It("should list the volumes of a backend requested more than once once", func() {
+			backend1.ListVolumesReturns([]resources.Volume{{Name: "vol1"}}, nil)
+
+			code, listResponse := listVolumes(resources.ListVolumesRequest{Backends: []string{"backend1", "backend1"}})
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(listResponse.Volumes).To(HaveLen(1))
+			Expect(backend1.ListVolumesCallCount()).To(Equal(1))
+			Expect(backend2.ListVolumesCallCount()).To(Equal(0))
+		})
+		It("should fail if the only backend requested more than once failed", func() {
+			backend2.ListVolumesReturns(nil, errors.New("error2"))
+
+			code, listResponse := listVolumes(resources.ListVolumesRequest{Backends: []string{"backend2", "backend2"}})
+			Expect(code).To(Equal(http.StatusInternalServerError))
+			Expect(listResponse.Volumes).To(BeEmpty())
+		})
+		It("should fail if all the backends failed", func() {
+			backend1.ListVolumesReturns(nil, errors.New("error1"))
+			backend2.ListVolumesReturns(nil, errors.New("error2"))
+
+			code, _ := listVolumes(resources.ListVolumesRequest{})
+			Expect(code).To(Equal(http.StatusInternalServerError))
+		})
+		It("should report a backend that did not answer within the fan-out timeout", func() {
+			handler = web_server.NewStorageApiHandler(
+				map[string]resources.StorageClient{"backend1": backend1, "backend2": backend2},
+				resources.UbiquityServerConfig{FanOut: resources.FanOutConfig{AllowPartialResults: true, Timeout: 10 * time.Millisecond}})
+			backend1.ListVolumesReturns([]resources.Volume{{Name: "vol1"}}, nil)
+			backend2.ListVolumesStub = func(listVolumesRequest resources.ListVolumesRequest) ([]resources.Volume, error) {
+				<-listVolumesRequest.Context.GetCtx().Done()
+				return nil, listVolumesRequest.Context.GetCtx().Err()
+			}
+
+			code, listResponse := listVolumes(resources.ListVolumesRequest{})
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(listResponse.Volumes).To(HaveLen(1))
+			Expect(listResponse.BackendErrors).To(HaveLen(1))
+			Expect(listResponse.BackendErrors[0].Backend).To(Equal("backend2"))
+			Expect(listResponse.BackendErrors[0].Code).To(Equal(resources.ErrorCodeDeadlineExceeded))
+		})
+	})
+
+	Context(".Activate", func() {
+		activate := func() *httptest.ResponseRecorder {
+			body, err := json.Marshal(resources.ActivateRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			recorder := httptest.NewRecorder()
+			handler.Activate()(recorder, httptest.NewRequest("POST", "/ubiquity_storage/activate", bytes.NewReader(body)))
+			return recorder
+		}
+
+		It("should activate all the backends", func() {
+			recorder := activate()
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(backend1.ActivateCallCount()).To(Equal(1))
+			Expect(backend2.ActivateCallCount()).To(Equal(1))
+		})
+		It("should succeed with partial results and report the failed backend", func() {
+			backend2.ActivateReturns(errors.New("error2"))
+
+			recorder := activate()
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			activateResponse := resources.ActivateResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &activateResponse)).To(Succeed())
+			Expect(activateResponse.BackendErrors).To(Equal([]resources.BackendError{{Backend: "backend2", Code: resources.ErrorCodeInternal, Err: "error2"}}))
+		})
+		It("should fail if a backend failed and partial results are not allowed", func() {
+			handler = web_server.NewStorageApiHandler(
+				map[string]resources.StorageClient{"backend1": backend1, "backend2": backend2},
+				resources.UbiquityServerConfig{})
+			backend2.ActivateReturns(errors.New("error2"))
+
+			recorder := activate()
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			genericResponse := resources.GenericResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &genericResponse)).To(Succeed())
+			Expect(genericResponse.Details).To(Equal(map[string]string{"backend2": "error2"}))
+		})
+		It("should fail if all the backends failed", func() {
+			backend1.ActivateReturns(errors.New("error1"))
+			backend2.ActivateReturns(errors.New("error2"))
+
+			recorder := activate()
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			genericResponse := resources.GenericResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &genericResponse)).To(Succeed())
+			Expect(genericResponse.Details).To(Equal(map[string]string{"backend1": "error1", "backend2": "error2"}))
+		})
+	})
+})
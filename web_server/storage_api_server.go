@@ -17,6 +17,7 @@
 package web_server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -24,6 +25,7 @@ import (
 
 	"github.com/IBM/ubiquity/utils"
 	"github.com/IBM/ubiquity/utils/logs"
+	"github.com/IBM/ubiquity/utils/metrics"
 	"github.com/gorilla/mux"
 	"os"
 	"strings"
@@ -35,38 +37,88 @@ const keyCertPrivate = "UBIQUITY_SERVER_CERT_PRIVATE"
 
 type StorageApiServer struct {
 	storageApiHandler *StorageApiHandler
+	healthApiHandler  *HealthApiHandler
 	logger            logs.Logger
 	config            resources.UbiquityServerConfig
+	httpServer        *http.Server
 }
 
-func NewStorageApiServer(backends map[string]resources.StorageClient, config resources.UbiquityServerConfig) (*StorageApiServer, error) {
-	return &StorageApiServer{storageApiHandler: NewStorageApiHandler(backends, config), logger: logs.GetLogger(), config: config}, nil
+func NewStorageApiServer(backends map[string]resources.StorageClient, config resources.UbiquityServerConfig, heartbeat utils.Heartbeat) (*StorageApiServer, error) {
+	return &StorageApiServer{
+		storageApiHandler: NewStorageApiHandler(backends, config),
+		healthApiHandler:  NewHealthApiHandler(backends, heartbeat, config.Heartbeat),
+		logger:            logs.GetLogger(),
+		config:            config,
+		httpServer:        &http.Server{Addr: fmt.Sprintf(":%d", config.Port)},
+	}, nil
 }
 
 func (s *StorageApiServer) InitializeHandler() http.Handler {
 	router := mux.NewRouter()
-	router.HandleFunc("/ubiquity_storage/activate", s.storageApiHandler.Activate()).Methods("POST")
-	router.HandleFunc("/ubiquity_storage/volumes", s.storageApiHandler.CreateVolume()).Methods("POST")
-	router.HandleFunc("/ubiquity_storage/volumes", s.storageApiHandler.ListVolumes()).Methods("GET")
-	router.HandleFunc("/ubiquity_storage/volumes/{volume}", s.storageApiHandler.RemoveVolume()).Methods("DELETE")
-	router.HandleFunc("/ubiquity_storage/volumes/{volume}/attach", s.storageApiHandler.AttachVolume()).Methods("PUT")
-	router.HandleFunc("/ubiquity_storage/volumes/{volume}/detach", s.storageApiHandler.DetachVolume()).Methods("PUT")
-	router.HandleFunc("/ubiquity_storage/volumes/{volume}", s.storageApiHandler.GetVolume()).Methods("GET")
-	router.HandleFunc("/ubiquity_storage/volumes/{volume}/config", s.storageApiHandler.GetVolumeConfig()).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/activate", instrumentRoute(s.storageApiHandler.Activate())).Methods("POST")
+	router.HandleFunc("/ubiquity_storage/volumes", instrumentRoute(s.storageApiHandler.CreateVolume())).Methods("POST")
+	router.HandleFunc("/ubiquity_storage/volumes", instrumentRoute(s.storageApiHandler.ListVolumes())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}", instrumentRoute(s.storageApiHandler.RemoveVolume())).Methods("DELETE")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/attach", instrumentRoute(s.storageApiHandler.AttachVolume())).Methods("PUT")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/detach", instrumentRoute(s.storageApiHandler.DetachVolume())).Methods("PUT")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/expand", instrumentRoute(s.storageApiHandler.ExpandVolume())).Methods("PUT")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/snapshots", instrumentRoute(s.storageApiHandler.CreateSnapshot())).Methods("POST")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/snapshots", instrumentRoute(s.storageApiHandler.ListSnapshots())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/snapshots/{snapshot}", instrumentRoute(s.storageApiHandler.DeleteSnapshot())).Methods("DELETE")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}", instrumentRoute(s.storageApiHandler.GetVolume())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/volumes/{volume}/config", instrumentRoute(s.storageApiHandler.GetVolumeConfig())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/backends", instrumentRoute(s.storageApiHandler.ListBackends())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/backends/health", instrumentRoute(s.storageApiHandler.BackendsHealth())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/backends/{backend}/services", instrumentRoute(s.storageApiHandler.ListServices())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/backends/{backend}/orphans", instrumentRoute(s.storageApiHandler.ReconcileBackend())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/orphans", instrumentRoute(s.storageApiHandler.Reconcile())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/admin/locks", instrumentRoute(s.storageApiHandler.ListLocks())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/admin/metadata", instrumentRoute(s.storageApiHandler.ExportMetadata())).Methods("GET")
+	router.HandleFunc("/ubiquity_storage/admin/metadata", instrumentRoute(s.storageApiHandler.RestoreMetadata())).Methods("POST")
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+	router.HandleFunc("/healthz", s.healthApiHandler.Healthz()).Methods("GET")
+	router.HandleFunc("/readyz", s.healthApiHandler.Readyz()).Methods("GET")
 	return router
 }
 
-func (s *StorageApiServer) Start() error {
+// Start serves the requests and runs the background jobs until stop is closed, then the server is shut down gracefully
+// (it stops accepting requests and waits for the running ones) and Start returns nil.
+func (s *StorageApiServer) Start(stop <-chan struct{}) error {
 	router := s.InitializeHandler()
 	http.Handle("/", router)
 
+	if s.config.ReconcileInterval > 0 {
+		s.logger.Info("Starting the reconciler", logs.Args{{"interval", s.config.ReconcileInterval}})
+		go s.storageApiHandler.reconciler.Run(s.config.ReconcileInterval, stop)
+	}
+	if s.config.HealthMonitor.Interval > 0 {
+		s.logger.Info("Starting the backend health monitor", logs.Args{{"interval", s.config.HealthMonitor.Interval}})
+		go s.storageApiHandler.healthMonitor.Run(stop)
+	}
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-stop
+		s.logger.Info("Stopping Storage API server")
+		if err := s.httpServer.Shutdown(context.Background()); err != nil {
+			s.logger.ErrorRet(err, "httpServer.Shutdown failed")
+		}
+	}()
+
+	var err error
 	useSsl := os.Getenv(keyUseSsl)
 	if strings.ToLower(useSsl) == "false" {
-		return s.StartNonSsl()
+		err = s.StartNonSsl()
 	} else {
 		// Ubiquity server uses by default with SSL on
-		return s.StartSsl()
+		err = s.StartSsl()
+	}
+	if err != http.ErrServerClosed {
+		return err
 	}
+	<-shutdownDone
+	return nil
 }
 
 func (s *StorageApiServer) printStartMsg() {
@@ -78,7 +130,7 @@ func (s *StorageApiServer) StartNonSsl() error {
 	defer s.logger.Trace(logs.DEBUG)()
 
 	s.printStartMsg()
-	return http.ListenAndServe(fmt.Sprintf(":%d", s.config.Port), nil)
+	return s.httpServer.ListenAndServe()
 }
 
 func (s *StorageApiServer) StartSsl() error {
@@ -91,7 +143,7 @@ func (s *StorageApiServer) StartSsl() error {
 	}
 
 	s.printStartMsg()
-	return http.ListenAndServeTLS(fmt.Sprintf(":%d", s.config.Port), public, private, nil)
+	return s.httpServer.ListenAndServeTLS(public, private)
 }
 
 func (s *StorageApiServer) getCertFilenames() (string, string, error) {
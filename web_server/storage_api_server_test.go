@@ -0,0 +1,71 @@
+/**
+ * Copyright 2018 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package web_server_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/IBM/ubiquity/fakes"
+	"github.com/IBM/ubiquity/resources"
+	"github.com/IBM/ubiquity/web_server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StorageApiServer", func() {
+	Context(".Start", func() {
+		var port int
+
+		BeforeEach(func() {
+			os.Setenv("UBIQUITY_SERVER_USE_SSL", "false")
+			listener, err := net.Listen("tcp", ":0")
+			Expect(err).ToNot(HaveOccurred())
+			port = listener.Addr().(*net.TCPAddr).Port
+			listener.Close()
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("UBIQUITY_SERVER_USE_SSL")
+		})
+
+		It("should serve until stop is closed and then return nil", func() {
+			config := resources.UbiquityServerConfig{Port: port}
+			server, err := web_server.NewStorageApiServer(map[string]resources.StorageClient{"backend1": new(fakes.FakeStorageClient)}, config, new(fakes.FakeHeartbeat))
+			Expect(err).ToNot(HaveOccurred())
+
+			stop := make(chan struct{})
+			done := make(chan error, 1)
+			go func() { done <- server.Start(stop) }()
+
+			Eventually(func() error {
+				resp, err := http.Get(fmt.Sprintf("http://localhost:%d/healthz", port))
+				if err == nil {
+					resp.Body.Close()
+				}
+				return err
+			}).ShouldNot(HaveOccurred())
+
+			close(stop)
+			Eventually(done).Should(Receive(BeNil()))
+			_, err = http.Get(fmt.Sprintf("http://localhost:%d/healthz", port))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})